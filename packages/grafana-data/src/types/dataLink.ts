@@ -66,6 +66,10 @@ export interface DataLink<T extends DataQuery = any> {
 export enum SupportedTransformationType {
   Regex = 'regex',
   Logfmt = 'logfmt',
+  JSONPath = 'jsonpath',
+  Template = 'template',
+  URLEncode = 'urlencode',
+  Lowercase = 'lowercase',
 }
 
 /** @internal */
@@ -609,6 +609,21 @@ export const versionedPages = {
       SettingsDropdown: {
         '10.1.0': 'data-testid public dashboard settings dropdown',
       },
+      TemplateVariablesDropdown: {
+        '11.4.0': 'data-testid public dashboard template variables dropdown',
+      },
+      AccessDropdown: {
+        '11.4.0': 'data-testid public dashboard access dropdown',
+      },
+      ExpirationSelect: {
+        '11.4.0': 'data-testid public dashboard expiration select',
+      },
+      PassphraseInput: {
+        '11.4.0': 'data-testid public dashboard passphrase input',
+      },
+      QueryRateLimitInput: {
+        '11.4.0': 'data-testid public dashboard query rate limit input',
+      },
       TemplateVariablesWarningAlert: {
         '9.1.0': 'data-testid public dashboard disabled template variables alert',
       },
@@ -778,6 +793,20 @@ export const versionedPages = {
       pausedDescription: {
         '9.5.0': 'public-dashboard-paused-description',
       },
+      expiredDescription: {
+        '11.4.0': 'public-dashboard-expired-description',
+      },
+    },
+    Passphrase: {
+      container: {
+        '11.4.0': 'public-dashboard-passphrase',
+      },
+      input: {
+        '11.4.0': 'data-testid public dashboard passphrase input',
+      },
+      submitButton: {
+        '11.4.0': 'data-testid public dashboard passphrase submit button',
+      },
     },
     footer: {
       '11.0.0': 'public-dashboard-footer',
@@ -4,6 +4,7 @@ import { DataQuery, DataQueryRequest, DataQueryResponse } from '@grafana/data';
 
 import { config } from '../config';
 import { getBackendSrv } from '../services/backendSrv';
+import { getTemplateSrv } from '../services/templateSrv';
 
 import { BackendDataSourceResponse, toDataQueryResponse } from './queryResponse';
 
@@ -30,6 +31,7 @@ export function publicDashboardQueryHandler(request: DataQueryRequest<DataQuery>
       to: toRange.valueOf().toString(),
       timezone: request.timezone,
     },
+    variables: getTemplateVariableValues(),
   };
 
   return getBackendSrv()
@@ -48,3 +50,19 @@ export function publicDashboardQueryHandler(request: DataQueryRequest<DataQuery>
       })
     );
 }
+
+/**
+ * Returns the selected values of the dashboard template variables. The server only uses the values of
+ * template variables that viewers of the public dashboard are allowed to change.
+ */
+function getTemplateVariableValues(): Record<string, string[]> {
+  const values: Record<string, string[]> = {};
+  for (const variable of getTemplateSrv().getVariables()) {
+    if (!('current' in variable) || variable.current?.value === undefined) {
+      continue;
+    }
+    const value = variable.current.value;
+    values[variable.name] = Array.isArray(value) ? value.map(String) : [String(value)];
+  }
+  return values;
+}
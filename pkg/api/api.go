@@ -552,6 +552,9 @@ func (hs *HTTPServer) registerRoutes() {
 
 			// Some channels may have info
 			liveRoute.Get("/info/*", routing.Wrap(hs.Live.HandleInfoHTTP))
+
+			// Frames kept in history for managed streams
+			liveRoute.Get("/history/*", routing.Wrap(hs.Live.HandleHistoryHTTP))
 		}, requestmeta.SetSLOGroup(requestmeta.SLOGroupNone))
 
 		// short urls
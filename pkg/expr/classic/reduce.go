@@ -3,6 +3,7 @@ package classic
 import (
 	"math"
 	"sort"
+	"strconv"
 
 	"github.com/grafana/grafana/pkg/expr/mathexp"
 )
@@ -15,6 +16,8 @@ func (cr reducer) ValidReduceFunc() bool {
 		return true
 	case "diff", "diff_abs", "percent_diff", "percent_diff_abs", "count_non_null":
 		return true
+	case "first", "range", "delta", "rate", "stddev", "variance", "p50", "p90", "p95", "p99":
+		return true
 	}
 	return false
 }
@@ -144,6 +147,73 @@ func (cr reducer) Reduce(series mathexp.Series) mathexp.Number {
 		if value > 0 {
 			allNull = false
 		}
+	case "first":
+		for i := 0; i < ff.Len(); i++ {
+			f := ff.GetValue(i)
+			if !nilOrNaN(f) {
+				value = *f
+				allNull = false
+				break
+			}
+		}
+	case "range":
+		values := nonNullValues(ff)
+		if len(values) > 0 {
+			allNull = false
+			minV, maxV := values[0], values[0]
+			for _, v := range values {
+				minV = math.Min(minV, v)
+				maxV = math.Max(maxV, v)
+			}
+			value = maxV - minV
+		}
+	case "delta":
+		allNull, value = calculateDiff(ff, allNull, value, diff)
+	case "rate":
+		first, last := -1, -1
+		for i := 0; i < ff.Len(); i++ {
+			if nilOrNaN(ff.GetValue(i)) {
+				continue
+			}
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+		if first >= 0 && last > first {
+			seconds := series.GetTime(last).Sub(series.GetTime(first)).Seconds()
+			if seconds > 0 {
+				value = (*ff.GetValue(last) - *ff.GetValue(first)) / seconds
+				allNull = false
+			}
+		}
+	case "stddev", "variance":
+		values := nonNullValues(ff)
+		if len(values) > 0 {
+			allNull = false
+			var mean float64
+			for _, v := range values {
+				mean += v
+			}
+			mean /= float64(len(values))
+			for _, v := range values {
+				value += (v - mean) * (v - mean)
+			}
+			value /= float64(len(values))
+			if cr == "stddev" {
+				value = math.Sqrt(value)
+			}
+		}
+	case "p50", "p90", "p95", "p99":
+		values := nonNullValues(ff)
+		if len(values) > 0 {
+			allNull = false
+			p, _ := strconv.ParseFloat(string(cr[1:]), 64)
+			sort.Float64s(values)
+			rank := p / 100 * float64(len(values)-1)
+			lower, upper := int(math.Floor(rank)), int(math.Ceil(rank))
+			value = values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+		}
 	}
 
 	if allNull {
@@ -182,6 +252,18 @@ func calculateDiff(ff mathexp.Float64Field, allNull bool, value float64, fn func
 	return allNull, value
 }
 
+// nonNullValues returns the values of the field that are neither null nor NaN.
+func nonNullValues(ff mathexp.Float64Field) []float64 {
+	var values []float64
+	for i := 0; i < ff.Len(); i++ {
+		f := ff.GetValue(i)
+		if !nilOrNaN(f) {
+			values = append(values, *f)
+		}
+	}
+	return values
+}
+
 func nilOrNaN(f *float64) bool {
 	return f == nil || math.IsNaN(*f)
 }
@@ -115,6 +115,60 @@ func TestReducer(t *testing.T) {
 			inputSeries:    newSeries(nil, nil),
 			expectedNumber: newNumber(nil),
 		},
+		{
+			name:           "first should ignore leading nulls",
+			reducer:        reducer("first"),
+			inputSeries:    newSeries(nil, util.Pointer(3.0), util.Pointer(4.0)),
+			expectedNumber: newNumber(util.Pointer(3.0)),
+		},
+		{
+			name:           "range",
+			reducer:        reducer("range"),
+			inputSeries:    newSeries(util.Pointer(1.0), nil, util.Pointer(5.0), util.Pointer(2.0)),
+			expectedNumber: newNumber(util.Pointer(4.0)),
+		},
+		{
+			name:           "delta",
+			reducer:        reducer("delta"),
+			inputSeries:    newSeries(util.Pointer(2.0), nil, util.Pointer(7.0)),
+			expectedNumber: newNumber(util.Pointer(5.0)),
+		},
+		{
+			name:           "rate should use the time between the first and last non-null points",
+			reducer:        reducer("rate"),
+			inputSeries:    newSeries(nil, util.Pointer(2.0), util.Pointer(4.0), util.Pointer(8.0)),
+			expectedNumber: newNumber(util.Pointer(3.0)),
+		},
+		{
+			name:           "rate with a single point",
+			reducer:        reducer("rate"),
+			inputSeries:    newSeries(util.Pointer(2.0), nil),
+			expectedNumber: newNumber(nil),
+		},
+		{
+			name:           "variance",
+			reducer:        reducer("variance"),
+			inputSeries:    newSeries(util.Pointer(2.0), util.Pointer(4.0), util.Pointer(4.0), util.Pointer(4.0), util.Pointer(5.0), util.Pointer(5.0), util.Pointer(7.0), util.Pointer(9.0)),
+			expectedNumber: newNumber(util.Pointer(4.0)),
+		},
+		{
+			name:           "stddev",
+			reducer:        reducer("stddev"),
+			inputSeries:    newSeries(util.Pointer(2.0), util.Pointer(4.0), util.Pointer(4.0), util.Pointer(4.0), util.Pointer(5.0), util.Pointer(5.0), util.Pointer(7.0), util.Pointer(9.0)),
+			expectedNumber: newNumber(util.Pointer(2.0)),
+		},
+		{
+			name:           "p50 should interpolate between values",
+			reducer:        reducer("p50"),
+			inputSeries:    newSeries(util.Pointer(4.0), util.Pointer(1.0), nil, util.Pointer(3.0), util.Pointer(2.0)),
+			expectedNumber: newNumber(util.Pointer(2.5)),
+		},
+		{
+			name:           "p99 with only nulls",
+			reducer:        reducer("p99"),
+			inputSeries:    newSeries(nil, nil),
+			expectedNumber: newNumber(nil),
+		},
 	}
 
 	for _, tt := range tests {
@@ -84,13 +84,14 @@ func (gm *MathCommand) Type() string {
 type ReduceCommand struct {
 	Reducer      mathexp.ReducerID
 	VarToReduce  string
+	Options      mathexp.ReduceOptions
 	refID        string
 	seriesMapper mathexp.ReduceMapper
 }
 
 // NewReduceCommand creates a new ReduceCMD.
-func NewReduceCommand(refID string, reducer mathexp.ReducerID, varToReduce string, mapper mathexp.ReduceMapper) (*ReduceCommand, error) {
-	_, err := mathexp.GetReduceFunc(reducer)
+func NewReduceCommand(refID string, reducer mathexp.ReducerID, varToReduce string, mapper mathexp.ReduceMapper, opts mathexp.ReduceOptions) (*ReduceCommand, error) {
+	_, err := mathexp.GetSeriesReduceFunc(reducer, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -98,6 +99,7 @@ func NewReduceCommand(refID string, reducer mathexp.ReducerID, varToReduce strin
 	return &ReduceCommand{
 		Reducer:      reducer,
 		VarToReduce:  varToReduce,
+		Options:      opts,
 		refID:        refID,
 		seriesMapper: mapper,
 	}, nil
@@ -126,10 +128,18 @@ func UnmarshalReduceCommand(rn *rawNode) (*ReduceCommand, error) {
 	redFunc := mathexp.ReducerID(strings.ToLower(redString))
 
 	var mapper mathexp.ReduceMapper = nil
+	opts := mathexp.ReduceOptions{}
 	settings, ok := rn.Query["settings"]
 	if ok {
 		switch s := settings.(type) {
 		case map[string]any:
+			if rawPercentile, ok := s["percentile"]; ok {
+				percentile, ok := rawPercentile.(float64)
+				if !ok {
+					return nil, fmt.Errorf("setting percentile must be a number, got %T", rawPercentile)
+				}
+				opts.Percentile = percentile
+			}
 			mode, ok := s["mode"]
 			if ok && mode != "" {
 				switch mode {
@@ -154,7 +164,7 @@ func UnmarshalReduceCommand(rn *rawNode) (*ReduceCommand, error) {
 			return nil, fmt.Errorf("field settings must be an object, got %T for refId %v", s, rn.RefID)
 		}
 	}
-	return NewReduceCommand(rn.RefID, redFunc, varToReduce, mapper)
+	return NewReduceCommand(rn.RefID, redFunc, varToReduce, mapper, opts)
 }
 
 // NeedsVars returns the variable names (refIds) that are dependencies
@@ -175,7 +185,7 @@ func (gr *ReduceCommand) Execute(ctx context.Context, _ time.Time, vars mathexp.
 	for i, val := range vars[gr.VarToReduce].Values {
 		switch v := val.(type) {
 		case mathexp.Series:
-			num, err := v.Reduce(gr.refID, gr.Reducer, gr.seriesMapper)
+			num, err := v.Reduce(gr.refID, gr.Reducer, gr.seriesMapper, gr.Options)
 			if err != nil {
 				return newRes, err
 			}
@@ -216,12 +226,13 @@ type ResampleCommand struct {
 	VarToResample string
 	Downsampler   mathexp.ReducerID
 	Upsampler     mathexp.Upsampler
+	Alignment     mathexp.Alignment
 	TimeRange     TimeRange
 	refID         string
 }
 
 // NewResampleCommand creates a new ResampleCMD.
-func NewResampleCommand(refID, rawWindow, varToResample string, downsampler mathexp.ReducerID, upsampler mathexp.Upsampler, tr TimeRange) (*ResampleCommand, error) {
+func NewResampleCommand(refID, rawWindow, varToResample string, downsampler mathexp.ReducerID, upsampler mathexp.Upsampler, alignment mathexp.Alignment, tr TimeRange) (*ResampleCommand, error) {
 	// TODO: validate reducer here, before execution
 	window, err := gtime.ParseDuration(rawWindow)
 	if err != nil {
@@ -232,6 +243,7 @@ func NewResampleCommand(refID, rawWindow, varToResample string, downsampler math
 		VarToResample: varToResample,
 		Downsampler:   downsampler,
 		Upsampler:     upsampler,
+		Alignment:     alignment,
 		TimeRange:     tr,
 		refID:         refID,
 	}, nil
@@ -280,10 +292,19 @@ func UnmarshalResampleCommand(rn *rawNode) (*ResampleCommand, error) {
 		return nil, fmt.Errorf("expected resample downsampler to be a string, got type %T", upsampler)
 	}
 
+	var alignment string
+	if rawAlignment, ok := rn.Query["alignment"]; ok {
+		alignment, ok = rawAlignment.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected resample alignment to be a string, got type %T", rawAlignment)
+		}
+	}
+
 	return NewResampleCommand(rn.RefID, window,
 		varToResample,
 		mathexp.ReducerID(downsampler),
 		mathexp.Upsampler(upsampler),
+		mathexp.Alignment(alignment),
 		rn.TimeRange)
 }
 
@@ -306,7 +327,7 @@ func (gr *ResampleCommand) Execute(ctx context.Context, now time.Time, vars math
 		}
 		switch v := val.(type) {
 		case mathexp.Series:
-			num, err := v.Resample(gr.refID, gr.Window, gr.Downsampler, gr.Upsampler, timeRange.From, timeRange.To)
+			num, err := v.Resample(gr.refID, gr.Window, gr.Downsampler, gr.Upsampler, gr.Alignment, timeRange.From, timeRange.To)
 			if err != nil {
 				return newRes, err
 			}
@@ -343,6 +364,8 @@ const (
 	TypeThreshold
 	// TypeSQL is the CMDType for running SQL expressions
 	TypeSQL
+	// TypeForecast is the CMDType for fitting a forecasting model to time series
+	TypeForecast
 )
 
 func (gt CommandType) String() string {
@@ -359,6 +382,8 @@ func (gt CommandType) String() string {
 		return "threshold"
 	case TypeSQL:
 		return "sql"
+	case TypeForecast:
+		return "forecast"
 	default:
 		return "unknown"
 	}
@@ -379,6 +404,8 @@ func ParseCommandType(s string) (CommandType, error) {
 		return TypeThreshold, nil
 	case "sql":
 		return TypeSQL, nil
+	case "forecast":
+		return TypeForecast, nil
 	default:
 		return TypeUnknown, fmt.Errorf("'%v' is not a recognized expression type", s)
 	}
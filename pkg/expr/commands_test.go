@@ -99,11 +99,46 @@ func Test_UnmarshalReduceCommand_Settings(t *testing.T) {
 	}
 }
 
+func Test_UnmarshalReduceCommand_Percentile(t *testing.T) {
+	unmarshal := func(q string) (*ReduceCommand, error) {
+		var qmap = make(map[string]any)
+		require.NoError(t, json.Unmarshal([]byte(q), &qmap))
+		return UnmarshalReduceCommand(&rawNode{
+			RefID:     "A",
+			Query:     qmap,
+			TimeRange: RelativeTimeRange{},
+		})
+	}
+
+	t.Run("percentile is read from the settings", func(t *testing.T) {
+		cmd, err := unmarshal(`{ "expression" : "$A", "reducer": "percentile", "settings": { "percentile": 99.9 } }`)
+		require.NoError(t, err)
+		require.Equal(t, mathexp.ReducerPercentile, cmd.Reducer)
+		require.Equal(t, 99.9, cmd.Options.Percentile)
+	})
+
+	t.Run("error when percentile is missing", func(t *testing.T) {
+		_, err := unmarshal(`{ "expression" : "$A", "reducer": "percentile" }`)
+		require.Error(t, err)
+	})
+
+	t.Run("error when percentile is not a number", func(t *testing.T) {
+		_, err := unmarshal(`{ "expression" : "$A", "reducer": "percentile", "settings": { "percentile": "99" } }`)
+		require.Error(t, err)
+	})
+
+	t.Run("fixed percentiles do not need settings", func(t *testing.T) {
+		cmd, err := unmarshal(`{ "expression" : "$A", "reducer": "p95" }`)
+		require.NoError(t, err)
+		require.Equal(t, mathexp.ReducerP95, cmd.Reducer)
+	})
+}
+
 func TestReduceExecute(t *testing.T) {
 	varToReduce := util.GenerateShortUID()
 
 	t.Run("when mapper is nil", func(t *testing.T) {
-		cmd, err := NewReduceCommand(util.GenerateShortUID(), randomReduceFunc(), varToReduce, nil)
+		cmd, err := NewReduceCommand(util.GenerateShortUID(), randomReduceFunc(), varToReduce, nil, mathexp.ReduceOptions{Percentile: 95})
 		require.NoError(t, err)
 
 		t.Run("should noop if Number", func(t *testing.T) {
@@ -161,7 +196,7 @@ func TestReduceExecute(t *testing.T) {
 		}
 
 		t.Run("drop all non numbers if mapper is DropNonNumber", func(t *testing.T) {
-			cmd, err := NewReduceCommand(util.GenerateShortUID(), randomReduceFunc(), varToReduce, &mathexp.DropNonNumber{})
+			cmd, err := NewReduceCommand(util.GenerateShortUID(), randomReduceFunc(), varToReduce, &mathexp.DropNonNumber{}, mathexp.ReduceOptions{Percentile: 95})
 			require.NoError(t, err)
 			execute, err := cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
 			require.NoError(t, err)
@@ -169,7 +204,7 @@ func TestReduceExecute(t *testing.T) {
 		})
 
 		t.Run("replace all non numbers if mapper is ReplaceNonNumberWithValue", func(t *testing.T) {
-			cmd, err := NewReduceCommand(util.GenerateShortUID(), randomReduceFunc(), varToReduce, &mathexp.ReplaceNonNumberWithValue{Value: 1})
+			cmd, err := NewReduceCommand(util.GenerateShortUID(), randomReduceFunc(), varToReduce, &mathexp.ReplaceNonNumberWithValue{Value: 1}, mathexp.ReduceOptions{Percentile: 95})
 			require.NoError(t, err)
 			execute, err := cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
 			require.NoError(t, err)
@@ -192,7 +227,7 @@ func TestReduceExecute(t *testing.T) {
 				Values: noData,
 			},
 		}
-		cmd, err := NewReduceCommand(util.GenerateShortUID(), randomReduceFunc(), varToReduce, nil)
+		cmd, err := NewReduceCommand(util.GenerateShortUID(), randomReduceFunc(), varToReduce, nil, mathexp.ReduceOptions{Percentile: 95})
 		require.NoError(t, err)
 		results, err := cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
 		require.NoError(t, err)
@@ -221,7 +256,7 @@ func TestResampleCommand_Execute(t *testing.T) {
 		From: -10 * time.Second,
 		To:   0,
 	}
-	cmd, err := NewResampleCommand(util.GenerateShortUID(), "1s", varToReduce, "sum", "pad", tr)
+	cmd, err := NewResampleCommand(util.GenerateShortUID(), "1s", varToReduce, "sum", "pad", "", tr)
 	require.NoError(t, err)
 
 	var tests = []struct {
@@ -0,0 +1,151 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+const (
+	// ForecastBandLabel is the label that tells apart the series produced by a forecast expression.
+	ForecastBandLabel = "forecast_band"
+
+	forecastBandForecast = "forecast"
+	forecastBandUpper    = "upper"
+	forecastBandLower    = "lower"
+
+	defaultForecastDeviations = 3
+	defaultForecastAlpha      = 0.5
+	defaultForecastBeta       = 0.1
+	defaultForecastGamma      = 0.1
+)
+
+// ForecastCommand is an expression command that fits a model to each input series
+// and returns the forecast of the model along with its upper and lower bands.
+type ForecastCommand struct {
+	RefID        string
+	ReferenceVar string
+	Options      mathexp.ForecastOptions
+}
+
+// NewForecastCommand creates a new ForecastCommand.
+func NewForecastCommand(refID, referenceVar string, opts mathexp.ForecastOptions) (*ForecastCommand, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return &ForecastCommand{
+		RefID:        refID,
+		ReferenceVar: referenceVar,
+		Options:      opts,
+	}, nil
+}
+
+// UnmarshalForecastCommand creates a ForecastCommand from Grafana's frontend query.
+func UnmarshalForecastCommand(rn *rawNode) (*ForecastCommand, error) {
+	q := ForecastQuery{}
+	if err := json.Unmarshal(rn.QueryRaw, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse the forecast command: %w", err)
+	}
+	referenceVar, err := getReferenceVar(q.Expression, rn.RefID)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := q.options()
+	if err != nil {
+		return nil, err
+	}
+	return NewForecastCommand(rn.RefID, referenceVar, opts)
+}
+
+// options converts the query to the options of the model, applying the defaults.
+func (q ForecastQuery) options() (mathexp.ForecastOptions, error) {
+	opts := mathexp.ForecastOptions{
+		Model:      q.Model,
+		Deviations: defaultForecastDeviations,
+		Alpha:      defaultForecastAlpha,
+		Beta:       defaultForecastBeta,
+		Gamma:      defaultForecastGamma,
+	}
+	var err error
+	if q.Season != "" {
+		if opts.Season, err = gtime.ParseDuration(q.Season); err != nil {
+			return opts, fmt.Errorf(`failed to parse forecast "season" duration field %q: %w`, q.Season, err)
+		}
+	}
+	if q.Horizon != "" {
+		if opts.Horizon, err = gtime.ParseDuration(q.Horizon); err != nil {
+			return opts, fmt.Errorf(`failed to parse forecast "horizon" duration field %q: %w`, q.Horizon, err)
+		}
+	}
+	if q.Deviations != nil {
+		opts.Deviations = *q.Deviations
+	}
+	if q.Alpha != nil {
+		opts.Alpha = *q.Alpha
+	}
+	if q.Beta != nil {
+		opts.Beta = *q.Beta
+	}
+	if q.Gamma != nil {
+		opts.Gamma = *q.Gamma
+	}
+	return opts, nil
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (fc *ForecastCommand) NeedsVars() []string {
+	return []string{fc.ReferenceVar}
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute. Series that are too short to fit the model are left out of the results.
+func (fc *ForecastCommand) Execute(ctx context.Context, _ time.Time, vars mathexp.Vars, tracer tracing.Tracer) (mathexp.Results, error) {
+	_, span := tracer.Start(ctx, "SSE.ExecuteForecast")
+	defer span.End()
+	newRes := mathexp.Results{}
+	for _, val := range vars[fc.ReferenceVar].Values {
+		switch v := val.(type) {
+		case mathexp.Series:
+			forecast, upper, lower, err := v.Forecast(fc.RefID, fc.Options)
+			if errors.Is(err, mathexp.ErrInsufficientData) {
+				continue
+			}
+			if err != nil {
+				return newRes, err
+			}
+			for _, b := range []struct {
+				name   string
+				series mathexp.Series
+			}{{forecastBandForecast, forecast}, {forecastBandUpper, upper}, {forecastBandLower, lower}} {
+				labels := data.Labels{}
+				for k, l := range v.GetLabels() {
+					labels[k] = l
+				}
+				labels[ForecastBandLabel] = b.name
+				b.series.SetLabels(labels)
+				newRes.Values = append(newRes.Values, b.series)
+			}
+		case mathexp.NoData:
+			return mathexp.Results{Values: mathexp.Values{v.New()}}, nil
+		default:
+			return newRes, fmt.Errorf("can only forecast type series, got type %v", val.Type())
+		}
+	}
+	if len(newRes.Values) == 0 && len(vars[fc.ReferenceVar].Values) > 0 {
+		return mathexp.Results{Values: mathexp.Values{mathexp.NewNoData()}}, nil
+	}
+	return newRes, nil
+}
+
+func (fc *ForecastCommand) Type() string {
+	return TypeForecast.String()
+}
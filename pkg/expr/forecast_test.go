@@ -0,0 +1,150 @@
+package expr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestUnmarshalForecastCommand(t *testing.T) {
+	cases := []struct {
+		description string
+		query       string
+		shouldError bool
+		expected    mathexp.ForecastOptions
+	}{
+		{
+			description: "defaults",
+			query:       `{ "expression": "$A", "type": "forecast", "model": "holt_winters" }`,
+			expected: mathexp.ForecastOptions{
+				Model:      mathexp.ForecastHoltWinters,
+				Deviations: 3,
+				Alpha:      0.5,
+				Beta:       0.1,
+				Gamma:      0.1,
+			},
+		},
+		{
+			description: "all settings",
+			query: `{
+				"expression": "A",
+				"type": "forecast",
+				"model": "holt_winters",
+				"season": "1d",
+				"horizon": "1h",
+				"deviations": 2,
+				"alpha": 0.2,
+				"beta": 0,
+				"gamma": 0.3
+			}`,
+			expected: mathexp.ForecastOptions{
+				Model:      mathexp.ForecastHoltWinters,
+				Season:     24 * time.Hour,
+				Horizon:    time.Hour,
+				Deviations: 2,
+				Alpha:      0.2,
+				Beta:       0,
+				Gamma:      0.3,
+			},
+		},
+		{
+			description: "seasonal naive without season should error",
+			query:       `{ "expression": "A", "type": "forecast", "model": "seasonal_naive" }`,
+			shouldError: true,
+		},
+		{
+			description: "unknown model should error",
+			query:       `{ "expression": "A", "type": "forecast", "model": "prophet" }`,
+			shouldError: true,
+		},
+		{
+			description: "invalid horizon should error",
+			query:       `{ "expression": "A", "type": "forecast", "model": "holt_winters", "horizon": "soon" }`,
+			shouldError: true,
+		},
+		{
+			description: "missing expression should error",
+			query:       `{ "type": "forecast", "model": "holt_winters" }`,
+			shouldError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			cmd, err := UnmarshalForecastCommand(&rawNode{
+				RefID:    "B",
+				QueryRaw: []byte(tc.query),
+			})
+			if tc.shouldError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []string{"A"}, cmd.NeedsVars())
+			require.Equal(t, tc.expected, cmd.Options)
+		})
+	}
+}
+
+func TestForecastExecute(t *testing.T) {
+	cmd, err := NewForecastCommand("B", "A", mathexp.ForecastOptions{
+		Model:      mathexp.ForecastSeasonalNaive,
+		Season:     2 * time.Minute,
+		Horizon:    time.Minute,
+		Deviations: 3,
+	})
+	require.NoError(t, err)
+
+	series := func(labels data.Labels, n int) mathexp.Series {
+		s := mathexp.NewSeries("A", labels, n)
+		for i := 0; i < n; i++ {
+			s.SetPoint(i, time.Unix(int64(i*60), 0), util.Pointer(float64(i%2)))
+		}
+		return s
+	}
+
+	t.Run("emits the forecast and the bands of each series", func(t *testing.T) {
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": mathexp.Results{Values: mathexp.Values{series(data.Labels{"host": "a"}, 4)}},
+		}, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Len(t, res.Values, 3)
+
+		for i, band := range []string{"forecast", "upper", "lower"} {
+			v := res.Values[i]
+			require.Equal(t, data.Labels{"host": "a", ForecastBandLabel: band}, v.GetLabels())
+			s := v.(mathexp.Series)
+			require.Equal(t, 5, s.Len())
+			require.Equal(t, float64(0), *s.GetValue(4))
+		}
+	})
+
+	t.Run("drops series that are too short", func(t *testing.T) {
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": mathexp.Results{Values: mathexp.Values{series(data.Labels{"host": "a"}, 4), series(data.Labels{"host": "b"}, 1)}},
+		}, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Len(t, res.Values, 3)
+
+		res, err = cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": mathexp.Results{Values: mathexp.Values{series(nil, 1)}},
+		}, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Len(t, res.Values, 1)
+		require.Equal(t, mathexp.NoData{}.New(), res.Values[0])
+	})
+
+	t.Run("fails on numbers", func(t *testing.T) {
+		_, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": mathexp.Results{Values: mathexp.Values{mathexp.NewNumber("A", nil)}},
+		}, tracing.InitializeTracerForTest())
+		require.Error(t, err)
+	})
+}
@@ -26,6 +26,8 @@ const (
 	TypeDatasourceNode
 	// TypeMLNode is a NodeType for Machine Learning queries.
 	TypeMLNode
+	// TypeInputNode is a NodeType for queries whose frames are provided with the request.
+	TypeInputNode
 )
 
 func (nt NodeType) String() string {
@@ -36,6 +38,8 @@ func (nt NodeType) String() string {
 		return "Datasource"
 	case TypeMLNode:
 		return "Machine Learning"
+	case TypeInputNode:
+		return "Input"
 	default:
 		return "Unknown"
 	}
@@ -287,6 +291,8 @@ func (s *Service) buildGraph(req *Request) (*simple.DirectedGraph, error) {
 					err = fmt.Errorf("fail to parse expression with refID %v: %w", rn.RefID, err)
 				}
 			}
+		case TypeInputNode:
+			node = buildInputNode(rn, query.Frames)
 		}
 
 		if node == nil && err == nil {
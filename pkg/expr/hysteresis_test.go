@@ -11,6 +11,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/expr/mathexp"
 	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
 )
 
 func TestHysteresisExecute(t *testing.T) {
@@ -219,3 +220,53 @@ func TestFingerprintsToFrame(t *testing.T) {
 		})
 	}
 }
+
+func TestHysteresisExecuteWithSeveralConditions(t *testing.T) {
+	number := func(label string, value float64) mathexp.Number {
+		n := mathexp.NewNumber("B", data.Labels{"label": label})
+		n.SetValue(&value)
+		return n
+	}
+	fingerprint := func(label string) data.Fingerprint {
+		return data.Labels{"label": label}.Fingerprint()
+	}
+
+	// fire above 100 or outside of [10, 20], recover from the range only once back within [12, 18]
+	cmd, err := newThresholdCommandFromConditions("B", "A", []ThresholdConditionJSON{
+		{
+			Evaluator: ConditionEvalJSON{Type: ThresholdIsAbove, Params: []float64{100}},
+		},
+		{
+			Evaluator:       ConditionEvalJSON{Type: ThresholdIsOutsideRange, Params: []float64{10, 20}},
+			UnloadEvaluator: &ConditionEvalJSON{Type: ThresholdIsWithinRange, Params: []float64{12, 18}},
+		},
+	}, featuremgmt.WithFeatures(featuremgmt.FlagRecoveryThreshold))
+	require.NoError(t, err)
+	require.IsType(t, &HysteresisCommand{}, cmd)
+	h := cmd.(*HysteresisCommand)
+	h.LoadedDimensions = Fingerprints{
+		fingerprint("loaded-19"):  {},
+		fingerprint("loaded-15"):  {},
+		fingerprint("loaded-150"): {},
+	}
+
+	result, err := h.Execute(context.Background(), time.Now(), mathexp.Vars{
+		"A": mathexp.Results{Values: mathexp.Values{
+			number("new-19", 19),
+			number("new-25", 25),
+			number("new-150", 150),
+			number("loaded-19", 19),
+			number("loaded-15", 15),
+			number("loaded-150", 150),
+		}},
+	}, tracing.InitializeTracerForTest())
+	require.NoError(t, err)
+	require.Equal(t, mathexp.Values{
+		number("new-19", 0),
+		number("new-25", 1),
+		number("new-150", 1),
+		number("loaded-19", 1),
+		number("loaded-15", 0),
+		number("loaded-150", 1),
+	}, result.Values)
+}
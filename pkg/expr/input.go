@@ -0,0 +1,60 @@
+package expr
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+)
+
+const (
+	// inputDatasourceID is similar to a fake ID for CMDNode. There is no specific reason for the selection of this value.
+	inputDatasourceID = -300
+
+	// InputDatasourceUID is the string constant used as the datasource name in requests
+	// to identify a query whose frames are provided by the caller in Query.Frames instead of being queried from a data source.
+	InputDatasourceUID = "__input__"
+)
+
+// InputNode is a node of expression tree that returns the frames provided with the request.
+type InputNode struct {
+	baseNode
+	frames data.Frames
+}
+
+// NodeType returns the data pipeline node type.
+func (in *InputNode) NodeType() NodeType {
+	return TypeInputNode
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the node.
+func (in *InputNode) NeedsVars() []string {
+	return []string{}
+}
+
+// Execute converts the frames provided with the request the same way DSNode converts the response of a data source.
+// Returns an error if no frames were provided.
+func (in *InputNode) Execute(ctx context.Context, _ time.Time, _ mathexp.Vars, s *Service) (mathexp.Results, error) {
+	if in.frames == nil {
+		return mathexp.Results{}, MakeQueryError(in.refID, InputDatasourceUID, fmt.Errorf("no input frames provided"))
+	}
+	_, result, err := s.converter.Convert(ctx, InputDatasourceUID, in.frames, s.allowLongFrames)
+	if err != nil {
+		err = makeConversionError(in.refID, err)
+	}
+	return result, err
+}
+
+func buildInputNode(rn *rawNode, frames data.Frames) *InputNode {
+	return &InputNode{
+		baseNode: baseNode{
+			id:    rn.idx,
+			refID: rn.RefID,
+		},
+		frames: frames,
+	}
+}
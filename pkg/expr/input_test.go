@@ -0,0 +1,90 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestInputNode(t *testing.T) {
+	features := featuremgmt.WithFeatures()
+	s := Service{
+		cfg:      setting.NewCfg(),
+		features: features,
+		tracer:   tracing.InitializeTracerForTest(),
+		metrics:  newMetrics(nil),
+		converter: &ResultConverter{
+			Features: features,
+			Tracer:   tracing.InitializeTracerForTest(),
+		},
+	}
+
+	inputModel, err := DataSourceModelFromNodeType(TypeInputNode)
+	require.NoError(t, err)
+	require.Equal(t, InputDatasourceUID, inputModel.UID)
+	require.Equal(t, TypeInputNode, NodeTypeFromDatasourceUID(InputDatasourceUID))
+
+	request := func(frames data.Frames) *Request {
+		return &Request{
+			Queries: []Query{
+				{
+					RefID:      "A",
+					DataSource: inputModel,
+					JSON:       json.RawMessage(`{}`),
+					Frames:     frames,
+				},
+				{
+					RefID:      "B",
+					DataSource: dataSourceModel(),
+					JSON:       json.RawMessage(`{ "datasource": { "uid": "__expr__", "type": "__expr__"}, "type": "math", "expression": "$A * 2" }`),
+				},
+			},
+			User: &user.SignedInUser{},
+		}
+	}
+
+	t.Run("should use provided frames as results of the query", func(t *testing.T) {
+		frame := data.NewFrame("",
+			data.NewField("time", nil, []time.Time{time.Unix(1, 0)}),
+			data.NewField("value", data.Labels{"test": "label"}, []*float64{fp(2)}))
+
+		pl, err := s.BuildPipeline(request(data.Frames{frame}))
+		require.NoError(t, err)
+		require.Equal(t, TypeInputNode, pl[0].NodeType())
+
+		res, err := s.ExecutePipeline(context.Background(), time.Now(), pl)
+		require.NoError(t, err)
+		require.NoError(t, res.Responses["B"].Error)
+		require.Len(t, res.Responses["B"].Frames, 1)
+		v, ok := res.Responses["B"].Frames[0].Fields[1].ConcreteAt(0)
+		require.True(t, ok)
+		require.Equal(t, 4.0, v)
+	})
+
+	t.Run("should return no data if frames are empty", func(t *testing.T) {
+		pl, err := s.BuildPipeline(request(data.Frames{}))
+		require.NoError(t, err)
+
+		res, err := s.ExecutePipeline(context.Background(), time.Now(), pl)
+		require.NoError(t, err)
+		require.NoError(t, res.Responses["A"].Error)
+	})
+
+	t.Run("should fail if frames are not provided", func(t *testing.T) {
+		pl, err := s.BuildPipeline(request(nil))
+		require.NoError(t, err)
+
+		res, err := s.ExecutePipeline(context.Background(), time.Now(), pl)
+		require.NoError(t, err)
+		require.ErrorContains(t, res.Responses["A"].Error, "no input frames provided")
+	})
+}
@@ -0,0 +1,262 @@
+package mathexp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// The model fitted to the series
+// +enum
+type ForecastModel string
+
+const (
+	// Repeat the values of the previous season
+	ForecastSeasonalNaive ForecastModel = "seasonal_naive"
+
+	// Additive triple exponential smoothing, or double exponential smoothing when there is no season
+	ForecastHoltWinters ForecastModel = "holt_winters"
+)
+
+// maxHorizonFactor caps the number of forecast points to this multiple of the number of points of the series.
+const maxHorizonFactor = 10
+
+// ErrInsufficientData is returned by Forecast when the series has too few points to fit the model.
+var ErrInsufficientData = errors.New("not enough data points to fit the model")
+
+// ForecastOptions holds the settings of the forecasting models.
+type ForecastOptions struct {
+	Model ForecastModel
+	// Season is the length of the seasonal cycle. It is required by ForecastSeasonalNaive and optional for ForecastHoltWinters.
+	Season time.Duration
+	// Horizon is how far past the last point of the series the forecast goes.
+	Horizon time.Duration
+	// Deviations is the distance of the bands from the forecast, in standard deviations of the fit errors.
+	Deviations float64
+	// Alpha, Beta and Gamma are the level, trend and seasonal smoothing factors of ForecastHoltWinters, in the [0, 1] range.
+	Alpha, Beta, Gamma float64
+}
+
+// Validate returns an error if the options cannot be used to fit a model.
+func (o ForecastOptions) Validate() error {
+	switch o.Model {
+	case ForecastSeasonalNaive:
+		if o.Season <= 0 {
+			return fmt.Errorf("model %s requires a season", o.Model)
+		}
+	case ForecastHoltWinters:
+		if o.Season < 0 {
+			return fmt.Errorf("season must not be negative, got %v", o.Season)
+		}
+		for _, f := range []struct {
+			name  string
+			value float64
+		}{{"alpha", o.Alpha}, {"beta", o.Beta}, {"gamma", o.Gamma}} {
+			if f.value < 0 || f.value > 1 || math.IsNaN(f.value) {
+				return fmt.Errorf("%s must be between 0 and 1, got %v", f.name, f.value)
+			}
+		}
+	default:
+		return fmt.Errorf("forecast model %v not implemented", o.Model)
+	}
+	if o.Horizon < 0 {
+		return fmt.Errorf("horizon must not be negative, got %v", o.Horizon)
+	}
+	if o.Deviations <= 0 || math.IsNaN(o.Deviations) {
+		return fmt.Errorf("deviations must be greater than 0, got %v", o.Deviations)
+	}
+	return nil
+}
+
+// Forecast fits the model to the series and returns the forecast with its upper and lower bands.
+// Over the range of the series the forecast holds the one-step-ahead predictions of the model,
+// followed by the predictions for the horizon, spaced by the typical interval between the points of the series.
+// The bands are the forecast plus and minus Deviations times the standard deviation of the fit errors.
+// Null and NaN points do not update the model. ErrInsufficientData is returned if the series is too short
+// to fit the model: one season for ForecastSeasonalNaive, two seasons for ForecastHoltWinters, or two points
+// when it has no season. The horizon must not hold more than ten times as many points as the series.
+func (s Series) Forecast(refID string, opts ForecastOptions) (forecast, upper, lower Series, err error) {
+	if err := opts.Validate(); err != nil {
+		return forecast, upper, lower, err
+	}
+	if s.Len() < 2 {
+		return forecast, upper, lower, ErrInsufficientData
+	}
+	step := typicalInterval(s)
+	if step <= 0 {
+		return forecast, upper, lower, fmt.Errorf("the points of the series must have distinct, increasing timestamps")
+	}
+	period := 0
+	if opts.Season > 0 {
+		period = int(math.Round(float64(opts.Season) / float64(step)))
+		if period < 1 {
+			period = 1
+		}
+	}
+	horizon := opts.Horizon / step
+	if maxHorizon := time.Duration(maxHorizonFactor * s.Len()); horizon > maxHorizon {
+		return forecast, upper, lower, fmt.Errorf("horizon %v is too long for the series: it spans %d points, the maximum is %d", opts.Horizon, horizon, maxHorizon)
+	}
+
+	values := make([]*float64, s.Len())
+	for i := range values {
+		if v := s.GetValue(i); v != nil && !math.IsNaN(*v) {
+			values[i] = v
+		}
+	}
+
+	var fitted []*float64
+	switch opts.Model {
+	case ForecastSeasonalNaive:
+		fitted, err = seasonalNaive(values, period, int(horizon))
+	case ForecastHoltWinters:
+		fitted, err = holtWinters(values, period, int(horizon), opts.Alpha, opts.Beta, opts.Gamma)
+	}
+	if err != nil {
+		return forecast, upper, lower, err
+	}
+
+	var sumSq float64
+	var count int
+	for i, v := range values {
+		if v != nil && fitted[i] != nil {
+			d := *v - *fitted[i]
+			sumSq += d * d
+			count++
+		}
+	}
+	width := math.NaN()
+	if count > 0 {
+		width = opts.Deviations * math.Sqrt(sumSq/float64(count))
+	}
+
+	forecast = NewSeries(refID, s.GetLabels(), len(fitted))
+	upper = NewSeries(refID, s.GetLabels(), len(fitted))
+	lower = NewSeries(refID, s.GetLabels(), len(fitted))
+	last := s.GetTime(s.Len() - 1)
+	for i, f := range fitted {
+		var t time.Time
+		if i < s.Len() {
+			t = s.GetTime(i)
+		} else {
+			t = last.Add(time.Duration(i-s.Len()+1) * step)
+		}
+		forecast.SetPoint(i, t, f)
+		if f == nil || math.IsNaN(width) {
+			upper.SetPoint(i, t, nil)
+			lower.SetPoint(i, t, nil)
+			continue
+		}
+		u, l := *f+width, *f-width
+		upper.SetPoint(i, t, &u)
+		lower.SetPoint(i, t, &l)
+	}
+	return forecast, upper, lower, nil
+}
+
+// typicalInterval returns the median interval between consecutive points of the series.
+func typicalInterval(s Series) time.Duration {
+	intervals := make([]time.Duration, 0, s.Len()-1)
+	for i := 1; i < s.Len(); i++ {
+		intervals = append(intervals, s.GetTime(i).Sub(s.GetTime(i-1)))
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i] < intervals[j] })
+	return intervals[len(intervals)/2]
+}
+
+// seasonalNaive predicts each point with the value one period before it. The horizon repeats the last period.
+func seasonalNaive(values []*float64, period, horizon int) ([]*float64, error) {
+	n := len(values)
+	if n < period {
+		return nil, ErrInsufficientData
+	}
+	fitted := make([]*float64, n+horizon)
+	for i := period; i < n; i++ {
+		fitted[i] = values[i-period]
+	}
+	for h := 0; h < horizon; h++ {
+		fitted[n+h] = values[n-period+h%period]
+	}
+	return fitted, nil
+}
+
+// holtWinters fits an additive Holt-Winters model, or Holt's linear trend model when period is 0,
+// and returns the one-step-ahead predictions followed by the horizon predictions.
+// The level and trend are initialised from the first two periods, or the first two points without a season.
+func holtWinters(values []*float64, period, horizon int, alpha, beta, gamma float64) ([]*float64, error) {
+	n := len(values)
+	var level, trend float64
+	seasonal := make([]float64, period)
+	start := 1
+	if period > 0 {
+		start = period
+		if n < 2*period {
+			return nil, ErrInsufficientData
+		}
+		first, ok1 := meanOf(values[:period])
+		second, ok2 := meanOf(values[period : 2*period])
+		if !ok1 || !ok2 {
+			return nil, ErrInsufficientData
+		}
+		level = first
+		trend = (second - first) / float64(period)
+		for i := 0; i < period; i++ {
+			if values[i] != nil {
+				seasonal[i] = *values[i] - first
+			}
+		}
+	} else {
+		if values[0] == nil || values[1] == nil {
+			return nil, ErrInsufficientData
+		}
+		level = *values[0]
+		trend = *values[1] - *values[0]
+	}
+
+	seasonAt := func(i int) float64 {
+		if period == 0 {
+			return 0
+		}
+		return seasonal[i%period]
+	}
+
+	fitted := make([]*float64, n+horizon)
+	for i := start; i < n; i++ {
+		s := seasonAt(i)
+		prediction := level + trend + s
+		fitted[i] = &prediction
+		y := prediction
+		if values[i] != nil {
+			y = *values[i]
+		}
+		prevLevel := level
+		level = alpha*(y-s) + (1-alpha)*(level+trend)
+		trend = beta*(level-prevLevel) + (1-beta)*trend
+		if period > 0 {
+			seasonal[i%period] = gamma*(y-level) + (1-gamma)*s
+		}
+	}
+	for h := 1; h <= horizon; h++ {
+		prediction := level + float64(h)*trend + seasonAt(n+h-1)
+		fitted[n+h-1] = &prediction
+	}
+	return fitted, nil
+}
+
+// meanOf returns the mean of the non-null values, and false if there are none.
+func meanOf(values []*float64) (float64, bool) {
+	var sum float64
+	var count int
+	for _, v := range values {
+		if v != nil {
+			sum += *v
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
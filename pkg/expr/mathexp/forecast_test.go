@@ -0,0 +1,111 @@
+package mathexp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeriesForecast(t *testing.T) {
+	seriesOf := func(values ...*float64) Series {
+		points := make([]tp, 0, len(values))
+		for i, v := range values {
+			points = append(points, tp{time.Unix(int64(i*10), 0), v})
+		}
+		return makeSeries("", nil, points...)
+	}
+	valuesOf := func(s Series) []*float64 {
+		values := make([]*float64, 0, s.Len())
+		for i := 0; i < s.Len(); i++ {
+			values = append(values, s.GetValue(i))
+		}
+		return values
+	}
+
+	t.Run("seasonal naive repeats the previous season", func(t *testing.T) {
+		s := seriesOf(float64Pointer(1), float64Pointer(5), float64Pointer(1), float64Pointer(5), float64Pointer(1), float64Pointer(3))
+		forecast, upper, lower, err := s.Forecast("B", ForecastOptions{
+			Model:      ForecastSeasonalNaive,
+			Season:     20 * time.Second,
+			Horizon:    40 * time.Second,
+			Deviations: 2,
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 10, forecast.Len())
+		require.Equal(t, time.Unix(90, 0), forecast.GetTime(9))
+		require.Equal(t, []*float64{
+			nil, nil, float64Pointer(1), float64Pointer(5), float64Pointer(1), float64Pointer(5),
+			float64Pointer(1), float64Pointer(3), float64Pointer(1), float64Pointer(3),
+		}, valuesOf(forecast))
+		// the only error is 3 instead of 5, so the standard deviation of the errors over 4 points is 1
+		require.Equal(t, []*float64{
+			nil, nil, float64Pointer(3), float64Pointer(7), float64Pointer(3), float64Pointer(7),
+			float64Pointer(3), float64Pointer(5), float64Pointer(3), float64Pointer(5),
+		}, valuesOf(upper))
+		require.Equal(t, float64Pointer(-1), lower.GetValue(2))
+	})
+
+	t.Run("holt winters fits a seasonal pattern", func(t *testing.T) {
+		s := seriesOf(float64Pointer(1), float64Pointer(5), float64Pointer(1), float64Pointer(5), float64Pointer(1), nil, float64Pointer(1), float64Pointer(5))
+		forecast, upper, _, err := s.Forecast("B", ForecastOptions{
+			Model:      ForecastHoltWinters,
+			Season:     20 * time.Second,
+			Horizon:    20 * time.Second,
+			Deviations: 3,
+			Alpha:      0.5,
+			Beta:       0.1,
+			Gamma:      0.1,
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 10, forecast.Len())
+		require.Nil(t, forecast.GetValue(1))
+		for i, expected := range []float64{1, 5, 1, 5, 1, 5, 1, 5} {
+			require.InDelta(t, expected, *forecast.GetValue(i + 2), 1e-9)
+			require.InDelta(t, expected, *upper.GetValue(i + 2), 1e-9)
+		}
+	})
+
+	t.Run("holt winters without season follows the trend", func(t *testing.T) {
+		s := seriesOf(float64Pointer(1), float64Pointer(2), float64Pointer(3), float64Pointer(4))
+		forecast, _, _, err := s.Forecast("B", ForecastOptions{
+			Model:      ForecastHoltWinters,
+			Horizon:    20 * time.Second,
+			Deviations: 3,
+			Alpha:      0.5,
+			Beta:       0.5,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 6, forecast.Len())
+		require.InDelta(t, 5, *forecast.GetValue(4), 1e-9)
+		require.InDelta(t, 6, *forecast.GetValue(5), 1e-9)
+	})
+
+	t.Run("not enough data", func(t *testing.T) {
+		s := seriesOf(float64Pointer(1), float64Pointer(5), float64Pointer(1))
+		_, _, _, err := s.Forecast("B", ForecastOptions{
+			Model:      ForecastHoltWinters,
+			Season:     20 * time.Second,
+			Deviations: 3,
+		})
+		require.ErrorIs(t, err, ErrInsufficientData)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		s := seriesOf(float64Pointer(1), float64Pointer(5), float64Pointer(1))
+		for _, opts := range []ForecastOptions{
+			{Model: "arima", Deviations: 3},
+			{Model: ForecastSeasonalNaive, Deviations: 3},
+			{Model: ForecastHoltWinters, Deviations: 0},
+			{Model: ForecastHoltWinters, Deviations: 3, Alpha: 1.5},
+			{Model: ForecastHoltWinters, Deviations: 3, Horizon: -time.Minute},
+			{Model: ForecastHoltWinters, Deviations: 3, Horizon: 310 * time.Second},
+		} {
+			_, _, _, err := s.Forecast("B", opts)
+			require.Error(t, err)
+			require.NotErrorIs(t, err, ErrInsufficientData)
+		}
+	})
+}
@@ -1,7 +1,11 @@
 package mathexp
 
 import (
+	"fmt"
 	"math"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
 
 	"github.com/grafana/grafana/pkg/expr/mathexp/parse"
 )
@@ -68,6 +72,43 @@ var builtins = map[string]parse.Func{
 		VariantReturn: true,
 		F:             floor,
 	},
+	"clamp_min": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet, parse.TypeScalar},
+		VariantReturn: true,
+		F:             clampMin,
+	},
+	"clamp_max": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet, parse.TypeScalar},
+		VariantReturn: true,
+		F:             clampMax,
+	},
+	"rate": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      rate,
+	},
+	"delta": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      delta,
+	},
+	"cumsum": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      cumsum,
+	},
+	"moving_avg": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet, parse.TypeString},
+		Return: parse.TypeSeriesSet,
+		F:      movingAvg,
+		Check:  checkDurationArg(1),
+	},
+	"shift": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet, parse.TypeString},
+		Return: parse.TypeSeriesSet,
+		F:      shift,
+		Check:  checkDurationArg(1),
+	},
 }
 
 // abs returns the absolute value for each result in NumberSet, SeriesSet, or Scalar
@@ -307,3 +348,200 @@ func floor(e *State, varSet Results) (Results, error) {
 	}
 	return newRes, nil
 }
+
+// clampMin returns the greater of the value and the scalar for each result in NumberSet, SeriesSet, or Scalar
+func clampMin(e *State, varSet Results, minSet Results) (Results, error) {
+	minV, err := scalarArg(minSet)
+	if err != nil {
+		return Results{}, fmt.Errorf("clamp_min: %w", err)
+	}
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		newVal, err := perFloat(e, res, func(f float64) float64 {
+			return math.Max(f, minV)
+		})
+		if err != nil {
+			return newRes, err
+		}
+		newRes.Values = append(newRes.Values, newVal)
+	}
+	return newRes, nil
+}
+
+// clampMax returns the lesser of the value and the scalar for each result in NumberSet, SeriesSet, or Scalar
+func clampMax(e *State, varSet Results, maxSet Results) (Results, error) {
+	maxV, err := scalarArg(maxSet)
+	if err != nil {
+		return Results{}, fmt.Errorf("clamp_max: %w", err)
+	}
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		newVal, err := perFloat(e, res, func(f float64) float64 {
+			return math.Min(f, maxV)
+		})
+		if err != nil {
+			return newRes, err
+		}
+		newRes.Values = append(newRes.Values, newVal)
+	}
+	return newRes, nil
+}
+
+// rate returns, for each point of each series but the first, the per-second rate of change since the previous point.
+func rate(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "rate", varSet, func(s Series) (Series, error) {
+		return consecutive(e, s, func(prev, cur float64, elapsed time.Duration) float64 {
+			return (cur - prev) / elapsed.Seconds()
+		}), nil
+	})
+}
+
+// delta returns, for each point of each series but the first, the difference with the previous point.
+func delta(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "delta", varSet, func(s Series) (Series, error) {
+		return consecutive(e, s, func(prev, cur float64, _ time.Duration) float64 {
+			return cur - prev
+		}), nil
+	})
+}
+
+// cumsum returns the running total of each series. Null points stay null and do not contribute to the total.
+func cumsum(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "cumsum", varSet, func(s Series) (Series, error) {
+		newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len())
+		sum := float64(0)
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			if f == nil {
+				newSeries.SetPoint(i, t, nil)
+				continue
+			}
+			sum += *f
+			total := sum
+			newSeries.SetPoint(i, t, &total)
+		}
+		return newSeries, nil
+	})
+}
+
+// movingAvg returns, for each point of each series, the mean of the non-null points in the window that ends at the point.
+// NaN points are ignored like null ones.
+func movingAvg(e *State, varSet Results, rawWindow string) (Results, error) {
+	window, err := gtime.ParseDuration(rawWindow)
+	if err != nil {
+		return Results{}, fmt.Errorf("moving_avg: invalid window %q: %w", rawWindow, err)
+	}
+	if window <= 0 {
+		return Results{}, fmt.Errorf("moving_avg: window must be positive, got %q", rawWindow)
+	}
+	return perSeries(e, "moving_avg", varSet, func(s Series) (Series, error) {
+		newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len())
+		start := 0
+		sum, count := float64(0), 0
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			if f != nil && !math.IsNaN(*f) {
+				sum += *f
+				count++
+			}
+			for ; start <= i && !s.GetTime(start).After(t.Add(-window)); start++ {
+				if old := s.GetValue(start); old != nil && !math.IsNaN(*old) {
+					sum -= *old
+					count--
+				}
+			}
+			if count == 0 {
+				newSeries.SetPoint(i, t, nil)
+				continue
+			}
+			avg := sum / float64(count)
+			newSeries.SetPoint(i, t, &avg)
+		}
+		return newSeries, nil
+	})
+}
+
+// shift moves each point of each series forward in time by the duration, or backward if it is negative.
+// For example $A - shift($A, "1d") compares each point with the one of the same time the day before.
+// Only the points returned by the query are shifted, so the time range of the query must be extended by the
+// duration, otherwise the shifted series is empty or only covers part of the time range.
+func shift(e *State, varSet Results, rawOffset string) (Results, error) {
+	offset, err := gtime.ParseDuration(rawOffset)
+	if err != nil {
+		return Results{}, fmt.Errorf("shift: invalid duration %q: %w", rawOffset, err)
+	}
+	return perSeries(e, "shift", varSet, func(s Series) (Series, error) {
+		newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len())
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			newSeries.SetPoint(i, t.Add(offset), f)
+		}
+		return newSeries, nil
+	})
+}
+
+// perSeries applies seriesF to each series of the results. NoData is passed through, any other type is an error.
+func perSeries(e *State, name string, varSet Results, seriesF func(s Series) (Series, error)) (Results, error) {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		switch v := res.(type) {
+		case Series:
+			newSeries, err := seriesF(v)
+			if err != nil {
+				return newRes, err
+			}
+			newRes.Values = append(newRes.Values, newSeries)
+		case NoData:
+			newRes.Values = append(newRes.Values, v.New())
+		default:
+			return newRes, fmt.Errorf("%s: can only be applied to series, got type %v", name, res.Type())
+		}
+	}
+	return newRes, nil
+}
+
+// consecutive builds a series with one point less than s, where each point is the result of pointF applied to
+// the previous and the current value. The point is null if either value is null.
+func consecutive(e *State, s Series, pointF func(prev, cur float64, elapsed time.Duration) float64) Series {
+	if s.Len() < 2 {
+		return NewSeries(e.RefID, s.GetLabels(), 0)
+	}
+	newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len()-1)
+	for i := 1; i < s.Len(); i++ {
+		prevT, prev := s.GetPoint(i - 1)
+		t, cur := s.GetPoint(i)
+		if prev == nil || cur == nil || !t.After(prevT) {
+			newSeries.SetPoint(i-1, t, nil)
+			continue
+		}
+		f := pointF(*prev, *cur, t.Sub(prevT))
+		newSeries.SetPoint(i-1, t, &f)
+	}
+	return newSeries
+}
+
+// scalarArg returns the value of a scalar argument.
+func scalarArg(res Results) (float64, error) {
+	if len(res.Values) != 1 || res.Values[0].Type() != parse.TypeScalar {
+		return 0, fmt.Errorf("expected a scalar argument")
+	}
+	f := res.Values[0].(Scalar).GetFloat64Value()
+	if f == nil {
+		return math.NaN(), nil
+	}
+	return *f, nil
+}
+
+// checkDurationArg returns a parse time check that the argument at idx is a valid duration string.
+func checkDurationArg(idx int) func(*parse.Tree, *parse.FuncNode) error {
+	return func(_ *parse.Tree, f *parse.FuncNode) error {
+		arg, ok := f.Args[idx].(*parse.StringNode)
+		if !ok {
+			return fmt.Errorf("parse: %s expects a duration string for argument %v", f.Name, idx)
+		}
+		if _, err := gtime.ParseDuration(arg.Text); err != nil {
+			return fmt.Errorf("parse: invalid duration %q for argument %v of %s: %w", arg.Text, idx, f.Name, err)
+		}
+		return nil
+	}
+}
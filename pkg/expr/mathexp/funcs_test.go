@@ -140,3 +140,129 @@ func TestIsNumberFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestSeriesFuncs(t *testing.T) {
+	series := Vars{
+		"A": resultValuesNoErr(
+			makeSeries("", nil,
+				tp{time.Unix(0, 0), float64Pointer(1)},
+				tp{time.Unix(10, 0), float64Pointer(3)},
+				tp{time.Unix(20, 0), nil},
+				tp{time.Unix(30, 0), float64Pointer(9)}),
+		),
+	}
+	var tests = []struct {
+		name    string
+		expr    string
+		vars    Vars
+		results Results
+	}{
+		{
+			name: "rate",
+			expr: "rate($A)",
+			vars: series,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(10, 0), float64Pointer(0.2)},
+					tp{time.Unix(20, 0), nil},
+					tp{time.Unix(30, 0), nil}),
+			),
+		},
+		{
+			name: "delta",
+			expr: "delta($A)",
+			vars: series,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(10, 0), float64Pointer(2)},
+					tp{time.Unix(20, 0), nil},
+					tp{time.Unix(30, 0), nil}),
+			),
+		},
+		{
+			name: "cumsum",
+			expr: "cumsum($A)",
+			vars: series,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(0, 0), float64Pointer(1)},
+					tp{time.Unix(10, 0), float64Pointer(4)},
+					tp{time.Unix(20, 0), nil},
+					tp{time.Unix(30, 0), float64Pointer(13)}),
+			),
+		},
+		{
+			name: "moving_avg",
+			expr: `moving_avg($A, "15s")`,
+			vars: series,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(0, 0), float64Pointer(1)},
+					tp{time.Unix(10, 0), float64Pointer(2)},
+					tp{time.Unix(20, 0), float64Pointer(3)},
+					tp{time.Unix(30, 0), float64Pointer(9)}),
+			),
+		},
+		{
+			name: "shift",
+			expr: `shift($A, "1m")`,
+			vars: series,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(60, 0), float64Pointer(1)},
+					tp{time.Unix(70, 0), float64Pointer(3)},
+					tp{time.Unix(80, 0), nil},
+					tp{time.Unix(90, 0), float64Pointer(9)}),
+			),
+		},
+		{
+			name: "clamp_min on number",
+			expr: "clamp_min($A, 2)",
+			vars: Vars{
+				"A": resultValuesNoErr(makeNumber("", nil, float64Pointer(1))),
+			},
+			results: resultValuesNoErr(makeNumber("", nil, float64Pointer(2))),
+		},
+		{
+			name: "clamp_max on series",
+			expr: "clamp_max($A, 2)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(0, 0), float64Pointer(1)},
+						tp{time.Unix(10, 0), float64Pointer(3)}),
+				),
+			},
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(0, 0), float64Pointer(1)},
+					tp{time.Unix(10, 0), float64Pointer(2)}),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			require.NoError(t, err)
+			res, err := e.Execute("", tt.vars, tracing.InitializeTracerForTest())
+			require.NoError(t, err)
+			require.Equal(t, tt.results, res)
+		})
+	}
+
+	t.Run("parse errors", func(t *testing.T) {
+		for _, expr := range []string{`shift($A, "yesterday")`, `moving_avg($A)`, `rate(1)`, `clamp_min($A, "1")`} {
+			_, err := New(expr)
+			require.Errorf(t, err, expr)
+		}
+	})
+
+	t.Run("series functions do not accept numbers", func(t *testing.T) {
+		e, err := New("rate($A)")
+		require.NoError(t, err)
+		_, err = e.Execute("", Vars{
+			"A": resultValuesNoErr(makeNumber("", nil, float64Pointer(1))),
+		}, tracing.InitializeTracerForTest())
+		require.Error(t, err)
+	})
+}
@@ -10,6 +10,15 @@ import (
 
 type ReducerFunc = func(fv *Float64Field) *float64
 
+// SeriesReducerFunc is a reducer that needs the timestamps of the series in addition to its values.
+type SeriesReducerFunc = func(s Series) *float64
+
+// ReduceOptions holds the parameters of the reducers that accept them.
+type ReduceOptions struct {
+	// Percentile is the percentile, in the (0, 100] range, computed by ReducerPercentile.
+	Percentile float64
+}
+
 // The reducer function
 // +enum
 type ReducerID string
@@ -22,11 +31,28 @@ const (
 	ReducerCount  ReducerID = "count"
 	ReducerLast   ReducerID = "last"
 	ReducerMedian ReducerID = "median"
+
+	ReducerFirst        ReducerID = "first"
+	ReducerRange        ReducerID = "range"
+	ReducerDelta        ReducerID = "delta"
+	ReducerRate         ReducerID = "rate"
+	ReducerStdDev       ReducerID = "stddev"
+	ReducerVariance     ReducerID = "variance"
+	ReducerCountNonNull ReducerID = "count_non_null"
+	ReducerP50          ReducerID = "p50"
+	ReducerP90          ReducerID = "p90"
+	ReducerP95          ReducerID = "p95"
+	ReducerP99          ReducerID = "p99"
+	ReducerPercentile   ReducerID = "percentile"
 )
 
 // GetSupportedReduceFuncs returns collection of supported function names
 func GetSupportedReduceFuncs() []ReducerID {
-	return []ReducerID{ReducerSum, ReducerMean, ReducerMin, ReducerMax, ReducerCount, ReducerLast, ReducerMedian}
+	return []ReducerID{
+		ReducerSum, ReducerMean, ReducerMin, ReducerMax, ReducerCount, ReducerLast, ReducerMedian,
+		ReducerFirst, ReducerRange, ReducerDelta, ReducerRate, ReducerStdDev, ReducerVariance, ReducerCountNonNull,
+		ReducerP50, ReducerP90, ReducerP95, ReducerP99, ReducerPercentile,
+	}
 }
 
 func Sum(fv *Float64Field) *float64 {
@@ -126,6 +152,111 @@ func Median(fv *Float64Field) *float64 {
 	}
 }
 
+func First(fv *Float64Field) *float64 {
+	var f float64
+	if fv.Len() == 0 {
+		f = math.NaN()
+		return &f
+	}
+	return fv.GetValue(0)
+}
+
+// Range returns the difference between the largest and the smallest value.
+func Range(fv *Float64Field) *float64 {
+	minV, maxV := Min(fv), Max(fv)
+	f := *maxV - *minV
+	return &f
+}
+
+// Delta returns the difference between the last and the first value.
+func Delta(fv *Float64Field) *float64 {
+	first, last := First(fv), Last(fv)
+	if first == nil || last == nil {
+		nan := math.NaN()
+		return &nan
+	}
+	f := *last - *first
+	return &f
+}
+
+// Variance returns the population variance of the values.
+func Variance(fv *Float64Field) *float64 {
+	mean := Avg(fv)
+	if math.IsNaN(*mean) {
+		return mean
+	}
+	var sum float64
+	for i := 0; i < fv.Len(); i++ {
+		d := *fv.GetValue(i) - *mean
+		sum += d * d
+	}
+	f := sum / float64(fv.Len())
+	return &f
+}
+
+// StdDev returns the population standard deviation of the values.
+func StdDev(fv *Float64Field) *float64 {
+	f := math.Sqrt(*Variance(fv))
+	return &f
+}
+
+// CountNonNull returns the number of values that are neither null nor NaN.
+func CountNonNull(fv *Float64Field) *float64 {
+	var f float64
+	for i := 0; i < fv.Len(); i++ {
+		v := fv.GetValue(i)
+		if v != nil && !math.IsNaN(*v) {
+			f++
+		}
+	}
+	return &f
+}
+
+// Percentile returns a reducer that computes the p-th percentile of the values,
+// interpolating linearly between the closest ranks.
+func Percentile(p float64) ReducerFunc {
+	return func(fv *Float64Field) *float64 {
+		values := make([]float64, 0, fv.Len())
+		for i := 0; i < fv.Len(); i++ {
+			v := fv.GetValue(i)
+			if v == nil || math.IsNaN(*v) {
+				nan := math.NaN()
+				return &nan
+			}
+			values = append(values, *v)
+		}
+
+		if len(values) == 0 {
+			nan := math.NaN()
+			return &nan
+		}
+
+		sort.Float64s(values)
+		rank := p / 100 * float64(len(values)-1)
+		lower := int(math.Floor(rank))
+		upper := int(math.Ceil(rank))
+		f := values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+		return &f
+	}
+}
+
+// Rate returns the per-second rate of change between the first and the last point of the series.
+// Unlike the Prometheus function of the same name it does not account for counter resets.
+func Rate(s Series) *float64 {
+	nan := math.NaN()
+	if s.Len() < 2 {
+		return &nan
+	}
+	firstT, first := s.GetPoint(0)
+	lastT, last := s.GetPoint(s.Len() - 1)
+	seconds := lastT.Sub(firstT).Seconds()
+	if first == nil || last == nil || seconds == 0 {
+		return &nan
+	}
+	f := (*last - *first) / seconds
+	return &f
+}
+
 func GetReduceFunc(rFunc ReducerID) (ReducerFunc, error) {
 	switch rFunc {
 	case ReducerSum:
@@ -142,15 +273,60 @@ func GetReduceFunc(rFunc ReducerID) (ReducerFunc, error) {
 		return Last, nil
 	case ReducerMedian:
 		return Median, nil
+	case ReducerFirst:
+		return First, nil
+	case ReducerRange:
+		return Range, nil
+	case ReducerDelta:
+		return Delta, nil
+	case ReducerStdDev:
+		return StdDev, nil
+	case ReducerVariance:
+		return Variance, nil
+	case ReducerCountNonNull:
+		return CountNonNull, nil
+	case ReducerP50:
+		return Percentile(50), nil
+	case ReducerP90:
+		return Percentile(90), nil
+	case ReducerP95:
+		return Percentile(95), nil
+	case ReducerP99:
+		return Percentile(99), nil
 	default:
 		return nil, fmt.Errorf("reduction %v not implemented", rFunc)
 	}
 }
 
+// GetSeriesReduceFunc returns the reducer for the given ID. Unlike GetReduceFunc it also supports
+// the reducers that need the timestamps of the series or that are configured by options.
+func GetSeriesReduceFunc(rFunc ReducerID, opts ReduceOptions) (SeriesReducerFunc, error) {
+	var reduceFunc ReducerFunc
+	switch rFunc {
+	case ReducerRate:
+		return Rate, nil
+	case ReducerPercentile:
+		if opts.Percentile <= 0 || opts.Percentile > 100 {
+			return nil, fmt.Errorf("percentile must be greater than 0 and less than or equal to 100, got %v", opts.Percentile)
+		}
+		reduceFunc = Percentile(opts.Percentile)
+	default:
+		var err error
+		reduceFunc, err = GetReduceFunc(rFunc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return func(s Series) *float64 {
+		ff := Float64Field(*s.Frame.Fields[seriesTypeValIdx])
+		return reduceFunc(&ff)
+	}, nil
+}
+
 // Reduce turns the Series into a Number based on the given reduction function
 // if ReduceMapper is defined it applies it to the provided series and performs reduction of the resulting series.
 // Otherwise, the reduction operation is done against the original series.
-func (s Series) Reduce(refID string, rFunc ReducerID, mapper ReduceMapper) (Number, error) {
+func (s Series) Reduce(refID string, rFunc ReducerID, mapper ReduceMapper, opts ReduceOptions) (Number, error) {
 	var l data.Labels
 	if s.GetLabels() != nil {
 		l = s.GetLabels().Copy()
@@ -161,13 +337,11 @@ func (s Series) Reduce(refID string, rFunc ReducerID, mapper ReduceMapper) (Numb
 	if mapper != nil {
 		series = mapSeries(s, mapper)
 	}
-	fVec := series.Frame.Fields[seriesTypeValIdx]
-	floatField := Float64Field(*fVec)
-	reduceFunc, err := GetReduceFunc(rFunc)
+	reduceFunc, err := GetSeriesReduceFunc(rFunc, opts)
 	if err != nil {
 		return number, fmt.Errorf("invalid expression '%s': %w", refID, err)
 	}
-	f = reduceFunc(&floatField)
+	f = reduceFunc(series)
 	if f != nil && mapper != nil {
 		f = mapper.MapOutput(f)
 	}
@@ -281,7 +281,7 @@ func TestSeriesReduce(t *testing.T) {
 			results := Results{}
 			seriesSet := tt.vars[tt.varToReduce]
 			for _, series := range seriesSet.Values {
-				ns, err := series.Value().(*Series).Reduce("", tt.red, nil)
+				ns, err := series.Value().(*Series).Reduce("", tt.red, nil, ReduceOptions{})
 				tt.errIs(t, err)
 				if err != nil {
 					return
@@ -401,7 +401,7 @@ func TestSeriesReduceDropNN(t *testing.T) {
 			results := Results{}
 			seriesSet := tt.vars[tt.varToReduce]
 			for _, series := range seriesSet.Values {
-				ns, err := series.Value().(*Series).Reduce("", tt.red, DropNonNumber{})
+				ns, err := series.Value().(*Series).Reduce("", tt.red, DropNonNumber{}, ReduceOptions{})
 				require.NoError(t, err)
 				results.Values = append(results.Values, ns)
 			}
@@ -523,7 +523,7 @@ func TestSeriesReduceReplaceNN(t *testing.T) {
 			results := Results{}
 			seriesSet := tt.vars[tt.varToReduce]
 			for _, series := range seriesSet.Values {
-				ns, err := series.Value().(*Series).Reduce("", tt.red, ReplaceNonNumberWithValue{Value: replaceWith})
+				ns, err := series.Value().(*Series).Reduce("", tt.red, ReplaceNonNumberWithValue{Value: replaceWith}, ReduceOptions{})
 				require.NoError(t, err)
 				results.Values = append(results.Values, ns)
 			}
@@ -538,6 +538,60 @@ func TestSeriesReduceReplaceNN(t *testing.T) {
 	}
 }
 
+func TestSeriesReduceStatistical(t *testing.T) {
+	series := makeSeries("temp", nil,
+		tp{time.Unix(0, 0), float64Pointer(4)},
+		tp{time.Unix(10, 0), float64Pointer(2)},
+		tp{time.Unix(20, 0), float64Pointer(8)},
+		tp{time.Unix(30, 0), float64Pointer(6)},
+		tp{time.Unix(40, 0), float64Pointer(10)},
+	)
+
+	var tests = []struct {
+		name     string
+		red      ReducerID
+		opts     ReduceOptions
+		series   Series
+		expected *float64
+	}{
+		{name: "first", red: ReducerFirst, series: series, expected: float64Pointer(4)},
+		{name: "range", red: ReducerRange, series: series, expected: float64Pointer(8)},
+		{name: "delta", red: ReducerDelta, series: series, expected: float64Pointer(6)},
+		{name: "rate", red: ReducerRate, series: series, expected: float64Pointer(0.15)},
+		{name: "variance", red: ReducerVariance, series: series, expected: float64Pointer(8)},
+		{name: "stddev", red: ReducerStdDev, series: series, expected: float64Pointer(math.Sqrt(8))},
+		{name: "count_non_null", red: ReducerCountNonNull, series: seriesWithNil["A"].Values[0].(Series), expected: float64Pointer(1)},
+		{name: "p50", red: ReducerP50, series: series, expected: float64Pointer(6)},
+		{name: "p90", red: ReducerP90, series: series, expected: float64Pointer(9.2)},
+		{name: "percentile 25", red: ReducerPercentile, opts: ReduceOptions{Percentile: 25}, series: series, expected: float64Pointer(4)},
+		{name: "rate with a nil value", red: ReducerRate, series: seriesWithNil["A"].Values[0].(Series), expected: NaN},
+		{name: "stddev with a nil value", red: ReducerStdDev, series: seriesWithNil["A"].Values[0].(Series), expected: NaN},
+		{name: "p99 of empty series", red: ReducerP99, series: seriesEmpty["A"].Values[0].(Series), expected: NaN},
+		{name: "first of empty series", red: ReducerFirst, series: seriesEmpty["A"].Values[0].(Series), expected: NaN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := tt.series.Reduce("", tt.red, nil, tt.opts)
+			require.NoError(t, err)
+			actual := n.GetFloat64Value()
+			require.NotNil(t, actual)
+			if math.IsNaN(*tt.expected) {
+				require.True(t, math.IsNaN(*actual))
+				return
+			}
+			require.InDelta(t, *tt.expected, *actual, 1e-9)
+		})
+	}
+
+	t.Run("percentile must be in range", func(t *testing.T) {
+		_, err := series.Reduce("", ReducerPercentile, nil, ReduceOptions{})
+		require.Error(t, err)
+		_, err = series.Reduce("", ReducerPercentile, nil, ReduceOptions{Percentile: 101})
+		require.Error(t, err)
+	})
+}
+
 func sortedFloat64(f []float64) []float64 {
 	f = append([]float64(nil), f...)
 	sort.Float64s(f)
@@ -20,10 +20,34 @@ const (
 
 	// Do not fill values (nill)
 	UpsamplerFillNA Upsampler = "fillna"
+
+	// Interpolate linearly between the surrounding values
+	UpsamplerLinear Upsampler = "linear"
+)
+
+// The alignment of the resampled points
+// +enum
+type Alignment string
+
+const (
+	// Start at the beginning of the time range
+	AlignmentFrom Alignment = "from"
+
+	// Start at the first multiple of the interval, e.g. on the minute or on the hour
+	AlignmentClock Alignment = "clock"
 )
 
 // Resample turns the Series into a Number based on the given reduction function
-func (s Series) Resample(refID string, interval time.Duration, downsampler ReducerID, upsampler Upsampler, from, to time.Time) (Series, error) {
+func (s Series) Resample(refID string, interval time.Duration, downsampler ReducerID, upsampler Upsampler, alignment Alignment, from, to time.Time) (Series, error) {
+	switch alignment {
+	case "", AlignmentFrom:
+	case AlignmentClock:
+		if aligned := from.Truncate(interval); aligned.Before(from) {
+			from = aligned.Add(interval)
+		}
+	default:
+		return s, fmt.Errorf("alignment %v not implemented", alignment)
+	}
 	newSeriesLength := int(float64(to.Sub(from).Nanoseconds()) / float64(interval.Nanoseconds()))
 	if newSeriesLength <= 0 {
 		return s, fmt.Errorf("the series cannot be sampled further; the time range is shorter than the interval")
@@ -31,6 +55,7 @@ func (s Series) Resample(refID string, interval time.Duration, downsampler Reduc
 	resampled := NewSeries(refID, s.GetLabels(), newSeriesLength+1)
 	bookmark := 0
 	var lastSeen *float64
+	var lastSeenTime time.Time
 	idx := 0
 	t := from
 	for !t.After(to) && idx <= newSeriesLength {
@@ -47,6 +72,7 @@ func (s Series) Resample(refID string, interval time.Duration, downsampler Reduc
 			bookmark++
 			sIdx++
 			lastSeen = v
+			lastSeenTime = st
 			vals = append(vals, v)
 		}
 		var value *float64
@@ -66,6 +92,16 @@ func (s Series) Resample(refID string, interval time.Duration, downsampler Reduc
 				}
 			case UpsamplerFillNA:
 				value = nil
+			case UpsamplerLinear:
+				value = nil
+				if lastSeen != nil && sIdx < s.Len() {
+					nextTime, next := s.GetPoint(sIdx)
+					if next != nil {
+						ratio := float64(t.Sub(lastSeenTime)) / float64(nextTime.Sub(lastSeenTime))
+						v := *lastSeen + (*next-*lastSeen)*ratio
+						value = &v
+					}
+				}
 			default:
 				return s, fmt.Errorf("upsampling %v not implemented", upsampler)
 			}
@@ -15,6 +15,7 @@ func TestResampleSeries(t *testing.T) {
 		interval         time.Duration
 		downsampler      ReducerID
 		upsampler        Upsampler
+		alignment        Alignment
 		timeRange        backend.TimeRange
 		seriesToResample Series
 		series           Series
@@ -277,10 +278,81 @@ func TestResampleSeries(t *testing.T) {
 				time.Unix(9, 0), float64Pointer(0),
 			}),
 		},
+		{
+			name:        "resample series: upsampling (mean / linear )",
+			interval:    time.Second * 2,
+			downsampler: "mean",
+			upsampler:   "linear",
+			timeRange: backend.TimeRange{
+				From: time.Unix(0, 0),
+				To:   time.Unix(10, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(1, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(5, 0), float64Pointer(6),
+			}, tp{
+				time.Unix(9, 0), nil,
+			}),
+			series: makeSeries("", nil, tp{
+				time.Unix(0, 0), nil,
+			}, tp{
+				time.Unix(2, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(4, 0), float64Pointer(5),
+			}, tp{
+				time.Unix(6, 0), float64Pointer(6),
+			}, tp{
+				time.Unix(8, 0), nil,
+			}, tp{
+				time.Unix(10, 0), nil,
+			}),
+		},
+		{
+			name:        "resample series: aligned to the clock (mean / fillna )",
+			interval:    time.Second * 5,
+			downsampler: "mean",
+			upsampler:   "fillna",
+			alignment:   "clock",
+			timeRange: backend.TimeRange{
+				From: time.Unix(3, 0),
+				To:   time.Unix(16, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(4, 0), float64Pointer(1),
+			}, tp{
+				time.Unix(6, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(9, 0), float64Pointer(3),
+			}, tp{
+				time.Unix(12, 0), float64Pointer(4),
+			}),
+			series: makeSeries("", nil, tp{
+				time.Unix(5, 0), float64Pointer(1),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(2.5),
+			}, tp{
+				time.Unix(15, 0), float64Pointer(4),
+			}),
+		},
+		{
+			name:        "resample series: unknown alignment",
+			interval:    time.Second * 5,
+			downsampler: "mean",
+			upsampler:   "fillna",
+			alignment:   "hourly",
+			timeRange: backend.TimeRange{
+				From: time.Unix(0, 0),
+				To:   time.Unix(16, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(2, 0), float64Pointer(2),
+			}),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			series, err := tt.seriesToResample.Resample("", tt.interval, tt.downsampler, tt.upsampler, tt.timeRange.From, tt.timeRange.To)
+			series, err := tt.seriesToResample.Resample("", tt.interval, tt.downsampler, tt.upsampler, tt.alignment, tt.timeRange.From, tt.timeRange.To)
 			if tt.series.Frame == nil {
 				require.Error(t, err)
 			} else {
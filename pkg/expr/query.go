@@ -29,6 +29,9 @@ const (
 
 	// SQL query via DuckDB
 	QueryTypeSQL QueryType = "sql"
+
+	// Forecast with anomaly bands
+	QueryTypeForecast QueryType = "forecast"
 )
 
 type MathQuery struct {
@@ -60,6 +63,9 @@ type ResampleQuery struct {
 
 	// The upsample function
 	Upsampler mathexp.Upsampler `json:"upsampler"`
+
+	// The alignment of the resampled points, defaults to the start of the time range
+	Alignment mathexp.Alignment `json:"alignment,omitempty"`
 }
 
 type ThresholdQuery struct {
@@ -79,6 +85,33 @@ type SQLExpression struct {
 	Expression string `json:"expression" jsonschema:"minLength=1,example=SELECT * FROM A LIMIT 1"`
 }
 
+// QueryType = forecast
+type ForecastQuery struct {
+	// Reference to single query result
+	Expression string `json:"expression" jsonschema:"minLength=1,example=$A"`
+
+	// The model fitted to the series
+	Model mathexp.ForecastModel `json:"model"`
+
+	// The length of the seasonal cycle, required by the seasonal_naive model
+	Season string `json:"season,omitempty" jsonschema:"example=1d,example=1h"`
+
+	// How far past the end of the series to forecast, at most ten times as many points as the series
+	Horizon string `json:"horizon,omitempty" jsonschema:"example=1h"`
+
+	// Distance of the bands from the forecast, in standard deviations of the fit errors. Defaults to 3
+	Deviations *float64 `json:"deviations,omitempty"`
+
+	// Level smoothing factor of the holt_winters model, between 0 and 1. Defaults to 0.5
+	Alpha *float64 `json:"alpha,omitempty"`
+
+	// Trend smoothing factor of the holt_winters model, between 0 and 1. Defaults to 0.1
+	Beta *float64 `json:"beta,omitempty"`
+
+	// Seasonal smoothing factor of the holt_winters model, between 0 and 1. Defaults to 0.1
+	Gamma *float64 `json:"gamma,omitempty"`
+}
+
 //-------------------------------
 // Non-query commands
 //-------------------------------
@@ -89,6 +122,9 @@ type ReduceSettings struct {
 
 	// Only valid when mode is replace
 	ReplaceWithValue *float64 `json:"replaceWithValue,omitempty"`
+
+	// Only valid when the reducer is percentile, between 0 (exclusive) and 100
+	Percentile *float64 `json:"percentile,omitempty"`
 }
 
 // Non-Number behavior mode
@@ -25,6 +25,8 @@ func TestQueryTypeDefinitions(t *testing.T) {
 				reflect.TypeOf(mathexp.ReducerSum),   // pick an example value (not the root)
 				reflect.TypeOf(mathexp.UpsamplerPad), // pick an example value (not the root)
 				reflect.TypeOf(ReduceModeDrop),       // pick an example value (not the root)
+				reflect.TypeOf(mathexp.AlignmentFrom),
+				reflect.TypeOf(mathexp.ForecastHoltWinters),
 				reflect.TypeOf(ThresholdIsAbove),
 				reflect.TypeOf(classic.ConditionOperatorAnd),
 			},
@@ -161,6 +163,21 @@ func TestQueryTypeDefinitions(t *testing.T) {
 				},
 			},
 		},
+		schemabuilder.QueryTypeInfo{
+			Discriminators: data.NewDiscriminators("type", QueryTypeForecast),
+			GoType:         reflect.TypeOf(&ForecastQuery{}),
+			Examples: []data.QueryExample{
+				{
+					Name: "band around a daily seasonal forecast",
+					SaveModel: data.AsUnstructured(ForecastQuery{
+						Expression: "$A",
+						Model:      mathexp.ForecastHoltWinters,
+						Season:     "1d",
+						Horizon:    "1h",
+					}),
+				},
+			},
+		},
 	)
 
 	require.NoError(t, err)
@@ -66,6 +66,7 @@ func (h *ExpressionQueryReader) ReadQuery(
 
 	case QueryTypeReduce:
 		var mapper mathexp.ReduceMapper = nil
+		opts := mathexp.ReduceOptions{}
 		q := &ReduceQuery{}
 		err = iter.ReadVal(q)
 		if err == nil {
@@ -73,6 +74,10 @@ func (h *ExpressionQueryReader) ReadQuery(
 			eq.Properties = q
 		}
 		if err == nil && q.Settings != nil {
+			// the percentile does not depend on the mode, like in UnmarshalReduceCommand
+			if q.Settings.Percentile != nil {
+				opts.Percentile = *q.Settings.Percentile
+			}
 			switch q.Settings.Mode {
 			case ReduceModeStrict:
 				mapper = nil
@@ -90,7 +95,7 @@ func (h *ExpressionQueryReader) ReadQuery(
 		if err == nil {
 			eq.Properties = q
 			eq.Command, err = NewReduceCommand(common.RefID,
-				q.Reducer, referenceVar, mapper)
+				q.Reducer, referenceVar, mapper, opts)
 		}
 
 	case QueryTypeResample:
@@ -110,6 +115,7 @@ func (h *ExpressionQueryReader) ReadQuery(
 				referenceVar,
 				q.Downsampler,
 				q.Upsampler,
+				q.Alignment,
 				AbsoluteTimeRange{
 					From: tr.GetFromAsTimeUTC(),
 					To:   tr.GetToAsTimeUTC(),
@@ -144,36 +150,22 @@ func (h *ExpressionQueryReader) ReadQuery(
 			referenceVar, err = getReferenceVar(q.Expression, common.RefID)
 		}
 		if err == nil {
-			// we only support one condition for now, we might want to turn this in to "OR" expressions later
-			if len(q.Conditions) != 1 {
-				return eq, fmt.Errorf("threshold expression requires exactly one condition")
-			}
-			firstCondition := q.Conditions[0]
-
-			threshold, err := NewThresholdCommand(common.RefID, referenceVar, firstCondition.Evaluator.Type, firstCondition.Evaluator.Params)
-			if err != nil {
-				return eq, fmt.Errorf("invalid condition: %w", err)
-			}
-			eq.Command = threshold
 			eq.Properties = q
+			eq.Command, err = newThresholdCommandFromConditions(common.RefID, referenceVar, q.Conditions, h.features)
+		}
 
-			if firstCondition.UnloadEvaluator != nil && h.features.IsEnabledGlobally(featuremgmt.FlagRecoveryThreshold) {
-				unloading, err := NewThresholdCommand(common.RefID, referenceVar, firstCondition.UnloadEvaluator.Type, firstCondition.UnloadEvaluator.Params)
-				unloading.Invert = true
-				if err != nil {
-					return eq, fmt.Errorf("invalid unloadCondition: %w", err)
-				}
-				var d Fingerprints
-				if firstCondition.LoadedDimensions != nil {
-					d, err = FingerprintsFromFrame(firstCondition.LoadedDimensions)
-					if err != nil {
-						return eq, fmt.Errorf("failed to parse loaded dimensions: %w", err)
-					}
-				}
-				eq.Command, err = NewHysteresisCommand(common.RefID, referenceVar, *threshold, *unloading, d)
-				if err != nil {
-					return eq, err
-				}
+	case QueryTypeForecast:
+		q := &ForecastQuery{}
+		err = iter.ReadVal(q)
+		if err == nil {
+			referenceVar, err = getReferenceVar(q.Expression, common.RefID)
+		}
+		if err == nil {
+			var opts mathexp.ForecastOptions
+			opts, err = q.options()
+			if err == nil {
+				eq.Properties = q
+				eq.Command, err = NewForecastCommand(common.RefID, referenceVar, opts)
 			}
 		}
 
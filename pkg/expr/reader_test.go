@@ -19,6 +19,7 @@ func TestReaderReduceMode(t *testing.T) {
 		expectError bool
 		hasMapper   bool
 		mapperType  reflect.Type
+		percentile  float64
 	}{
 		{
 			name: "no_settings",
@@ -104,6 +105,72 @@ func TestReaderReduceMode(t *testing.T) {
 			expectError: false,
 			hasMapper:   false,
 		},
+		{
+			name: "percentile_without_mode",
+			bytes: []byte(`
+				{
+					"refId": "B",
+					"datasource": {
+						"type": "__expr__",
+						"uid": "__expr__"
+					},
+					"reducer": "percentile",
+					"expression": "A",
+					"window": "",
+					"settings": {
+						"percentile": 99.9
+					},
+					"type": "reduce"
+				}
+			`),
+			expectError: false,
+			hasMapper:   false,
+			percentile:  99.9,
+		},
+		{
+			name: "percentile_with_mode",
+			bytes: []byte(`
+				{
+					"refId": "B",
+					"datasource": {
+						"type": "__expr__",
+						"uid": "__expr__"
+					},
+					"reducer": "percentile",
+					"expression": "A",
+					"window": "",
+					"settings": {
+						"mode": "dropNN",
+						"percentile": 50
+					},
+					"type": "reduce"
+				}
+			`),
+			expectError: false,
+			hasMapper:   true,
+			mapperType:  reflect.TypeOf(mathexp.DropNonNumber{}),
+			percentile:  50,
+		},
+		{
+			name: "percentile_missing",
+			bytes: []byte(`
+				{
+					"refId": "B",
+					"datasource": {
+						"type": "__expr__",
+						"uid": "__expr__"
+					},
+					"reducer": "percentile",
+					"expression": "A",
+					"window": "",
+					"settings": {
+						"mode": "dropNN"
+					},
+					"type": "reduce"
+				}
+			`),
+			expectError: true,
+		},
 		{
 			name: "mode_invalid",
 			bytes: []byte(`
@@ -156,6 +223,7 @@ func TestReaderReduceMode(t *testing.T) {
 				} else {
 					require.Nil(t, rc.seriesMapper)
 				}
+				require.Equal(t, test.percentile, rc.Options.Percentile)
 			}
 		})
 	}
@@ -1,26 +1,499 @@
 package sql
 
 import (
-	"errors"
+	"context"
+	gosql "database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	// registers the pure Go "sqlite" database/sql driver
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
+// timeLayout is the layout used to store time values. It is understood by the SQLite date and time
+// functions and sorts lexically in chronological order.
+const timeLayout = "2006-01-02 15:04:05.000000000"
+
+// DB is an embedded, in-memory SQL engine. Each query runs against a fresh database in which the
+// input frames are loaded as tables named after their refID.
 type DB struct {
 }
 
+// TablesList returns the tables referenced by the SQL statement.
 func (db *DB) TablesList(rawSQL string) ([]string, error) {
-	return nil, errors.New("not implemented")
+	return TablesList(rawSQL)
 }
 
+// RunCommands executes the commands in order against an empty database and returns the rows
+// produced by the last command encoded as a JSON array of objects. Unlike queries, commands may
+// change the database, so they must not come from users.
 func (db *DB) RunCommands(commands []string) (string, error) {
-	return "", errors.New("not implemented")
+	ctx := context.Background()
+	conn, closeConn, err := open(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer closeConn()
+
+	var last *data.Frame
+	for _, cmd := range commands {
+		last, err = queryFrame(ctx, conn, "", cmd, nil)
+		if err != nil {
+			return "", err
+		}
+	}
+	if last == nil {
+		return "[]", nil
+	}
+
+	rows := make([]map[string]any, last.Rows())
+	for i := range rows {
+		rows[i] = make(map[string]any, len(last.Fields))
+		for _, f := range last.Fields {
+			v, _ := f.ConcreteAt(i)
+			rows[i][f.Name] = v
+		}
+	}
+	b, err := json.Marshal(rows)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
 }
 
-func (db *DB) QueryFramesInto(name string, query string, frames []*data.Frame, f *data.Frame) error {
-	return errors.New("not implemented")
+// QueryFramesInto loads the frames as tables, executes the query and writes the result into f.
+// Frames that share a refID are appended to the same table. Labels of the fields become text
+// columns, so that series from a multi-dimensional response can be told apart. The query must be
+// a single SELECT statement, and the database is read only while it runs.
+func (db *DB) QueryFramesInto(ctx context.Context, name string, query string, frames []*data.Frame, f *data.Frame) error {
+	if err := ValidateQuery(query); err != nil {
+		return err
+	}
+
+	conn, closeConn, err := open(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	for _, t := range tablesFromFrames(frames) {
+		if err := t.load(ctx, conn); err != nil {
+			return fmt.Errorf("failed to load %s: %w", t.name, err)
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		return fmt.Errorf("failed to make the database read only: %w", err)
+	}
+
+	res, err := queryFrame(ctx, conn, name, query, nil)
+	if err != nil {
+		return err
+	}
+	*f = *res
+	return nil
 }
 
+// NewInMemoryDB returns a new in-memory SQL engine.
 func NewInMemoryDB() *DB {
 	return &DB{}
 }
+
+// open returns a connection to a new in-memory database and a function to close it. No database
+// can be attached to the connection, so that SQL cannot create or write files on the host.
+func open(ctx context.Context) (*gosql.Conn, func(), error) {
+	db, err := gosql.Open("sqlite", ":memory:")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open in-memory database: %w", err)
+	}
+	// every connection to :memory: is a separate database, so keep exactly one
+	db.SetMaxOpenConns(1)
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, fmt.Errorf("failed to open in-memory database: %w", err)
+	}
+	closeConn := func() {
+		_ = conn.Close()
+		_ = db.Close()
+	}
+
+	if _, err := sqlite.Limit(conn, sqlite3.SQLITE_LIMIT_ATTACHED, 0); err != nil {
+		closeConn()
+		return nil, nil, fmt.Errorf("failed to limit attached databases: %w", err)
+	}
+	return conn, closeConn, nil
+}
+
+type column struct {
+	// key is the name of the field or label the column holds, name is the name of the column in the
+	// table. They only differ when a label and a field have the same name.
+	key     string
+	name    string
+	sqlType string
+	isLabel bool
+}
+
+type table struct {
+	name    string
+	columns []column
+	rows    [][]any
+}
+
+// tablesFromFrames groups the frames by refID and flattens them into tables. Frames without fields,
+// such as the frame of a query that returned no data, still create their table.
+func tablesFromFrames(frames []*data.Frame) []*table {
+	byName := map[string]*table{}
+	names := []string{}
+	for _, frame := range frames {
+		if frame == nil || frame.RefID == "" {
+			continue
+		}
+		t, ok := byName[frame.RefID]
+		if !ok {
+			t = &table{name: frame.RefID}
+			byName[frame.RefID] = t
+			names = append(names, frame.RefID)
+		}
+		t.append(frame)
+	}
+
+	tables := make([]*table, 0, len(names))
+	for _, n := range names {
+		tables = append(tables, byName[n])
+	}
+	return tables
+}
+
+// columnIndex returns the index of the column holding the field or label, adding the column if
+// needed. Column names are case insensitive, so when a label and a field have the same name the
+// label column gets a "label_" prefix.
+func (t *table) columnIndex(key, sqlType string, isLabel bool) int {
+	for i, c := range t.columns {
+		if c.isLabel == isLabel && strings.EqualFold(c.key, key) {
+			return i
+		}
+	}
+
+	name := key
+	if i := t.columnByName(name); i >= 0 {
+		if isLabel {
+			name = t.uniqueName("label_" + key)
+		} else {
+			t.columns[i].name = t.uniqueName("label_" + t.columns[i].key)
+		}
+	}
+
+	t.columns = append(t.columns, column{key: key, name: name, sqlType: sqlType, isLabel: isLabel})
+	for i := range t.rows {
+		t.rows[i] = append(t.rows[i], nil)
+	}
+	return len(t.columns) - 1
+}
+
+func (t *table) columnByName(name string) int {
+	for i, c := range t.columns {
+		if strings.EqualFold(c.name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t *table) uniqueName(name string) string {
+	unique := name
+	for n := 2; t.columnByName(unique) >= 0; n++ {
+		unique = fmt.Sprintf("%s_%d", name, n)
+	}
+	return unique
+}
+
+func (t *table) append(frame *data.Frame) {
+	fieldIdx := make([]int, len(frame.Fields))
+	for i, field := range frame.Fields {
+		name := field.Name
+		if name == "" {
+			name = fmt.Sprintf("field_%d", i)
+		}
+		fieldIdx[i] = t.columnIndex(name, sqlType(field.Type()), false)
+	}
+
+	labels := data.Labels{}
+	for _, field := range frame.Fields {
+		for k, v := range field.Labels {
+			labels[k] = v
+		}
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	labelIdx := make([]int, 0, len(keys))
+	for _, k := range keys {
+		labelIdx = append(labelIdx, t.columnIndex(k, "TEXT", true))
+	}
+
+	for r := 0; r < frame.Rows(); r++ {
+		row := make([]any, len(t.columns))
+		for i, field := range frame.Fields {
+			v, ok := field.ConcreteAt(r)
+			if !ok {
+				continue
+			}
+			row[fieldIdx[i]] = sqlValue(v)
+		}
+		for i, k := range keys {
+			row[labelIdx[i]] = labels[k]
+		}
+		t.rows = append(t.rows, row)
+	}
+}
+
+func (t *table) load(ctx context.Context, conn *gosql.Conn) error {
+	defs := make([]string, len(t.columns))
+	params := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		defs[i] = quoteIdent(c.name) + " " + c.sqlType
+		params[i] = "?"
+	}
+	// a table needs at least one column, the table of a refID without data is empty
+	if len(defs) == 0 {
+		defs = []string{`"value" REAL`}
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	create := fmt.Sprintf("CREATE TABLE %s (%s)", quoteIdent(t.name), strings.Join(defs, ", "))
+	if _, err := tx.ExecContext(ctx, create); err != nil {
+		return err
+	}
+	if len(t.rows) == 0 {
+		return tx.Commit()
+	}
+
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s VALUES (%s)", quoteIdent(t.name), strings.Join(params, ", ")))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stmt.Close() }()
+	for _, row := range t.rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// queryFrame runs the query and converts its result set to a frame.
+func queryFrame(ctx context.Context, conn *gosql.Conn, name string, query string, args []any) (*data.Frame, error) {
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	cols, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([][]any, len(cols))
+	for rows.Next() {
+		dest := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range dest {
+			ptrs[i] = &dest[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		for i, v := range dest {
+			values[i] = append(values[i], v)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	frame := data.NewFrame(name)
+	for i, c := range cols {
+		frame.Fields = append(frame.Fields, fieldFromColumn(c.Name(), c.DatabaseTypeName(), values[i]))
+	}
+	return frame, nil
+}
+
+// fieldFromColumn builds a nullable field from the scanned values of a result column. SQLite columns
+// are dynamically typed, so the field type is inferred from the declared type and the values.
+func fieldFromColumn(name, declType string, values []any) *data.Field {
+	declType = strings.ToUpper(declType)
+	var hasInt, hasFloat, hasBool, hasTime, hasString bool
+	allTimes := true
+	for _, v := range values {
+		switch v := v.(type) {
+		case int64:
+			hasInt = true
+		case float64:
+			hasFloat = true
+		case bool:
+			hasBool = true
+		case time.Time:
+			hasTime = true
+		case string:
+			hasString = true
+			if _, ok := toTime(v); !ok {
+				allTimes = false
+			}
+		case []byte:
+			hasString = true
+			allTimes = false
+		}
+	}
+
+	switch {
+	case (hasString || hasTime) && allTimes && !hasInt && !hasFloat && !hasBool,
+		!hasString && !hasInt && !hasFloat && !hasBool && (declType == "DATETIME" || declType == "TIMESTAMP"):
+		vals := make([]*time.Time, len(values))
+		for i, v := range values {
+			if t, ok := toTime(v); ok {
+				vals[i] = &t
+			}
+		}
+		return data.NewField(name, nil, vals)
+	case hasString || hasTime:
+		vals := make([]*string, len(values))
+		for i, v := range values {
+			if v != nil {
+				s := toString(v)
+				vals[i] = &s
+			}
+		}
+		return data.NewField(name, nil, vals)
+	case hasFloat:
+		vals := make([]*float64, len(values))
+		for i, v := range values {
+			switch v := v.(type) {
+			case int64:
+				f := float64(v)
+				vals[i] = &f
+			case float64:
+				vals[i] = &v
+			}
+		}
+		return data.NewField(name, nil, vals)
+	case hasBool || (declType == "BOOLEAN" && hasInt):
+		vals := make([]*bool, len(values))
+		for i, v := range values {
+			switch v := v.(type) {
+			case bool:
+				vals[i] = &v
+			case int64:
+				b := v != 0
+				vals[i] = &b
+			}
+		}
+		return data.NewField(name, nil, vals)
+	case hasInt:
+		vals := make([]*int64, len(values))
+		for i, v := range values {
+			if n, ok := v.(int64); ok {
+				vals[i] = &n
+			}
+		}
+		return data.NewField(name, nil, vals)
+	default:
+		// no values or only NULLs
+		return data.NewField(name, nil, make([]*float64, len(values)))
+	}
+}
+
+func toTime(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		t, err := time.ParseInLocation(timeLayout, v, time.UTC)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+func toString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.UTC().Format(timeLayout)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// sqlType returns the SQLite column type used to store values of the field type.
+func sqlType(ft data.FieldType) string {
+	switch {
+	case ft.Time():
+		return "DATETIME"
+	case ft.Numeric():
+		if ft.NonNullableType() == data.FieldTypeFloat32 || ft.NonNullableType() == data.FieldTypeFloat64 {
+			return "REAL"
+		}
+		return "INTEGER"
+	case ft.NonNullableType() == data.FieldTypeBool:
+		return "BOOLEAN"
+	default:
+		return "TEXT"
+	}
+}
+
+// sqlValue converts a concrete field value to a value accepted by the database driver.
+func sqlValue(v any) any {
+	switch v := v.(type) {
+	case time.Time:
+		return v.UTC().Format(timeLayout)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		// SQLite integers are signed 64 bits, larger values are stored as reals
+		if v > math.MaxInt64 {
+			return float64(v)
+		}
+		return int64(v)
+	case float32:
+		return float64(v)
+	case json.RawMessage:
+		return string(v)
+	case int64, float64, bool, string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
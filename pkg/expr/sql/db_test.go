@@ -0,0 +1,128 @@
+package sql
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryFramesInto(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	series := func(host string, values ...float64) *data.Frame {
+		times := make([]time.Time, len(values))
+		for i := range values {
+			times[i] = ts.Add(time.Duration(i) * time.Minute)
+		}
+		f := data.NewFrame("",
+			data.NewField("time", nil, times),
+			data.NewField("value", data.Labels{"host": host}, values),
+		)
+		f.RefID = "A"
+		return f
+	}
+	lookup := data.NewFrame("",
+		data.NewField("host", nil, []string{"a", "b"}),
+		data.NewField("team", nil, []string{"red", "blue"}),
+	)
+	lookup.RefID = "B"
+	frames := []*data.Frame{series("a", 1, 2, 3), series("b", 10, 20, 30), lookup}
+
+	t.Run("labels become columns and frames with the same refID share a table", func(t *testing.T) {
+		f := &data.Frame{}
+		err := NewInMemoryDB().QueryFramesInto(context.Background(), "C", `SELECT time, host, value FROM A ORDER BY host, time`, frames, f)
+		require.NoError(t, err)
+
+		require.Equal(t, "C", f.Name)
+		require.Equal(t, 6, f.Rows())
+		require.Equal(t, data.FieldTypeNullableTime, f.Fields[0].Type())
+		require.Equal(t, ts, *f.Fields[0].At(0).(*time.Time))
+		require.Equal(t, "b", *f.Fields[1].At(3).(*string))
+		require.Equal(t, 10.0, *f.Fields[2].At(3).(*float64))
+	})
+
+	t.Run("join and group by", func(t *testing.T) {
+		f := &data.Frame{}
+		err := NewInMemoryDB().QueryFramesInto(context.Background(), "C", `
+			SELECT B.team, sum(A.value) AS total, count(*) AS n
+			FROM A JOIN B ON A.host = B.host
+			GROUP BY B.team
+			ORDER BY B.team`, frames, f)
+		require.NoError(t, err)
+
+		require.Equal(t, 2, f.Rows())
+		require.Equal(t, "blue", *f.Fields[0].At(0).(*string))
+		require.Equal(t, 60.0, *f.Fields[1].At(0).(*float64))
+		require.Equal(t, int64(3), *f.Fields[2].At(0).(*int64))
+	})
+
+	t.Run("window functions", func(t *testing.T) {
+		f := &data.Frame{}
+		err := NewInMemoryDB().QueryFramesInto(context.Background(), "C", `
+			SELECT host, value - lag(value) OVER (PARTITION BY host ORDER BY time) AS delta
+			FROM A
+			WHERE host = 'b'
+			ORDER BY time`, frames, f)
+		require.NoError(t, err)
+
+		require.Equal(t, 3, f.Rows())
+		require.Nil(t, f.Fields[1].At(0).(*float64))
+		require.Equal(t, 10.0, *f.Fields[1].At(1).(*float64))
+	})
+
+	t.Run("unknown table", func(t *testing.T) {
+		f := &data.Frame{}
+		err := NewInMemoryDB().QueryFramesInto(context.Background(), "C", `SELECT * FROM Z`, frames, f)
+		require.Error(t, err)
+	})
+
+	t.Run("refID without data is an empty table", func(t *testing.T) {
+		noData := data.NewFrame("no data")
+		noData.RefID = "Z"
+		f := &data.Frame{}
+		err := NewInMemoryDB().QueryFramesInto(context.Background(), "C", `SELECT * FROM Z`, append(frames, noData), f)
+		require.NoError(t, err)
+		require.Equal(t, 0, f.Rows())
+	})
+
+	t.Run("statements that change the database are rejected", func(t *testing.T) {
+		for _, q := range []string{
+			`ATTACH DATABASE '/tmp/x.db' AS x`,
+			`SELECT 1; CREATE TABLE x (a INTEGER)`,
+			`WITH x AS (SELECT 1) INSERT INTO A SELECT * FROM x`,
+		} {
+			f := &data.Frame{}
+			err := NewInMemoryDB().QueryFramesInto(context.Background(), "C", q, frames, f)
+			require.Error(t, err, q)
+		}
+	})
+}
+
+func TestTableColumns(t *testing.T) {
+	frame := data.NewFrame("",
+		data.NewField("host", nil, []string{"x"}),
+		data.NewField("big", data.Labels{"Host": "a"}, []uint64{math.MaxUint64}),
+	)
+	frame.RefID = "A"
+	f := &data.Frame{}
+	err := NewInMemoryDB().QueryFramesInto(context.Background(), "C", `SELECT host, label_Host, big FROM A`, []*data.Frame{frame}, f)
+	require.NoError(t, err)
+
+	require.Equal(t, "x", *f.Fields[0].At(0).(*string))
+	require.Equal(t, "a", *f.Fields[1].At(0).(*string))
+	require.Equal(t, float64(math.MaxUint64), *f.Fields[2].At(0).(*float64))
+}
+
+func TestRunCommands(t *testing.T) {
+	res, err := NewInMemoryDB().RunCommands([]string{
+		"CREATE TABLE t (a INTEGER)",
+		"INSERT INTO t VALUES (1), (2)",
+		"SELECT sum(a) AS s FROM t",
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"s": 3}]`, res)
+}
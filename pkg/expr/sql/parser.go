@@ -1,82 +1,358 @@
 package sql
 
 import (
-	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/grafana/grafana/pkg/infra/log"
-	"github.com/jeremywohl/flatten"
 )
 
+var logger = log.New("sql_expr")
+
+type tokenKind int
+
 const (
-	TABLE_NAME    = "table_name"
-	ERROR         = ".error"
-	ERROR_MESSAGE = ".error_message"
+	tokenIdent tokenKind = iota
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenSymbol
 )
 
-var logger = log.New("sql_expr")
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// isKeyword reports whether the token is the unquoted keyword kw.
+func (t token) isKeyword(kw string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.value, kw)
+}
+
+func (t token) isSymbol(s string) bool {
+	return t.kind == tokenSymbol && t.value == s
+}
+
+func (t token) isIdent() bool {
+	return t.kind == tokenIdent || t.kind == tokenQuotedIdent
+}
+
+// clauseKeywords are the keywords that may follow a table reference (and its alias)
+// in a FROM clause.
+var clauseKeywords = map[string]bool{
+	"WHERE": true, "GROUP": true, "ORDER": true, "HAVING": true, "LIMIT": true, "OFFSET": true,
+	"JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true, "FULL": true, "OUTER": true,
+	"CROSS": true, "NATURAL": true, "ON": true, "USING": true, "UNION": true, "EXCEPT": true,
+	"INTERSECT": true, "WINDOW": true, "AS": true,
+}
 
 // TablesList returns a list of tables for the sql statement
 func TablesList(rawSQL string) ([]string, error) {
-	duckDB := NewInMemoryDB()
-	rawSQL = strings.Replace(rawSQL, "'", "''", -1)
-	cmd := fmt.Sprintf("SELECT json_serialize_sql('%s')", rawSQL)
-	ret, err := duckDB.RunCommands([]string{cmd})
+	tokens, err := tokenize(rawSQL)
 	if err != nil {
-		logger.Error("error serializing sql", "error", err.Error(), "sql", rawSQL, "cmd", cmd)
-		return nil, fmt.Errorf("error serializing sql: %s", err.Error())
+		logger.Error("error tokenizing sql", "error", err.Error(), "sql", rawSQL)
+		return nil, fmt.Errorf("error in sql: %w", err)
 	}
 
-	ast := []map[string]any{}
-	err = json.Unmarshal([]byte(ret), &ast)
+	tables, err := tablesFromTokens(tokens)
 	if err != nil {
-		logger.Error("error converting json sql to ast", "error", err.Error(), "ret", ret)
-		return nil, fmt.Errorf("error converting json to ast: %s", err.Error())
+		logger.Error("error in sql", "error", err.Error(), "sql", rawSQL)
+		return nil, fmt.Errorf("error in sql: %w", err)
 	}
 
-	return tablesFromAST(ast)
+	logger.Debug("tables found in sql", "tables", tables)
+
+	return tables, nil
 }
 
-// tablesFromAST returns a list of tables from the ast
-func tablesFromAST(ast []map[string]any) ([]string, error) {
-	flat, err := flatten.Flatten(ast[0], "", flatten.DotStyle)
+// ValidateQuery checks that the sql is a single SELECT statement, optionally starting with common
+// table expressions whose bodies are SELECT statements too. Only the leading keywords are checked,
+// the query runs on a read-only connection that rejects any change to the database.
+func ValidateQuery(rawSQL string) error {
+	tokens, err := tokenize(rawSQL)
 	if err != nil {
-		logger.Error("error flattening ast", "error", err.Error(), "ast", ast)
-		return nil, fmt.Errorf("error flattening ast: %s", err.Error())
+		return fmt.Errorf("error in sql: %w", err)
 	}
 
-	tables := []string{}
-	for k, v := range flat {
-		if strings.HasSuffix(k, ERROR) {
-			v, ok := v.(bool)
-			if ok && v {
-				logger.Error("error in sql", "error", k)
-				return nil, astError(k, flat)
+	// trailing semicolons end the statement
+	for len(tokens) > 0 && tokens[len(tokens)-1].isSymbol(";") {
+		tokens = tokens[:len(tokens)-1]
+	}
+	for _, t := range tokens {
+		if t.isSymbol(";") {
+			return fmt.Errorf("only a single statement is supported")
+		}
+	}
+	return validateSelect(tokens)
+}
+
+// validateSelect checks that the tokens are a SELECT statement, possibly preceded by a WITH clause.
+func validateSelect(tokens []token) error {
+	i := 0
+	if len(tokens) > 0 && tokens[0].isKeyword("WITH") {
+		i = 1
+		if i < len(tokens) && tokens[i].isKeyword("RECURSIVE") {
+			i++
+		}
+		for {
+			// name [(columns)] AS [NOT] [MATERIALIZED] (body)
+			if i >= len(tokens) || !tokens[i].isIdent() {
+				return fmt.Errorf("invalid common table expression")
 			}
+			i++
+			if i < len(tokens) && tokens[i].isSymbol("(") {
+				i = closingParen(tokens, i) + 1
+			}
+			if i >= len(tokens) || !tokens[i].isKeyword("AS") {
+				return fmt.Errorf("invalid common table expression")
+			}
+			i++
+			if i < len(tokens) && tokens[i].isKeyword("NOT") {
+				i++
+			}
+			if i < len(tokens) && tokens[i].isKeyword("MATERIALIZED") {
+				i++
+			}
+			if i >= len(tokens) || !tokens[i].isSymbol("(") {
+				return fmt.Errorf("invalid common table expression")
+			}
+			end := closingParen(tokens, i)
+			if end >= len(tokens) {
+				return fmt.Errorf("unbalanced parentheses in common table expression")
+			}
+			if err := validateSelect(tokens[i+1 : end]); err != nil {
+				return err
+			}
+			i = end + 1
+			if i < len(tokens) && tokens[i].isSymbol(",") {
+				i++
+				continue
+			}
+			break
+		}
+	}
+
+	if i >= len(tokens) || !tokens[i].isKeyword("SELECT") {
+		if i < len(tokens) && tokens[i].kind == tokenIdent {
+			return fmt.Errorf("%s is not supported, only SELECT statements are supported", strings.ToUpper(tokens[i].value))
 		}
-		if strings.Contains(k, TABLE_NAME) {
-			table, ok := v.(string)
-			if ok && !existsInList(table, tables) {
-				tables = append(tables, v.(string))
+		return fmt.Errorf("only SELECT statements are supported")
+	}
+	return nil
+}
+
+// closingParen returns the index of the parenthesis closing the one at index open, or len(tokens)
+// if it is not closed.
+func closingParen(tokens []token, open int) int {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		switch {
+		case tokens[i].isSymbol("("):
+			depth++
+		case tokens[i].isSymbol(")"):
+			depth--
+			if depth == 0 {
+				return i
 			}
 		}
 	}
-	sort.Strings(tables)
+	return len(tokens)
+}
 
-	logger.Debug("tables found in sql", "tables", tables)
+// tablesFromTokens walks the token stream and collects every table referenced after FROM or JOIN.
+// Names defined by common table expressions (WITH x AS (...)) are not tables and are excluded.
+func tablesFromTokens(tokens []token) ([]string, error) {
+	ctes := map[string]bool{}
+	for i := 0; i+2 < len(tokens); i++ {
+		if tokens[i].isIdent() && tokens[i+1].isKeyword("AS") && tokens[i+2].isSymbol("(") {
+			ctes[tokens[i].value] = true
+		}
+	}
+
+	tables := []string{}
+	for i := 0; i < len(tokens); i++ {
+		if !tokens[i].isKeyword("FROM") && !tokens[i].isKeyword("JOIN") {
+			continue
+		}
+		if tokens[i].isKeyword("FROM") && !isTableFrom(tokens, i) {
+			continue
+		}
+		for {
+			next, table, err := readTableRef(tokens, i+1)
+			if err != nil {
+				return nil, err
+			}
+			if table != "" && !ctes[table] && !existsInList(table, tables) {
+				tables = append(tables, table)
+			}
+			// a comma continues the list of tables in the same FROM clause
+			if next < len(tokens) && tokens[next].isSymbol(",") && table != "" {
+				i = next
+				continue
+			}
+			i = next - 1
+			break
+		}
+	}
+	sort.Strings(tables)
 
 	return tables, nil
 }
 
-func astError(k string, flat map[string]any) error {
-	key := strings.Replace(k, ERROR, "", 1)
-	message, ok := flat[key+ERROR_MESSAGE]
-	if !ok {
-		message = "unknown error in sql"
+// readTableRef reads a single table reference starting at position i. It returns the position
+// of the first token following the reference and its alias, and the table name. The name is empty
+// when the reference is a subquery or a table-valued function, whose tokens are left to be walked.
+func readTableRef(tokens []token, i int) (int, string, error) {
+	for i < len(tokens) && tokens[i].isSymbol("(") {
+		i++
+	}
+	if i >= len(tokens) {
+		return i, "", fmt.Errorf("missing table name")
+	}
+	if tokens[i].isKeyword("SELECT") || tokens[i].isKeyword("WITH") || tokens[i].isKeyword("VALUES") {
+		return i, "", nil
+	}
+	if !tokens[i].isIdent() {
+		return i, "", fmt.Errorf("unexpected %q where a table name was expected", tokens[i].value)
+	}
+
+	name := tokens[i].value
+	i++
+	// schema qualified names, e.g. main.A
+	for i+1 < len(tokens) && tokens[i].isSymbol(".") && tokens[i+1].isIdent() {
+		name = tokens[i+1].value
+		i += 2
+	}
+	if i < len(tokens) && tokens[i].isSymbol("(") {
+		return i, "", nil
+	}
+
+	// optional alias
+	if i < len(tokens) && tokens[i].isKeyword("AS") {
+		i++
+		if i >= len(tokens) || !tokens[i].isIdent() {
+			return i, "", fmt.Errorf("missing alias for table %q", name)
+		}
+		i++
+	} else if i < len(tokens) && tokens[i].isIdent() && !isClauseKeyword(tokens[i]) {
+		i++
+	}
+
+	if i < len(tokens) {
+		t := tokens[i]
+		if !t.isSymbol(",") && !t.isSymbol(")") && !t.isSymbol(";") && !isClauseKeyword(t) {
+			return i, "", fmt.Errorf("unexpected %q after table %q", t.value, name)
+		}
+	}
+	return i, name, nil
+}
+
+// functionsWithFrom are the functions whose arguments may contain a FROM keyword.
+var functionsWithFrom = map[string]bool{
+	"EXTRACT": true, "SUBSTRING": true, "SUBSTR": true, "TRIM": true, "OVERLAY": true, "POSITION": true,
+}
+
+// isTableFrom reports whether the FROM keyword at position i starts a FROM clause, rather than being
+// part of IS DISTINCT FROM or of the arguments of a function such as EXTRACT(year FROM t).
+func isTableFrom(tokens []token, i int) bool {
+	if i > 0 && tokens[i-1].isKeyword("DISTINCT") {
+		return false
+	}
+	depth := 0
+	for j := i - 1; j >= 0; j-- {
+		switch {
+		case tokens[j].isSymbol(")"):
+			depth++
+		case tokens[j].isSymbol("("):
+			if depth == 0 {
+				return j == 0 || tokens[j-1].kind != tokenIdent || !functionsWithFrom[strings.ToUpper(tokens[j-1].value)]
+			}
+			depth--
+		}
+	}
+	return true
+}
+
+func isClauseKeyword(t token) bool {
+	return t.kind == tokenIdent && clauseKeywords[strings.ToUpper(t.value)]
+}
+
+// tokenize splits the sql into identifiers, literals and symbols, dropping whitespace and comments.
+func tokenize(rawSQL string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(rawSQL)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && (runes[i] != '*' || runes[i+1] != '/') {
+				i++
+			}
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += 2
+		case r == '\'' || r == '"' || r == '`' || r == '[':
+			closing := r
+			if r == '[' {
+				closing = ']'
+			}
+			value, next, err := readQuoted(runes, i+1, closing)
+			if err != nil {
+				return nil, err
+			}
+			kind := tokenQuotedIdent
+			if r == '\'' {
+				kind = tokenString
+			}
+			tokens = append(tokens, token{kind: kind, value: value})
+			i = next
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '$') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: string(runes[start:i])})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || unicode.IsLetter(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: string(runes[start:i])})
+		default:
+			tokens = append(tokens, token{kind: tokenSymbol, value: string(r)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// readQuoted reads a quoted value that starts at i (just after the opening quote). A doubled
+// closing quote is an escaped quote. It returns the unquoted value and the position after the closing quote.
+func readQuoted(runes []rune, i int, closing rune) (string, int, error) {
+	var sb strings.Builder
+	for i < len(runes) {
+		if runes[i] == closing {
+			if closing != ']' && i+1 < len(runes) && runes[i+1] == closing {
+				sb.WriteRune(closing)
+				i += 2
+				continue
+			}
+			return sb.String(), i + 1, nil
+		}
+		sb.WriteRune(runes[i])
+		i++
 	}
-	return fmt.Errorf("error in sql: %s", message)
+	return "", i, fmt.Errorf("unterminated quoted value %q", string(closing))
 }
 
 func existsInList(table string, list []string) bool {
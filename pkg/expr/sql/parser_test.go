@@ -7,7 +7,6 @@ import (
 )
 
 func TestParse(t *testing.T) {
-	t.Skip()
 	sql := "select * from foo"
 	tables, err := TablesList((sql))
 	assert.Nil(t, err)
@@ -16,7 +15,6 @@ func TestParse(t *testing.T) {
 }
 
 func TestParseWithComma(t *testing.T) {
-	t.Skip()
 	sql := "select * from foo,bar"
 	tables, err := TablesList((sql))
 	assert.Nil(t, err)
@@ -26,7 +24,6 @@ func TestParseWithComma(t *testing.T) {
 }
 
 func TestParseWithCommas(t *testing.T) {
-	t.Skip()
 	sql := "select * from foo,bar,baz"
 	tables, err := TablesList((sql))
 	assert.Nil(t, err)
@@ -37,7 +34,6 @@ func TestParseWithCommas(t *testing.T) {
 }
 
 func TestArray(t *testing.T) {
-	t.Skip()
 	sql := "SELECT array_value(1, 2, 3)"
 	tables, err := TablesList((sql))
 	assert.Nil(t, err)
@@ -46,7 +42,6 @@ func TestArray(t *testing.T) {
 }
 
 func TestArray2(t *testing.T) {
-	t.Skip()
 	sql := "SELECT array_value(1, 2, 3)[2]"
 	tables, err := TablesList((sql))
 	assert.Nil(t, err)
@@ -55,7 +50,6 @@ func TestArray2(t *testing.T) {
 }
 
 func TestXxx(t *testing.T) {
-	t.Skip()
 	sql := "SELECT [3, 2, 1]::INT[3];"
 	tables, err := TablesList((sql))
 	assert.Nil(t, err)
@@ -64,7 +58,6 @@ func TestXxx(t *testing.T) {
 }
 
 func TestParseSubquery(t *testing.T) {
-	t.Skip()
 	sql := "select * from (select * from people limit 1)"
 	tables, err := TablesList((sql))
 	assert.Nil(t, err)
@@ -74,7 +67,6 @@ func TestParseSubquery(t *testing.T) {
 }
 
 func TestJoin(t *testing.T) {
-	t.Skip()
 	sql := `select * from A
 	JOIN B ON A.name = B.name
 	LIMIT 10`
@@ -87,7 +79,6 @@ func TestJoin(t *testing.T) {
 }
 
 func TestRightJoin(t *testing.T) {
-	t.Skip()
 	sql := `select * from A
 	RIGHT JOIN B ON A.name = B.name
 	LIMIT 10`
@@ -100,7 +91,6 @@ func TestRightJoin(t *testing.T) {
 }
 
 func TestAliasWithJoin(t *testing.T) {
-	t.Skip()
 	sql := `select * from A as X
 	RIGHT JOIN B ON A.name = X.name
 	LIMIT 10`
@@ -113,7 +103,6 @@ func TestAliasWithJoin(t *testing.T) {
 }
 
 func TestAlias(t *testing.T) {
-	t.Skip()
 	sql := `select * from A as X LIMIT 10`
 	tables, err := TablesList((sql))
 	assert.Nil(t, err)
@@ -123,14 +112,12 @@ func TestAlias(t *testing.T) {
 }
 
 func TestError(t *testing.T) {
-	t.Skip()
 	sql := `select * from zzz aaa zzz`
 	_, err := TablesList((sql))
 	assert.NotNil(t, err)
 }
 
 func TestParens(t *testing.T) {
-	t.Skip()
 	sql := `SELECT  t1.Col1,
 	t2.Col1,
 	t3.Col1
@@ -149,7 +136,6 @@ func TestParens(t *testing.T) {
 }
 
 func TestWith(t *testing.T) {
-	t.Skip()
 	sql := `WITH
 
 	current_month AS (
@@ -191,14 +177,23 @@ func TestWith(t *testing.T) {
 	tables, err := TablesList((sql))
 	assert.Nil(t, err)
 
-	assert.Equal(t, 5, len(tables))
+	// current_month and last_month_bill are common table expressions, not inputs
+	assert.Equal(t, 3, len(tables))
 	assert.Equal(t, "A", tables[0])
 	assert.Equal(t, "B", tables[1])
 	assert.Equal(t, "BEE", tables[2])
 }
 
+func TestFromInFunctionArguments(t *testing.T) {
+	sql := "select extract(year from time) from A where value is distinct from 0"
+	tables, err := TablesList((sql))
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, len(tables))
+	assert.Equal(t, "A", tables[0])
+}
+
 func TestWithQuote(t *testing.T) {
-	t.Skip()
 	sql := "select *,'junk' from foo"
 	tables, err := TablesList((sql))
 	assert.Nil(t, err)
@@ -207,10 +202,33 @@ func TestWithQuote(t *testing.T) {
 }
 
 func TestWithQuote2(t *testing.T) {
-	t.Skip()
 	sql := "SELECT json_serialize_sql('SELECT 1')"
 	tables, err := TablesList((sql))
 	assert.Nil(t, err)
 
 	assert.Equal(t, 0, len(tables))
 }
+
+func TestValidateQuery(t *testing.T) {
+	for _, q := range []string{
+		"SELECT * FROM A;",
+		"with x as (select * from A) select replace(host, 'a', 'b') from x",
+		"SELECT release, begin, analyze AS pragma FROM A",
+		"WITH RECURSIVE x(n) AS NOT MATERIALIZED (SELECT 1 UNION ALL SELECT n + 1 FROM x WHERE n < 3), y AS (SELECT * FROM x) SELECT * FROM y",
+	} {
+		assert.NoError(t, ValidateQuery(q), q)
+	}
+
+	for _, q := range []string{
+		"",
+		"ATTACH DATABASE '/var/lib/grafana/x.db' AS x",
+		"PRAGMA query_only = OFF",
+		"SELECT 1; DROP TABLE A",
+		"WITH x AS (SELECT 1) DELETE FROM A",
+		"REPLACE INTO A VALUES (1)",
+		"WITH x AS (DELETE FROM A RETURNING *) SELECT * FROM x",
+		"WITH x AS (SELECT 1) SELECT * FROM x; SELECT 1",
+	} {
+		assert.Error(t, ValidateQuery(q), q)
+	}
+}
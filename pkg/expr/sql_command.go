@@ -34,6 +34,12 @@ func NewSQLCommand(refID, rawSQL string) (*SQLCommand, error) {
 			errutil.WithPublicMessage("error reading SQL command"),
 		)
 	}
+	if err := sql.ValidateQuery(rawSQL); err != nil {
+		logger.Warn("unsupported sql query", "sql", rawSQL, "error", err)
+		return nil, errutil.BadRequest("sql-unsupported-statement",
+			errutil.WithPublicMessage(err.Error()),
+		)
+	}
 	if len(tables) == 0 {
 		logger.Warn("no tables found in SQL query", "sql", rawSQL)
 	}
@@ -77,7 +83,7 @@ func (gr *SQLCommand) NeedsVars() []string {
 // Execute runs the command and returns the results or an error if the command
 // failed to execute.
 func (gr *SQLCommand) Execute(ctx context.Context, now time.Time, vars mathexp.Vars, tracer tracing.Tracer) (mathexp.Results, error) {
-	_, span := tracer.Start(ctx, "SSE.ExecuteSQL")
+	ctx, span := tracer.Start(ctx, "SSE.ExecuteSQL")
 	defer span.End()
 
 	allFrames := []*data.Frame{}
@@ -88,6 +94,12 @@ func (gr *SQLCommand) Execute(ctx context.Context, now time.Time, vars mathexp.V
 			continue
 		}
 		frames := results.Values.AsDataFrames(ref)
+		if len(frames) == 0 {
+			// an empty frame creates an empty table, so queries of a refID without data return no rows
+			empty := data.NewFrame("")
+			empty.RefID = ref
+			frames = []*data.Frame{empty}
+		}
 		allFrames = append(allFrames, frames...)
 	}
 
@@ -97,7 +109,7 @@ func (gr *SQLCommand) Execute(ctx context.Context, now time.Time, vars mathexp.V
 	var frame = &data.Frame{}
 
 	logger.Debug("Executing query", "query", gr.query, "frames", len(allFrames))
-	err := db.QueryFramesInto(gr.refID, gr.query, allFrames, frame)
+	err := db.QueryFramesInto(ctx, gr.refID, gr.query, allFrames, frame)
 	if err != nil {
 		logger.Error("Failed to query frames", "error", err.Error())
 		rsp.Error = err
@@ -111,6 +123,7 @@ func (gr *SQLCommand) Execute(ctx context.Context, now time.Time, vars mathexp.V
 		rsp.Values = mathexp.Values{
 			mathexp.NoData{Frame: frame},
 		}
+		return rsp, nil
 	}
 
 	rsp.Values = mathexp.Values{
@@ -21,8 +21,9 @@ type predicate interface {
 }
 
 type ThresholdCommand struct {
-	ReferenceVar  string
-	RefID         string
+	ReferenceVar string
+	RefID        string
+	// ThresholdFunc is empty if the command combines several conditions.
 	ThresholdFunc ThresholdType
 	Invert        bool
 	predicate     predicate
@@ -97,33 +98,86 @@ func UnmarshalThresholdCommand(rn *rawNode, features featuremgmt.FeatureToggles)
 		return nil, fmt.Errorf("no variable specified to reference for refId %v", rn.RefID)
 	}
 	referenceVar := cmdConfig.Expression
+	return newThresholdCommandFromConditions(rn.RefID, referenceVar, cmdConfig.Conditions, features)
+}
 
-	// we only support one condition for now, we might want to turn this in to "OR" expressions later
-	if len(cmdConfig.Conditions) != 1 {
-		return nil, fmt.Errorf("threshold expression requires exactly one condition")
+// newThresholdCommandFromConditions creates the command for the conditions of a threshold expression.
+// A value crosses the threshold if it matches any of the conditions.
+// If a condition has an unload evaluator and the recovery threshold feature is enabled, a HysteresisCommand is returned.
+// A value that crossed the threshold at the previous evaluation then keeps crossing it until it matches
+// the unload evaluators of all the conditions that have one, and stops matching the evaluators of the other conditions.
+func newThresholdCommandFromConditions(refID, referenceVar string, conditions []ThresholdConditionJSON, features featuremgmt.FeatureToggles) (Command, error) {
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("threshold expression requires at least one condition")
 	}
-	firstCondition := cmdConfig.Conditions[0]
-
-	threshold, err := NewThresholdCommand(rn.RefID, referenceVar, firstCondition.Evaluator.Type, firstCondition.Evaluator.Params)
-	if err != nil {
-		return nil, fmt.Errorf("invalid condition: %w", err)
-	}
-	if firstCondition.UnloadEvaluator != nil && features.IsEnabledGlobally(featuremgmt.FlagRecoveryThreshold) {
-		unloading, err := NewThresholdCommand(rn.RefID, referenceVar, firstCondition.UnloadEvaluator.Type, firstCondition.UnloadEvaluator.Params)
+	loading := make(anyPredicate, 0, len(conditions))
+	unloading := make(anyPredicate, 0, len(conditions))
+	var firstThreshold, firstUnloading *ThresholdCommand
+	var loadedDimensions *data.Frame
+	for i, condition := range conditions {
+		threshold, err := NewThresholdCommand(refID, referenceVar, condition.Evaluator.Type, condition.Evaluator.Params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid condition: %w", wrapConditionIndex(err, i, len(conditions)))
+		}
+		if firstThreshold == nil {
+			firstThreshold = threshold
+		}
+		loading = append(loading, threshold.predicate)
+		if condition.UnloadEvaluator == nil || !features.IsEnabledGlobally(featuremgmt.FlagRecoveryThreshold) {
+			unloading = append(unloading, threshold.predicate)
+			continue
+		}
+		unloadingThreshold, err := NewThresholdCommand(refID, referenceVar, condition.UnloadEvaluator.Type, condition.UnloadEvaluator.Params)
 		if err != nil {
-			return nil, fmt.Errorf("invalid unloadCondition: %w", err)
+			return nil, fmt.Errorf("invalid unloadCondition: %w", wrapConditionIndex(err, i, len(conditions)))
 		}
-		unloading.Invert = true
-		var d Fingerprints
-		if firstCondition.LoadedDimensions != nil {
-			d, err = FingerprintsFromFrame(firstCondition.LoadedDimensions)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse loaded dimensions: %w", err)
+		unloadingThreshold.Invert = true
+		unloading = append(unloading, notPredicate{unloadingThreshold.predicate})
+		if firstUnloading == nil {
+			firstUnloading = unloadingThreshold
+			loadedDimensions = condition.LoadedDimensions
+		}
+	}
+
+	// a single condition is kept as is, so the command describes it
+	if len(conditions) > 1 {
+		firstThreshold = &ThresholdCommand{
+			RefID:        refID,
+			ReferenceVar: referenceVar,
+			predicate:    loading,
+		}
+		if firstUnloading != nil {
+			firstUnloading = &ThresholdCommand{
+				RefID:        refID,
+				ReferenceVar: referenceVar,
+				predicate:    unloading,
 			}
 		}
-		return NewHysteresisCommand(rn.RefID, referenceVar, *threshold, *unloading, d)
 	}
-	return threshold, nil
+	if firstUnloading == nil {
+		return firstThreshold, nil
+	}
+	return newHysteresisCommandWithDimensions(refID, referenceVar, *firstThreshold, *firstUnloading, loadedDimensions)
+}
+
+func newHysteresisCommandWithDimensions(refID, referenceVar string, loading, unloading ThresholdCommand, loadedDimensions *data.Frame) (Command, error) {
+	var d Fingerprints
+	if loadedDimensions != nil {
+		var err error
+		d, err = FingerprintsFromFrame(loadedDimensions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse loaded dimensions: %w", err)
+		}
+	}
+	return NewHysteresisCommand(refID, referenceVar, loading, unloading, d)
+}
+
+// wrapConditionIndex adds the position of the condition to the error if there are several conditions.
+func wrapConditionIndex(err error, idx, count int) error {
+	if count == 1 {
+		return err
+	}
+	return fmt.Errorf("condition %d: %w", idx, err)
 }
 
 // NeedsVars returns the variable names (refIds) that are dependencies
@@ -202,8 +256,8 @@ type ThresholdConditionJSON struct {
 
 // IsHysteresisExpression returns true if the raw model describes a hysteresis command:
 // - field 'type' has value "threshold",
-// - field 'conditions' is array of objects and has exactly one element
-// - field 'conditions[0].unloadEvaluator is not nil
+// - field 'conditions' is a non-empty array of objects
+// - field 'unloadEvaluator' of one of the conditions is not nil
 func IsHysteresisExpression(query map[string]any) bool {
 	c, err := getConditionForHysteresisCommand(query)
 	if err != nil {
@@ -212,7 +266,7 @@ func IsHysteresisExpression(query map[string]any) bool {
 	return c != nil
 }
 
-// SetLoadedDimensionsToHysteresisCommand mutates the input map and sets field "conditions[0].loadedMetrics" with the data frame created from the provided fingerprints.
+// SetLoadedDimensionsToHysteresisCommand mutates the input map and sets field "loadedDimensions" of the first condition that has an unload evaluator with the data frame created from the provided fingerprints.
 func SetLoadedDimensionsToHysteresisCommand(query map[string]any, fingerprints Fingerprints) error {
 	condition, err := getConditionForHysteresisCommand(query)
 	if err != nil {
@@ -226,6 +280,8 @@ func SetLoadedDimensionsToHysteresisCommand(query map[string]any, fingerprints F
 	return nil
 }
 
+// getConditionForHysteresisCommand returns the first condition that has an unload evaluator,
+// which is the one that holds the loaded dimensions, or nil if there is none.
 func getConditionForHysteresisCommand(query map[string]any) (map[string]any, error) {
 	t, err := GetExpressionCommandType(query)
 	if err != nil {
@@ -239,26 +295,43 @@ func getConditionForHysteresisCommand(query map[string]any) (map[string]any, err
 	if !ok {
 		return nil, errors.New("invalid threshold command: expected field \"condition\"")
 	}
-	var condition map[string]any
-	switch arr := c.(type) {
-	case []any:
-		if len(arr) != 1 {
-			return nil, errors.New("invalid threshold command: field \"condition\" expected to have exactly 1 field")
+	arr, ok := c.([]any)
+	if !ok {
+		return nil, errors.New("invalid threshold command: field \"condition\" expected to be an array of objects")
+	}
+	if len(arr) == 0 {
+		return nil, errors.New("invalid threshold command: field \"condition\" expected to have at least 1 element")
+	}
+	for i, el := range arr {
+		condition, ok := el.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid threshold command: element %d of field \"condition\" expected to be an object", i)
 		}
-		switch m := arr[0].(type) {
-		case map[string]any:
-			condition = m
-		default:
-			return nil, errors.New("invalid threshold command: value of the first element of field \"condition\" expected to be an object")
+		if _, ok := condition["unloadEvaluator"]; ok {
+			return condition, nil
 		}
-	default:
-		return nil, errors.New("invalid threshold command: field \"condition\" expected to be an array of objects")
 	}
-	_, ok = condition["unloadEvaluator"]
-	if !ok {
-		return nil, nil
+	return nil, nil
+}
+
+// anyPredicate is true if any of the predicates is true.
+type anyPredicate []predicate
+
+func (p anyPredicate) Eval(f float64) bool {
+	for _, pr := range p {
+		if pr.Eval(f) {
+			return true
+		}
 	}
-	return condition, nil
+	return false
+}
+
+type notPredicate struct {
+	predicate predicate
+}
+
+func (p notPredicate) Eval(f float64) bool {
+	return !p.predicate.Eval(f)
 }
 
 type withinRangePredicate struct {
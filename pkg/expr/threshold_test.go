@@ -126,7 +126,7 @@ func TestUnmarshalThresholdCommand(t *testing.T) {
 				"conditions": []
 			}`,
 			shouldError:   true,
-			expectedError: "threshold expression requires exactly one condition",
+			expectedError: "threshold expression requires at least one condition",
 		},
 		{
 			description: "unmarshal with unsupported threshold function",
@@ -312,6 +312,11 @@ func TestIsHysteresisExpression(t *testing.T) {
 			input:    json.RawMessage(`{ "type": "threshold", "conditions": [{ "unloadEvaluator" : {}}] }`),
 			expected: true,
 		},
+		{
+			name:     "true type is threshold and one of many conditions has unloadEvaluator field",
+			input:    json.RawMessage(`{ "type": "threshold", "conditions": [{}, { "unloadEvaluator" : {}}] }`),
+			expected: true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -381,6 +386,25 @@ func TestSetLoadedDimensionsToHysteresisCommand(t *testing.T) {
 
 		require.Equal(t, fingerprints, cmd.(*HysteresisCommand).LoadedDimensions)
 	})
+
+	t.Run("when one of many conditions has unloadEvaluator, mutates that condition with loaded dimensions", func(t *testing.T) {
+		fingerprints := Fingerprints{2: {}, 3: {}}
+		input := json.RawMessage(`{ "type": "threshold", "conditions": [{ "evaluator": { "params": [100], "type": "gt" }}, { "evaluator": { "params": [5], "type": "gt" }, "unloadEvaluator" : {"params": [2], "type": "lt"}}], "expression": "A" }`)
+		query := map[string]any{}
+		require.NoError(t, json.Unmarshal(input, &query))
+		require.NoError(t, SetLoadedDimensionsToHysteresisCommand(query, fingerprints))
+		require.NotContains(t, query["conditions"].([]any)[0], "loadedDimensions")
+		raw, err := json.Marshal(query)
+		require.NoError(t, err)
+
+		cmd, err := UnmarshalThresholdCommand(&rawNode{
+			RefID:    "B",
+			QueryRaw: raw,
+		}, featuremgmt.WithFeatures(featuremgmt.FlagRecoveryThreshold))
+		require.NoError(t, err)
+
+		require.Equal(t, fingerprints, cmd.(*HysteresisCommand).LoadedDimensions)
+	})
 }
 
 func TestThresholdExecute(t *testing.T) {
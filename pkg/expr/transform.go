@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/services/datasources"
@@ -31,6 +32,8 @@ type Query struct {
 	Interval      time.Duration
 	QueryType     string
 	MaxDataPoints int64
+	// Frames are the results of the query if it is an input query (see InputDatasourceUID).
+	Frames data.Frames `json:"-"`
 }
 
 // TimeRange is a time.Time based TimeRange.
@@ -111,6 +111,15 @@ func (b *SearchAPIBuilder) GetAPIRoutes() *builder.APIRoutes {
 						Query:     queryParams.Get("query"),
 						Limit:     int64(limit),
 						Offset:    int64(offset),
+						Folders:   queryParams["folder"],
+						Tags:      queryParams["tag"],
+						Labels:    queryParams["label"],
+						SortBy:    queryParams["sort"],
+
+						NextPageToken: queryParams.Get("next_page_token"),
+					}
+					for _, field := range queryParams["facet"] {
+						searchRequest.Facets = append(searchRequest.Facets, &resource.SearchFacetRequest{Field: field})
 					}
 
 					res, err := b.unified.Search(r.Context(), searchRequest)
@@ -125,8 +134,24 @@ func (b *SearchAPIBuilder) GetAPIRoutes() *builder.APIRoutes {
 						rawMessages[i] = item.Value
 					}
 
+					// the response is a list of resources, the metadata of the search is returned in headers.
+					// The facets are only returned when the metadata is requested in the body with metadata=true
+					var result any = rawMessages
+					if queryParams.Get("metadata") == "true" {
+						result = searchResult{
+							Items:         rawMessages,
+							TotalHits:     res.GetTotalHits(),
+							Facets:        res.GetFacets(),
+							NextPageToken: res.GetNextPageToken(),
+						}
+					}
+
 					w.Header().Set("Content-Type", "application/json")
-					if err := json.NewEncoder(w).Encode(rawMessages); err != nil {
+					w.Header().Set(totalHitsHeader, strconv.FormatInt(res.GetTotalHits(), 10))
+					if token := res.GetNextPageToken(); token != "" {
+						w.Header().Set(nextPageTokenHeader, token)
+					}
+					if err := json.NewEncoder(w).Encode(result); err != nil {
 						response.Error(500, "failed to json encode raw response", err)
 					}
 				},
@@ -135,6 +160,19 @@ func (b *SearchAPIBuilder) GetAPIRoutes() *builder.APIRoutes {
 	}
 }
 
+const (
+	totalHitsHeader     = "X-Search-Total-Hits"
+	nextPageTokenHeader = "X-Search-Next-Page-Token"
+)
+
+// searchResult is the response of a search with metadata=true
+type searchResult struct {
+	Items         []json.RawMessage                `json:"items"`
+	TotalHits     int64                            `json:"totalHits"`
+	Facets        map[string]*resource.SearchFacet `json:"facets,omitempty"`
+	NextPageToken string                           `json:"nextPageToken,omitempty"`
+}
+
 func (b *SearchAPIBuilder) GetAuthorizer() authorizer.Authorizer {
 	return nil
 }
@@ -2,20 +2,36 @@ package annotationsimpl
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gocloud.dev/blob"
+
+	// Supported archive drivers
+	_ "gocloud.dev/blob/azureblob"
+	_ "gocloud.dev/blob/fileblob"
+	_ "gocloud.dev/blob/gcsblob"
+	_ "gocloud.dev/blob/s3blob"
 
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+	"github.com/grafana/grafana/pkg/services/tag"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
 // CleanupServiceImpl is responsible for cleaning old annotations.
 type CleanupServiceImpl struct {
-	store store
+	store   store
+	dialect migrator.Dialect
 }
 
 func ProvideCleanupService(db db.DB, cfg *setting.Cfg) *CleanupServiceImpl {
 	return &CleanupServiceImpl{
-		store: NewXormStore(cfg, log.New("annotations"), db, nil),
+		store:   NewXormStore(cfg, log.New("annotations"), db, nil),
+		dialect: db.GetDialect(),
 	}
 }
 
@@ -25,28 +41,60 @@ const (
 	apiAnnotationType       = "alert_id = 0 AND dashboard_id = 0"
 )
 
+var annotationTypeConditions = map[string]string{
+	setting.AnnotationTypeAlert:     alertAnnotationType,
+	setting.AnnotationTypeDashboard: dashboardAnnotationType,
+	setting.AnnotationTypeAPI:       apiAnnotationType,
+}
+
+// cleanupFilter is an SQL condition on the annotation table selecting the annotations to clean up.
+type cleanupFilter struct {
+	cond string
+	args []any
+}
+
+func (f cleanupFilter) andNot(other cleanupFilter) cleanupFilter {
+	return cleanupFilter{
+		cond: fmt.Sprintf("(%s) AND NOT (%s)", f.cond, other.cond),
+		args: append(append([]any{}, f.args...), other.args...),
+	}
+}
+
+// archiveFunc stores annotations before they are deleted.
+type archiveFunc func(ctx context.Context, items []*annotations.Item) error
+
 // Run deletes old annotations created by alert rules, API
 // requests and human made in the UI. It subsequently deletes orphaned rows
 // from the annotation_tag table. Cleanup actions are performed in batches
 // so that no query takes too long to complete.
 //
+// Annotations matched by a retention rule are cleaned up according to the
+// first rule they match, and are not affected by the per type settings.
+//
 // Returns the number of annotation and annotation_tag rows deleted. If an
 // error occurs, it returns the number of rows affected so far.
 func (cs *CleanupServiceImpl) Run(ctx context.Context, cfg *setting.Cfg) (int64, int64, error) {
 	var totalCleanedAnnotations int64
-	affected, err := cs.store.CleanAnnotations(ctx, cfg.AlertingAnnotationCleanupSetting, alertAnnotationType)
+
+	affected, err := cs.runRetentionRules(ctx, cfg)
 	totalCleanedAnnotations += affected
 	if err != nil {
 		return totalCleanedAnnotations, 0, err
 	}
 
-	affected, err = cs.store.CleanAnnotations(ctx, cfg.APIAnnotationCleanupSettings, apiAnnotationType)
+	affected, err = cs.store.CleanMatchingAnnotations(ctx, cfg.AlertingAnnotationCleanupSetting, cs.excludeRetentionRules(cleanupFilter{cond: alertAnnotationType}, cfg.AnnotationRetentionRules), nil)
 	totalCleanedAnnotations += affected
 	if err != nil {
 		return totalCleanedAnnotations, 0, err
 	}
 
-	affected, err = cs.store.CleanAnnotations(ctx, cfg.DashboardAnnotationCleanupSettings, dashboardAnnotationType)
+	affected, err = cs.store.CleanMatchingAnnotations(ctx, cfg.APIAnnotationCleanupSettings, cs.excludeRetentionRules(cleanupFilter{cond: apiAnnotationType}, cfg.AnnotationRetentionRules), nil)
+	totalCleanedAnnotations += affected
+	if err != nil {
+		return totalCleanedAnnotations, 0, err
+	}
+
+	affected, err = cs.store.CleanMatchingAnnotations(ctx, cfg.DashboardAnnotationCleanupSettings, cs.excludeRetentionRules(cleanupFilter{cond: dashboardAnnotationType}, cfg.AnnotationRetentionRules), nil)
 	totalCleanedAnnotations += affected
 	if err != nil {
 		return totalCleanedAnnotations, 0, err
@@ -56,3 +104,111 @@ func (cs *CleanupServiceImpl) Run(ctx context.Context, cfg *setting.Cfg) (int64,
 	}
 	return totalCleanedAnnotations, affected, err
 }
+
+func (cs *CleanupServiceImpl) runRetentionRules(ctx context.Context, cfg *setting.Cfg) (int64, error) {
+	var archiver *annotationArchiver
+	for _, rule := range cfg.AnnotationRetentionRules {
+		if rule.Archive {
+			bucket, err := blob.OpenBucket(ctx, cfg.AnnotationArchiveURL)
+			if err != nil {
+				return 0, fmt.Errorf("failed to open annotation archive: %w", err)
+			}
+			defer func() { _ = bucket.Close() }()
+			archiver = &annotationArchiver{bucket: bucket}
+			break
+		}
+	}
+
+	var totalAffected int64
+	for i, rule := range cfg.AnnotationRetentionRules {
+		if rule.MaxAge == 0 && rule.MaxCount == 0 {
+			continue
+		}
+
+		// an annotation is only cleaned up by the first rule it matches
+		filter := cs.excludeRetentionRules(cs.retentionRuleCondition(rule), cfg.AnnotationRetentionRules[:i])
+
+		var archive archiveFunc
+		if rule.Archive {
+			archive = archiver.archiveFunc(rule.Name)
+		}
+
+		affected, err := cs.store.CleanMatchingAnnotations(ctx, rule.AnnotationCleanupSettings, filter, archive)
+		totalAffected += affected
+		if err != nil {
+			return totalAffected, fmt.Errorf("failed to apply annotation retention rule %q: %w", rule.Name, err)
+		}
+	}
+	return totalAffected, nil
+}
+
+// excludeRetentionRules returns a filter matching the filter and none of the rules.
+func (cs *CleanupServiceImpl) excludeRetentionRules(filter cleanupFilter, rules []setting.AnnotationRetentionRule) cleanupFilter {
+	for _, rule := range rules {
+		filter = filter.andNot(cs.retentionRuleCondition(rule))
+	}
+	return filter
+}
+
+func (cs *CleanupServiceImpl) retentionRuleCondition(rule setting.AnnotationRetentionRule) cleanupFilter {
+	conds := make([]string, 0)
+	args := make([]any, 0)
+
+	if rule.OrgID != 0 {
+		conds = append(conds, "org_id = ?")
+		args = append(args, rule.OrgID)
+	}
+
+	if rule.DashboardUID != "" {
+		conds = append(conds, "dashboard_id IN (SELECT d.id FROM dashboard d WHERE d.uid = ? AND d.org_id = annotation.org_id)")
+		args = append(args, rule.DashboardUID)
+	}
+
+	if rule.Type != "" {
+		conds = append(conds, annotationTypeConditions[rule.Type])
+	}
+
+	for _, t := range tag.ParseTagPairs(rule.Tags) {
+		tagCond := "EXISTS (SELECT 1 FROM annotation_tag at INNER JOIN tag ON tag.id = at.tag_id WHERE at.annotation_id = annotation.id AND tag." + cs.dialect.Quote("key") + " = ?"
+		args = append(args, t.Key)
+		if t.Value != "" {
+			tagCond += " AND tag." + cs.dialect.Quote("value") + " = ?"
+			args = append(args, t.Value)
+		}
+		conds = append(conds, tagCond+")")
+	}
+
+	if len(conds) == 0 {
+		return cleanupFilter{cond: "1 = 1"}
+	}
+	return cleanupFilter{cond: strings.Join(conds, " AND "), args: args}
+}
+
+// annotationArchiver writes annotations to a bucket as newline delimited JSON,
+// one object per cleanup batch.
+type annotationArchiver struct {
+	bucket *blob.Bucket
+}
+
+func (a *annotationArchiver) archiveFunc(rule string) archiveFunc {
+	return func(ctx context.Context, items []*annotations.Item) error {
+		if len(items) == 0 {
+			return nil
+		}
+
+		key := fmt.Sprintf("annotations/%s/%s-%d-%d.jsonl", rule, timeNow().UTC().Format("20060102T150405Z"), items[0].ID, items[len(items)-1].ID)
+		w, err := a.bucket.NewWriter(ctx, key, &blob.WriterOptions{ContentType: "application/x-ndjson"})
+		if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(w)
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				_ = w.Close()
+				return err
+			}
+		}
+		return w.Close()
+	}
+}
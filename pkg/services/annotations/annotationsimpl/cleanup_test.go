@@ -1,13 +1,17 @@
 package annotationsimpl
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob"
 
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -205,6 +209,86 @@ func TestIntegrationOldAnnotationsAreDeletedFirst(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestIntegrationAnnotationRetentionRules(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	fakeSQL := db.InitTestDB(t)
+	cfg := setting.NewCfg()
+	cfg.AnnotationCleanupJobBatchSize = 2
+	cfg.AnnotationMaximumTagsLength = 500
+	repo := NewXormStore(cfg, log.New("annotation.test"), fakeSQL, nil)
+
+	add := func(t *testing.T, age time.Duration, item annotations.Item) {
+		t.Helper()
+		timeNow = func() time.Time { return time.Now().Add(-age) }
+		t.Cleanup(func() { timeNow = time.Now })
+		require.NoError(t, repo.Add(context.Background(), &item))
+		timeNow = time.Now
+	}
+
+	tenDays := 10 * 24 * time.Hour
+	for i := 0; i < 2; i++ {
+		add(t, tenDays, annotations.Item{OrgID: 1, DashboardID: 1, Text: "deploy", Tags: []string{"deploy", "env:prod"}})
+	}
+	for i := 0; i < 3; i++ {
+		add(t, tenDays, annotations.Item{OrgID: 1, DashboardID: 1, Text: "auto", Tags: []string{"env:prod"}})
+	}
+	add(t, 0, annotations.Item{OrgID: 1, DashboardID: 1, Text: "auto"})
+	for i := 0; i < 4; i++ {
+		add(t, 0, annotations.Item{OrgID: 2, Text: "api"})
+	}
+
+	archiveDir := t.TempDir()
+	runCfg := &setting.Cfg{
+		DashboardAnnotationCleanupSettings: settingsFn(time.Hour, 0),
+		AnnotationArchiveURL:               "file://" + archiveDir,
+		AnnotationRetentionRules: []setting.AnnotationRetentionRule{
+			{Name: "deployments", Tags: []string{"deploy"}},
+			{Name: "dashboards", Type: setting.AnnotationTypeDashboard, AnnotationCleanupSettings: settingsFn(24*time.Hour, 0), Archive: true},
+			{Name: "org2", OrgID: 2, AnnotationCleanupSettings: settingsFn(0, 1)},
+		},
+	}
+
+	cleaner := ProvideCleanupService(fakeSQL, cfg)
+	affected, _, err := cleaner.Run(context.Background(), runCfg)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), affected)
+
+	assertAnnotationCount(t, fakeSQL, "text = 'deploy'", 2)
+	assertAnnotationCount(t, fakeSQL, "text = 'auto'", 1)
+	assertAnnotationCount(t, fakeSQL, "org_id = 2", 1)
+
+	bucket, err := blob.OpenBucket(context.Background(), "file://"+archiveDir)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = bucket.Close() })
+
+	archived := make([]*annotations.Item, 0)
+	iter := bucket.List(&blob.ListOptions{Prefix: "annotations/dashboards/"})
+	for {
+		obj, err := iter.Next(context.Background())
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		data, err := bucket.ReadAll(context.Background(), obj.Key)
+		require.NoError(t, err)
+		dec := json.NewDecoder(bytes.NewReader(data))
+		for dec.More() {
+			item := &annotations.Item{}
+			require.NoError(t, dec.Decode(item))
+			archived = append(archived, item)
+		}
+	}
+	require.Len(t, archived, 3)
+	for _, item := range archived {
+		require.Equal(t, "auto", item.Text)
+		require.Equal(t, []string{"env:prod"}, item.Tags)
+	}
+}
+
 func assertAnnotationCount(t *testing.T, fakeSQL db.DB, sql string, expectedCount int64) {
 	t.Helper()
 
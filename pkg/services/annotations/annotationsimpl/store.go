@@ -31,5 +31,6 @@ type writeStore interface {
 	Update(ctx context.Context, item *annotations.Item) error
 	Delete(ctx context.Context, params *annotations.DeleteParams) error
 	CleanAnnotations(ctx context.Context, cfg setting.AnnotationCleanupSettings, annotationType string) (int64, error)
+	CleanMatchingAnnotations(ctx context.Context, cfg setting.AnnotationCleanupSettings, filter cleanupFilter, archive archiveFunc) (int64, error)
 	CleanOrphanedAnnotationTags(ctx context.Context) (int64, error)
 }
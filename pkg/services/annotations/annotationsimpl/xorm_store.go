@@ -527,6 +527,13 @@ func (r *xormRepositoryImpl) validateTagsLength(item *annotations.Item) error {
 }
 
 func (r *xormRepositoryImpl) CleanAnnotations(ctx context.Context, cfg setting.AnnotationCleanupSettings, annotationType string) (int64, error) {
+	return r.CleanMatchingAnnotations(ctx, cfg, cleanupFilter{cond: annotationType}, nil)
+}
+
+// CleanMatchingAnnotations deletes the annotations matched by the filter that are older than the
+// maximum age or exceed the maximum count. If archive is set, each batch is passed to it before
+// being deleted and the batch is not deleted if archiving fails.
+func (r *xormRepositoryImpl) CleanMatchingAnnotations(ctx context.Context, cfg setting.AnnotationCleanupSettings, filter cleanupFilter, archive archiveFunc) (int64, error) {
 	var totalAffected int64
 	if cfg.MaxAge > 0 {
 		cutoffDate := timeNow().Add(-cfg.MaxAge).UnixNano() / int64(time.Millisecond)
@@ -536,13 +543,13 @@ func (r *xormRepositoryImpl) CleanAnnotations(ctx context.Context, cfg setting.A
 		//
 		// We execute the following batched operation repeatedly until either we run out of objects, the context is cancelled, or there is an error.
 		affected, err := untilDoneOrCancelled(ctx, func() (int64, error) {
-			cond := fmt.Sprintf(`%s AND created < %v ORDER BY id DESC %s`, annotationType, cutoffDate, r.db.GetDialect().Limit(r.cfg.AnnotationCleanupJobBatchSize))
-			ids, err := r.fetchIDs(ctx, "annotation", cond)
+			cond := fmt.Sprintf(`%s AND created < %v ORDER BY id DESC %s`, filter.cond, cutoffDate, r.db.GetDialect().Limit(r.cfg.AnnotationCleanupJobBatchSize))
+			ids, err := r.fetchIDs(ctx, "annotation", cond, filter.args...)
 			if err != nil {
 				return 0, err
 			}
 
-			return r.deleteByIDs(ctx, "annotation", ids)
+			return r.archiveAndDeleteByIDs(ctx, ids, archive)
 		})
 		totalAffected += affected
 		if err != nil {
@@ -553,13 +560,13 @@ func (r *xormRepositoryImpl) CleanAnnotations(ctx context.Context, cfg setting.A
 	if cfg.MaxCount > 0 {
 		// Similar strategy as the above cleanup process, to avoid deadlocks.
 		affected, err := untilDoneOrCancelled(ctx, func() (int64, error) {
-			cond := fmt.Sprintf(`%s ORDER BY id DESC %s`, annotationType, r.db.GetDialect().LimitOffset(r.cfg.AnnotationCleanupJobBatchSize, cfg.MaxCount))
-			ids, err := r.fetchIDs(ctx, "annotation", cond)
+			cond := fmt.Sprintf(`%s ORDER BY id DESC %s`, filter.cond, r.db.GetDialect().LimitOffset(r.cfg.AnnotationCleanupJobBatchSize, cfg.MaxCount))
+			ids, err := r.fetchIDs(ctx, "annotation", cond, filter.args...)
 			if err != nil {
 				return 0, err
 			}
 
-			return r.deleteByIDs(ctx, "annotation", ids)
+			return r.archiveAndDeleteByIDs(ctx, ids, archive)
 		})
 		totalAffected += affected
 		if err != nil {
@@ -570,6 +577,37 @@ func (r *xormRepositoryImpl) CleanAnnotations(ctx context.Context, cfg setting.A
 	return totalAffected, nil
 }
 
+func (r *xormRepositoryImpl) archiveAndDeleteByIDs(ctx context.Context, ids []int64, archive archiveFunc) (int64, error) {
+	if archive != nil && len(ids) > 0 {
+		items, err := r.fetchItems(ctx, ids)
+		if err != nil {
+			return 0, err
+		}
+		if err := archive(ctx, items); err != nil {
+			return 0, fmt.Errorf("failed to archive annotations: %w", err)
+		}
+	}
+
+	return r.deleteByIDs(ctx, "annotation", ids)
+}
+
+func (r *xormRepositoryImpl) fetchItems(ctx context.Context, ids []int64) ([]*annotations.Item, error) {
+	// Stay below the SQLite parameter limit, see deleteByIDs.
+	const chunkSize = 999
+	items := make([]*annotations.Item, 0, len(ids))
+	err := r.db.WithDbSession(ctx, func(session *db.Session) error {
+		for i := 0; i < len(ids); i += chunkSize {
+			chunk := make([]*annotations.Item, 0)
+			if err := session.In("id", asAny(ids[i:min(i+chunkSize, len(ids))])...).Asc("id").Find(&chunk); err != nil {
+				return err
+			}
+			items = append(items, chunk...)
+		}
+		return nil
+	})
+	return items, err
+}
+
 func (r *xormRepositoryImpl) CleanOrphanedAnnotationTags(ctx context.Context) (int64, error) {
 	return untilDoneOrCancelled(ctx, func() (int64, error) {
 		cond := fmt.Sprintf(`NOT EXISTS (SELECT 1 FROM annotation a WHERE annotation_id = a.id) %s`, r.db.GetDialect().Limit(r.cfg.AnnotationCleanupJobBatchSize))
@@ -582,7 +620,7 @@ func (r *xormRepositoryImpl) CleanOrphanedAnnotationTags(ctx context.Context) (i
 	})
 }
 
-func (r *xormRepositoryImpl) fetchIDs(ctx context.Context, table, condition string) ([]int64, error) {
+func (r *xormRepositoryImpl) fetchIDs(ctx context.Context, table, condition string, args ...any) ([]int64, error) {
 	sql := fmt.Sprintf(`SELECT id FROM %s`, table)
 	if condition == "" {
 		return nil, fmt.Errorf("condition must be supplied; cannot fetch IDs from entire table")
@@ -590,7 +628,7 @@ func (r *xormRepositoryImpl) fetchIDs(ctx context.Context, table, condition stri
 	sql += fmt.Sprintf(` WHERE %s`, condition)
 	ids := make([]int64, 0)
 	err := r.db.WithDbSession(ctx, func(session *db.Session) error {
-		return session.SQL(sql).Find(&ids)
+		return session.SQL(sql, args...).Find(&ids)
 	})
 	return ids, err
 }
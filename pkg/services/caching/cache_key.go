@@ -0,0 +1,126 @@
+package caching
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+)
+
+const (
+	queryKeyPrefix    = "query-cache:"
+	resourceKeyPrefix = "resource-cache:"
+)
+
+// volatileQueryFields are fields of a query model that change between otherwise identical queries
+// without affecting the result, e.g. the identifier that Explore adds to every query.
+var volatileQueryFields = []string{"key", "requestId"}
+
+type dataSourceKey struct {
+	OrgID   int64  `json:"orgId"`
+	UID     string `json:"uid,omitempty"`
+	Updated int64  `json:"updated,omitempty"`
+}
+
+type queryKey struct {
+	RefID         string `json:"refId"`
+	QueryType     string `json:"queryType,omitempty"`
+	Model         any    `json:"model,omitempty"`
+	Interval      int64  `json:"interval"`
+	MaxDataPoints int64  `json:"maxDataPoints"`
+	From          int64  `json:"from"`
+	To            int64  `json:"to"`
+}
+
+// queryCacheKey returns the key of the response of the request, identity being the user the response depends on if any.
+// The query models are normalized so that the order of their fields does not matter, and the time ranges ending now are
+// truncated to the time to live, which makes the requests of relative time ranges share the cached response until it expires.
+func queryCacheKey(req *backend.QueryDataRequest, ttl time.Duration, now time.Time, identity map[string]string) (string, error) {
+	queries := make([]queryKey, 0, len(req.Queries))
+	for _, q := range req.Queries {
+		model, err := normalizeQueryModel(q.JSON)
+		if err != nil {
+			return "", err
+		}
+		from, to := q.TimeRange.From, q.TimeRange.To
+		// the backend only gets absolute time ranges, relative time ranges are the ones ending now
+		if to.Sub(now).Abs() <= ttl {
+			from, to = from.Truncate(ttl), to.Truncate(ttl)
+		}
+		queries = append(queries, queryKey{
+			RefID:         q.RefID,
+			QueryType:     q.QueryType,
+			Model:         model,
+			Interval:      q.Interval.Milliseconds(),
+			MaxDataPoints: q.MaxDataPoints,
+			From:          from.UnixMilli(),
+			To:            to.UnixMilli(),
+		})
+	}
+	return hashKey(queryKeyPrefix, struct {
+		DataSource dataSourceKey     `json:"datasource"`
+		Identity   map[string]string `json:"identity,omitempty"`
+		Queries    []queryKey        `json:"queries"`
+	}{
+		DataSource: newDataSourceKey(req.PluginContext),
+		Identity:   identity,
+		Queries:    queries,
+	})
+}
+
+// resourceCacheKey returns the key of the response of the resource request, identity being the user the response depends on if any.
+func resourceCacheKey(req *backend.CallResourceRequest, identity map[string]string) (string, error) {
+	return hashKey(resourceKeyPrefix, struct {
+		DataSource dataSourceKey     `json:"datasource"`
+		Identity   map[string]string `json:"identity,omitempty"`
+		PluginID   string            `json:"pluginId"`
+		Path       string            `json:"path"`
+		URL        string            `json:"url"`
+		Body       []byte            `json:"body,omitempty"`
+	}{
+		DataSource: newDataSourceKey(req.PluginContext),
+		Identity:   identity,
+		PluginID:   req.PluginContext.PluginID,
+		Path:       req.Path,
+		URL:        req.URL,
+		Body:       req.Body,
+	})
+}
+
+func newDataSourceKey(pCtx backend.PluginContext) dataSourceKey {
+	key := dataSourceKey{OrgID: pCtx.OrgID}
+	if ds := pCtx.DataSourceInstanceSettings; ds != nil {
+		key.UID = ds.UID
+		// the responses cached before the data source was updated are not used anymore.
+		key.Updated = ds.Updated.UnixMilli()
+	}
+	return key
+}
+
+func normalizeQueryModel(raw json.RawMessage) (any, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var model any
+	if err := json.Unmarshal(raw, &model); err != nil {
+		return nil, err
+	}
+	if m, ok := model.(map[string]any); ok {
+		for _, field := range volatileQueryFields {
+			delete(m, field)
+		}
+	}
+	return model, nil
+}
+
+// hashKey marshals the value, which sorts the keys of maps, and hashes the result.
+func hashKey(prefix string, v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return prefix + hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,29 @@
+package caching
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	requestTypeQuery    = "query"
+	requestTypeResource = "resource"
+)
+
+type metrics struct {
+	requests *prometheus.CounterVec
+}
+
+func newMetrics(reg prometheus.Registerer) *metrics {
+	m := &metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "grafana",
+			Subsystem: "caching",
+			Name:      "requests_total",
+			Help:      "Number of data source query and resource requests handled by the cache, by cache status.",
+		}, []string{"request_type", "status"}),
+	}
+	if reg != nil {
+		reg.MustRegister(m.requests)
+	}
+	return m
+}
@@ -2,8 +2,21 @@ package caching
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/services/contexthandler"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util/proxyutil"
 )
 
 const (
@@ -15,6 +28,9 @@ const (
 	StatusDisabled = "DISABLED"
 )
 
+// XCacheSkipHeader is the request header used to bypass the cache.
+const XCacheSkipHeader = "X-Cache-Skip"
+
 type CacheQueryResponseFn func(context.Context, *backend.QueryDataResponse)
 type CacheResourceResponseFn func(context.Context, *backend.CallResourceResponse)
 
@@ -35,10 +51,6 @@ type CachedResourceDataResponse struct {
 	UpdateCacheFn CacheResourceResponseFn
 }
 
-func ProvideCachingService() *OSSCachingService {
-	return &OSSCachingService{}
-}
-
 type CachingService interface {
 	// HandleQueryRequest uses a QueryDataRequest to check the cache for any existing results for that query.
 	// If none are found, it should return false and a CachedQueryDataResponse with an UpdateCacheFn which can be used to update the results cache after the fact.
@@ -49,16 +61,258 @@ type CachingService interface {
 	HandleResourceRequest(context.Context, *backend.CallResourceRequest) (bool, CachedResourceDataResponse)
 }
 
-// Implementation of interface - does nothing
+// OSSCachingService caches the responses of data source queries and resource requests in the remote cache.
+// The zero value does not cache anything.
 type OSSCachingService struct {
+	cfg     setting.CachingSettings
+	cache   remotecache.CacheStorage
+	metrics *metrics
+	log     log.Logger
+	// sendUserHeader is true when the login of the signed-in user is forwarded to data sources
+	sendUserHeader bool
+}
+
+func ProvideCachingService(cfg *setting.Cfg, cache remotecache.CacheStorage, reg prometheus.Registerer) *OSSCachingService {
+	return &OSSCachingService{
+		cfg:            cfg.Caching,
+		cache:          cache,
+		metrics:        newMetrics(reg),
+		log:            log.New("caching"),
+		sendUserHeader: cfg.SendUserHeader,
+	}
 }
 
 func (s *OSSCachingService) HandleQueryRequest(ctx context.Context, req *backend.QueryDataRequest) (bool, CachedQueryDataResponse) {
-	return false, CachedQueryDataResponse{}
+	if !s.enabled() || req.PluginContext.DataSourceInstanceSettings == nil {
+		return false, CachedQueryDataResponse{}
+	}
+
+	ds := req.PluginContext.DataSourceInstanceSettings
+	ttl := s.cfg.TTL
+	if dsTTL, ok := s.cfg.DataSourceTTLs[ds.UID]; ok {
+		ttl = dsTTL
+	}
+	if ttl <= 0 || forwardsOAuthIdentity(ds) {
+		s.setStatus(ctx, requestTypeQuery, StatusDisabled)
+		return false, CachedQueryDataResponse{}
+	}
+	if skipCache(ctx, req.Headers) {
+		s.setStatus(ctx, requestTypeQuery, StatusBypass)
+		return false, CachedQueryDataResponse{}
+	}
+
+	key, err := queryCacheKey(req, ttl, time.Now(), s.userIdentity(ctx, req.PluginContext, req.Headers))
+	if err != nil {
+		s.log.FromContext(ctx).Warn("Failed to build query cache key", "datasource", ds.UID, "error", err)
+		s.setStatus(ctx, requestTypeQuery, StatusError)
+		return false, CachedQueryDataResponse{}
+	}
+
+	value, err := s.cache.Get(ctx, key)
+	if err == nil {
+		resp := &backend.QueryDataResponse{}
+		if err = json.Unmarshal(value, resp); err == nil {
+			s.setStatus(ctx, requestTypeQuery, StatusHit)
+			return true, CachedQueryDataResponse{Response: resp}
+		}
+	}
+	if !errors.Is(err, remotecache.ErrCacheItemNotFound) {
+		s.log.FromContext(ctx).Warn("Failed to get query response from cache", "datasource", ds.UID, "error", err)
+		s.setStatus(ctx, requestTypeQuery, StatusError)
+		return false, CachedQueryDataResponse{}
+	}
+
+	s.setStatus(ctx, requestTypeQuery, StatusMiss)
+	return false, CachedQueryDataResponse{
+		UpdateCacheFn: func(ctx context.Context, resp *backend.QueryDataResponse) {
+			if resp == nil || !isCacheableQueryResponse(resp) {
+				return
+			}
+			value, err := json.Marshal(resp)
+			if err != nil {
+				s.log.FromContext(ctx).Warn("Failed to marshal query response", "datasource", ds.UID, "error", err)
+				return
+			}
+			s.store(ctx, key, value, ttl)
+		},
+	}
 }
 
 func (s *OSSCachingService) HandleResourceRequest(ctx context.Context, req *backend.CallResourceRequest) (bool, CachedResourceDataResponse) {
-	return false, CachedResourceDataResponse{}
+	if !s.enabled() || s.cfg.ResourcesTTL <= 0 || req.Method != http.MethodGet {
+		return false, CachedResourceDataResponse{}
+	}
+	if ds := req.PluginContext.DataSourceInstanceSettings; ds != nil && forwardsOAuthIdentity(ds) {
+		s.setStatus(ctx, requestTypeResource, StatusDisabled)
+		return false, CachedResourceDataResponse{}
+	}
+	if skipCache(ctx, flattenHeaders(req.Headers)) {
+		s.setStatus(ctx, requestTypeResource, StatusBypass)
+		return false, CachedResourceDataResponse{}
+	}
+
+	key, err := resourceCacheKey(req, s.userIdentity(ctx, req.PluginContext, flattenHeaders(req.Headers)))
+	if err != nil {
+		s.log.FromContext(ctx).Warn("Failed to build resource cache key", "plugin", req.PluginContext.PluginID, "error", err)
+		s.setStatus(ctx, requestTypeResource, StatusError)
+		return false, CachedResourceDataResponse{}
+	}
+
+	value, err := s.cache.Get(ctx, key)
+	if err == nil {
+		resp := &backend.CallResourceResponse{}
+		if err = json.Unmarshal(value, resp); err == nil {
+			s.setStatus(ctx, requestTypeResource, StatusHit)
+			return true, CachedResourceDataResponse{Response: resp}
+		}
+	}
+	if !errors.Is(err, remotecache.ErrCacheItemNotFound) {
+		s.log.FromContext(ctx).Warn("Failed to get resource response from cache", "plugin", req.PluginContext.PluginID, "error", err)
+		s.setStatus(ctx, requestTypeResource, StatusError)
+		return false, CachedResourceDataResponse{}
+	}
+
+	s.setStatus(ctx, requestTypeResource, StatusMiss)
+	// Streamed resources send several responses, which cannot be replayed from a single cached response.
+	// Only the first response is cached, and it is deleted again if more responses follow.
+	var responses atomic.Int32
+	return false, CachedResourceDataResponse{
+		UpdateCacheFn: func(ctx context.Context, resp *backend.CallResourceResponse) {
+			if responses.Add(1) > 1 {
+				if err := s.cache.Delete(ctx, key); err != nil && !errors.Is(err, remotecache.ErrCacheItemNotFound) {
+					s.log.FromContext(ctx).Warn("Failed to delete streamed resource response from cache", "plugin", req.PluginContext.PluginID, "error", err)
+				}
+				return
+			}
+			if resp == nil || resp.Status < http.StatusOK || resp.Status >= http.StatusMultipleChoices {
+				return
+			}
+			value, err := json.Marshal(resp)
+			if err != nil {
+				s.log.FromContext(ctx).Warn("Failed to marshal resource response", "plugin", req.PluginContext.PluginID, "error", err)
+				return
+			}
+			s.store(ctx, key, value, s.cfg.ResourcesTTL)
+		},
+	}
+}
+
+func (s *OSSCachingService) enabled() bool {
+	return s.cfg.Enabled && s.cache != nil
+}
+
+func (s *OSSCachingService) store(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	if s.cfg.MaxValueSize > 0 && len(value) > s.cfg.MaxValueSize {
+		s.log.FromContext(ctx).Debug("Response is too large to be cached", "size", len(value), "limit", s.cfg.MaxValueSize)
+		return
+	}
+	if err := s.cache.Set(ctx, key, value, ttl); err != nil {
+		s.log.FromContext(ctx).Warn("Failed to store response in cache", "error", err)
+	}
+}
+
+// setStatus counts the request and reports the cache status in the X-Cache header of the response.
+func (s *OSSCachingService) setStatus(ctx context.Context, requestType, status string) {
+	if s.metrics != nil {
+		s.metrics.requests.WithLabelValues(requestType, strings.ToLower(status)).Inc()
+	}
+	if reqCtx := contexthandler.FromContext(ctx); reqCtx != nil && reqCtx.Resp != nil {
+		reqCtx.Resp.Header().Set(XCacheHeader, status)
+	}
 }
 
 var _ CachingService = &OSSCachingService{}
+
+// skipCache returns true if the caller asked to bypass the cache with the X-Cache-Skip header.
+func skipCache(ctx context.Context, headers map[string]string) bool {
+	if reqCtx := contexthandler.FromContext(ctx); reqCtx != nil && reqCtx.SkipQueryCache {
+		return true
+	}
+	for name, value := range headers {
+		if strings.EqualFold(strings.TrimPrefix(name, "http_"), XCacheSkipHeader) && value == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+func flattenHeaders(headers map[string][]string) map[string]string {
+	result := make(map[string]string, len(headers))
+	for name, values := range headers {
+		if len(values) > 0 {
+			result[name] = values[0]
+		}
+	}
+	return result
+}
+
+// identityHeaders are the headers sent to data sources that depend on the signed-in user.
+var identityHeaders = []string{
+	"Authorization", "Cookie", "X-Id-Token", "X-Prom-Label-Policy", proxyutil.UserHeaderName, proxyutil.IDHeaderName,
+}
+
+// userIdentity returns the identity of the signed-in user and the headers that depend on it when the response
+// of the request may depend on the user, so that the response is only shared between requests of the same user.
+// It returns nil when the response can be shared between users.
+// The user and ID token headers are added to requests after the cache is checked, so they are not in the headers yet.
+func (s *OSSCachingService) userIdentity(ctx context.Context, pCtx backend.PluginContext, headers map[string]string) map[string]string {
+	identity := map[string]string{}
+	for name, value := range headers {
+		name = strings.TrimPrefix(name, "http_")
+		for _, h := range identityHeaders {
+			if strings.EqualFold(name, h) {
+				identity[h] = value
+			}
+		}
+	}
+
+	dependsOnUser := len(identity) > 0 || s.sendUserHeader ||
+		// app plugins are not data sources, their responses are assumed to depend on the user
+		pCtx.DataSourceInstanceSettings == nil ||
+		hasTeamHTTPHeaders(pCtx.DataSourceInstanceSettings)
+	if reqCtx := contexthandler.FromContext(ctx); reqCtx != nil && reqCtx.SignedInUser != nil && reqCtx.SignedInUser.GetIDToken() != "" {
+		dependsOnUser = true
+	}
+	if !dependsOnUser {
+		return nil
+	}
+
+	if pCtx.User != nil {
+		identity["user"] = pCtx.User.Login
+	}
+	return identity
+}
+
+// hasTeamHTTPHeaders returns true if the data source sends different headers depending on the teams of the user.
+func hasTeamHTTPHeaders(ds *backend.DataSourceInstanceSettings) bool {
+	var jsonData struct {
+		TeamHTTPHeaders json.RawMessage `json:"teamHttpHeaders"`
+	}
+	if len(ds.JSONData) == 0 || json.Unmarshal(ds.JSONData, &jsonData) != nil {
+		return false
+	}
+	headers := strings.TrimSpace(string(jsonData.TeamHTTPHeaders))
+	return headers != "" && headers != "null" && headers != "{}"
+}
+
+// forwardsOAuthIdentity returns true if the data source queries on behalf of the signed-in user,
+// in which case responses cannot be shared between users.
+func forwardsOAuthIdentity(ds *backend.DataSourceInstanceSettings) bool {
+	var jsonData struct {
+		OAuthPassThru bool `json:"oauthPassThru"`
+	}
+	if len(ds.JSONData) == 0 || json.Unmarshal(ds.JSONData, &jsonData) != nil {
+		return false
+	}
+	return jsonData.OAuthPassThru
+}
+
+// isCacheableQueryResponse returns true if none of the queries failed.
+func isCacheableQueryResponse(resp *backend.QueryDataResponse) bool {
+	for _, r := range resp.Responses {
+		if r.Error != nil || int(r.Status) >= http.StatusMultipleChoices {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,323 @@
+package caching
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/services/contexthandler/ctxkey"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestOSSCachingService_HandleQueryRequest(t *testing.T) {
+	// the time ranges of the requests end now, like relative time ranges
+	now := time.Now().Truncate(time.Minute)
+	newRequest := func(dsUID string, model string, to time.Time) *backend.QueryDataRequest {
+		return &backend.QueryDataRequest{
+			PluginContext: backend.PluginContext{
+				OrgID:                      1,
+				User:                       &backend.User{Login: "viewer"},
+				DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{UID: dsUID, JSONData: json.RawMessage(`{}`)},
+			},
+			Queries: []backend.DataQuery{
+				{
+					RefID:     "A",
+					JSON:      json.RawMessage(model),
+					TimeRange: backend.TimeRange{From: to.Add(-time.Hour), To: to},
+				},
+			},
+		}
+	}
+	response := &backend.QueryDataResponse{
+		Responses: backend.Responses{
+			"A": {Frames: data.Frames{data.NewFrame("test", data.NewField("value", nil, []float64{1, 2}))}},
+		},
+	}
+
+	t.Run("should not cache when disabled", func(t *testing.T) {
+		s := newTestService(t, setting.CachingSettings{TTL: time.Minute})
+		ctx, rec := newTestContext(false)
+
+		hit, cr := s.HandleQueryRequest(ctx, newRequest("ds", `{}`, now))
+		require.False(t, hit)
+		require.Nil(t, cr.UpdateCacheFn)
+		require.Empty(t, rec.Header().Get(XCacheHeader))
+
+		hit, cr = (&OSSCachingService{}).HandleQueryRequest(ctx, newRequest("ds", `{}`, now))
+		require.False(t, hit)
+		require.Nil(t, cr.UpdateCacheFn)
+	})
+
+	t.Run("should return cached response for identical queries", func(t *testing.T) {
+		s := newTestService(t, setting.CachingSettings{Enabled: true, TTL: time.Minute})
+
+		ctx, rec := newTestContext(false)
+		hit, cr := s.HandleQueryRequest(ctx, newRequest("ds", `{"rawSql": "SELECT 1", "format": "table"}`, now))
+		require.False(t, hit)
+		require.Equal(t, StatusMiss, rec.Header().Get(XCacheHeader))
+		require.NotNil(t, cr.UpdateCacheFn)
+		cr.UpdateCacheFn(ctx, response)
+
+		// the order of fields, volatile fields and a time range within the same ttl window do not matter.
+		ctx, rec = newTestContext(false)
+		hit, cr = s.HandleQueryRequest(ctx, newRequest("ds", `{"format": "table", "key": "Q-1", "rawSql": "SELECT 1"}`, now.Add(10*time.Second)))
+		require.True(t, hit)
+		require.Equal(t, StatusHit, rec.Header().Get(XCacheHeader))
+		require.Len(t, cr.Response.Responses["A"].Frames, 1)
+		assert.Equal(t, response.Responses["A"].Frames[0].Fields[0].Len(), cr.Response.Responses["A"].Frames[0].Fields[0].Len())
+	})
+
+	t.Run("should miss for different queries", func(t *testing.T) {
+		s := newTestService(t, setting.CachingSettings{Enabled: true, TTL: time.Minute})
+		ctx, _ := newTestContext(false)
+		_, cr := s.HandleQueryRequest(ctx, newRequest("ds", `{"rawSql": "SELECT 1"}`, now))
+		cr.UpdateCacheFn(ctx, response)
+
+		testCases := map[string]*backend.QueryDataRequest{
+			"model":       newRequest("ds", `{"rawSql": "SELECT 2"}`, now),
+			"data source": newRequest("other", `{"rawSql": "SELECT 1"}`, now),
+			"time range":  newRequest("ds", `{"rawSql": "SELECT 1"}`, now.Add(time.Minute)),
+		}
+		for name, req := range testCases {
+			t.Run(name, func(t *testing.T) {
+				ctx, rec := newTestContext(false)
+				hit, _ := s.HandleQueryRequest(ctx, req)
+				require.False(t, hit)
+				require.Equal(t, StatusMiss, rec.Header().Get(XCacheHeader))
+			})
+		}
+	})
+
+	t.Run("should not truncate absolute time ranges", func(t *testing.T) {
+		s := newTestService(t, setting.CachingSettings{Enabled: true, TTL: time.Minute})
+		past := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		ctx, _ := newTestContext(false)
+		_, cr := s.HandleQueryRequest(ctx, newRequest("ds", `{}`, past))
+		cr.UpdateCacheFn(ctx, response)
+
+		ctx, rec := newTestContext(false)
+		hit, _ := s.HandleQueryRequest(ctx, newRequest("ds", `{}`, past.Add(10*time.Second)))
+		require.False(t, hit)
+		require.Equal(t, StatusMiss, rec.Header().Get(XCacheHeader))
+	})
+
+	t.Run("should not share responses that depend on the user", func(t *testing.T) {
+		testCases := map[string]struct {
+			sendUserHeader bool
+			update         func(req *backend.QueryDataRequest)
+		}{
+			"user header": {sendUserHeader: true, update: func(req *backend.QueryDataRequest) {}},
+			"forwarded headers": {update: func(req *backend.QueryDataRequest) {
+				req.Headers = map[string]string{"http_Cookie": "session=" + req.PluginContext.User.Login}
+			}},
+			"team headers": {update: func(req *backend.QueryDataRequest) {
+				req.PluginContext.DataSourceInstanceSettings.JSONData = json.RawMessage(`{"teamHttpHeaders": {"1": [{"header": "X-Prom-Label-Policy"}]}}`)
+			}},
+		}
+		for name, tc := range testCases {
+			t.Run(name, func(t *testing.T) {
+				s := newTestService(t, setting.CachingSettings{Enabled: true, TTL: time.Minute})
+				s.sendUserHeader = tc.sendUserHeader
+				req := newRequest("ds", `{}`, now)
+				tc.update(req)
+				ctx, _ := newTestContext(false)
+				_, cr := s.HandleQueryRequest(ctx, req)
+				cr.UpdateCacheFn(ctx, response)
+
+				ctx, _ = newTestContext(false)
+				hit, _ := s.HandleQueryRequest(ctx, req)
+				require.True(t, hit)
+
+				other := newRequest("ds", `{}`, now)
+				other.PluginContext.User = &backend.User{Login: "other"}
+				tc.update(other)
+				ctx, rec := newTestContext(false)
+				hit, _ = s.HandleQueryRequest(ctx, other)
+				require.False(t, hit)
+				require.Equal(t, StatusMiss, rec.Header().Get(XCacheHeader))
+			})
+		}
+	})
+
+	t.Run("should bypass cache when asked to skip it", func(t *testing.T) {
+		s := newTestService(t, setting.CachingSettings{Enabled: true, TTL: time.Minute})
+		ctx, rec := newTestContext(true)
+
+		hit, cr := s.HandleQueryRequest(ctx, newRequest("ds", `{}`, now))
+		require.False(t, hit)
+		require.Nil(t, cr.UpdateCacheFn)
+		require.Equal(t, StatusBypass, rec.Header().Get(XCacheHeader))
+
+		req := newRequest("ds", `{}`, now)
+		req.Headers = map[string]string{XCacheSkipHeader: "true"}
+		ctx, rec = newTestContext(false)
+		hit, _ = s.HandleQueryRequest(ctx, req)
+		require.False(t, hit)
+		require.Equal(t, StatusBypass, rec.Header().Get(XCacheHeader))
+	})
+
+	t.Run("should use data source ttl", func(t *testing.T) {
+		s := newTestService(t, setting.CachingSettings{
+			Enabled:        true,
+			TTL:            time.Minute,
+			DataSourceTTLs: map[string]time.Duration{"disabled": 0},
+		})
+		ctx, rec := newTestContext(false)
+
+		hit, cr := s.HandleQueryRequest(ctx, newRequest("disabled", `{}`, now))
+		require.False(t, hit)
+		require.Nil(t, cr.UpdateCacheFn)
+		require.Equal(t, StatusDisabled, rec.Header().Get(XCacheHeader))
+	})
+
+	t.Run("should not cache responses of data sources that forward the identity of the user", func(t *testing.T) {
+		s := newTestService(t, setting.CachingSettings{Enabled: true, TTL: time.Minute})
+		ctx, rec := newTestContext(false)
+		req := newRequest("ds", `{}`, now)
+		req.PluginContext.DataSourceInstanceSettings.JSONData = json.RawMessage(`{"oauthPassThru": true}`)
+
+		hit, cr := s.HandleQueryRequest(ctx, req)
+		require.False(t, hit)
+		require.Nil(t, cr.UpdateCacheFn)
+		require.Equal(t, StatusDisabled, rec.Header().Get(XCacheHeader))
+	})
+
+	t.Run("should not cache failed or too large responses", func(t *testing.T) {
+		cache := remotecache.NewFakeCacheStorage()
+		s := &OSSCachingService{
+			cfg:   setting.CachingSettings{Enabled: true, TTL: time.Minute, MaxValueSize: 1024},
+			cache: cache,
+			log:   log.NewNopLogger(),
+		}
+		ctx, _ := newTestContext(false)
+
+		_, cr := s.HandleQueryRequest(ctx, newRequest("ds", `{}`, now))
+		cr.UpdateCacheFn(ctx, &backend.QueryDataResponse{
+			Responses: backend.Responses{"A": backend.ErrDataResponse(backend.StatusBadRequest, "bad query")},
+		})
+		require.Empty(t, cache.Storage)
+
+		large := make([]float64, 1024)
+		cr.UpdateCacheFn(ctx, &backend.QueryDataResponse{
+			Responses: backend.Responses{"A": {Frames: data.Frames{data.NewFrame("test", data.NewField("value", nil, large))}}},
+		})
+		require.Empty(t, cache.Storage)
+
+		cr.UpdateCacheFn(ctx, response)
+		require.Len(t, cache.Storage, 1)
+	})
+}
+
+func TestOSSCachingService_HandleResourceRequest(t *testing.T) {
+	newRequest := func(method string) *backend.CallResourceRequest {
+		return &backend.CallResourceRequest{
+			PluginContext: backend.PluginContext{
+				OrgID:                      1,
+				PluginID:                   "test",
+				DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{UID: "ds"},
+			},
+			Method: method,
+			Path:   "tables",
+			URL:    "tables?schema=public",
+		}
+	}
+	response := &backend.CallResourceResponse{Status: http.StatusOK, Body: []byte(`["a","b"]`)}
+
+	t.Run("should not cache when resources ttl is not set", func(t *testing.T) {
+		s := newTestService(t, setting.CachingSettings{Enabled: true, TTL: time.Minute})
+		ctx, _ := newTestContext(false)
+
+		hit, cr := s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		require.False(t, hit)
+		require.Nil(t, cr.UpdateCacheFn)
+	})
+
+	t.Run("should cache GET requests", func(t *testing.T) {
+		s := newTestService(t, setting.CachingSettings{Enabled: true, ResourcesTTL: time.Minute})
+
+		ctx, rec := newTestContext(false)
+		hit, cr := s.HandleResourceRequest(ctx, newRequest(http.MethodPost))
+		require.False(t, hit)
+		require.Nil(t, cr.UpdateCacheFn)
+		require.Empty(t, rec.Header().Get(XCacheHeader))
+
+		hit, cr = s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		require.False(t, hit)
+		require.Equal(t, StatusMiss, rec.Header().Get(XCacheHeader))
+		cr.UpdateCacheFn(ctx, response)
+
+		ctx, rec = newTestContext(false)
+		hit, cr = s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		require.True(t, hit)
+		require.Equal(t, StatusHit, rec.Header().Get(XCacheHeader))
+		require.Equal(t, response.Body, cr.Response.Body)
+	})
+
+	t.Run("should not share app plugin responses between users", func(t *testing.T) {
+		s := newTestService(t, setting.CachingSettings{Enabled: true, ResourcesTTL: time.Minute})
+		req := newRequest(http.MethodGet)
+		req.PluginContext.DataSourceInstanceSettings = nil
+		req.PluginContext.User = &backend.User{Login: "viewer"}
+		ctx, _ := newTestContext(false)
+		_, cr := s.HandleResourceRequest(ctx, req)
+		cr.UpdateCacheFn(ctx, response)
+
+		other := newRequest(http.MethodGet)
+		other.PluginContext.DataSourceInstanceSettings = nil
+		other.PluginContext.User = &backend.User{Login: "other"}
+		ctx, _ = newTestContext(false)
+		hit, _ := s.HandleResourceRequest(ctx, other)
+		require.False(t, hit)
+	})
+
+	t.Run("should not cache streamed responses", func(t *testing.T) {
+		cache := remotecache.NewFakeCacheStorage()
+		s := &OSSCachingService{
+			cfg:   setting.CachingSettings{Enabled: true, ResourcesTTL: time.Minute},
+			cache: cache,
+			log:   log.NewNopLogger(),
+		}
+		ctx, _ := newTestContext(false)
+
+		_, cr := s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		cr.UpdateCacheFn(ctx, response)
+		require.Len(t, cache.Storage, 1)
+		cr.UpdateCacheFn(ctx, response)
+		require.Empty(t, cache.Storage)
+	})
+}
+
+func newTestService(t *testing.T, cfg setting.CachingSettings) *OSSCachingService {
+	t.Helper()
+	return &OSSCachingService{
+		cfg:     cfg,
+		cache:   remotecache.NewFakeCacheStorage(),
+		metrics: newMetrics(nil),
+		log:     log.NewNopLogger(),
+	}
+}
+
+func newTestContext(skipCache bool) (context.Context, *httptest.ResponseRecorder) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/ds/query", nil)
+	reqCtx := &contextmodel.ReqContext{
+		Context: &web.Context{
+			Req:  req,
+			Resp: web.NewResponseWriter(req.Method, rec),
+		},
+		SkipQueryCache: skipCache,
+	}
+	return ctxkey.Set(context.Background(), reqCtx), rec
+}
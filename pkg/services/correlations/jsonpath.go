@@ -0,0 +1,51 @@
+package correlations
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// validateJSONPath checks that the expression is in the subset of JSONPath supported by jsonpath transformations.
+// Expressions start with $ followed by dot notation (.key), bracket notation (['key'] or ["key"]) and array indexes ([0]).
+// Wildcards, slices and filters are not supported as transformations extract a single value.
+func validateJSONPath(expr string) error {
+	if !strings.HasPrefix(expr, "$") {
+		return errors.New("expression must start with $")
+	}
+	if expr == "$" {
+		return errors.New("expression must select a field")
+	}
+
+	rest := expr[1:]
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			if key := rest[1 : end+1]; key == "" || key == "*" {
+				return fmt.Errorf("invalid key at %q", rest)
+			}
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return fmt.Errorf("unclosed bracket at %q", rest)
+			}
+			inner := rest[1:end]
+			isQuotedKey := len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]
+			if !isQuotedKey {
+				if index, err := strconv.Atoi(inner); err != nil || index < 0 {
+					return fmt.Errorf("invalid index at %q", rest)
+				}
+			}
+			rest = rest[end+1:]
+		default:
+			return fmt.Errorf("unexpected character at %q", rest)
+		}
+	}
+	return nil
+}
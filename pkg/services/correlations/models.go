@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/grafana/grafana/pkg/services/quota"
 )
@@ -18,6 +19,9 @@ var (
 	ErrInvalidTransformationType     = errors.New("invalid transformation type")
 	ErrTransformationNotNested       = errors.New("transformations must be nested under config")
 	ErrTransformationRegexReqExp     = errors.New("regex transformations require expression")
+	ErrTransformationJSONPathInvalid = errors.New("jsonpath transformations require a valid JSONPath expression")
+	ErrTransformationTemplateInvalid = errors.New("template transformations require expression and mapValue")
+	ErrTransformationReqMapValue     = errors.New("urlencode and lowercase transformations require mapValue")
 	ErrCorrelationsQuotaFailed       = errors.New("error getting correlations quota")
 	ErrCorrelationsQuotaReached      = errors.New("correlations quota reached")
 	ErrInvalidConfigType             = errors.New("correlation contains non default value in config.type")
@@ -37,12 +41,28 @@ const (
 	external CorrelationType = "external"
 )
 
+const (
+	regexTransformation     = "regex"
+	logfmtTransformation    = "logfmt"
+	jsonPathTransformation  = "jsonpath"
+	templateTransformation  = "template"
+	urlEncodeTransformation = "urlencode"
+	lowercaseTransformation = "lowercase"
+)
+
+// Transformation extracts variables from the source data or modifies extracted variables.
+// Transformations are applied in order, so later transformations can use variables of the previous ones.
 type Transformation struct {
-	//Enum: regex,logfmt
-	Type       string `json:"type"`
+	//Enum: regex,logfmt,jsonpath,template,urlencode,lowercase
+	Type string `json:"type"`
+	// Regular expression for regex, JSONPath expression for jsonpath and template for template transformations.
+	// Templates reference variables as $name or ${name}.
 	Expression string `json:"expression,omitempty"`
-	Field      string `json:"field,omitempty"`
-	MapValue   string `json:"mapValue,omitempty"`
+	// Field of the source data the transformation is applied to. Defaults to the field of the correlation.
+	Field string `json:"field,omitempty"`
+	// Name of the variable the result is stored in.
+	// The urlencode and lowercase transformations replace the value of this variable.
+	MapValue string `json:"mapValue,omitempty"`
 }
 
 func (t CorrelationType) Validate() error {
@@ -54,15 +74,46 @@ func (t CorrelationType) Validate() error {
 
 func (t Transformations) Validate() error {
 	for _, v := range t {
-		if v.Type != "regex" && v.Type != "logfmt" {
-			return fmt.Errorf("%s: \"%s\"", ErrInvalidTransformationType, t)
-		} else if v.Type == "regex" && len(v.Expression) == 0 {
-			return fmt.Errorf("%s: \"%s\"", ErrTransformationRegexReqExp, t)
+		switch v.Type {
+		case regexTransformation:
+			if len(v.Expression) == 0 {
+				return fmt.Errorf("%w: \"%s\"", ErrTransformationRegexReqExp, t)
+			}
+		case logfmtTransformation:
+		case jsonPathTransformation:
+			if err := validateJSONPath(v.Expression); err != nil {
+				return fmt.Errorf("%w: \"%s\": %s", ErrTransformationJSONPathInvalid, v.Expression, err)
+			}
+		case templateTransformation:
+			if len(v.Expression) == 0 || len(v.MapValue) == 0 || !validTemplate(v.Expression) {
+				return fmt.Errorf("%w: \"%s\"", ErrTransformationTemplateInvalid, t)
+			}
+		case urlEncodeTransformation, lowercaseTransformation:
+			if len(v.MapValue) == 0 {
+				return fmt.Errorf("%w: \"%s\"", ErrTransformationReqMapValue, t)
+			}
+		default:
+			return fmt.Errorf("%w: \"%s\"", ErrInvalidTransformationType, t)
 		}
 	}
 	return nil
 }
 
+// validTemplate checks that all ${name} variable references of the template are closed and not empty
+func validTemplate(template string) bool {
+	for {
+		start := strings.Index(template, "${")
+		if start < 0 {
+			return true
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 || end == 2 {
+			return false
+		}
+		template = template[start+end+1:]
+	}
+}
+
 type Transformations []Transformation
 
 // swagger:model
@@ -242,6 +293,12 @@ func (c UpdateCorrelationCommand) Validate() error {
 		return ErrUpdateCorrelationEmptyParams
 	}
 
+	if c.Config != nil {
+		if err := Transformations(c.Config.Transformations).Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
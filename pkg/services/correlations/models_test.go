@@ -75,6 +75,49 @@ func TestCorrelationModels(t *testing.T) {
 		})
 	})
 
+	t.Run("Transformations Validate", func(t *testing.T) {
+		type test struct {
+			name      string
+			input     Transformation
+			assertion require.ErrorAssertionFunc
+		}
+
+		tests := []test{
+			{name: "logfmt", input: Transformation{Type: "logfmt"}, assertion: require.NoError},
+			{name: "regex", input: Transformation{Type: "regex", Expression: "trace=(\\w+)"}, assertion: require.NoError},
+			{name: "regex without expression", input: Transformation{Type: "regex"}, assertion: require.Error},
+			{name: "jsonpath", input: Transformation{Type: "jsonpath", Expression: "$.trace.id", MapValue: "traceId"}, assertion: require.NoError},
+			{name: "jsonpath with brackets", input: Transformation{Type: "jsonpath", Expression: "$.spans[0]['span-id']"}, assertion: require.NoError},
+			{name: "jsonpath without root", input: Transformation{Type: "jsonpath", Expression: "trace.id"}, assertion: require.Error},
+			{name: "jsonpath with wildcard", input: Transformation{Type: "jsonpath", Expression: "$.spans[*]"}, assertion: require.Error},
+			{name: "jsonpath with unclosed bracket", input: Transformation{Type: "jsonpath", Expression: "$.spans[0"}, assertion: require.Error},
+			{name: "template", input: Transformation{Type: "template", Expression: "${namespace}/$pod", MapValue: "target"}, assertion: require.NoError},
+			{name: "template without mapValue", input: Transformation{Type: "template", Expression: "${namespace}"}, assertion: require.Error},
+			{name: "template with unclosed variable", input: Transformation{Type: "template", Expression: "${namespace", MapValue: "target"}, assertion: require.Error},
+			{name: "urlencode", input: Transformation{Type: "urlencode", MapValue: "target"}, assertion: require.NoError},
+			{name: "lowercase without mapValue", input: Transformation{Type: "lowercase"}, assertion: require.Error},
+			{name: "unknown type", input: Transformation{Type: "uppercase", MapValue: "target"}, assertion: require.Error},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				tc.assertion(t, Transformations{tc.input}.Validate())
+			})
+		}
+	})
+
+	t.Run("UpdateCorrelationCommand Validate", func(t *testing.T) {
+		t.Run("Fails if transformations are invalid", func(t *testing.T) {
+			label := "label"
+			cmd := UpdateCorrelationCommand{
+				Label:  &label,
+				Config: &CorrelationConfigUpdateDTO{Transformations: []Transformation{{Type: "jsonpath", Expression: "$"}}},
+			}
+
+			require.ErrorIs(t, cmd.Validate(), ErrTransformationJSONPathInvalid)
+		})
+	})
+
 	t.Run("CorrelationConfig JSON Marshaling", func(t *testing.T) {
 		t.Run("Applies a default empty object if target is not defined", func(t *testing.T) {
 			config := CorrelationConfig{
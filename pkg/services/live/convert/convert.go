@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	influx "github.com/influxdata/line-protocol"
+
 	"github.com/grafana/grafana/pkg/services/live/telemetry"
 	"github.com/grafana/grafana/pkg/services/live/telemetry/telegraf"
 )
@@ -27,16 +29,22 @@ func NewConverter() *Converter {
 
 var ErrUnsupportedFrameFormat = errors.New("unsupported frame format")
 
-func (c *Converter) Convert(data []byte, frameFormat string) ([]telemetry.FrameWrapper, error) {
-	var converter telemetry.Converter
+func (c *Converter) telegrafConverter(frameFormat string) (*telegraf.Converter, error) {
 	switch frameFormat {
 	case "wide":
-		converter = c.telegrafConverterWide
+		return c.telegrafConverterWide, nil
 	case "labels_column":
-		converter = c.telegrafConverterLabelsColumn
+		return c.telegrafConverterLabelsColumn, nil
 	default:
 		return nil, ErrUnsupportedFrameFormat
 	}
+}
+
+func (c *Converter) Convert(data []byte, frameFormat string) ([]telemetry.FrameWrapper, error) {
+	converter, err := c.telegrafConverter(frameFormat)
+	if err != nil {
+		return nil, err
+	}
 
 	metricFrames, err := converter.Convert(data)
 	if err != nil {
@@ -44,3 +52,17 @@ func (c *Converter) Convert(data []byte, frameFormat string) ([]telemetry.FrameW
 	}
 	return metricFrames, nil
 }
+
+// ConvertMetrics converts metrics decoded from formats other than Influx line protocol.
+func (c *Converter) ConvertMetrics(metrics []influx.Metric, frameFormat string) ([]telemetry.FrameWrapper, error) {
+	converter, err := c.telegrafConverter(frameFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	metricFrames, err := converter.ConvertMetrics(metrics)
+	if err != nil {
+		return nil, fmt.Errorf("error converting metrics: %w", err)
+	}
+	return metricFrames, nil
+}
@@ -154,17 +154,21 @@ func ProvideService(plugCtxProvider *plugincontext.Provider, cfg *setting.Cfg, r
 		}
 	}
 
+	historyConfig := managedstream.HistoryConfig{
+		MaxFrames: g.Cfg.LiveManagedStreamHistorySize,
+		MaxAge:    g.Cfg.LiveManagedStreamHistoryMaxAge,
+	}
 	if redisClient != nil {
 		managedStreamRunner = managedstream.NewRunner(
 			g.Publish,
 			channelLocalPublisher,
-			managedstream.NewRedisFrameCache(redisClient, g.keyPrefix),
+			managedstream.NewRedisFrameCache(redisClient, g.keyPrefix, managedstream.WithHistory(historyConfig)),
 		)
 	} else {
 		managedStreamRunner = managedstream.NewRunner(
 			g.Publish,
 			channelLocalPublisher,
-			managedstream.NewMemoryFrameCache(),
+			managedstream.NewMemoryFrameCache(managedstream.WithHistory(historyConfig)),
 		)
 	}
 
@@ -1058,6 +1062,28 @@ func (g *GrafanaLive) HandleInfoHTTP(ctx *contextmodel.ReqContext) response.Resp
 	})
 }
 
+// HandleHistoryHTTP returns the frames kept in history for a managed stream channel.
+func (g *GrafanaLive) HandleHistoryHTTP(ctx *contextmodel.ReqContext) response.Response {
+	channel := web.Params(ctx.Req)["*"]
+	addr, err := live.ParseChannel(channel)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "invalid channel", err)
+	}
+	if addr.Scope != live.ScopeStream {
+		return response.Error(http.StatusNotFound, "History is only supported for stream channels", nil)
+	}
+	frameJSON, ok, err := g.ManagedStreamRunner.GetHistory(ctx.Req.Context(), ctx.SignedInUser.GetOrgID(), channel)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), err)
+	}
+	if !ok {
+		return response.Error(http.StatusNotFound, "No data for channel", nil)
+	}
+	return response.JSONStreaming(http.StatusOK, util.DynMap{
+		"data": frameJSON,
+	})
+}
+
 // HandleChannelRulesListHTTP ...
 func (g *GrafanaLive) HandleChannelRulesListHTTP(c *contextmodel.ReqContext) response.Response {
 	result, err := g.pipelineStorage.ListChannelRules(c.Req.Context(), c.SignedInUser.GetOrgID())
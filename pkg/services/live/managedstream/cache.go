@@ -13,6 +13,9 @@ type FrameCache interface {
 	GetActiveChannels(orgID int64) (map[string]json.RawMessage, error)
 	// GetFrame returns full JSON frame for a channel in org.
 	GetFrame(ctx context.Context, orgID int64, channel string) (json.RawMessage, bool, error)
+	// GetHistory returns full JSON frames kept in history for a channel in org, oldest first.
+	// Returns no frames if history is disabled.
+	GetHistory(ctx context.Context, orgID int64, channel string) ([]json.RawMessage, error)
 	// Update updates frame cache and returns true if schema changed.
 	Update(ctx context.Context, orgID int64, channel string, frameJson data.FrameJSONCache) (bool, error)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
@@ -12,16 +13,28 @@ import (
 
 // MemoryFrameCache ...
 type MemoryFrameCache struct {
-	mu     sync.RWMutex
-	frames map[int64]map[string]data.FrameJSONCache
-	log    log.Logger
+	mu      sync.RWMutex
+	frames  map[int64]map[string]data.FrameJSONCache
+	history map[int64]map[string][]memoryHistoryEntry
+	config  HistoryConfig
+	log     log.Logger
+	nowFunc func() time.Time
+}
+
+type memoryHistoryEntry struct {
+	time  time.Time
+	frame json.RawMessage
 }
 
 // NewMemoryFrameCache ...
-func NewMemoryFrameCache() *MemoryFrameCache {
+func NewMemoryFrameCache(opts ...FrameCacheOption) *MemoryFrameCache {
+	o := newFrameCacheOptions(opts)
 	return &MemoryFrameCache{
-		frames: map[int64]map[string]data.FrameJSONCache{},
-		log:    log.New("live.memoryframecache"),
+		frames:  map[int64]map[string]data.FrameJSONCache{},
+		history: map[int64]map[string][]memoryHistoryEntry{},
+		config:  o.history,
+		log:     log.New("live.memoryframecache"),
+		nowFunc: time.Now,
 	}
 }
 
@@ -52,6 +65,20 @@ func (c *MemoryFrameCache) GetFrame(ctx context.Context, orgID int64, channel st
 	return raw, ok, nil
 }
 
+func (c *MemoryFrameCache) GetHistory(_ context.Context, orgID int64, channel string) ([]json.RawMessage, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entries := c.history[orgID][channel]
+	frames := make([]json.RawMessage, 0, len(entries))
+	for _, e := range entries {
+		if c.config.MaxAge > 0 && c.nowFunc().Sub(e.time) > c.config.MaxAge {
+			continue
+		}
+		frames = append(frames, e.frame)
+	}
+	return frames, nil
+}
+
 func (c *MemoryFrameCache) Update(ctx context.Context, orgID int64, channel string, jsonFrame data.FrameJSONCache) (bool, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -61,6 +88,9 @@ func (c *MemoryFrameCache) Update(ctx context.Context, orgID int64, channel stri
 	cachedJsonFrame, exists := c.frames[orgID][channel]
 	schemaUpdated := !exists || !cachedJsonFrame.SameSchema(&jsonFrame)
 	c.frames[orgID][channel] = jsonFrame
+	if c.config.enabled() {
+		c.appendHistory(orgID, channel, jsonFrame.Bytes(data.IncludeAll))
+	}
 	c.log.Debug("Cache update",
 		"orgId", orgID,
 		"channel", channel,
@@ -68,3 +98,20 @@ func (c *MemoryFrameCache) Update(ctx context.Context, orgID int64, channel stri
 	)
 	return schemaUpdated, nil
 }
+
+func (c *MemoryFrameCache) appendHistory(orgID int64, channel string, frame json.RawMessage) {
+	if _, ok := c.history[orgID]; !ok {
+		c.history[orgID] = map[string][]memoryHistoryEntry{}
+	}
+	now := c.nowFunc()
+	entries := append(c.history[orgID][channel], memoryHistoryEntry{time: now, frame: frame})
+	start := 0
+	if maxFrames := c.config.maxFrames(); len(entries) > maxFrames {
+		start = len(entries) - maxFrames
+	}
+	for c.config.MaxAge > 0 && start < len(entries) && now.Sub(entries[start].time) > c.config.MaxAge {
+		start++
+	}
+	// copy to release the frames dropped from the buffer.
+	c.history[orgID][channel] = append([]memoryHistoryEntry(nil), entries[start:]...)
+}
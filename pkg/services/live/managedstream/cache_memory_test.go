@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/stretchr/testify/require"
@@ -67,3 +68,79 @@ func TestMemoryFrameCache(t *testing.T) {
 	require.NotNil(t, c)
 	testFrameCache(t, c)
 }
+
+func TestMemoryFrameCache_History(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := NewMemoryFrameCache(WithHistory(HistoryConfig{MaxFrames: 2, MaxAge: time.Minute}))
+	c.nowFunc = func() time.Time { return now }
+
+	for i := int64(0); i < 3; i++ {
+		frameJsonCache, err := data.FrameToJSONCache(data.NewFrame("hello", data.NewField("value", nil, []int64{i})))
+		require.NoError(t, err)
+		_, err = c.Update(context.Background(), 1, "test", frameJsonCache)
+		require.NoError(t, err)
+		now = now.Add(time.Second)
+	}
+
+	// Only the last two frames are kept.
+	history, err := c.GetHistory(context.Background(), 1, "test")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	merged, err := mergeFrames(history)
+	require.NoError(t, err)
+	var f data.Frame
+	require.NoError(t, json.Unmarshal(merged, &f))
+	require.Equal(t, 2, f.Fields[0].Len())
+	require.Equal(t, int64(1), f.Fields[0].At(0))
+	require.Equal(t, int64(2), f.Fields[0].At(1))
+
+	// Frames older than max age are not returned.
+	now = now.Add(time.Minute)
+	history, err = c.GetHistory(context.Background(), 1, "test")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+
+	// History is empty for other orgs.
+	history, err = c.GetHistory(context.Background(), 2, "test")
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
+
+func TestMemoryFrameCache_HistoryMaxAgeOnly(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := NewMemoryFrameCache(WithHistory(HistoryConfig{MaxAge: time.Minute}))
+	c.nowFunc = func() time.Time { return now }
+
+	for i := int64(0); i < defaultHistoryMaxFrames+10; i++ {
+		frameJsonCache, err := data.FrameToJSONCache(data.NewFrame("hello", data.NewField("value", nil, []int64{i})))
+		require.NoError(t, err)
+		_, err = c.Update(context.Background(), 1, "test", frameJsonCache)
+		require.NoError(t, err)
+	}
+
+	// History is enabled with the default number of frames.
+	history, err := c.GetHistory(context.Background(), 1, "test")
+	require.NoError(t, err)
+	require.Len(t, history, defaultHistoryMaxFrames)
+}
+
+func TestMergeFrames_SchemaChange(t *testing.T) {
+	frames := make([]json.RawMessage, 0, 3)
+	for _, frame := range []*data.Frame{
+		data.NewFrame("hello", data.NewField("old", nil, []int64{1})),
+		data.NewFrame("hello", data.NewField("new", nil, []float64{2})),
+		data.NewFrame("hello", data.NewField("new", nil, []float64{3})),
+	} {
+		raw, err := json.Marshal(frame)
+		require.NoError(t, err)
+		frames = append(frames, raw)
+	}
+
+	merged, err := mergeFrames(frames)
+	require.NoError(t, err)
+	var f data.Frame
+	require.NoError(t, json.Unmarshal(merged, &f))
+	require.Equal(t, "new", f.Fields[0].Name)
+	require.Equal(t, 2, f.Fields[0].Len())
+}
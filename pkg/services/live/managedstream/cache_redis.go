@@ -19,14 +19,17 @@ type RedisFrameCache struct {
 	redisClient *redis.Client
 	frames      map[int64]map[string]data.FrameJSONCache
 	keyPrefix   string
+	config      HistoryConfig
 }
 
 // NewRedisFrameCache ...
-func NewRedisFrameCache(redisClient *redis.Client, keyPrefix string) *RedisFrameCache {
+func NewRedisFrameCache(redisClient *redis.Client, keyPrefix string, opts ...FrameCacheOption) *RedisFrameCache {
+	o := newFrameCacheOptions(opts)
 	return &RedisFrameCache{
 		keyPrefix:   keyPrefix,
 		frames:      map[int64]map[string]data.FrameJSONCache{},
 		redisClient: redisClient,
+		config:      o.history,
 	}
 }
 
@@ -57,6 +60,36 @@ func (c *RedisFrameCache) GetFrame(ctx context.Context, orgID int64, channel str
 	return json.RawMessage(result["frame"]), true, nil
 }
 
+// redisHistoryEntry is stored in a Redis list per channel.
+type redisHistoryEntry struct {
+	Time  int64           `json:"time"`
+	Frame json.RawMessage `json:"frame"`
+}
+
+func (c *RedisFrameCache) GetHistory(ctx context.Context, orgID int64, channel string) ([]json.RawMessage, error) {
+	if !c.config.enabled() {
+		return nil, nil
+	}
+	key := c.getHistoryKey(orgchannel.PrependOrgID(orgID, channel))
+	result, err := c.redisClient.LRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	frames := make([]json.RawMessage, 0, len(result))
+	for _, item := range result {
+		var entry redisHistoryEntry
+		if err := json.Unmarshal([]byte(item), &entry); err != nil {
+			return nil, err
+		}
+		if c.config.MaxAge > 0 && now.Sub(time.UnixMilli(entry.Time)) > c.config.MaxAge {
+			continue
+		}
+		frames = append(frames, entry.Frame)
+	}
+	return frames, nil
+}
+
 const (
 	frameCacheTTL = 7 * 24 * time.Hour
 )
@@ -83,6 +116,24 @@ func (c *RedisFrameCache) Update(ctx context.Context, orgID int64, channel strin
 	})
 	pipe.Expire(ctx, key, frameCacheTTL)
 
+	if c.config.enabled() {
+		entry, err := json.Marshal(redisHistoryEntry{
+			Time:  time.Now().UnixMilli(),
+			Frame: jsonFrame.Bytes(data.IncludeAll),
+		})
+		if err != nil {
+			return false, err
+		}
+		historyKey := c.getHistoryKey(orgchannel.PrependOrgID(orgID, channel))
+		ttl := frameCacheTTL
+		if c.config.MaxAge > 0 {
+			ttl = c.config.MaxAge
+		}
+		pipe.RPush(ctx, historyKey, entry)
+		pipe.LTrim(ctx, historyKey, int64(-c.config.maxFrames()), -1)
+		pipe.Expire(ctx, historyKey, ttl)
+	}
+
 	replies, err := pipe.Exec(ctx)
 	if err != nil {
 		return false, err
@@ -112,3 +163,7 @@ func (c *RedisFrameCache) Update(ctx context.Context, orgID int64, channel strin
 func (c *RedisFrameCache) getCacheKey(channelID string) string {
 	return c.keyPrefix + ".managed_stream." + channelID
 }
+
+func (c *RedisFrameCache) getHistoryKey(channelID string) string {
+	return c.keyPrefix + ".managed_stream_history." + channelID
+}
@@ -1,6 +1,7 @@
 package managedstream
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
@@ -36,10 +37,14 @@ func TestIntegrationRedisCacheStorage(t *testing.T) {
 
 	t.Cleanup(redisCleanup(t, redisClient, prefix))
 
-	c := NewRedisFrameCache(redisClient, prefix)
+	c := NewRedisFrameCache(redisClient, prefix, WithHistory(HistoryConfig{MaxFrames: 2}))
 	require.NotNil(t, c)
 	testFrameCache(t, c)
 
+	history, err := c.GetHistory(context.Background(), 1, "test")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
 	keys, err := redisClient.Keys(redisClient.Context(), "*").Result()
 	if err != nil {
 		require.NoError(t, err)
@@ -0,0 +1,95 @@
+package managedstream
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// defaultHistoryMaxFrames bounds the history of a channel when only its age is limited.
+const defaultHistoryMaxFrames = 1000
+
+// HistoryConfig configures a bounded buffer of the last frames pushed to each channel,
+// which is replayed to new subscribers. History is disabled if neither MaxFrames nor MaxAge is positive.
+type HistoryConfig struct {
+	// MaxFrames is the maximum number of frames kept per channel, defaultHistoryMaxFrames if not set.
+	MaxFrames int
+	// MaxAge drops frames older than the duration if set.
+	MaxAge time.Duration
+}
+
+func (c HistoryConfig) enabled() bool {
+	return c.MaxFrames > 0 || c.MaxAge > 0
+}
+
+func (c HistoryConfig) maxFrames() int {
+	if c.MaxFrames > 0 {
+		return c.MaxFrames
+	}
+	return defaultHistoryMaxFrames
+}
+
+type frameCacheOptions struct {
+	history HistoryConfig
+}
+
+// FrameCacheOption configures a FrameCache.
+type FrameCacheOption func(*frameCacheOptions)
+
+// WithHistory keeps a history of frames per channel.
+func WithHistory(config HistoryConfig) FrameCacheOption {
+	return func(o *frameCacheOptions) {
+		o.history = config
+	}
+}
+
+func newFrameCacheOptions(opts []FrameCacheOption) frameCacheOptions {
+	o := frameCacheOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// mergeFrames combines frames into a single frame with the rows of all frames. If the
+// schema changes, only the frames since the last schema change are combined.
+func mergeFrames(frames []json.RawMessage) (json.RawMessage, error) {
+	decoded := make([]*data.Frame, 0, len(frames))
+	for _, raw := range frames {
+		f := &data.Frame{}
+		if err := json.Unmarshal(raw, f); err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, f)
+	}
+
+	start := len(decoded) - 1
+	for start > 0 && sameFrameSchema(decoded[start-1], decoded[len(decoded)-1]) {
+		start--
+	}
+
+	merged := decoded[start]
+	for _, f := range decoded[start+1:] {
+		rows, err := f.RowLen()
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < rows; i++ {
+			merged.AppendRow(f.RowCopy(i)...)
+		}
+	}
+	return json.Marshal(merged)
+}
+
+func sameFrameSchema(a, b *data.Frame) bool {
+	if len(a.Fields) != len(b.Fields) {
+		return false
+	}
+	for i := range a.Fields {
+		if a.Fields[i].Name != b.Fields[i].Name || a.Fields[i].Type() != b.Fields[i].Type() {
+			return false
+		}
+	}
+	return true
+}
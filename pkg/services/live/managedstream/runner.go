@@ -131,6 +131,27 @@ func (r *Runner) GetOrCreateStream(orgID int64, scope string, namespace string)
 	return s, nil
 }
 
+// GetHistory returns a frame with the rows of frames kept in history for a channel,
+// or the last frame pushed to the channel if history is disabled.
+func (r *Runner) GetHistory(ctx context.Context, orgID int64, channel string) (json.RawMessage, bool, error) {
+	return getReplayFrame(ctx, r.frameCache, orgID, channel)
+}
+
+func getReplayFrame(ctx context.Context, frameCache FrameCache, orgID int64, channel string) (json.RawMessage, bool, error) {
+	history, err := frameCache.GetHistory(ctx, orgID, channel)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(history) > 0 {
+		frameJSON, err := mergeFrames(history)
+		if err != nil {
+			return nil, false, err
+		}
+		return frameJSON, true, nil
+	}
+	return frameCache.GetFrame(ctx, orgID, channel)
+}
+
 // NamespaceStream holds the state of a managed stream.
 type NamespaceStream struct {
 	orgID          int64
@@ -241,7 +262,7 @@ func (s *NamespaceStream) GetHandlerForPath(_ string) (model.ChannelHandler, err
 
 func (s *NamespaceStream) OnSubscribe(ctx context.Context, u identity.Requester, e model.SubscribeEvent) (model.SubscribeReply, backend.SubscribeStreamStatus, error) {
 	reply := model.SubscribeReply{}
-	frameJSON, ok, err := s.frameCache.GetFrame(ctx, u.GetOrgID(), e.Channel)
+	frameJSON, ok, err := getReplayFrame(ctx, s.frameCache, u.GetOrgID(), e.Channel)
 	if err != nil {
 		return reply, 0, err
 	}
@@ -2,11 +2,16 @@ package managedstream
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/live/model"
+	"github.com/grafana/grafana/pkg/services/user"
 )
 
 type testPublisher struct {
@@ -83,3 +88,39 @@ func TestGetManagedStreams(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, managedChannels, 7) // Not affected by other org.
 }
+
+func TestManagedStreamReplayHistory(t *testing.T) {
+	publisher := &testPublisher{t: t}
+	frameCache := NewMemoryFrameCache(WithHistory(HistoryConfig{MaxFrames: 3}))
+	runner := NewRunner(publisher.publish, nil, frameCache)
+	s, err := runner.GetOrCreateStream(1, "stream", "test")
+	require.NoError(t, err)
+
+	for i := int64(0); i < 5; i++ {
+		err = s.Push(context.Background(), "cpu", data.NewFrame("cpu", data.NewField("value", nil, []int64{i})))
+		require.NoError(t, err)
+	}
+
+	reply, status, err := s.OnSubscribe(
+		context.Background(),
+		&user.SignedInUser{OrgID: 1, UserID: 2},
+		model.SubscribeEvent{Channel: "stream/test/cpu", Path: "cpu"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, backend.SubscribeStreamStatusOK, status)
+
+	var f data.Frame
+	require.NoError(t, json.Unmarshal(reply.Data, &f))
+	require.Equal(t, 3, f.Fields[0].Len())
+	require.Equal(t, int64(2), f.Fields[0].At(0))
+	require.Equal(t, int64(4), f.Fields[0].At(2))
+
+	frameJSON, ok, err := runner.GetHistory(context.Background(), 1, "stream/test/cpu")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.JSONEq(t, string(reply.Data), string(frameJSON))
+
+	_, ok, err = runner.GetHistory(context.Background(), 2, "stream/test/cpu")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -35,11 +35,14 @@ type ChannelRule struct {
 }
 
 type ConverterConfig struct {
-	Type                      string                     `json:"type" ts_type:"Omit<keyof ConverterConfig, 'type'>"`
-	AutoJsonConverterConfig   *AutoJsonConverterConfig   `json:"jsonAuto,omitempty"`
-	ExactJsonConverterConfig  *ExactJsonConverterConfig  `json:"jsonExact,omitempty"`
-	AutoInfluxConverterConfig *AutoInfluxConverterConfig `json:"influxAuto,omitempty"`
-	JsonFrameConverterConfig  *JsonFrameConverterConfig  `json:"jsonFrame,omitempty"`
+	Type                       string                      `json:"type" ts_type:"Omit<keyof ConverterConfig, 'type'>"`
+	AutoJsonConverterConfig    *AutoJsonConverterConfig    `json:"jsonAuto,omitempty"`
+	ExactJsonConverterConfig   *ExactJsonConverterConfig   `json:"jsonExact,omitempty"`
+	AutoInfluxConverterConfig  *AutoInfluxConverterConfig  `json:"influxAuto,omitempty"`
+	JsonFrameConverterConfig   *JsonFrameConverterConfig   `json:"jsonFrame,omitempty"`
+	PrometheusConverterConfig  *PrometheusConverterConfig  `json:"prometheus,omitempty"`
+	CSVConverterConfig         *CSVConverterConfig         `json:"csv,omitempty"`
+	OTLPMetricsConverterConfig *OTLPMetricsConverterConfig `json:"otlpMetrics,omitempty"`
 }
 
 type DropFieldsFrameProcessorConfig struct {
@@ -50,11 +53,41 @@ type KeepFieldsFrameProcessorConfig struct {
 	FieldNames []string `json:"fieldNames"`
 }
 
+type RenameFieldsFrameProcessorConfig struct {
+	// Renames maps current field names to new field names.
+	Renames map[string]string `json:"renames"`
+}
+
+type ComputedFieldFrameProcessorConfig struct {
+	FieldName string `json:"fieldName"`
+	// Expression in math server side expression syntax, fields are referenced as $name or ${name}.
+	Expression string `json:"expression"`
+}
+
+type WindowAggregateFrameProcessorConfig struct {
+	WindowSeconds int64 `json:"windowSeconds"`
+	// SlideSeconds allows sliding windows which overlap, windows are tumbling if not set.
+	SlideSeconds int64 `json:"slideSeconds,omitempty"`
+	// TimeField is the name of the time field, the first time field is used if not set.
+	TimeField    string                    `json:"timeField,omitempty"`
+	Aggregations []WindowAggregationConfig `json:"aggregations"`
+}
+
+type WindowAggregationConfig struct {
+	FieldName string        `json:"fieldName"`
+	Reducer   WindowReducer `json:"reducer"`
+	// Name of the aggregated field, <fieldName>_<reducer> if not set.
+	Name string `json:"name,omitempty"`
+}
+
 type FrameProcessorConfig struct {
-	Type                      string                          `json:"type" ts_type:"Omit<keyof FrameProcessorConfig, 'type'>"`
-	DropFieldsProcessorConfig *DropFieldsFrameProcessorConfig `json:"dropFields,omitempty"`
-	KeepFieldsProcessorConfig *KeepFieldsFrameProcessorConfig `json:"keepFields,omitempty"`
-	MultipleProcessorConfig   *MultipleFrameProcessorConfig   `json:"multiple,omitempty"`
+	Type                           string                               `json:"type" ts_type:"Omit<keyof FrameProcessorConfig, 'type'>"`
+	DropFieldsProcessorConfig      *DropFieldsFrameProcessorConfig      `json:"dropFields,omitempty"`
+	KeepFieldsProcessorConfig      *KeepFieldsFrameProcessorConfig      `json:"keepFields,omitempty"`
+	RenameFieldsProcessorConfig    *RenameFieldsFrameProcessorConfig    `json:"renameFields,omitempty"`
+	ComputedFieldProcessorConfig   *ComputedFieldFrameProcessorConfig   `json:"computedField,omitempty"`
+	WindowAggregateProcessorConfig *WindowAggregateFrameProcessorConfig `json:"windowAggregate,omitempty"`
+	MultipleProcessorConfig        *MultipleFrameProcessorConfig        `json:"multiple,omitempty"`
 }
 
 type MultipleFrameProcessorConfig struct {
@@ -152,4 +185,19 @@ type AutoInfluxConverterConfig struct {
 
 type JsonFrameConverterConfig struct{}
 
+type PrometheusConverterConfig struct {
+	FrameFormat string `json:"frameFormat"`
+}
+
+type CSVConverterConfig struct {
+	// Delimiter is a single character separating values, comma by default.
+	Delimiter string `json:"delimiter,omitempty"`
+	// TimeField is the name of the time column, time by default.
+	TimeField string `json:"timeField,omitempty"`
+}
+
+type OTLPMetricsConverterConfig struct {
+	FrameFormat string `json:"frameFormat"`
+}
+
 type ManagedStreamOutputConfig struct{}
@@ -0,0 +1,131 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// CSVConverter decodes CSV with a header row into a single frame. Columns with
+// only numeric values become number fields and other columns become string
+// fields, empty values are nulls. The time column may contain RFC3339 timestamps
+// or Unix timestamps in milliseconds. If there is no time column, time is added
+// automatically.
+type CSVConverter struct {
+	config      CSVConverterConfig
+	nowTimeFunc func() time.Time
+}
+
+// NewCSVConverter creates new CSVConverter.
+func NewCSVConverter(config CSVConverterConfig) *CSVConverter {
+	return &CSVConverter{config: config}
+}
+
+const ConverterTypeCSV = "csv"
+
+func (c *CSVConverter) Type() string {
+	return ConverterTypeCSV
+}
+
+func (c *CSVConverter) Convert(_ context.Context, vars Vars, body []byte) ([]*ChannelFrame, error) {
+	nowTimeFunc := c.nowTimeFunc
+	if nowTimeFunc == nil {
+		nowTimeFunc = time.Now
+	}
+
+	reader := csv.NewReader(bytes.NewReader(body))
+	if c.config.Delimiter != "" {
+		delimiter, size := utf8.DecodeRuneInString(c.config.Delimiter)
+		if size != len(c.config.Delimiter) {
+			return nil, fmt.Errorf("delimiter must be a single character")
+		}
+		reader.Comma = delimiter
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("missing CSV header row")
+	}
+	header, rows := records[0], records[1:]
+
+	timeField := c.config.TimeField
+	if timeField == "" {
+		timeField = "time"
+	}
+
+	var fields []*data.Field
+	hasTime := false
+	for col, name := range header {
+		values := make([]string, len(rows))
+		for i, row := range rows {
+			values[i] = row[col]
+		}
+		if name == timeField {
+			field, err := csvTimeField(name, values)
+			if err != nil {
+				return nil, err
+			}
+			fields = append([]*data.Field{field}, fields...)
+			hasTime = true
+			continue
+		}
+		fields = append(fields, csvValueField(name, values))
+	}
+	if !hasTime {
+		times := make([]time.Time, len(rows))
+		now := nowTimeFunc()
+		for i := range times {
+			times[i] = now
+		}
+		fields = append([]*data.Field{data.NewField(timeField, nil, times)}, fields...)
+	}
+
+	return []*ChannelFrame{
+		{Channel: "", Frame: data.NewFrame(vars.Path, fields...)},
+	}, nil
+}
+
+func csvTimeField(name string, values []string) (*data.Field, error) {
+	times := make([]time.Time, len(values))
+	for i, v := range values {
+		if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+			times[i] = time.UnixMilli(ms)
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q in row %d", v, i+1)
+		}
+		times[i] = t
+	}
+	return data.NewField(name, nil, times), nil
+}
+
+func csvValueField(name string, values []string) *data.Field {
+	numbers := make([]*float64, len(values))
+	for i, v := range values {
+		if v == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			strs := make([]*string, len(values))
+			for j := range values {
+				if values[j] != "" {
+					strs[j] = &values[j]
+				}
+			}
+			return data.NewField(name, nil, strs)
+		}
+		numbers[i] = &f
+	}
+	return data.NewField(name, nil, numbers)
+}
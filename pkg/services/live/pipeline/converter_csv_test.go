@@ -0,0 +1,57 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVConverter(t *testing.T) {
+	c := NewCSVConverter(CSVConverterConfig{})
+	body := "device,time,value\na,2023-11-14T22:13:20Z,1.5\nb,1700000001000,\n"
+	frames, err := c.Convert(context.Background(), Vars{Path: "edge"}, []byte(body))
+	require.NoError(t, err)
+	require.Len(t, frames, 1)
+	require.Equal(t, "", frames[0].Channel)
+
+	frame := frames[0].Frame
+	require.Equal(t, "edge", frame.Name)
+	require.Equal(t, 2, frame.Rows())
+	require.Equal(t, "time", frame.Fields[0].Name)
+	require.True(t, time.Unix(1700000000, 0).Equal(frame.Fields[0].At(0).(time.Time)))
+	require.Equal(t, time.UnixMilli(1700000001000), frame.Fields[0].At(1))
+	require.Equal(t, data.FieldTypeNullableString, frame.Fields[1].Type())
+	require.Equal(t, data.FieldTypeNullableFloat64, frame.Fields[2].Type())
+	require.Equal(t, 1.5, *frame.Fields[2].At(0).(*float64))
+	require.Nil(t, frame.Fields[2].At(1))
+}
+
+func TestCSVConverter_NoTimeColumn(t *testing.T) {
+	now := time.Now()
+	c := NewCSVConverter(CSVConverterConfig{Delimiter: ";", TimeField: "ts"})
+	c.nowTimeFunc = func() time.Time { return now }
+
+	frames, err := c.Convert(context.Background(), Vars{}, []byte("value;state\n1;ok\n"))
+	require.NoError(t, err)
+	frame := frames[0].Frame
+	require.Equal(t, "ts", frame.Fields[0].Name)
+	require.Equal(t, now, frame.Fields[0].At(0))
+	require.Equal(t, "state", frame.Fields[2].Name)
+}
+
+func TestCSVConverter_InvalidInput(t *testing.T) {
+	c := NewCSVConverter(CSVConverterConfig{})
+	for name, body := range map[string]string{
+		"empty":        "",
+		"invalid time": "time,value\nyesterday,1\n",
+		"wrong width":  "time,value\n1700000000000\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.Convert(context.Background(), Vars{}, []byte(body))
+			require.Error(t, err)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/grafana/grafana/pkg/services/live/convert"
+	"github.com/grafana/grafana/pkg/services/live/telemetry"
 )
 
 // AutoInfluxConverter decodes Influx line protocol input and transforms it
@@ -30,6 +31,12 @@ func (c *AutoInfluxConverter) Convert(_ context.Context, vars Vars, body []byte)
 	if err != nil {
 		return nil, err
 	}
+	return metricChannelFrames(vars, frameWrappers), nil
+}
+
+// metricChannelFrames returns a ChannelFrame for each metric frame where Channel
+// is constructed from original channel + / + <metric_name>.
+func metricChannelFrames(vars Vars, frameWrappers []telemetry.FrameWrapper) []*ChannelFrame {
 	channelFrames := make([]*ChannelFrame, 0, len(frameWrappers))
 	for _, fw := range frameWrappers {
 		channelFrames = append(channelFrames, &ChannelFrame{
@@ -37,5 +44,5 @@ func (c *AutoInfluxConverter) Convert(_ context.Context, vars Vars, body []byte)
 			Frame:   fw.Frame(),
 		})
 	}
-	return channelFrames, nil
+	return channelFrames
 }
@@ -0,0 +1,155 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	influx "github.com/influxdata/line-protocol"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+
+	"github.com/grafana/grafana/pkg/services/live/convert"
+)
+
+// OTLPMetricsConverter decodes OTLP/HTTP metrics export requests encoded as protobuf and
+// transforms them to several ChannelFrame objects where Channel is constructed from original
+// channel + / + <metric_name>. Resource and data point attributes become labels. Gauges and sums
+// have a value field, histograms and summaries have sum, count and a field for each bucket or
+// quantile.
+type OTLPMetricsConverter struct {
+	config    OTLPMetricsConverterConfig
+	converter *convert.Converter
+}
+
+// NewOTLPMetricsConverter creates new OTLPMetricsConverter.
+func NewOTLPMetricsConverter(config OTLPMetricsConverterConfig) *OTLPMetricsConverter {
+	return &OTLPMetricsConverter{config: config, converter: convert.NewConverter()}
+}
+
+const ConverterTypeOTLPMetrics = "otlpMetrics"
+
+func (c *OTLPMetricsConverter) Type() string {
+	return ConverterTypeOTLPMetrics
+}
+
+func (c *OTLPMetricsConverter) Convert(_ context.Context, vars Vars, body []byte) ([]*ChannelFrame, error) {
+	req := pmetricotlp.NewExportRequest()
+	if err := req.UnmarshalProto(body); err != nil {
+		return nil, fmt.Errorf("error parsing metrics: %w", err)
+	}
+
+	var metrics []influx.Metric
+	resourceMetrics := req.Metrics().ResourceMetrics()
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		resourceMetric := resourceMetrics.At(i)
+		resourceAttrs := resourceMetric.Resource().Attributes()
+		scopeMetrics := resourceMetric.ScopeMetrics()
+		for j := 0; j < scopeMetrics.Len(); j++ {
+			scopeMetric := scopeMetrics.At(j).Metrics()
+			for k := 0; k < scopeMetric.Len(); k++ {
+				converted, err := otlpMetricToInflux(scopeMetric.At(k), resourceAttrs)
+				if err != nil {
+					return nil, err
+				}
+				metrics = append(metrics, converted...)
+			}
+		}
+	}
+
+	frameWrappers, err := c.converter.ConvertMetrics(metrics, c.config.FrameFormat)
+	if err != nil {
+		return nil, err
+	}
+	return metricChannelFrames(vars, frameWrappers), nil
+}
+
+func otlpMetricToInflux(m pmetric.Metric, resourceAttrs pcommon.Map) ([]influx.Metric, error) {
+	var metrics []influx.Metric
+	add := func(attrs pcommon.Map, ts pcommon.Timestamp, fields map[string]any) error {
+		tags := otlpAttributesToTags(resourceAttrs)
+		for k, v := range otlpAttributesToTags(attrs) {
+			tags[k] = v
+		}
+		metric, err := influx.New(m.Name(), tags, fields, ts.AsTime())
+		if err != nil {
+			return err
+		}
+		metrics = append(metrics, metric)
+		return nil
+	}
+
+	switch m.Type() {
+	case pmetric.MetricTypeGauge, pmetric.MetricTypeSum:
+		var dataPoints pmetric.NumberDataPointSlice
+		if m.Type() == pmetric.MetricTypeGauge {
+			dataPoints = m.Gauge().DataPoints()
+		} else {
+			dataPoints = m.Sum().DataPoints()
+		}
+		for i := 0; i < dataPoints.Len(); i++ {
+			dp := dataPoints.At(i)
+			value := dp.DoubleValue()
+			if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+				value = float64(dp.IntValue())
+			}
+			if err := add(dp.Attributes(), dp.Timestamp(), map[string]any{"value": value}); err != nil {
+				return nil, err
+			}
+		}
+	case pmetric.MetricTypeHistogram:
+		dataPoints := m.Histogram().DataPoints()
+		for i := 0; i < dataPoints.Len(); i++ {
+			dp := dataPoints.At(i)
+			fields := map[string]any{"sum": dp.Sum(), "count": float64(dp.Count())}
+			// OTLP bucket counts are not cumulative, the last bucket has no upper bound.
+			var cumulative uint64
+			bounds := dp.ExplicitBounds()
+			for b := 0; b < dp.BucketCounts().Len(); b++ {
+				cumulative += dp.BucketCounts().At(b)
+				le := "+Inf"
+				if b < bounds.Len() {
+					le = strconv.FormatFloat(bounds.At(b), 'g', -1, 64)
+				}
+				fields["le_"+le] = float64(cumulative)
+			}
+			if err := add(dp.Attributes(), dp.Timestamp(), fields); err != nil {
+				return nil, err
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dataPoints := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dataPoints.Len(); i++ {
+			dp := dataPoints.At(i)
+			if err := add(dp.Attributes(), dp.Timestamp(), map[string]any{"sum": dp.Sum(), "count": float64(dp.Count())}); err != nil {
+				return nil, err
+			}
+		}
+	case pmetric.MetricTypeSummary:
+		dataPoints := m.Summary().DataPoints()
+		for i := 0; i < dataPoints.Len(); i++ {
+			dp := dataPoints.At(i)
+			fields := map[string]any{"sum": dp.Sum(), "count": float64(dp.Count())}
+			for q := 0; q < dp.QuantileValues().Len(); q++ {
+				quantile := dp.QuantileValues().At(q)
+				fields["quantile_"+strconv.FormatFloat(quantile.Quantile(), 'g', -1, 64)] = quantile.Value()
+			}
+			if err := add(dp.Attributes(), dp.Timestamp(), fields); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		logger.Debug("Skipping OTLP metric of unsupported type", "name", m.Name(), "type", m.Type().String())
+	}
+	return metrics, nil
+}
+
+func otlpAttributesToTags(attrs pcommon.Map) map[string]string {
+	tags := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		tags[k] = v.AsString()
+		return true
+	})
+	return tags
+}
@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+)
+
+func TestOTLPMetricsConverter(t *testing.T) {
+	ts := time.UnixMilli(1700000000000)
+	metrics := pmetric.NewMetrics()
+	resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+	resourceMetrics.Resource().Attributes().PutStr("service.name", "edge-agent")
+	scopeMetrics := resourceMetrics.ScopeMetrics().AppendEmpty()
+
+	gauge := scopeMetrics.Metrics().AppendEmpty()
+	gauge.SetName("temperature")
+	dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	dp.SetDoubleValue(21.5)
+	dp.Attributes().PutStr("device", "a")
+
+	histogram := scopeMetrics.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	hdp.SetCount(6)
+	hdp.SetSum(4.2)
+	hdp.ExplicitBounds().FromRaw([]float64{0.1, 1})
+	hdp.BucketCounts().FromRaw([]uint64{3, 2, 1})
+
+	body, err := pmetricotlp.NewExportRequestFromMetrics(metrics).MarshalProto()
+	require.NoError(t, err)
+
+	c := NewOTLPMetricsConverter(OTLPMetricsConverterConfig{FrameFormat: "labels_column"})
+	frames, err := c.Convert(context.Background(), Vars{Channel: "stream/otlp/edge"}, body)
+	require.NoError(t, err)
+	require.Len(t, frames, 2)
+
+	require.Equal(t, "stream/otlp/edge/temperature", frames[0].Channel)
+	temperature := frames[0].Frame
+	require.Equal(t, "device=a, service.name=edge-agent", temperature.Fields[0].At(0))
+	require.True(t, ts.Equal(temperature.Fields[1].At(0).(time.Time)))
+	field, _ := temperature.FieldByName("value")
+	require.Equal(t, 21.5, *field.At(0).(*float64))
+
+	require.Equal(t, "stream/otlp/edge/latency", frames[1].Channel)
+	for name, expected := range map[string]float64{"le_0.1": 3, "le_1": 5, "le_+Inf": 6, "count": 6, "sum": 4.2} {
+		field, _ := frames[1].Frame.FieldByName(name)
+		require.NotNil(t, field, name)
+		require.Equal(t, expected, *field.At(0).(*float64), name)
+	}
+}
+
+func TestOTLPMetricsConverter_InvalidInput(t *testing.T) {
+	c := NewOTLPMetricsConverter(OTLPMetricsConverterConfig{FrameFormat: "labels_column"})
+	_, err := c.Convert(context.Background(), Vars{}, []byte("not protobuf"))
+	require.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	influx "github.com/influxdata/line-protocol"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/grafana/grafana/pkg/services/live/convert"
+)
+
+// PrometheusConverter decodes Prometheus text exposition format and transforms it
+// to several ChannelFrame objects where Channel is constructed from original
+// channel + / + <metric_name>. Counters, gauges and untyped metrics have a value
+// field, summaries and histograms have sum, count and a field for each quantile
+// or bucket.
+type PrometheusConverter struct {
+	config      PrometheusConverterConfig
+	converter   *convert.Converter
+	nowTimeFunc func() time.Time
+}
+
+// NewPrometheusConverter creates new PrometheusConverter.
+func NewPrometheusConverter(config PrometheusConverterConfig) *PrometheusConverter {
+	return &PrometheusConverter{config: config, converter: convert.NewConverter()}
+}
+
+const ConverterTypePrometheus = "prometheus"
+
+func (c *PrometheusConverter) Type() string {
+	return ConverterTypePrometheus
+}
+
+func (c *PrometheusConverter) Convert(_ context.Context, vars Vars, body []byte) ([]*ChannelFrame, error) {
+	nowTimeFunc := c.nowTimeFunc
+	if nowTimeFunc == nil {
+		nowTimeFunc = time.Now
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing metrics: %w", err)
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := nowTimeFunc()
+	var metrics []influx.Metric
+	for _, name := range names {
+		family := families[name]
+		for _, m := range family.GetMetric() {
+			tags := make(map[string]string, len(m.GetLabel()))
+			for _, label := range m.GetLabel() {
+				tags[label.GetName()] = label.GetValue()
+			}
+
+			ts := now
+			if m.TimestampMs != nil {
+				ts = time.UnixMilli(m.GetTimestampMs())
+			}
+
+			metric, err := influx.New(name, tags, prometheusMetricFields(family.GetType(), m), ts)
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics, metric)
+		}
+	}
+
+	frameWrappers, err := c.converter.ConvertMetrics(metrics, c.config.FrameFormat)
+	if err != nil {
+		return nil, err
+	}
+	return metricChannelFrames(vars, frameWrappers), nil
+}
+
+func prometheusMetricFields(metricType dto.MetricType, m *dto.Metric) map[string]any {
+	fields := map[string]any{}
+	switch metricType {
+	case dto.MetricType_COUNTER:
+		fields["value"] = m.GetCounter().GetValue()
+	case dto.MetricType_GAUGE:
+		fields["value"] = m.GetGauge().GetValue()
+	case dto.MetricType_SUMMARY:
+		fields["sum"] = m.GetSummary().GetSampleSum()
+		fields["count"] = float64(m.GetSummary().GetSampleCount())
+		for _, q := range m.GetSummary().GetQuantile() {
+			fields["quantile_"+strconv.FormatFloat(q.GetQuantile(), 'g', -1, 64)] = q.GetValue()
+		}
+	case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+		fields["sum"] = m.GetHistogram().GetSampleSum()
+		fields["count"] = float64(m.GetHistogram().GetSampleCount())
+		for _, b := range m.GetHistogram().GetBucket() {
+			fields["le_"+strconv.FormatFloat(b.GetUpperBound(), 'g', -1, 64)] = float64(b.GetCumulativeCount())
+		}
+	default:
+		fields["value"] = m.GetUntyped().GetValue()
+	}
+	return fields
+}
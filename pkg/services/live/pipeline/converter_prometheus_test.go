@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+const prometheusTestInput = `# HELP temperature_celsius Current temperature.
+# TYPE temperature_celsius gauge
+temperature_celsius{device="a"} 21.5 1700000000000
+temperature_celsius{device="b"} 22 1700000000000
+# TYPE request_duration_seconds histogram
+request_duration_seconds_bucket{le="0.1"} 3 1700000000000
+request_duration_seconds_bucket{le="1"} 5 1700000000000
+request_duration_seconds_bucket{le="+Inf"} 6 1700000000000
+request_duration_seconds_sum 4.2 1700000000000
+request_duration_seconds_count 6 1700000000000
+`
+
+func TestPrometheusConverter(t *testing.T) {
+	c := NewPrometheusConverter(PrometheusConverterConfig{FrameFormat: "labels_column"})
+	frames, err := c.Convert(context.Background(), Vars{Channel: "stream/metrics/edge"}, []byte(prometheusTestInput))
+	require.NoError(t, err)
+	require.Len(t, frames, 2)
+
+	require.Equal(t, "stream/metrics/edge/request_duration_seconds", frames[0].Channel)
+	histogram := frames[0].Frame
+	require.Equal(t, 1, histogram.Rows())
+	field, _ := histogram.FieldByName("le_1")
+	require.Equal(t, 5.0, *field.At(0).(*float64))
+	field, _ = histogram.FieldByName("sum")
+	require.Equal(t, 4.2, *field.At(0).(*float64))
+
+	require.Equal(t, "stream/metrics/edge/temperature_celsius", frames[1].Channel)
+	gauge := frames[1].Frame
+	require.Equal(t, 2, gauge.Rows())
+	require.Equal(t, `device=a`, gauge.Fields[0].At(0))
+	require.Equal(t, time.UnixMilli(1700000000000), gauge.Fields[1].At(0))
+	field, _ = gauge.FieldByName("value")
+	require.Equal(t, data.FieldTypeNullableFloat64, field.Type())
+	require.Equal(t, 22.0, *field.At(1).(*float64))
+}
+
+func TestPrometheusConverter_InvalidInput(t *testing.T) {
+	c := NewPrometheusConverter(PrometheusConverterConfig{FrameFormat: "labels_column"})
+	_, err := c.Convert(context.Background(), Vars{}, []byte("temperature{ 1"))
+	require.Error(t, err)
+
+	c = NewPrometheusConverter(PrometheusConverterConfig{FrameFormat: "unknown"})
+	_, err = c.Convert(context.Background(), Vars{}, []byte(prometheusTestInput))
+	require.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+// ComputedFieldFrameProcessor can add a field computed from other fields of a data.Frame
+// for each row. The expression uses the syntax of math server side expressions where
+// variables refer to numeric fields by name, e.g. `$temperature * 1.8 + 32`.
+type ComputedFieldFrameProcessor struct {
+	config ComputedFieldFrameProcessorConfig
+	expr   *mathexp.Expr
+	tracer tracing.Tracer
+}
+
+func NewComputedFieldFrameProcessor(config ComputedFieldFrameProcessorConfig) (*ComputedFieldFrameProcessor, error) {
+	if config.FieldName == "" {
+		return nil, fmt.Errorf("field name is required")
+	}
+	expr, err := mathexp.New(config.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression: %w", err)
+	}
+	return &ComputedFieldFrameProcessor{
+		config: config,
+		expr:   expr,
+		tracer: tracing.NewNoopTracerService(),
+	}, nil
+}
+
+const FrameProcessorTypeComputedField = "computedField"
+
+func (p *ComputedFieldFrameProcessor) Type() string {
+	return FrameProcessorTypeComputedField
+}
+
+func (p *ComputedFieldFrameProcessor) ProcessFrame(_ context.Context, _ Vars, frame *data.Frame) (*data.Frame, error) {
+	inputs := make(map[string]*data.Field, len(p.expr.VarNames))
+	for _, name := range p.expr.VarNames {
+		idx := -1
+		for i, field := range frame.Fields {
+			if field.Name == name {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("field %s used in expression not found", name)
+		}
+		if !frame.Fields[idx].Type().Numeric() {
+			return nil, fmt.Errorf("field %s used in expression is not numeric", name)
+		}
+		inputs[name] = frame.Fields[idx]
+	}
+
+	rows, err := frame.RowLen()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]*float64, rows)
+	for row := 0; row < rows; row++ {
+		vars := make(mathexp.Vars, len(inputs))
+		for name, field := range inputs {
+			value, err := field.NullableFloatAt(row)
+			if err != nil {
+				return nil, err
+			}
+			vars[name] = mathexp.NewScalarResults(name, value)
+		}
+
+		results, err := p.expr.Execute(p.config.FieldName, vars, p.tracer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute expression: %w", err)
+		}
+		if len(results.Values) != 1 {
+			return nil, fmt.Errorf("expression must return a single value, got %d", len(results.Values))
+		}
+		value, ok := results.Values[0].(mathexp.Scalar)
+		if !ok {
+			return nil, fmt.Errorf("expression must return a number, got %s", results.Values[0].Type())
+		}
+		values[row] = value.GetFloat64Value()
+	}
+
+	computed := data.NewField(p.config.FieldName, nil, values)
+	for i, field := range frame.Fields {
+		if field.Name == p.config.FieldName {
+			frame.Fields[i] = computed
+			return frame, nil
+		}
+	}
+	frame.Fields = append(frame.Fields, computed)
+	return frame, nil
+}
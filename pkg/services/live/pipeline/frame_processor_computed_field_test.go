@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputedFieldFrameProcessor(t *testing.T) {
+	proc, err := NewComputedFieldFrameProcessor(ComputedFieldFrameProcessorConfig{
+		FieldName:  "fahrenheit",
+		Expression: "${celsius} * 1.8 + 32",
+	})
+	require.NoError(t, err)
+
+	celsius := 100.0
+	frame := data.NewFrame("test",
+		data.NewField("celsius", nil, []*float64{&celsius, nil}),
+		data.NewField("device", nil, []string{"a", "b"}),
+	)
+
+	result, err := proc.ProcessFrame(context.Background(), Vars{}, frame)
+	require.NoError(t, err)
+	require.Len(t, result.Fields, 3)
+	require.Equal(t, "fahrenheit", result.Fields[2].Name)
+	require.InDelta(t, 212.0, *result.Fields[2].At(0).(*float64), 0.0001)
+	require.Nil(t, result.Fields[2].At(1))
+}
+
+func TestComputedFieldFrameProcessor_Errors(t *testing.T) {
+	_, err := NewComputedFieldFrameProcessor(ComputedFieldFrameProcessorConfig{FieldName: "x", Expression: "$a +"})
+	require.Error(t, err)
+
+	proc, err := NewComputedFieldFrameProcessor(ComputedFieldFrameProcessorConfig{FieldName: "x", Expression: "$device * 2"})
+	require.NoError(t, err)
+
+	frame := data.NewFrame("test", data.NewField("device", nil, []string{"a"}))
+	_, err = proc.ProcessFrame(context.Background(), Vars{}, frame)
+	require.Error(t, err)
+
+	frame = data.NewFrame("test", data.NewField("other", nil, []float64{1}))
+	_, err = proc.ProcessFrame(context.Background(), Vars{}, frame)
+	require.Error(t, err)
+}
+
+func TestRenameFieldsFrameProcessor(t *testing.T) {
+	proc := NewRenameFieldsFrameProcessor(RenameFieldsFrameProcessorConfig{Renames: map[string]string{"temp": "temperature"}})
+	frame := data.NewFrame("test", data.NewField("temp", nil, []float64{1}), data.NewField("humidity", nil, []float64{2}))
+
+	result, err := proc.ProcessFrame(context.Background(), Vars{}, frame)
+	require.NoError(t, err)
+	require.Equal(t, "temperature", result.Fields[0].Name)
+	require.Equal(t, "humidity", result.Fields[1].Name)
+}
@@ -26,6 +26,10 @@ func (p *MultipleFrameProcessor) ProcessFrame(ctx context.Context, vars Vars, fr
 			logger.Error("Error processing frame", "error", err)
 			return nil, err
 		}
+		// a processor can hold back the frame, e.g. while a window is open
+		if frame == nil {
+			return nil, nil
+		}
 	}
 	return frame, nil
 }
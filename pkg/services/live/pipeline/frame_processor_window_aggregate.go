@@ -0,0 +1,318 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// WindowReducer is the function used to aggregate field values in a window.
+type WindowReducer string
+
+const (
+	WindowReducerMean  WindowReducer = "mean"
+	WindowReducerMin   WindowReducer = "min"
+	WindowReducerMax   WindowReducer = "max"
+	WindowReducerSum   WindowReducer = "sum"
+	WindowReducerCount WindowReducer = "count"
+	WindowReducerLast  WindowReducer = "last"
+)
+
+const (
+	// windowMaxClockSkew is how far in the future points can be. Later points are ignored, as they would
+	// move the windows forward and every following point would be dropped.
+	windowMaxClockSkew = time.Minute
+	// windowStateIdleTimeout is how long the points of a channel are kept without new frames.
+	windowStateIdleTimeout = 10 * time.Minute
+)
+
+// WindowAggregateFrameProcessor aggregates rows of frames over time windows. Windows are aligned to
+// the epoch and end every SlideSeconds (every WindowSeconds for tumbling windows). Frames are buffered
+// per channel, and a frame with one row per window is produced once a frame with a time past the end of
+// the window arrives. While a window is open the processor returns no frame so that outputs are skipped.
+// The buffered points of channels that stop sending frames are dropped after a while.
+type WindowAggregateFrameProcessor struct {
+	config WindowAggregateFrameProcessorConfig
+	window time.Duration
+	slide  time.Duration
+	now    func() time.Time
+
+	mu           sync.Mutex
+	states       map[string]*windowState
+	lastEviction time.Time
+}
+
+type windowPoint struct {
+	time   time.Time
+	values []*float64
+}
+
+type windowState struct {
+	// end of the next window to produce.
+	nextEnd time.Time
+	points  []windowPoint
+	// time of the last frame of the channel.
+	lastSeen time.Time
+}
+
+func NewWindowAggregateFrameProcessor(config WindowAggregateFrameProcessorConfig) (*WindowAggregateFrameProcessor, error) {
+	if config.WindowSeconds <= 0 {
+		return nil, fmt.Errorf("window seconds must be positive")
+	}
+	if config.SlideSeconds < 0 || config.SlideSeconds > config.WindowSeconds {
+		return nil, fmt.Errorf("slide seconds must be between 0 and window seconds")
+	}
+	if len(config.Aggregations) == 0 {
+		return nil, fmt.Errorf("at least one aggregation is required")
+	}
+	for _, a := range config.Aggregations {
+		switch a.Reducer {
+		case WindowReducerMean, WindowReducerMin, WindowReducerMax, WindowReducerSum, WindowReducerCount, WindowReducerLast:
+		default:
+			return nil, fmt.Errorf("unknown reducer %q for field %s", a.Reducer, a.FieldName)
+		}
+	}
+
+	window := time.Duration(config.WindowSeconds) * time.Second
+	slide := window
+	if config.SlideSeconds > 0 {
+		slide = time.Duration(config.SlideSeconds) * time.Second
+	}
+	return &WindowAggregateFrameProcessor{
+		config: config,
+		window: window,
+		slide:  slide,
+		now:    time.Now,
+		states: map[string]*windowState{},
+	}, nil
+}
+
+const FrameProcessorTypeWindowAggregate = "windowAggregate"
+
+func (p *WindowAggregateFrameProcessor) Type() string {
+	return FrameProcessorTypeWindowAggregate
+}
+
+func (p *WindowAggregateFrameProcessor) ProcessFrame(_ context.Context, vars Vars, frame *data.Frame) (*data.Frame, error) {
+	points, err := p.extractPoints(frame)
+	if err != nil {
+		return nil, err
+	}
+	now := p.now()
+	points = dropPointsAfter(points, now.Add(windowMaxClockSkew))
+	if len(points) == 0 {
+		return nil, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.evictIdleStates(now)
+
+	key := fmt.Sprintf("%d/%s", vars.OrgID, vars.Channel)
+	state, ok := p.states[key]
+	if !ok {
+		state = &windowState{}
+		p.states[key] = state
+	}
+	state.lastSeen = now
+	if !state.nextEnd.IsZero() {
+		// late points before the windows still to produce would never be aggregated.
+		points = dropPointsBefore(points, state.nextEnd.Add(-p.window))
+		if len(points) == 0 {
+			return nil, nil
+		}
+	}
+	state.points = append(state.points, points...)
+
+	latest := points[0].time
+	for _, point := range points[1:] {
+		if point.time.After(latest) {
+			latest = point.time
+		}
+	}
+	if state.nextEnd.IsZero() {
+		state.nextEnd = state.points[0].time.Truncate(p.slide).Add(p.slide)
+	}
+
+	var times []time.Time
+	values := make([][]*float64, len(p.config.Aggregations))
+	for !latest.Before(state.nextEnd) {
+		start := state.nextEnd.Add(-p.window)
+		earliest := state.nextEnd
+		var inWindow []windowPoint
+		for _, point := range state.points {
+			if !point.time.Before(start) && point.time.Before(state.nextEnd) {
+				inWindow = append(inWindow, point)
+			}
+			if point.time.Before(earliest) {
+				earliest = point.time
+			}
+		}
+
+		if len(inWindow) > 0 {
+			times = append(times, state.nextEnd)
+			for i, a := range p.config.Aggregations {
+				values[i] = append(values[i], reduceWindow(a.Reducer, inWindow, i))
+			}
+			state.nextEnd = state.nextEnd.Add(p.slide)
+		} else if !earliest.Before(state.nextEnd) {
+			// skip empty windows after a gap in the data.
+			state.nextEnd = earliest.Truncate(p.slide).Add(p.slide)
+		} else {
+			state.nextEnd = state.nextEnd.Add(p.slide)
+		}
+
+		state.points = dropPointsBefore(state.points, state.nextEnd.Add(-p.window))
+	}
+
+	if len(times) == 0 {
+		return nil, nil
+	}
+
+	fields := make([]*data.Field, 0, len(p.config.Aggregations)+1)
+	fields = append(fields, data.NewField("time", nil, times))
+	for i, a := range p.config.Aggregations {
+		name := a.Name
+		if name == "" {
+			name = a.FieldName + "_" + string(a.Reducer)
+		}
+		fields = append(fields, data.NewField(name, nil, values[i]))
+	}
+	return data.NewFrame(frame.Name, fields...), nil
+}
+
+// evictIdleStates drops the states of channels without frames for longer than the idle timeout, or than
+// the window if it is longer. States are checked at most once per minute.
+func (p *WindowAggregateFrameProcessor) evictIdleStates(now time.Time) {
+	if now.Sub(p.lastEviction) < time.Minute {
+		return
+	}
+	p.lastEviction = now
+
+	timeout := windowStateIdleTimeout
+	if p.window > timeout {
+		timeout = p.window
+	}
+	for key, state := range p.states {
+		if now.Sub(state.lastSeen) > timeout {
+			delete(p.states, key)
+		}
+	}
+}
+
+func (p *WindowAggregateFrameProcessor) extractPoints(frame *data.Frame) ([]windowPoint, error) {
+	timeIdx := -1
+	for i, field := range frame.Fields {
+		if p.config.TimeField == "" && field.Type().Time() || p.config.TimeField != "" && field.Name == p.config.TimeField {
+			timeIdx = i
+			break
+		}
+	}
+	if timeIdx < 0 {
+		return nil, fmt.Errorf("time field not found")
+	}
+	timeField := frame.Fields[timeIdx]
+	if !timeField.Type().Time() {
+		return nil, fmt.Errorf("field %s is not a time field", timeField.Name)
+	}
+
+	valueFields := make([]*data.Field, len(p.config.Aggregations))
+	for i, a := range p.config.Aggregations {
+		for _, field := range frame.Fields {
+			if field.Name == a.FieldName {
+				valueFields[i] = field
+				break
+			}
+		}
+		if valueFields[i] == nil {
+			return nil, fmt.Errorf("field %s not found", a.FieldName)
+		}
+		if !valueFields[i].Type().Numeric() {
+			return nil, fmt.Errorf("field %s is not numeric", a.FieldName)
+		}
+	}
+
+	points := make([]windowPoint, 0, timeField.Len())
+	for row := 0; row < timeField.Len(); row++ {
+		t, ok := timeField.ConcreteAt(row)
+		if !ok {
+			continue
+		}
+		point := windowPoint{time: t.(time.Time), values: make([]*float64, len(valueFields))}
+		for i, field := range valueFields {
+			v, err := field.NullableFloatAt(row)
+			if err != nil {
+				return nil, err
+			}
+			point.values[i] = v
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+func reduceWindow(reducer WindowReducer, points []windowPoint, idx int) *float64 {
+	var count, sum float64
+	var last *float64
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	for _, point := range points {
+		v := point.values[idx]
+		if v == nil {
+			continue
+		}
+		count++
+		sum += *v
+		lowest = math.Min(lowest, *v)
+		highest = math.Max(highest, *v)
+		last = v
+	}
+
+	var result float64
+	switch reducer {
+	case WindowReducerCount:
+		return &count
+	case WindowReducerLast:
+		return last
+	}
+	if count == 0 {
+		return nil
+	}
+	switch reducer {
+	case WindowReducerMean:
+		result = sum / count
+	case WindowReducerMin:
+		result = lowest
+	case WindowReducerMax:
+		result = highest
+	case WindowReducerSum:
+		result = sum
+	}
+	return &result
+}
+
+func dropPointsAfter(points []windowPoint, t time.Time) []windowPoint {
+	kept := points[:0]
+	for _, point := range points {
+		if !point.time.After(t) {
+			kept = append(kept, point)
+		} else {
+			logger.Debug("Ignoring point in the future", "time", point.time)
+		}
+	}
+	return kept
+}
+
+func dropPointsBefore(points []windowPoint, t time.Time) []windowPoint {
+	kept := points[:0]
+	for _, point := range points {
+		if !point.time.Before(t) {
+			kept = append(kept, point)
+		}
+	}
+	return kept
+}
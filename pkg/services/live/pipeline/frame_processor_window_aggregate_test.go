@@ -0,0 +1,173 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func windowTestFrame(start time.Time, interval time.Duration, values ...float64) *data.Frame {
+	times := make([]time.Time, len(values))
+	for i := range values {
+		times[i] = start.Add(time.Duration(i) * interval)
+	}
+	return data.NewFrame("test", data.NewField("time", nil, times), data.NewField("value", nil, values))
+}
+
+func TestWindowAggregateFrameProcessor_Tumbling(t *testing.T) {
+	proc, err := NewWindowAggregateFrameProcessor(WindowAggregateFrameProcessorConfig{
+		WindowSeconds: 1,
+		Aggregations: []WindowAggregationConfig{
+			{FieldName: "value", Reducer: WindowReducerMean},
+			{FieldName: "value", Reducer: WindowReducerMax},
+			{FieldName: "value", Reducer: WindowReducerCount, Name: "samples"},
+		},
+	})
+	require.NoError(t, err)
+
+	start := time.Unix(1000, 0)
+	vars := Vars{OrgID: 1, Channel: "stream/iot/device"}
+
+	// 50 Hz stream, the window is not complete yet.
+	frame, err := proc.ProcessFrame(context.Background(), vars, windowTestFrame(start, 20*time.Millisecond, 1, 2, 3))
+	require.NoError(t, err)
+	require.Nil(t, frame)
+
+	// frames of other channels are aggregated separately.
+	frame, err = proc.ProcessFrame(context.Background(), Vars{OrgID: 1, Channel: "stream/iot/other"}, windowTestFrame(start.Add(time.Second), 0, 100))
+	require.NoError(t, err)
+	require.Nil(t, frame)
+
+	frame, err = proc.ProcessFrame(context.Background(), vars, windowTestFrame(start.Add(time.Second), 0, 10))
+	require.NoError(t, err)
+	require.NotNil(t, frame)
+	require.Equal(t, 1, frame.Rows())
+	require.Equal(t, start.Add(time.Second), frame.Fields[0].At(0))
+	require.Equal(t, "value_mean", frame.Fields[1].Name)
+	require.Equal(t, 2.0, *frame.Fields[1].At(0).(*float64))
+	require.Equal(t, 3.0, *frame.Fields[2].At(0).(*float64))
+	require.Equal(t, "samples", frame.Fields[3].Name)
+	require.Equal(t, 3.0, *frame.Fields[3].At(0).(*float64))
+
+	// empty windows after a gap are skipped.
+	frame, err = proc.ProcessFrame(context.Background(), vars, windowTestFrame(start.Add(10*time.Second), 0, 20))
+	require.NoError(t, err)
+	require.Equal(t, 1, frame.Rows())
+	require.Equal(t, start.Add(2*time.Second), frame.Fields[0].At(0))
+	require.Equal(t, 10.0, *frame.Fields[1].At(0).(*float64))
+}
+
+func TestWindowAggregateFrameProcessor_Sliding(t *testing.T) {
+	proc, err := NewWindowAggregateFrameProcessor(WindowAggregateFrameProcessorConfig{
+		WindowSeconds: 2,
+		SlideSeconds:  1,
+		Aggregations:  []WindowAggregationConfig{{FieldName: "value", Reducer: WindowReducerSum}},
+	})
+	require.NoError(t, err)
+
+	start := time.Unix(1000, 0)
+	frame, err := proc.ProcessFrame(context.Background(), Vars{}, windowTestFrame(start, 500*time.Millisecond, 1, 2, 3, 4, 5, 6, 7))
+	require.NoError(t, err)
+	require.Equal(t, 3, frame.Rows())
+	// windows ending at 1001, 1002 and 1003 overlap by one second.
+	require.Equal(t, 3.0, *frame.Fields[1].At(0).(*float64))
+	require.Equal(t, 10.0, *frame.Fields[1].At(1).(*float64))
+	require.Equal(t, 18.0, *frame.Fields[1].At(2).(*float64))
+}
+
+func TestWindowAggregateFrameProcessor_InvalidConfig(t *testing.T) {
+	testCases := map[string]WindowAggregateFrameProcessorConfig{
+		"no window":         {Aggregations: []WindowAggregationConfig{{FieldName: "value", Reducer: WindowReducerMean}}},
+		"slide over window": {WindowSeconds: 1, SlideSeconds: 2, Aggregations: []WindowAggregationConfig{{FieldName: "value", Reducer: WindowReducerMean}}},
+		"no aggregations":   {WindowSeconds: 1},
+		"unknown reducer":   {WindowSeconds: 1, Aggregations: []WindowAggregationConfig{{FieldName: "value", Reducer: "median"}}},
+	}
+	for name, config := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewWindowAggregateFrameProcessor(config)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestWindowAggregateFrameProcessor_FutureAndIdle(t *testing.T) {
+	proc, err := NewWindowAggregateFrameProcessor(WindowAggregateFrameProcessorConfig{
+		WindowSeconds: 1,
+		Aggregations:  []WindowAggregationConfig{{FieldName: "value", Reducer: WindowReducerSum}},
+	})
+	require.NoError(t, err)
+
+	now := time.Unix(2000, 0)
+	proc.now = func() time.Time { return now }
+	start := time.Unix(1000, 0)
+	vars := Vars{OrgID: 1, Channel: "stream/iot/device"}
+
+	_, err = proc.ProcessFrame(context.Background(), vars, windowTestFrame(start, 0, 1))
+	require.NoError(t, err)
+
+	// a point far in the future is ignored and does not move the windows.
+	frame, err := proc.ProcessFrame(context.Background(), vars, windowTestFrame(now.Add(time.Hour), 0, 100))
+	require.NoError(t, err)
+	require.Nil(t, frame)
+
+	frame, err = proc.ProcessFrame(context.Background(), vars, windowTestFrame(start.Add(time.Second), 0, 2))
+	require.NoError(t, err)
+	require.NotNil(t, frame)
+	require.Equal(t, 1.0, *frame.Fields[1].At(0).(*float64))
+
+	// the state of a channel without frames is dropped.
+	now = now.Add(windowStateIdleTimeout + time.Minute)
+	_, err = proc.ProcessFrame(context.Background(), Vars{OrgID: 1, Channel: "stream/iot/other"}, windowTestFrame(start, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, proc.states, 1)
+	require.Contains(t, proc.states, "1/stream/iot/other")
+}
+
+func TestWindowAggregateFrameProcessor_LatePoints(t *testing.T) {
+	proc, err := NewWindowAggregateFrameProcessor(WindowAggregateFrameProcessorConfig{
+		WindowSeconds: 1,
+		Aggregations:  []WindowAggregationConfig{{FieldName: "value", Reducer: WindowReducerSum}},
+	})
+	require.NoError(t, err)
+
+	start := time.Unix(1000, 0)
+	vars := Vars{OrgID: 1, Channel: "stream/iot/device"}
+
+	frame, err := proc.ProcessFrame(context.Background(), vars, windowTestFrame(start, 500*time.Millisecond, 1, 2, 3))
+	require.NoError(t, err)
+	require.Equal(t, 1, frame.Rows())
+
+	// points of windows already produced are dropped when they arrive.
+	frame, err = proc.ProcessFrame(context.Background(), vars, windowTestFrame(start, 0, 100, 100))
+	require.NoError(t, err)
+	require.Nil(t, frame)
+	require.Len(t, proc.states["1/stream/iot/device"].points, 1)
+
+	frame, err = proc.ProcessFrame(context.Background(), vars, windowTestFrame(start.Add(1500*time.Millisecond), 500*time.Millisecond, 4, 5))
+	require.NoError(t, err)
+	require.Equal(t, 1, frame.Rows())
+	require.Equal(t, 7.0, *frame.Fields[1].At(0).(*float64))
+}
+
+func TestMultipleFrameProcessor_HeldBackFrame(t *testing.T) {
+	window, err := NewWindowAggregateFrameProcessor(WindowAggregateFrameProcessorConfig{
+		WindowSeconds: 1,
+		Aggregations:  []WindowAggregationConfig{{FieldName: "value", Reducer: WindowReducerSum}},
+	})
+	require.NoError(t, err)
+	proc := NewMultipleFrameProcessor(window, NewRenameFieldsFrameProcessor(RenameFieldsFrameProcessorConfig{
+		Renames: map[string]string{"value_sum": "total"},
+	}))
+
+	start := time.Unix(1000, 0)
+	frame, err := proc.ProcessFrame(context.Background(), Vars{}, windowTestFrame(start, 0, 1))
+	require.NoError(t, err)
+	require.Nil(t, frame)
+
+	frame, err = proc.ProcessFrame(context.Background(), Vars{}, windowTestFrame(start.Add(time.Second), 0, 2))
+	require.NoError(t, err)
+	require.Equal(t, "total", frame.Fields[1].Name)
+}
@@ -66,6 +66,25 @@ var ConvertersRegistry = []EntityInfo{
 		Type:        ConverterTypeJsonFrame,
 		Description: "JSON-encoded Grafana data frame",
 	},
+	{
+		Type:        ConverterTypePrometheus,
+		Description: "accept Prometheus text exposition format",
+		Example: PrometheusConverterConfig{
+			FrameFormat: "labels_column",
+		},
+	},
+	{
+		Type:        ConverterTypeCSV,
+		Description: "CSV with a header row",
+		Example:     CSVConverterConfig{},
+	},
+	{
+		Type:        ConverterTypeOTLPMetrics,
+		Description: "accept OTLP/HTTP metrics in protobuf encoding",
+		Example: OTLPMetricsConverterConfig{
+			FrameFormat: "labels_column",
+		},
+	},
 }
 
 var FrameProcessorsRegistry = []EntityInfo{
@@ -79,6 +98,31 @@ var FrameProcessorsRegistry = []EntityInfo{
 		Description: "list the fields that should be removed",
 		Example:     DropFieldsFrameProcessorConfig{},
 	},
+	{
+		Type:        FrameProcessorTypeRenameFields,
+		Description: "rename fields",
+		Example: RenameFieldsFrameProcessorConfig{
+			Renames: map[string]string{"temp": "temperature"},
+		},
+	},
+	{
+		Type:        FrameProcessorTypeComputedField,
+		Description: "add a field computed from other fields with a math expression",
+		Example: ComputedFieldFrameProcessorConfig{
+			FieldName:  "fahrenheit",
+			Expression: "$celsius * 1.8 + 32",
+		},
+	},
+	{
+		Type:        FrameProcessorTypeWindowAggregate,
+		Description: "aggregate fields over tumbling or sliding time windows",
+		Example: WindowAggregateFrameProcessorConfig{
+			WindowSeconds: 1,
+			Aggregations: []WindowAggregationConfig{
+				{FieldName: "value", Reducer: WindowReducerMean},
+			},
+		},
+	},
 }
 
 var DataOutputsRegistry = []EntityInfo{
@@ -69,6 +69,21 @@ func (f *StorageRuleBuilder) extractConverter(config *ConverterConfig) (Converte
 			return nil, missingConfiguration
 		}
 		return NewAutoInfluxConverter(*config.AutoInfluxConverterConfig), nil
+	case ConverterTypePrometheus:
+		if config.PrometheusConverterConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewPrometheusConverter(*config.PrometheusConverterConfig), nil
+	case ConverterTypeCSV:
+		if config.CSVConverterConfig == nil {
+			config.CSVConverterConfig = &CSVConverterConfig{}
+		}
+		return NewCSVConverter(*config.CSVConverterConfig), nil
+	case ConverterTypeOTLPMetrics:
+		if config.OTLPMetricsConverterConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewOTLPMetricsConverter(*config.OTLPMetricsConverterConfig), nil
 	default:
 		return nil, fmt.Errorf("unknown converter type: %s", config.Type)
 	}
@@ -90,6 +105,21 @@ func (f *StorageRuleBuilder) extractFrameProcessor(config *FrameProcessorConfig)
 			return nil, missingConfiguration
 		}
 		return NewKeepFieldsFrameProcessor(*config.KeepFieldsProcessorConfig), nil
+	case FrameProcessorTypeRenameFields:
+		if config.RenameFieldsProcessorConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewRenameFieldsFrameProcessor(*config.RenameFieldsProcessorConfig), nil
+	case FrameProcessorTypeComputedField:
+		if config.ComputedFieldProcessorConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewComputedFieldFrameProcessor(*config.ComputedFieldProcessorConfig)
+	case FrameProcessorTypeWindowAggregate:
+		if config.WindowAggregateProcessorConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewWindowAggregateFrameProcessor(*config.WindowAggregateProcessorConfig)
 	case FrameProcessorTypeMultiple:
 		if config.MultipleProcessorConfig == nil {
 			return nil, missingConfiguration
@@ -66,6 +66,12 @@ func (c *Converter) Convert(body []byte) ([]telemetry.FrameWrapper, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error parsing metrics: %w", err)
 	}
+	return c.ConvertMetrics(metrics)
+}
+
+// ConvertMetrics converts already parsed metrics. It allows converting metrics
+// decoded from other formats in the same way as Influx line protocol.
+func (c *Converter) ConvertMetrics(metrics []influx.Metric) ([]telemetry.FrameWrapper, error) {
 	if !c.useLabelsColumn {
 		return c.convertWideFields(metrics)
 	}
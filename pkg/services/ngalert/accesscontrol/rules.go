@@ -70,6 +70,10 @@ func (r *RuleService) getRulesQueryEvaluator(rules ...*models.AlertRule) accessc
 				DatasourceUID == expr.OldDatasourceUID {
 				continue
 			}
+			// input queries use the results of another rule of the group, which queries are checked separately.
+			if query.IsInput() {
+				continue
+			}
 			if _, ok := added[query.DatasourceUID]; ok {
 				continue
 			}
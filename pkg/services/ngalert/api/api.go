@@ -70,6 +70,8 @@ type API struct {
 	ContactPointService  *provisioning.ContactPointService
 	Templates            *provisioning.TemplateService
 	MuteTimings          *provisioning.MuteTimingService
+	SilenceTemplates     *provisioning.SilenceTemplateService
+	RecurringSilences    *provisioning.RecurringSilenceService
 	AlertRules           *provisioning.AlertRuleService
 	AlertsRouter         *sender.AlertsRouter
 	EvaluatorFactory     eval.EvaluatorFactory
@@ -150,6 +152,7 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 			appUrl:          api.AppUrl,
 			tracer:          api.Tracer,
 			folderService:   api.RuleStore,
+			amConfig:        api.MultiOrgAlertmanager,
 		}), m)
 	api.RegisterConfigurationApiEndpoints(NewConfiguration(
 		&ConfigSrv{
@@ -167,6 +170,8 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 		contactPointService: api.ContactPointService,
 		templates:           api.Templates,
 		muteTimings:         api.MuteTimings,
+		silenceTemplates:    api.SilenceTemplates,
+		recurringSilences:   api.RecurringSilences,
 		alertRules:          api.AlertRules,
 		// XXX: Used to flag recording rules, remove when FT is removed
 		featureManager: api.FeatureManager,
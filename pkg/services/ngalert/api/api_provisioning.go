@@ -29,6 +29,8 @@ type ProvisioningSrv struct {
 	contactPointService ContactPointService
 	templates           TemplateService
 	muteTimings         MuteTimingService
+	silenceTemplates    SilenceTemplateService
+	recurringSilences   RecurringSilenceService
 	alertRules          AlertRuleService
 	folderSvc           folder.Service
 
@@ -64,6 +66,22 @@ type MuteTimingService interface {
 	DeleteMuteTiming(ctx context.Context, name string, orgID int64, provenance definitions.Provenance, version string) error
 }
 
+type SilenceTemplateService interface {
+	GetSilenceTemplates(ctx context.Context, orgID int64) ([]definitions.SilenceTemplate, error)
+	GetSilenceTemplate(ctx context.Context, uid string, orgID int64) (definitions.SilenceTemplate, error)
+	CreateSilenceTemplate(ctx context.Context, t definitions.SilenceTemplate, orgID int64) (definitions.SilenceTemplate, error)
+	UpdateSilenceTemplate(ctx context.Context, t definitions.SilenceTemplate, orgID int64) (definitions.SilenceTemplate, error)
+	DeleteSilenceTemplate(ctx context.Context, uid string, orgID int64, provenance definitions.Provenance) error
+}
+
+type RecurringSilenceService interface {
+	GetRecurringSilences(ctx context.Context, orgID int64) ([]definitions.RecurringSilence, error)
+	GetRecurringSilence(ctx context.Context, uid string, orgID int64) (definitions.RecurringSilence, error)
+	CreateRecurringSilence(ctx context.Context, s definitions.RecurringSilence, orgID int64) (definitions.RecurringSilence, error)
+	UpdateRecurringSilence(ctx context.Context, s definitions.RecurringSilence, orgID int64) (definitions.RecurringSilence, error)
+	DeleteRecurringSilence(ctx context.Context, uid string, orgID int64, provenance definitions.Provenance) error
+}
+
 type AlertRuleService interface {
 	GetAlertRules(ctx context.Context, user identity.Requester) ([]*alerting_models.AlertRule, map[string]alerting_models.Provenance, error)
 	GetAlertRule(ctx context.Context, user identity.Requester, ruleUID string) (alerting_models.AlertRule, alerting_models.Provenance, error)
@@ -313,6 +331,92 @@ func (srv *ProvisioningSrv) RouteDeleteMuteTiming(c *contextmodel.ReqContext, na
 	return response.JSON(http.StatusNoContent, nil)
 }
 
+func (srv *ProvisioningSrv) RouteGetSilenceTemplates(c *contextmodel.ReqContext) response.Response {
+	templates, err := srv.silenceTemplates.GetSilenceTemplates(c.Req.Context(), c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get silence templates", err)
+	}
+	return response.JSON(http.StatusOK, templates)
+}
+
+func (srv *ProvisioningSrv) RouteGetSilenceTemplate(c *contextmodel.ReqContext, uid string) response.Response {
+	template, err := srv.silenceTemplates.GetSilenceTemplate(c.Req.Context(), uid, c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get silence template", err)
+	}
+	return response.JSON(http.StatusOK, template)
+}
+
+func (srv *ProvisioningSrv) RoutePostSilenceTemplate(c *contextmodel.ReqContext, t definitions.SilenceTemplate) response.Response {
+	t.Provenance = determineProvenance(c)
+	created, err := srv.silenceTemplates.CreateSilenceTemplate(c.Req.Context(), t, c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to create silence template", err)
+	}
+	return response.JSON(http.StatusCreated, created)
+}
+
+func (srv *ProvisioningSrv) RoutePutSilenceTemplate(c *contextmodel.ReqContext, t definitions.SilenceTemplate, uid string) response.Response {
+	t.UID = uid
+	t.Provenance = determineProvenance(c)
+	updated, err := srv.silenceTemplates.UpdateSilenceTemplate(c.Req.Context(), t, c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to update silence template", err)
+	}
+	return response.JSON(http.StatusAccepted, updated)
+}
+
+func (srv *ProvisioningSrv) RouteDeleteSilenceTemplate(c *contextmodel.ReqContext, uid string) response.Response {
+	err := srv.silenceTemplates.DeleteSilenceTemplate(c.Req.Context(), uid, c.SignedInUser.GetOrgID(), determineProvenance(c))
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to delete silence template", err)
+	}
+	return response.JSON(http.StatusNoContent, nil)
+}
+
+func (srv *ProvisioningSrv) RouteGetRecurringSilences(c *contextmodel.ReqContext) response.Response {
+	silences, err := srv.recurringSilences.GetRecurringSilences(c.Req.Context(), c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get recurring silences", err)
+	}
+	return response.JSON(http.StatusOK, silences)
+}
+
+func (srv *ProvisioningSrv) RouteGetRecurringSilence(c *contextmodel.ReqContext, uid string) response.Response {
+	silence, err := srv.recurringSilences.GetRecurringSilence(c.Req.Context(), uid, c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get recurring silence", err)
+	}
+	return response.JSON(http.StatusOK, silence)
+}
+
+func (srv *ProvisioningSrv) RoutePostRecurringSilence(c *contextmodel.ReqContext, s definitions.RecurringSilence) response.Response {
+	s.Provenance = determineProvenance(c)
+	created, err := srv.recurringSilences.CreateRecurringSilence(c.Req.Context(), s, c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to create recurring silence", err)
+	}
+	return response.JSON(http.StatusCreated, created)
+}
+
+func (srv *ProvisioningSrv) RoutePutRecurringSilence(c *contextmodel.ReqContext, s definitions.RecurringSilence, uid string) response.Response {
+	s.UID = uid
+	s.Provenance = determineProvenance(c)
+	updated, err := srv.recurringSilences.UpdateRecurringSilence(c.Req.Context(), s, c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to update recurring silence", err)
+	}
+	return response.JSON(http.StatusAccepted, updated)
+}
+
+func (srv *ProvisioningSrv) RouteDeleteRecurringSilence(c *contextmodel.ReqContext, uid string) response.Response {
+	err := srv.recurringSilences.DeleteRecurringSilence(c.Req.Context(), uid, c.SignedInUser.GetOrgID(), determineProvenance(c))
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to delete recurring silence", err)
+	}
+	return response.JSON(http.StatusNoContent, nil)
+}
+
 func (srv *ProvisioningSrv) RouteGetAlertRules(c *contextmodel.ReqContext) response.Response {
 	rules, provenances, err := srv.alertRules.GetAlertRules(c.Req.Context(), c.SignedInUser)
 	if err != nil {
@@ -589,7 +693,7 @@ func exportHcl(download bool, body definitions.AlertingFileExport) response.Resp
 			gr := group
 			hash := getHash([]string{gr.Name, gr.FolderUID})
 			resources = append(resources, hcl.Resource{
-				Type: "grafana_rule_group",
+				Type: hclRuleGroupResource,
 				Name: fmt.Sprintf("rule_group_%016x", hash),
 				Body: &gr,
 			})
@@ -601,7 +705,7 @@ func exportHcl(download bool, body definitions.AlertingFileExport) response.Resp
 			}
 			hash := getHash([]string{upd.Name})
 			resources = append(resources, hcl.Resource{
-				Type: "grafana_contact_point",
+				Type: hclContactPointResource,
 				Name: fmt.Sprintf("contact_point_%016x", hash),
 				Body: &upd,
 			})
@@ -610,7 +714,7 @@ func exportHcl(download bool, body definitions.AlertingFileExport) response.Resp
 		for idx, cp := range body.Policies {
 			policy := cp.RouteExport
 			resources = append(resources, hcl.Resource{
-				Type: "grafana_notification_policy",
+				Type: hclNotificationPolicyResource,
 				Name: fmt.Sprintf("notification_policy_%d", idx+1),
 				Body: policy,
 			})
@@ -623,7 +727,7 @@ func exportHcl(download bool, body definitions.AlertingFileExport) response.Resp
 			}
 			hash := getHash([]string{mthcl.Name})
 			resources = append(resources, hcl.Resource{
-				Type: "grafana_mute_timing",
+				Type: hclMuteTimingResource,
 				Name: fmt.Sprintf("mute_timing_%016x", hash),
 				Body: mthcl,
 			})
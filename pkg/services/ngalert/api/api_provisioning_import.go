@@ -0,0 +1,381 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/hcl"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	alerting_models "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/util/cmputil"
+)
+
+// Types of the HCL resources. They match the resources of the Grafana Terraform provider.
+const (
+	hclRuleGroupResource          = "grafana_rule_group"
+	hclContactPointResource       = "grafana_contact_point"
+	hclNotificationPolicyResource = "grafana_notification_policy"
+	hclMuteTimingResource         = "grafana_mute_timing"
+)
+
+// hclImportOrder is the order in which the resources are applied, so that they can refer to the resources defined in the same document.
+var hclImportOrder = map[string]int{
+	hclMuteTimingResource:         0,
+	hclContactPointResource:       1,
+	hclNotificationPolicyResource: 2,
+	hclRuleGroupResource:          3,
+}
+
+var errInvalidHclResource = errors.New("invalid resource")
+
+// hclImport is a resource decoded from HCL and the function that applies it.
+type hclImport struct {
+	result definitions.ImportedResource
+	apply  func(ctx context.Context) error
+}
+
+// RoutePostImportHcl imports the resources of an HCL document in the format of the export endpoints.
+// All resources are decoded and compared with the current state before any of them is applied. The changes are not applied
+// atomically, and if one of the resources fails to apply, the ones applied before it are kept.
+func (srv *ProvisioningSrv) RoutePostImportHcl(c *contextmodel.ReqContext, body definitions.ImportHcl) response.Response {
+	resources, err := hcl.Decode([]byte(body.Hcl))
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	policies := 0
+	for _, r := range resources {
+		if _, ok := hclImportOrder[r.Type]; !ok {
+			return ErrResp(http.StatusBadRequest, fmt.Errorf("%w %s.%s: unsupported resource type", errInvalidHclResource, r.Type, r.Name), "")
+		}
+		if r.Type == hclNotificationPolicyResource {
+			policies++
+		}
+	}
+	if policies > 1 {
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("%w: only one %s resource is allowed", errInvalidHclResource, hclNotificationPolicyResource), "")
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		return hclImportOrder[resources[i].Type] < hclImportOrder[resources[j].Type]
+	})
+
+	provenance := determineProvenance(c)
+	imports := make([]hclImport, 0, len(resources))
+	for _, r := range resources {
+		var imp hclImport
+		switch r.Type {
+		case hclRuleGroupResource:
+			imp, err = srv.planRuleGroupImport(c, r, provenance)
+		case hclContactPointResource:
+			imp, err = srv.planContactPointImport(c, r, provenance)
+		case hclNotificationPolicyResource:
+			imp, err = srv.planNotificationPolicyImport(c, r, provenance)
+		case hclMuteTimingResource:
+			imp, err = srv.planMuteTimingImport(c, r, provenance)
+		}
+		if errors.Is(err, errInvalidHclResource) {
+			return ErrResp(http.StatusBadRequest, err, "")
+		}
+		if err != nil {
+			return response.ErrOrFallback(http.StatusInternalServerError, fmt.Sprintf("failed to read the current state of %s.%s", r.Type, r.Name), err)
+		}
+		imports = append(imports, imp)
+	}
+
+	result := definitions.ImportHclResult{
+		DryRun:    body.DryRun,
+		Resources: make([]definitions.ImportedResource, 0, len(imports)),
+	}
+	for _, imp := range imports {
+		if !body.DryRun && imp.result.Action != definitions.ImportActionNone {
+			if err := imp.apply(c.Req.Context()); err != nil {
+				err = fmt.Errorf("failed to import %s.%s: %w", imp.result.Type, imp.result.Name, err)
+				if errors.Is(err, provisioning.ErrValidation) ||
+					errors.Is(err, alerting_models.ErrAlertRuleFailedValidation) ||
+					errors.Is(err, alerting_models.ErrAlertRuleUniqueConstraintViolation) {
+					return ErrResp(http.StatusBadRequest, err, "")
+				}
+				if errors.Is(err, store.ErrOptimisticLock) {
+					return ErrResp(http.StatusConflict, err, "")
+				}
+				return response.ErrOrFallback(http.StatusInternalServerError, err.Error(), err)
+			}
+		}
+		result.Resources = append(result.Resources, imp.result)
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+func (srv *ProvisioningSrv) planRuleGroupImport(c *contextmodel.ReqContext, r hcl.DecodedResource, provenance definitions.Provenance) (hclImport, error) {
+	var export definitions.AlertRuleGroupExport
+	if err := r.DecodeBody(&export); err != nil {
+		return hclImport{}, fmt.Errorf("%w: %s", errInvalidHclResource, err)
+	}
+	group, err := AlertRuleGroupFromAlertRuleGroupExport(export)
+	if err != nil {
+		return hclImport{}, fmt.Errorf("%w %s.%s: %s", errInvalidHclResource, r.Type, r.Name, err)
+	}
+
+	imp := hclImport{
+		result: definitions.ImportedResource{Type: r.Type, Name: r.Name, Action: definitions.ImportActionCreate},
+		apply: func(ctx context.Context) error {
+			return srv.alertRules.ReplaceRuleGroup(ctx, c.SignedInUser, group, alerting_models.Provenance(provenance))
+		},
+	}
+
+	current, err := srv.alertRules.GetRuleGroup(c.Req.Context(), c.SignedInUser, group.FolderUID, group.Title)
+	if errors.Is(err, alerting_models.ErrAlertRuleGroupNotFound) {
+		return imp, nil
+	}
+	if err != nil {
+		return hclImport{}, err
+	}
+
+	// HCL does not contain the UIDs of the rules, so the rules are matched by title to be updated instead of re-created.
+	byTitle := make(map[string]alerting_models.AlertRule, len(current.Rules))
+	for _, rule := range current.Rules {
+		byTitle[rule.Title] = rule
+	}
+	for i := range group.Rules {
+		if existing, ok := byTitle[group.Rules[i].Title]; ok {
+			group.Rules[i].UID = existing.UID
+			group.Rules[i].DashboardUID = existing.DashboardUID
+			group.Rules[i].PanelID = existing.PanelID
+		}
+	}
+
+	// Both states are converted to the export model to be compared in the same format.
+	currentExport, err := AlertRuleGroupExportFromAlertRuleGroupWithFolderFullpath(alerting_models.AlertRuleGroupWithFolderFullpath{AlertRuleGroup: &current})
+	if err != nil {
+		return hclImport{}, err
+	}
+	desiredExport, err := AlertRuleGroupExportFromAlertRuleGroupWithFolderFullpath(alerting_models.AlertRuleGroupWithFolderFullpath{AlertRuleGroup: &group})
+	if err != nil {
+		return hclImport{}, fmt.Errorf("%w %s.%s: %s", errInvalidHclResource, r.Type, r.Name, err)
+	}
+	for _, e := range []*definitions.AlertRuleGroupExport{&currentExport, &desiredExport} {
+		for i := range e.Rules {
+			e.Rules[i].Annotations = NilIfEmptyMap(e.Rules[i].Annotations)
+			e.Rules[i].Labels = NilIfEmptyMap(e.Rules[i].Labels)
+		}
+	}
+	imp.setDiff(diffHclResource(currentExport, desiredExport,
+		cmpopts.IgnoreFields(definitions.AlertRuleGroupExport{}, "Interval"),
+		cmpopts.IgnoreFields(definitions.AlertRuleExport{}, "For", "KeepFiringFor"),
+		cmpopts.IgnoreFields(definitions.AlertQueryExport{}, "Model"),
+	))
+	return imp, nil
+}
+
+func (srv *ProvisioningSrv) planContactPointImport(c *contextmodel.ReqContext, r hcl.DecodedResource, provenance definitions.Provenance) (hclImport, error) {
+	var cp definitions.ContactPoint
+	if err := r.DecodeBody(&cp); err != nil {
+		return hclImport{}, fmt.Errorf("%w: %s", errInvalidHclResource, err)
+	}
+	receiver, err := ContactPointToContactPointExport(cp)
+	if err != nil {
+		return hclImport{}, fmt.Errorf("%w %s.%s: %s", errInvalidHclResource, r.Type, r.Name, err)
+	}
+	desired := make([]definitions.EmbeddedContactPoint, 0, len(receiver.Integrations))
+	for _, integration := range receiver.Integrations {
+		settings, err := simplejson.NewJson(integration.Settings)
+		if err != nil {
+			return hclImport{}, fmt.Errorf("%w %s.%s: %s", errInvalidHclResource, r.Type, r.Name, err)
+		}
+		desired = append(desired, definitions.EmbeddedContactPoint{
+			Name:                  cp.Name,
+			Type:                  integration.Type,
+			Settings:              settings,
+			DisableResolveMessage: integration.DisableResolveMessage,
+		})
+	}
+
+	orgID := c.SignedInUser.GetOrgID()
+	current, err := srv.contactPointService.GetContactPoints(c.Req.Context(), provisioning.ContactPointQuery{Name: cp.Name, OrgID: orgID}, c.SignedInUser)
+	if err != nil {
+		return hclImport{}, err
+	}
+
+	imp := hclImport{
+		result: definitions.ImportedResource{Type: r.Type, Name: r.Name, Action: definitions.ImportActionCreate},
+		apply: func(ctx context.Context) error {
+			// HCL does not contain the UIDs of the integrations, so they are matched in order with the existing integrations of the same type.
+			// The secrets that are redacted in the document keep their current values.
+			used := make([]bool, len(current))
+			for _, integration := range desired {
+				idx := -1
+				for i, existing := range current {
+					if !used[i] && strings.EqualFold(existing.Type, integration.Type) {
+						idx = i
+						break
+					}
+				}
+				if idx < 0 {
+					if _, err := srv.contactPointService.CreateContactPoint(ctx, orgID, c.SignedInUser, integration, alerting_models.Provenance(provenance)); err != nil {
+						return err
+					}
+					continue
+				}
+				used[idx] = true
+				integration.UID = current[idx].UID
+				if err := srv.contactPointService.UpdateContactPoint(ctx, orgID, integration, alerting_models.Provenance(provenance)); err != nil {
+					return err
+				}
+			}
+			for i, existing := range current {
+				if !used[i] {
+					if err := srv.contactPointService.DeleteContactPoint(ctx, orgID, existing.UID); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+	if len(current) == 0 {
+		return imp, nil
+	}
+
+	// Both states are parsed from the settings of the integrations to be compared in the same format.
+	currentExport, err := AlertingFileExportFromEmbeddedContactPoints(orgID, current)
+	if err != nil {
+		return hclImport{}, err
+	}
+	currentContactPoint, err := ContactPointFromContactPointExport(currentExport.ContactPoints[0])
+	if err != nil {
+		return hclImport{}, err
+	}
+	desiredExport, err := AlertingFileExportFromEmbeddedContactPoints(orgID, desired)
+	if err != nil {
+		return hclImport{}, fmt.Errorf("%w %s.%s: %s", errInvalidHclResource, r.Type, r.Name, err)
+	}
+	desiredContactPoint := definitions.ContactPoint{Name: cp.Name}
+	if len(desiredExport.ContactPoints) > 0 {
+		if desiredContactPoint, err = ContactPointFromContactPointExport(desiredExport.ContactPoints[0]); err != nil {
+			return hclImport{}, fmt.Errorf("%w %s.%s: %s", errInvalidHclResource, r.Type, r.Name, err)
+		}
+	}
+	imp.setDiff(diffHclResource(currentContactPoint, desiredContactPoint))
+	return imp, nil
+}
+
+func (srv *ProvisioningSrv) planNotificationPolicyImport(c *contextmodel.ReqContext, r hcl.DecodedResource, provenance definitions.Provenance) (hclImport, error) {
+	var export definitions.RouteExport
+	if err := r.DecodeBody(&export); err != nil {
+		return hclImport{}, fmt.Errorf("%w: %s", errInvalidHclResource, err)
+	}
+	tree, err := RouteFromRouteExport(&export)
+	if err != nil {
+		return hclImport{}, fmt.Errorf("%w %s.%s: %s", errInvalidHclResource, r.Type, r.Name, err)
+	}
+
+	orgID := c.SignedInUser.GetOrgID()
+	current, _, err := srv.policies.GetPolicyTree(c.Req.Context(), orgID)
+	if err != nil {
+		return hclImport{}, err
+	}
+	imp := hclImport{
+		result: definitions.ImportedResource{Type: r.Type, Name: r.Name},
+		apply: func(ctx context.Context) error {
+			_, _, err := srv.policies.UpdatePolicyTree(ctx, orgID, *tree, alerting_models.Provenance(provenance), "")
+			return err
+		},
+	}
+	// The policy tree always exists, so it can only be updated. The fields that cannot be defined in HCL are not compared.
+	imp.setDiff(diffHclResource(RouteExportFromRoute(&current), RouteExportFromRoute(tree),
+		cmpopts.IgnoreFields(definitions.RouteExport{}, "Match", "MatchRE", "Matchers", "ObjectMatchers"),
+	))
+	return imp, nil
+}
+
+func (srv *ProvisioningSrv) planMuteTimingImport(c *contextmodel.ReqContext, r hcl.DecodedResource, provenance definitions.Provenance) (hclImport, error) {
+	var export definitions.MuteTimeIntervalExportHcl
+	if err := r.DecodeBody(&export); err != nil {
+		return hclImport{}, fmt.Errorf("%w: %s", errInvalidHclResource, err)
+	}
+	mt, err := MuteTimingFromMuteTimeIntervalHclExport(export)
+	if err != nil {
+		return hclImport{}, fmt.Errorf("%w %s.%s: %s", errInvalidHclResource, r.Type, r.Name, err)
+	}
+	mt.Provenance = provenance
+
+	orgID := c.SignedInUser.GetOrgID()
+	current, err := srv.muteTimings.GetMuteTiming(c.Req.Context(), mt.Name, orgID)
+	if errors.Is(err, provisioning.ErrTimeIntervalNotFound) {
+		return hclImport{
+			result: definitions.ImportedResource{Type: r.Type, Name: r.Name, Action: definitions.ImportActionCreate},
+			apply: func(ctx context.Context) error {
+				_, err := srv.muteTimings.CreateMuteTiming(ctx, mt, orgID)
+				return err
+			},
+		}, nil
+	}
+	if err != nil {
+		return hclImport{}, err
+	}
+
+	imp := hclImport{
+		result: definitions.ImportedResource{Type: r.Type, Name: r.Name},
+		apply: func(ctx context.Context) error {
+			_, err := srv.muteTimings.UpdateMuteTiming(ctx, mt, orgID)
+			return err
+		},
+	}
+	// Both states are converted to the HCL model to be compared in the same format.
+	currentExport, err := MuteTimingIntervalToMuteTimeIntervalHclExport(MuteTimeIntervalExportFromMuteTiming(orgID, current))
+	if err != nil {
+		return hclImport{}, err
+	}
+	desiredExport, err := MuteTimingIntervalToMuteTimeIntervalHclExport(MuteTimeIntervalExportFromMuteTiming(orgID, mt))
+	if err != nil {
+		return hclImport{}, fmt.Errorf("%w %s.%s: %s", errInvalidHclResource, r.Type, r.Name, err)
+	}
+	imp.setDiff(diffHclResource(currentExport, desiredExport))
+	return imp, nil
+}
+
+// setDiff sets the changes of an existing resource, and whether it needs to be updated.
+func (imp *hclImport) setDiff(diff []definitions.ImportFieldDiff) {
+	imp.result.Diff = diff
+	imp.result.Action = definitions.ImportActionUpdate
+	if len(diff) == 0 {
+		imp.result.Action = definitions.ImportActionNone
+	}
+}
+
+// diffHclResource returns the fields that differ between the current and the desired state of a resource. Nil and empty collections are equal.
+func diffHclResource(current, desired any, opts ...cmp.Option) []definitions.ImportFieldDiff {
+	var reporter cmputil.DiffReporter
+	opts = append(opts, cmpopts.EquateEmpty(), cmp.Reporter(&reporter))
+	if cmp.Equal(current, desired, opts...) {
+		return nil
+	}
+	result := make([]definitions.ImportFieldDiff, 0, len(reporter.Diffs))
+	for _, d := range reporter.Diffs {
+		result = append(result, definitions.ImportFieldDiff{
+			Path:    d.Path,
+			Current: diffValue(d.Left),
+			Desired: diffValue(d.Right),
+		})
+	}
+	return result
+}
+
+func diffValue(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+)
+
+func TestRoutePostImportHcl(t *testing.T) {
+	importHcl := func(t *testing.T, sut ProvisioningSrv, body definitions.ImportHcl) (int, definitions.ImportHclResult) {
+		t.Helper()
+		rc := createTestRequestCtx()
+		response := sut.RoutePostImportHcl(&rc, body)
+		var result definitions.ImportHclResult
+		if response.Status() == http.StatusOK {
+			require.NoError(t, json.Unmarshal(response.Body(), &result))
+		}
+		return response.Status(), result
+	}
+	exportGroup := func(t *testing.T, sut ProvisioningSrv) string {
+		t.Helper()
+		rc := createTestRequestCtx()
+		rc.Context.Req.Form.Set("format", "hcl")
+		response := sut.RouteGetAlertRuleGroupExport(&rc, "folder-uid", "my-cool-group")
+		require.Equal(t, http.StatusOK, response.Status())
+		return string(response.Body())
+	}
+	createRule := func(title string) definitions.ProvisionedAlertRule {
+		rule := createTestAlertRule(title, 1)
+		rule.For = model.Duration(time.Minute)
+		rule.Data[0].RelativeTimeRange = definitions.RelativeTimeRange{From: definitions.Duration(time.Minute)}
+		return rule
+	}
+
+	t.Run("rule group exported to HCL is not changed", func(t *testing.T) {
+		sut := createProvisioningSrvSut(t)
+		insertRule(t, sut, createRule("rule1"))
+		insertRule(t, sut, createRule("rule2"))
+
+		status, result := importHcl(t, sut, definitions.ImportHcl{Hcl: exportGroup(t, sut)})
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, result.Resources, 1)
+		require.Equal(t, hclRuleGroupResource, result.Resources[0].Type)
+		require.Equal(t, definitions.ImportActionNone, result.Resources[0].Action)
+		require.Empty(t, result.Resources[0].Diff)
+	})
+
+	t.Run("dry run of edited rule group returns the diff", func(t *testing.T) {
+		sut := createProvisioningSrvSut(t)
+		insertRule(t, sut, createRule("rule1"))
+		exported := exportGroup(t, sut)
+		edited := strings.Replace(exported, `for            = "1m"`, `for            = "5m"`, 1)
+		require.NotEqual(t, exported, edited)
+
+		status, result := importHcl(t, sut, definitions.ImportHcl{Hcl: edited, DryRun: true})
+		require.Equal(t, http.StatusOK, status)
+		require.True(t, result.DryRun)
+		require.Len(t, result.Resources, 1)
+		require.Equal(t, definitions.ImportActionUpdate, result.Resources[0].Action)
+		require.Equal(t, []definitions.ImportFieldDiff{{Path: "Rules[0].ForString", Current: "1m", Desired: "5m"}}, result.Resources[0].Diff)
+
+		rc := createTestRequestCtx()
+		rule, _, err := sut.alertRules.GetAlertRule(rc.Req.Context(), rc.SignedInUser, "rule1")
+		require.NoError(t, err)
+		require.Equal(t, time.Minute, rule.For)
+
+		t.Run("and applies it otherwise", func(t *testing.T) {
+			status, result := importHcl(t, sut, definitions.ImportHcl{Hcl: edited})
+			require.Equal(t, http.StatusOK, status)
+			require.Equal(t, definitions.ImportActionUpdate, result.Resources[0].Action)
+
+			rule, _, err := sut.alertRules.GetAlertRule(rc.Req.Context(), rc.SignedInUser, "rule1")
+			require.NoError(t, err)
+			require.Equal(t, 5*time.Minute, rule.For)
+		})
+	})
+
+	t.Run("mute timings are compared with the current ones", func(t *testing.T) {
+		sut := createProvisioningSrvSut(t)
+		rc := createTestRequestCtx()
+		rc.Context.Req.Form.Set("format", "hcl")
+		response := sut.RouteGetMuteTimingExport(&rc, "interval-2")
+		require.Equal(t, http.StatusOK, response.Status())
+		exported := string(response.Body())
+		edited := strings.Replace(exported, `"monday"`, `"tuesday"`, 1)
+		require.NotEqual(t, exported, edited)
+		created := `resource "grafana_mute_timing" "mute_timing_test" {
+  name = "imported-interval"
+
+  intervals {
+    times {
+      start = "10:00"
+      end   = "12:00"
+    }
+    weekdays = ["monday"]
+  }
+}
+`
+
+		status, result := importHcl(t, sut, definitions.ImportHcl{Hcl: exported + edited + created, DryRun: true})
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, result.Resources, 3)
+		require.Equal(t, definitions.ImportActionNone, result.Resources[0].Action)
+		require.Equal(t, definitions.ImportActionUpdate, result.Resources[1].Action)
+		require.Equal(t, []definitions.ImportFieldDiff{{Path: "TimeIntervals[0].Weekdays[0]", Current: "monday", Desired: "tuesday"}}, result.Resources[1].Diff)
+		require.Equal(t, definitions.ImportActionCreate, result.Resources[2].Action)
+
+		_, err := sut.muteTimings.GetMuteTiming(rc.Req.Context(), "imported-interval", 1)
+		require.ErrorIs(t, err, provisioning.ErrTimeIntervalNotFound)
+	})
+
+	t.Run("notification policy is updated", func(t *testing.T) {
+		sut := createProvisioningSrvSut(t)
+		sut.policies = createFakeNotificationPolicyService()
+		doc := `resource "grafana_notification_policy" "notification_policy_1" {
+  contact_point = "default-receiver"
+  group_by      = ["g1"]
+
+  policy {
+    contact_point = "nested-receiver"
+
+    matcher {
+      label = "foo"
+      match = "=~"
+      value = "ba.*"
+    }
+  }
+}
+`
+		status, result := importHcl(t, sut, definitions.ImportHcl{Hcl: doc})
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, definitions.ImportActionUpdate, result.Resources[0].Action)
+		require.NotEmpty(t, result.Resources[0].Diff)
+
+		rc := createTestRequestCtx()
+		tree, _, err := sut.policies.GetPolicyTree(rc.Req.Context(), 1)
+		require.NoError(t, err)
+		require.Equal(t, []string{"g1"}, tree.GroupByStr)
+		require.Nil(t, tree.GroupWait)
+		require.Len(t, tree.Routes, 1)
+		require.Len(t, tree.Routes[0].ObjectMatchers, 1)
+		require.Equal(t, "foo=~\"ba.*\"", tree.Routes[0].ObjectMatchers[0].String())
+	})
+
+	t.Run("invalid documents return 400", func(t *testing.T) {
+		sut := createProvisioningSrvSut(t)
+		testCases := map[string]string{
+			"syntax error":       `resource "grafana_rule_group" "test" {`,
+			"unsupported type":   `resource "grafana_folder" "test" {}`,
+			"missing attributes": `resource "grafana_mute_timing" "test" {}`,
+			"invalid duration":   `resource "grafana_notification_policy" "test" { group_wait = "soon" }`,
+			"two policy trees":   "resource \"grafana_notification_policy\" \"a\" {}\nresource \"grafana_notification_policy\" \"b\" {}",
+			"invalid query model": `resource "grafana_rule_group" "test" {
+  name             = "g"
+  folder_uid       = "folder-uid"
+  interval_seconds = 60
+
+  rule {
+    name = "r"
+
+    data {
+      ref_id         = "A"
+      datasource_uid = ""
+      model          = "{"
+
+      relative_time_range {
+        from = 0
+        to   = 0
+      }
+    }
+  }
+}`,
+		}
+		for name, doc := range testCases {
+			t.Run(name, func(t *testing.T) {
+				status, _ := importHcl(t, sut, definitions.ImportHcl{Hcl: doc})
+				require.Equal(t, http.StatusBadRequest, status)
+			})
+		}
+	})
+}
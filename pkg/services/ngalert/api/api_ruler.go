@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -328,6 +330,162 @@ func (srv RulerSrv) RouteGetRuleByUID(c *contextmodel.ReqContext, ruleUID string
 	return response.JSON(http.StatusOK, result)
 }
 
+// RouteGetRuleVersionsByUID returns all versions of the alert rule with the given UID, the most recent first
+func (srv RulerSrv) RouteGetRuleVersionsByUID(c *contextmodel.ReqContext, ruleUID string) response.Response {
+	ctx := c.Req.Context()
+
+	rule, err := srv.getAuthorizedRuleByUid(ctx, c, ruleUID)
+	if err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return response.Empty(http.StatusNotFound)
+		}
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule by UID", err)
+	}
+
+	versions, err := srv.store.GetAlertRuleVersions(ctx, rule.GetKey())
+	if err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return response.Empty(http.StatusNotFound)
+		}
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule versions", err)
+	}
+
+	result := make(apimodels.GettableRuleVersions, 0, len(versions))
+	for _, version := range versions {
+		result = append(result, toGettableExtendedRuleNode(*version, nil))
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// RouteGetRuleVersionsDiff returns the differences between two versions of the alert rule with the given UID
+func (srv RulerSrv) RouteGetRuleVersionsDiff(c *contextmodel.ReqContext, ruleUID string) response.Response {
+	ctx := c.Req.Context()
+
+	fromVersion, err := strconv.ParseInt(c.Query("from"), 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid value of parameter 'from'")
+	}
+	toVersion, err := strconv.ParseInt(c.Query("to"), 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid value of parameter 'to'")
+	}
+
+	rule, err := srv.getAuthorizedRuleByUid(ctx, c, ruleUID)
+	if err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return response.Empty(http.StatusNotFound)
+		}
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule by UID", err)
+	}
+
+	versions, err := srv.store.GetAlertRuleVersions(ctx, rule.GetKey())
+	if err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return response.Empty(http.StatusNotFound)
+		}
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule versions", err)
+	}
+
+	from := findRuleVersion(versions, fromVersion)
+	if from == nil {
+		return ErrResp(http.StatusNotFound, ngmodels.ErrAlertRuleNotFound, "version %d of the rule does not exist", fromVersion)
+	}
+	to := findRuleVersion(versions, toVersion)
+	if to == nil {
+		return ErrResp(http.StatusNotFound, ngmodels.ErrAlertRuleNotFound, "version %d of the rule does not exist", toVersion)
+	}
+
+	result := apimodels.RuleVersionDiff{
+		From: fromVersion,
+		To:   toVersion,
+		Diff: make([]apimodels.RuleFieldDiff, 0),
+	}
+	for _, d := range from.Diff(to, store.AlertRuleFieldsToIgnoreInDiff[:]...) {
+		result.Diff = append(result.Diff, apimodels.RuleFieldDiff{
+			Path: d.Path,
+			From: diffValue(d.Left),
+			To:   diffValue(d.Right),
+		})
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// RoutePostRestoreRuleVersion restores a previous version of the alert rule with the given UID.
+// The content of the version is saved as a new version of the rule. The rule stays in its current folder and group.
+func (srv RulerSrv) RoutePostRestoreRuleVersion(c *contextmodel.ReqContext, ruleUID string, version string) response.Response {
+	ctx := c.Req.Context()
+
+	restoreVersion, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid version")
+	}
+
+	rule, err := srv.getAuthorizedRuleByUid(ctx, c, ruleUID)
+	if err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return response.Empty(http.StatusNotFound)
+		}
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule by UID", err)
+	}
+
+	versions, err := srv.store.GetAlertRuleVersions(ctx, rule.GetKey())
+	if err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return response.Empty(http.StatusNotFound)
+		}
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule versions", err)
+	}
+	restored := findRuleVersion(versions, restoreVersion)
+	if restored == nil {
+		return ErrResp(http.StatusNotFound, ngmodels.ErrAlertRuleNotFound, "version %d of the rule does not exist", restoreVersion)
+	}
+
+	groupKey := rule.GetGroupKey()
+	group, err := srv.getAuthorizedRuleGroup(ctx, c, groupKey)
+	if err != nil {
+		return errorToResponse(err)
+	}
+
+	rules := make([]*ngmodels.AlertRuleWithOptionals, 0, len(group))
+	for _, r := range group {
+		if r.UID != rule.UID {
+			rules = append(rules, &ngmodels.AlertRuleWithOptionals{AlertRule: *r, HasPause: true})
+			continue
+		}
+		content := ngmodels.CopyRule(restored)
+		// the location of the rule and the fields that are not stored in the version are taken from the current rule
+		content.ID = r.ID
+		content.Version = r.Version
+		content.NamespaceUID = r.NamespaceUID
+		content.RuleGroup = r.RuleGroup
+		content.RuleGroupIndex = r.RuleGroupIndex
+		content.IntervalSeconds = r.IntervalSeconds
+		content.DashboardUID = r.DashboardUID
+		content.PanelID = r.PanelID
+		rules = append(rules, &ngmodels.AlertRuleWithOptionals{AlertRule: *content, HasPause: true})
+	}
+
+	return srv.updateAlertRulesInGroup(c, groupKey, rules)
+}
+
+// findRuleVersion returns the version of the rule with the given number, or nil if it does not exist
+func findRuleVersion(versions []*ngmodels.AlertRule, version int64) *ngmodels.AlertRule {
+	for _, v := range versions {
+		if v.Version == version {
+			return v
+		}
+	}
+	return nil
+}
+
+// diffValue returns the value of one side of a difference, or nil if the field does not exist on that side
+func diffValue(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
 func (srv RulerSrv) RoutePostNameRulesConfig(c *contextmodel.ReqContext, ruleGroupConfig apimodels.PostableRuleGroupConfig, namespaceUID string) response.Response {
 	namespace, err := srv.store.GetNamespaceByUID(c.Req.Context(), namespaceUID, c.SignedInUser.GetOrgID(), c.SignedInUser)
 	if err != nil {
@@ -420,6 +578,7 @@ func (srv RulerSrv) updateAlertRulesInGroup(c *contextmodel.ReqContext, groupKey
 		}
 
 		finalChanges = store.UpdateCalculatedRuleFields(groupChanges)
+		updatedBy := util.Pointer(ngmodels.UserUID(c.SignedInUser.GetUID()))
 		logger.Debug("Updating database with the authorized changes", "add", len(finalChanges.New), "update", len(finalChanges.New), "delete", len(finalChanges.Delete))
 
 		// Delete first as this could prevent future unique constraint violations.
@@ -438,6 +597,7 @@ func (srv RulerSrv) updateAlertRulesInGroup(c *contextmodel.ReqContext, groupKey
 			updates := make([]ngmodels.UpdateRule, 0, len(finalChanges.Update))
 			for _, update := range finalChanges.Update {
 				logger.Debug("Updating rule", "rule_uid", update.New.UID, "diff", update.Diff.String())
+				update.New.UpdatedBy = updatedBy
 				updates = append(updates, ngmodels.UpdateRule{
 					Existing: update.Existing,
 					New:      *update.New,
@@ -452,6 +612,7 @@ func (srv RulerSrv) updateAlertRulesInGroup(c *contextmodel.ReqContext, groupKey
 		if len(finalChanges.New) > 0 {
 			inserts := make([]ngmodels.AlertRule, 0, len(finalChanges.New))
 			for _, rule := range finalChanges.New {
+				rule.UpdatedBy = updatedBy
 				inserts = append(inserts, *rule)
 			}
 			added, err := srv.store.InsertAlertRules(tranCtx, inserts)
@@ -578,12 +739,19 @@ func toGettableExtendedRuleNode(r ngmodels.AlertRule, provenanceRecords map[stri
 			Metadata:             AlertRuleMetadataFromModelMetadata(r.Metadata),
 		},
 	}
+	if r.UpdatedBy != nil {
+		gettableExtendedRuleNode.GrafanaManagedAlert.UpdatedBy = string(*r.UpdatedBy)
+	}
 	forDuration := model.Duration(r.For)
 	gettableExtendedRuleNode.ApiRuleNode = &apimodels.ApiRuleNode{
 		For:         &forDuration,
 		Annotations: r.Annotations,
 		Labels:      r.Labels,
 	}
+	if r.KeepFiringFor > 0 {
+		keepFiringFor := model.Duration(r.KeepFiringFor)
+		gettableExtendedRuleNode.ApiRuleNode.KeepFiringFor = &keepFiringFor
+	}
 	return gettableExtendedRuleNode
 }
 
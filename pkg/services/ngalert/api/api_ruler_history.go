@@ -2,15 +2,21 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/common/model"
+
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/infra/log"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state/historian"
 )
 
 type Historian interface {
@@ -24,7 +30,131 @@ type HistorySrv struct {
 
 const labelQueryPrefix = "labels_"
 
+// defaultStateHistoryStatsRange is the time range of the statistics of state history if the request does not define it.
+const defaultStateHistoryStatsRange = 6 * time.Hour
+
+// The statistics of state history are computed from all the entries of the time range, which are read in pages of
+// stateHistoryStatsPageSize entries, from the most recent. At most stateHistoryStatsMaxPages pages are read, the statistics
+// are marked as truncated if there are more entries.
+const (
+	stateHistoryStatsPageSize = 5000
+	stateHistoryStatsMaxPages = 20
+)
+
 func (srv *HistorySrv) RouteQueryStateHistory(c *contextmodel.ReqContext) response.Response {
+	query, err := parseHistoryQuery(c)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	frame, err := srv.hist.Query(c.Req.Context(), query)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	return response.JSON(http.StatusOK, frame)
+}
+
+func (srv *HistorySrv) RouteQueryStateHistoryStats(c *contextmodel.ReqContext) response.Response {
+	query, err := parseHistoryQuery(c)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	// The time spent in each state is computed over the whole time range, so it must be known.
+	if c.QueryInt64("to") == 0 {
+		query.To = time.Now()
+	}
+	if c.QueryInt64("from") == 0 {
+		query.From = query.To.Add(-defaultStateHistoryStatsRange)
+	}
+	buckets, err := getHistogramBucketsFromQuery(c.QueryStrings("bucket"))
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	statsQuery := models.HistoryStatsQuery{
+		HistoryQuery: query,
+		GroupBy:      c.QueryStrings("groupBy"),
+		Buckets:      buckets,
+	}
+
+	frame, truncated, err := srv.queryAllStateHistory(c.Req.Context(), query)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	groups, err := historian.AggregateStateHistory(frame, statsQuery)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to aggregate state history")
+	}
+	result := stateHistoryStatsFromGroups(groups, buckets)
+	result.Truncated = truncated
+	return response.JSON(http.StatusOK, result)
+}
+
+// queryAllStateHistory reads the state history of the whole time range of the query in pages, from the most recent entries.
+// Each next page ends at the oldest entry of the previous one, whose entries at that time are read again with the next page
+// so that none is skipped when several entries have the same time. It returns true if not all entries could be read.
+func (srv *HistorySrv) queryAllStateHistory(ctx context.Context, query models.HistoryQuery) (*data.Frame, bool, error) {
+	query.Limit = stateHistoryStatsPageSize
+	var result *data.Frame
+	for page := 0; ; page++ {
+		frame, err := srv.hist.Query(ctx, query)
+		if err != nil {
+			return nil, false, err
+		}
+		if frame == nil {
+			return result, false, nil
+		}
+		if result == nil {
+			result = frame.EmptyCopy()
+		}
+
+		times, _ := frame.FieldByName("time")
+		if times == nil || frame.Rows() < stateHistoryStatsPageSize {
+			return result, false, appendFrameRows(result, frame, time.Time{})
+		}
+		oldest, ok := oldestTime(times)
+		// The page cannot be moved back if all its entries have the same time, or are outside the time range.
+		if !ok || !oldest.After(query.From) || !oldest.Add(time.Nanosecond).Before(query.To) || page+1 >= stateHistoryStatsMaxPages {
+			return result, true, appendFrameRows(result, frame, time.Time{})
+		}
+		if err := appendFrameRows(result, frame, oldest); err != nil {
+			return nil, false, err
+		}
+		query.To = oldest.Add(time.Nanosecond)
+	}
+}
+
+// oldestTime returns the oldest time of a time field.
+func oldestTime(times *data.Field) (time.Time, bool) {
+	var oldest time.Time
+	for i := 0; i < times.Len(); i++ {
+		t, ok := times.At(i).(time.Time)
+		if !ok {
+			return time.Time{}, false
+		}
+		if i == 0 || t.Before(oldest) {
+			oldest = t
+		}
+	}
+	return oldest, times.Len() > 0
+}
+
+// appendFrameRows appends the rows of the frame to the result, except the rows at or before the time after if it is set.
+func appendFrameRows(result, frame *data.Frame, after time.Time) error {
+	if len(result.Fields) != len(frame.Fields) {
+		return fmt.Errorf("pages of state history have different fields")
+	}
+	times, _ := frame.FieldByName("time")
+	for i := 0; i < frame.Rows(); i++ {
+		if !after.IsZero() {
+			if t, ok := times.At(i).(time.Time); ok && !t.After(after) {
+				continue
+			}
+		}
+		result.AppendRow(frame.RowCopy(i)...)
+	}
+	return nil
+}
+
+func parseHistoryQuery(c *contextmodel.ReqContext) (models.HistoryQuery, error) {
 	from := c.QueryInt64("from")
 	to := c.QueryInt64("to")
 	limit := c.QueryInt("limit")
@@ -38,8 +168,12 @@ func (srv *HistorySrv) RouteQueryStateHistory(c *contextmodel.ReqContext) respon
 			labels[k[len(labelQueryPrefix):]] = v[0]
 		}
 	}
+	matchers, err := getMatchersFromQuery(c.Req.URL.Query())
+	if err != nil {
+		return models.HistoryQuery{}, fmt.Errorf("invalid matcher: %w", err)
+	}
 
-	query := models.HistoryQuery{
+	return models.HistoryQuery{
 		RuleUID:      ruleUID,
 		OrgID:        c.SignedInUser.GetOrgID(),
 		DashboardUID: dashUID,
@@ -49,10 +183,65 @@ func (srv *HistorySrv) RouteQueryStateHistory(c *contextmodel.ReqContext) respon
 		To:           time.Unix(to, 0),
 		Limit:        limit,
 		Labels:       labels,
+		Matchers:     matchers,
+	}, nil
+}
+
+func getHistogramBucketsFromQuery(values []string) ([]time.Duration, error) {
+	if len(values) == 0 {
+		return models.DefaultHistoryStatsBuckets, nil
 	}
-	frame, err := srv.hist.Query(c.Req.Context(), query)
-	if err != nil {
-		return ErrResp(http.StatusInternalServerError, err, "")
+	buckets := make([]time.Duration, 0, len(values))
+	for _, v := range values {
+		d, err := model.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bucket %q: %w", v, err)
+		}
+		buckets = append(buckets, time.Duration(d))
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i] < buckets[j]
+	})
+	return buckets, nil
+}
+
+func stateHistoryStatsFromGroups(groups []models.HistoryStatsGroup, buckets []time.Duration) apimodels.StateHistoryStats {
+	result := apimodels.StateHistoryStats{
+		Buckets: make([]float64, 0, len(buckets)),
+		Groups:  make([]apimodels.StateHistoryStatsGroup, 0, len(groups)),
+	}
+	for _, b := range buckets {
+		result.Buckets = append(result.Buckets, b.Seconds())
+	}
+	for _, g := range groups {
+		group := apimodels.StateHistoryStatsGroup{
+			Labels:                g.Labels,
+			StateHistoryAggregate: stateHistoryAggregateFromStats(g.HistoryStats),
+			Series:                make([]apimodels.StateHistorySeriesStats, 0, len(g.Series)),
+		}
+		for _, s := range g.Series {
+			group.Series = append(group.Series, apimodels.StateHistorySeriesStats{
+				RuleUID:               s.RuleUID,
+				Labels:                s.Labels,
+				StateHistoryAggregate: stateHistoryAggregateFromStats(s.HistoryStats),
+			})
+		}
+		result.Groups = append(result.Groups, group)
+	}
+	return result
+}
+
+func stateHistoryAggregateFromStats(s models.HistoryStats) apimodels.StateHistoryAggregate {
+	timeInState := make(map[string]float64, len(s.TimeInState))
+	for k, v := range s.TimeInState {
+		timeInState[k] = v.Seconds()
+	}
+	return apimodels.StateHistoryAggregate{
+		Transitions:        s.Transitions,
+		TransitionsByState: s.TransitionsByState,
+		TimeInState:        timeInState,
+		Resolved:           s.Resolved,
+		MTTR:               s.MTTR().Seconds(),
+		FiringHistogram:    s.FiringHistogram,
 	}
-	return response.JSON(http.StatusOK, frame)
 }
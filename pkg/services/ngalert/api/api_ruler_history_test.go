@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/infra/log"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+type fakeHistorian struct {
+	frame *data.Frame
+	query models.HistoryQuery
+}
+
+func (f *fakeHistorian) Query(_ context.Context, query models.HistoryQuery) (*data.Frame, error) {
+	f.query = query
+	return f.frame, nil
+}
+
+func TestRouteQueryStateHistoryStats(t *testing.T) {
+	start := time.Unix(3600, 0)
+	lbls := data.Labels{"from": "state-history", "ruleUID": "rule-1"}
+	hist := &fakeHistorian{frame: data.NewFrame("states",
+		data.NewField("time", lbls, []time.Time{start.Add(time.Hour), start.Add(2 * time.Hour)}),
+		data.NewField("text", lbls, []string{"rule {team=db} - A=1.000000", "rule {team=db} - A=0.000000"}),
+		data.NewField("prev", lbls, []string{"Normal", "Alerting"}),
+		data.NewField("next", lbls, []string{"Alerting", "Normal"}),
+		data.NewField("data", lbls, []string{"{}", "{}"}),
+	)}
+	srv := &HistorySrv{logger: log.NewNopLogger(), hist: hist}
+
+	request := func(values url.Values) response.Response {
+		rc := createRequestContext(1, nil)
+		rc.Req.URL.RawQuery = values.Encode()
+		rc.Req.Form = values
+		return srv.RouteQueryStateHistoryStats(rc)
+	}
+
+	t.Run("should aggregate history by the group labels", func(t *testing.T) {
+		r := request(url.Values{
+			"ruleUID": {"rule-1"},
+			"from":    {"3600"},
+			"to":      {"18000"},
+			"groupBy": {"team"},
+			"bucket":  {"2h", "30m"},
+			"matcher": {`{"name":"team","value":"db|web","isRegex":true,"isEqual":true}`},
+		})
+		require.Equal(t, http.StatusOK, r.Status())
+		require.Equal(t, "rule-1", hist.query.RuleUID)
+		require.Equal(t, labels.Matchers{labels.MustNewMatcher(labels.MatchRegexp, "team", "db|web")}.String(), hist.query.Matchers.String())
+
+		var stats apimodels.StateHistoryStats
+		require.NoError(t, json.Unmarshal(r.Body(), &stats))
+		require.Equal(t, []float64{1800, 7200}, stats.Buckets)
+		require.Len(t, stats.Groups, 1)
+		g := stats.Groups[0]
+		require.Equal(t, map[string]string{"team": "db"}, g.Labels)
+		require.Equal(t, 2, g.Transitions)
+		require.Equal(t, 1, g.Resolved)
+		require.Equal(t, float64(3600), g.MTTR)
+		require.Equal(t, []int{0, 1, 0}, g.FiringHistogram)
+		require.Equal(t, map[string]float64{"Normal": 10800, "Alerting": 3600}, g.TimeInState)
+		require.Len(t, g.Series, 1)
+		require.Equal(t, "rule-1", g.Series[0].RuleUID)
+	})
+
+	t.Run("should reject invalid matchers", func(t *testing.T) {
+		r := request(url.Values{"matcher": {"team=db"}})
+		require.Equal(t, http.StatusBadRequest, r.Status())
+	})
+
+	t.Run("should reject invalid buckets", func(t *testing.T) {
+		r := request(url.Values{"bucket": {"soon"}})
+		require.Equal(t, http.StatusBadRequest, r.Status())
+	})
+}
+
+// pagingHistorian returns the most recent entries of the time range of the query, up to its limit, like the Loki backend.
+type pagingHistorian struct {
+	times   []time.Time
+	queries int
+}
+
+func (f *pagingHistorian) Query(_ context.Context, query models.HistoryQuery) (*data.Frame, error) {
+	f.queries++
+	var times []time.Time
+	for i := len(f.times) - 1; i >= 0 && len(times) < query.Limit; i-- {
+		if !f.times[i].Before(query.From) && f.times[i].Before(query.To) {
+			times = append([]time.Time{f.times[i]}, times...)
+		}
+	}
+	texts, prev, next, values := make([]string, 0, len(times)), make([]string, 0, len(times)), make([]string, 0, len(times)), make([]string, 0, len(times))
+	for range times {
+		texts = append(texts, "rule {team=db} - A=1.000000")
+		prev = append(prev, "Normal")
+		next = append(next, "Alerting")
+		values = append(values, "{}")
+	}
+	lbls := data.Labels{"from": "state-history", "ruleUID": "rule-1"}
+	return data.NewFrame("states",
+		data.NewField("time", lbls, times),
+		data.NewField("text", lbls, texts),
+		data.NewField("prev", lbls, prev),
+		data.NewField("next", lbls, next),
+		data.NewField("data", lbls, values),
+	), nil
+}
+
+func TestRouteQueryStateHistoryStatsPaging(t *testing.T) {
+	request := func(hist Historian) apimodels.StateHistoryStats {
+		srv := &HistorySrv{logger: log.NewNopLogger(), hist: hist}
+		values := url.Values{"ruleUID": {"rule-1"}, "from": {"1"}, "to": {"100000"}}
+		rc := createRequestContext(1, nil)
+		rc.Req.URL.RawQuery = values.Encode()
+		rc.Req.Form = values
+		r := srv.RouteQueryStateHistoryStats(rc)
+		require.Equal(t, http.StatusOK, r.Status())
+		var stats apimodels.StateHistoryStats
+		require.NoError(t, json.Unmarshal(r.Body(), &stats))
+		return stats
+	}
+
+	t.Run("should read all the entries of the time range", func(t *testing.T) {
+		hist := &pagingHistorian{}
+		for i := 0; i < 2*stateHistoryStatsPageSize+10; i++ {
+			// two entries per second so that pages end in the middle of a second
+			hist.times = append(hist.times, time.Unix(int64(10+i/2), 0))
+		}
+		stats := request(hist)
+		require.False(t, stats.Truncated)
+		require.Len(t, stats.Groups, 1)
+		require.Equal(t, len(hist.times), stats.Groups[0].Transitions)
+		require.Equal(t, 3, hist.queries)
+	})
+
+	t.Run("should mark the statistics as truncated when the entries cannot be paged", func(t *testing.T) {
+		hist := &pagingHistorian{}
+		for i := 0; i < stateHistoryStatsPageSize+10; i++ {
+			hist.times = append(hist.times, time.Unix(10, 0))
+		}
+		stats := request(hist)
+		require.True(t, stats.Truncated)
+		require.Equal(t, stateHistoryStatsPageSize, stats.Groups[0].Transitions)
+		require.Equal(t, 1, hist.queries)
+	})
+}
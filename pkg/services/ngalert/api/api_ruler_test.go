@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/infra/log"
 	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
@@ -34,6 +35,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util"
 	"github.com/grafana/grafana/pkg/util/cmputil"
 	"github.com/grafana/grafana/pkg/web"
 )
@@ -379,6 +381,174 @@ func TestRouteGetRuleByUID(t *testing.T) {
 	})
 }
 
+func TestRouteGetRuleVersionsByUID(t *testing.T) {
+	orgID := rand.Int63()
+	folder := randFolder()
+	ruleStore := fakes.NewRuleStore(t)
+	ruleStore.Folders[orgID] = append(ruleStore.Folders[orgID], folder)
+	gen := models.RuleGen.With(models.RuleGen.WithOrgID(orgID), models.RuleGen.WithNamespaceUID(folder.UID))
+
+	rule := gen.With(withVersion(2)).GenerateRef()
+	ruleStore.PutRule(context.Background(), rule)
+	previous := models.CopyRule(rule, withVersion(1), gen.WithTitle("previous"))
+	ruleStore.History[rule.GetKey()] = []*models.AlertRule{
+		models.CopyRule(rule, func(r *models.AlertRule) { r.UpdatedBy = util.Pointer(models.UserUID("user:current")) }),
+		previous,
+	}
+	perms := createPermissionsForRules([]*models.AlertRule{rule}, orgID)
+
+	t.Run("returns versions of the rule", func(t *testing.T) {
+		req := createRequestContextWithPerms(orgID, perms, nil)
+		response := createService(ruleStore).RouteGetRuleVersionsByUID(req, rule.UID)
+
+		require.Equal(t, http.StatusOK, response.Status())
+		var result apimodels.GettableRuleVersions
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.Len(t, result, 2)
+		require.Equal(t, int64(2), result[0].GrafanaManagedAlert.Version)
+		require.Equal(t, "user:current", result[0].GrafanaManagedAlert.UpdatedBy)
+		require.Equal(t, int64(1), result[1].GrafanaManagedAlert.Version)
+		require.Equal(t, "previous", result[1].GrafanaManagedAlert.Title)
+	})
+
+	t.Run("returns 403 if user is not authorized to read the rule", func(t *testing.T) {
+		req := createRequestContextWithPerms(orgID, map[int64]map[string][]string{}, nil)
+		response := createService(ruleStore).RouteGetRuleVersionsByUID(req, rule.UID)
+
+		require.Equal(t, http.StatusForbidden, response.Status())
+	})
+
+	t.Run("returns 404 if rule does not exist", func(t *testing.T) {
+		req := createRequestContextWithPerms(orgID, perms, nil)
+		response := createService(ruleStore).RouteGetRuleVersionsByUID(req, "foobar")
+
+		require.Equal(t, http.StatusNotFound, response.Status())
+	})
+}
+
+func TestRouteGetRuleVersionsDiff(t *testing.T) {
+	orgID := rand.Int63()
+	folder := randFolder()
+	ruleStore := fakes.NewRuleStore(t)
+	ruleStore.Folders[orgID] = append(ruleStore.Folders[orgID], folder)
+	gen := models.RuleGen.With(models.RuleGen.WithOrgID(orgID), models.RuleGen.WithNamespaceUID(folder.UID))
+
+	rule := gen.With(withVersion(2), gen.WithTitle("current")).GenerateRef()
+	ruleStore.PutRule(context.Background(), rule)
+	ruleStore.History[rule.GetKey()] = []*models.AlertRule{
+		models.CopyRule(rule),
+		models.CopyRule(rule, withVersion(1), gen.WithTitle("previous")),
+	}
+	perms := createPermissionsForRules([]*models.AlertRule{rule}, orgID)
+
+	diff := func(from, to string) response.Response {
+		req := createRequestContextWithPerms(orgID, perms, nil)
+		req.Req.Form.Set("from", from)
+		req.Req.Form.Set("to", to)
+		return createService(ruleStore).RouteGetRuleVersionsDiff(req, rule.UID)
+	}
+
+	t.Run("returns changed fields", func(t *testing.T) {
+		response := diff("1", "2")
+
+		require.Equal(t, http.StatusOK, response.Status())
+		result := apimodels.RuleVersionDiff{}
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.Equal(t, int64(1), result.From)
+		require.Equal(t, int64(2), result.To)
+		require.Equal(t, []apimodels.RuleFieldDiff{{Path: "Title", From: "previous", To: "current"}}, result.Diff)
+	})
+
+	t.Run("returns empty diff for the same version", func(t *testing.T) {
+		response := diff("2", "2")
+
+		require.Equal(t, http.StatusOK, response.Status())
+		result := apimodels.RuleVersionDiff{}
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.Empty(t, result.Diff)
+	})
+
+	t.Run("returns 400 if version is not a number", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, diff("1", "latest").Status())
+		require.Equal(t, http.StatusBadRequest, diff("", "2").Status())
+	})
+
+	t.Run("returns 404 if version does not exist", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, diff("1", "3").Status())
+	})
+}
+
+func TestRoutePostRestoreRuleVersion(t *testing.T) {
+	orgID := rand.Int63()
+	folder := randFolder()
+	ruleStore := fakes.NewRuleStore(t)
+	ruleStore.Folders[orgID] = append(ruleStore.Folders[orgID], folder)
+	groupKey := models.GenerateGroupKey(orgID)
+	groupKey.NamespaceUID = folder.UID
+	gen := models.RuleGen.With(models.RuleGen.WithGroupKey(groupKey), models.RuleGen.WithIntervalSeconds(60))
+
+	rules := gen.With(gen.WithUniqueGroupIndex(), gen.WithUniqueID(), withVersion(2)).GenerateManyRef(2)
+	ruleStore.PutRule(context.Background(), rules...)
+	rule := rules[0]
+	previous := models.CopyRule(rule, withVersion(1), gen.WithTitle("previous"), func(r *models.AlertRule) {
+		r.RuleGroup = "old-group"
+		r.IntervalSeconds = 10
+	})
+	ruleStore.History[rule.GetKey()] = []*models.AlertRule{models.CopyRule(rule), previous}
+
+	perms := createPermissionsForRules(rules, orgID)
+	perms[orgID][ac.ActionAlertingRuleUpdate] = []string{dashboards.ScopeFoldersProvider.GetResourceScopeUID(folder.UID)}
+
+	restore := func(version string) response.Response {
+		req := createRequestContextWithPerms(orgID, perms, nil)
+		req.SignedInUser.UserID = 1
+		req.SignedInUser.UserUID = "test"
+		svc := createService(ruleStore)
+		svc.conditionValidator = &recordingConditionValidator{}
+		return svc.RoutePostRestoreRuleVersion(req, rule.UID, version)
+	}
+
+	t.Run("saves the content of the version as a new version", func(t *testing.T) {
+		ruleStore.RecordedOps = nil
+		response := restore("1")
+
+		require.Equal(t, http.StatusAccepted, response.Status())
+		result := apimodels.UpdateRuleGroupResponse{}
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.Equal(t, []string{rule.UID}, result.Updated)
+		require.Empty(t, result.Created)
+		require.Empty(t, result.Deleted)
+
+		var updates []models.UpdateRule
+		for _, op := range ruleStore.GetRecordedCommands(func(cmd any) (any, bool) {
+			u, ok := cmd.([]models.UpdateRule)
+			return u, ok
+		}) {
+			updates = append(updates, op.([]models.UpdateRule)...)
+		}
+		require.Len(t, updates, 1)
+		updated := updates[0].New
+		require.Equal(t, "previous", updated.Title)
+		require.Equal(t, rule.RuleGroup, updated.RuleGroup)
+		require.Equal(t, rule.IntervalSeconds, updated.IntervalSeconds)
+		require.Equal(t, models.UserUID("user:test"), *updated.UpdatedBy)
+	})
+
+	t.Run("returns 400 if version is not a number", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, restore("latest").Status())
+	})
+
+	t.Run("returns 404 if version does not exist", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, restore("3").Status())
+	})
+}
+
+func withVersion(version int64) models.AlertRuleMutator {
+	return func(rule *models.AlertRule) {
+		rule.Version = version
+	}
+}
+
 func TestRouteGetRulesConfig(t *testing.T) {
 	gen := models.RuleGen
 	t.Run("fine-grained access is enabled", func(t *testing.T) {
@@ -3,6 +3,7 @@ package api
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -155,6 +156,11 @@ func validateAlertingRuleFields(in *apimodels.PostableExtendedRuleNode, newRule
 		return ngmodels.AlertRule{}, err
 	}
 
+	newRule.KeepFiringFor, err = validateKeepFiringForInterval(in)
+	if err != nil {
+		return ngmodels.AlertRule{}, err
+	}
+
 	return newRule, nil
 }
 
@@ -183,6 +189,7 @@ func validateRecordingRuleFields(in *apimodels.PostableExtendedRuleNode, newRule
 	newRule.ExecErrState = ""
 	newRule.Condition = ""
 	newRule.For = 0
+	newRule.KeepFiringFor = 0
 	newRule.NotificationSettings = nil
 
 	return newRule, nil
@@ -270,6 +277,21 @@ func validateForInterval(ruleNode *apimodels.PostableExtendedRuleNode) (time.Dur
 	return duration, nil
 }
 
+// validateKeepFiringForInterval validates ApiRuleNode.KeepFiringFor and converts it to time.Duration. If the field is not specified returns 0 if GrafanaManagedAlert.UID is empty and -1 if it is not.
+func validateKeepFiringForInterval(ruleNode *apimodels.PostableExtendedRuleNode) (time.Duration, error) {
+	if ruleNode.ApiRuleNode == nil || ruleNode.ApiRuleNode.KeepFiringFor == nil {
+		if ruleNode.GrafanaManagedAlert.UID != "" {
+			return -1, nil // will be patched later with the real value of the current version of the rule
+		}
+		return 0, nil // if it's a new rule, use the 0 as the default
+	}
+	duration := time.Duration(*ruleNode.ApiRuleNode.KeepFiringFor)
+	if duration < 0 {
+		return 0, fmt.Errorf("field `keep_firing_for` cannot be negative [%v]. 0 or any positive duration are allowed", *ruleNode.ApiRuleNode.KeepFiringFor)
+	}
+	return duration, nil
+}
+
 // ValidateRuleGroup validates API model (definitions.PostableRuleGroupConfig) and converts it to a collection of models.AlertRule.
 // Returns a slice that contains all rules described by API model or error if either group specification or an alert definition is not valid.
 // It also returns a map containing current existing alerts that don't contain the is_paused field in the body of the call.
@@ -312,6 +334,9 @@ func ValidateRuleGroup(
 			}
 			uids[rule.UID] = idx
 		}
+		if err := validateRuleDependencies(rule, result); err != nil {
+			return nil, fmt.Errorf("invalid rule specification at index [%d]: %w", idx, err)
+		}
 
 		var hasPause, isPaused bool
 		original := ruleGroupConfig.Rules[idx]
@@ -333,6 +358,29 @@ func ValidateRuleGroup(
 	return result, nil
 }
 
+// validateRuleDependencies checks that the input queries of the rule refer to recording rules that precede it in the group.
+func validateRuleDependencies(rule *ngmodels.AlertRule, preceding []*ngmodels.AlertRuleWithOptionals) error {
+	for _, q := range rule.Data {
+		if !q.IsInput() {
+			continue
+		}
+		uid, err := q.GetInputRuleUID()
+		if err != nil {
+			return fmt.Errorf("%w: input query '%s' is invalid: %w", ngmodels.ErrAlertRuleFailedValidation, q.RefID, err)
+		}
+		idx := slices.IndexFunc(preceding, func(r *ngmodels.AlertRuleWithOptionals) bool {
+			return r.UID == uid
+		})
+		if idx < 0 {
+			return fmt.Errorf("%w: input query '%s' refers to rule '%s' that does not precede it in the group", ngmodels.ErrAlertRuleFailedValidation, q.RefID, uid)
+		}
+		if preceding[idx].Type() != ngmodels.RuleTypeRecording {
+			return fmt.Errorf("%w: input query '%s' refers to rule '%s' that is not a recording rule", ngmodels.ErrAlertRuleFailedValidation, q.RefID, uid)
+		}
+	}
+	return nil
+}
+
 func validateNotificationSettings(n *apimodels.AlertRuleNotificationSettings) ([]ngmodels.NotificationSettings, error) {
 	s := ngmodels.NotificationSettings{
 		Receiver:          n.Receiver,
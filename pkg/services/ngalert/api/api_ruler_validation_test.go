@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"path"
 	"strconv"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/rand"
 
+	"github.com/grafana/grafana/pkg/expr"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/folder"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
@@ -239,6 +241,38 @@ func TestValidateRuleGroup(t *testing.T) {
 			require.True(t, alert.HasPause)
 		}
 	})
+
+	t.Run("should validate that input queries refer to preceding recording rules", func(t *testing.T) {
+		recordingLimits := *allowRecording(limits)
+		recording := validRule()
+		recording.GrafanaManagedAlert.Record = &apimodels.Record{Metric: "some_metric", From: "A"}
+		alerting := validRule()
+		dependentOn := func(uid string) apimodels.PostableExtendedRuleNode {
+			r := validRule()
+			r.GrafanaManagedAlert.Data = append(r.GrafanaManagedAlert.Data, apimodels.AlertQuery{
+				RefID:         "B",
+				DatasourceUID: expr.InputDatasourceUID,
+				Model:         json.RawMessage(fmt.Sprintf(`{"ruleUid": %q}`, uid)),
+			})
+			return r
+		}
+
+		g := validGroup(cfg, recording, dependentOn(recording.GrafanaManagedAlert.UID))
+		_, err := ValidateRuleGroup(&g, orgId, folder.UID, recordingLimits)
+		require.NoError(t, err)
+
+		g = validGroup(cfg, dependentOn(recording.GrafanaManagedAlert.UID), recording)
+		_, err = ValidateRuleGroup(&g, orgId, folder.UID, recordingLimits)
+		require.ErrorContains(t, err, "does not precede it in the group")
+
+		g = validGroup(cfg, alerting, dependentOn(alerting.GrafanaManagedAlert.UID))
+		_, err = ValidateRuleGroup(&g, orgId, folder.UID, recordingLimits)
+		require.ErrorContains(t, err, "is not a recording rule")
+
+		g = validGroup(cfg, recording, dependentOn(""))
+		_, err = ValidateRuleGroup(&g, orgId, folder.UID, recordingLimits)
+		require.ErrorIs(t, err, models.ErrNoInputRule)
+	})
 }
 
 func TestValidateRuleGroupFailures(t *testing.T) {
@@ -370,6 +404,18 @@ func TestValidateRuleNode_NoUID(t *testing.T) {
 				require.Nil(t, alert.Labels)
 			},
 		},
+		{
+			name: "converts keep_firing_for",
+			rule: func() *apimodels.PostableExtendedRuleNode {
+				r := validRule()
+				keepFiringFor := model.Duration(5 * time.Minute)
+				r.ApiRuleNode.KeepFiringFor = &keepFiringFor
+				return &r
+			},
+			assert: func(t *testing.T, api *apimodels.PostableExtendedRuleNode, alert *models.AlertRule) {
+				require.Equal(t, 5*time.Minute, alert.KeepFiringFor)
+			},
+		},
 		{
 			name: "defaults to NoData if NoDataState is empty",
 			rule: func() *apimodels.PostableExtendedRuleNode {
@@ -460,6 +506,7 @@ func TestValidateRuleNode_NoUID(t *testing.T) {
 				r.GrafanaManagedAlert.ExecErrState = apimodels.AlertingErrState
 				r.GrafanaManagedAlert.NotificationSettings = &apimodels.AlertRuleNotificationSettings{}
 				r.ApiRuleNode.For = func() *model.Duration { five := model.Duration(time.Second * 5); return &five }()
+				r.ApiRuleNode.KeepFiringFor = func() *model.Duration { five := model.Duration(time.Second * 5); return &five }()
 				return &r
 			},
 			assert: func(t *testing.T, api *apimodels.PostableExtendedRuleNode, alert *models.AlertRule) {
@@ -468,6 +515,7 @@ func TestValidateRuleNode_NoUID(t *testing.T) {
 				require.Empty(t, alert.ExecErrState)
 				require.Nil(t, alert.NotificationSettings)
 				require.Zero(t, alert.For)
+				require.Zero(t, alert.KeepFiringFor)
 			},
 		},
 	}
@@ -544,6 +592,15 @@ func TestValidateRuleNodeFailures_NoUID(t *testing.T) {
 				return &r
 			},
 		},
+		{
+			name: "fail if keep_firing_for is negative",
+			rule: func() *apimodels.PostableExtendedRuleNode {
+				r := validRule()
+				keepFiringFor := model.Duration(-time.Minute)
+				r.ApiRuleNode.KeepFiringFor = &keepFiringFor
+				return &r
+			},
+		},
 		{
 			name: "fail if ExecErrState is not known",
 			rule: func() *apimodels.PostableExtendedRuleNode {
@@ -745,6 +802,17 @@ func TestValidateRuleNode_UID(t *testing.T) {
 				require.Equal(t, "", alert.Title)
 			},
 		},
+		{
+			name: "use -1 if keep_firing_for is not specified",
+			rule: func() *apimodels.PostableExtendedRuleNode {
+				r := validRule()
+				r.ApiRuleNode.KeepFiringFor = nil
+				return &r
+			},
+			assert: func(t *testing.T, api *apimodels.PostableExtendedRuleNode, alert *models.AlertRule) {
+				require.Equal(t, time.Duration(-1), alert.KeepFiringFor)
+			},
+		},
 		{
 			name: "use empty NoData if NoDataState is empty",
 			rule: func() *apimodels.PostableExtendedRuleNode {
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/url"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/benbjohnson/clock"
 	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/common/model"
 
 	"github.com/grafana/alerting/models"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -39,6 +41,10 @@ type folderService interface {
 	GetNamespaceByUID(ctx context.Context, uid string, orgID int64, user identity.Requester) (*folder.Folder, error)
 }
 
+type alertmanagerConfigProvider interface {
+	GetAlertmanagerConfiguration(ctx context.Context, org int64, withAutogen bool) (apimodels.GettableUserConfig, error)
+}
+
 type TestingApiSrv struct {
 	*AlertingProxy
 	DatasourceCache datasources.CacheService
@@ -51,6 +57,7 @@ type TestingApiSrv struct {
 	appUrl          *url.URL
 	tracer          tracing.Tracer
 	folderService   folderService
+	amConfig        alertmanagerConfigProvider
 }
 
 // RouteTestGrafanaRuleConfig returns a list of potential alerts for a given rule configuration. This is intended to be
@@ -286,3 +293,106 @@ func (srv TestingApiSrv) BacktestAlertRule(c *contextmodel.ReqContext, cmd apimo
 	}
 	return response.JSON(http.StatusOK, body)
 }
+
+// BacktestAlertRuleGroup evaluates all rules of a group over a range of time and sends the alerts they produce
+// through the notification policies and inhibition rules of an Alertmanager configuration.
+// It returns the states of the rules and the notifications that would have been sent.
+func (srv TestingApiSrv) BacktestAlertRuleGroup(c *contextmodel.ReqContext, cmd apimodels.BacktestGroupConfig) response.Response {
+	if !srv.featureManager.IsEnabled(c.Req.Context(), featuremgmt.FlagAlertingBacktesting) {
+		return ErrResp(http.StatusNotFound, nil, "Backtesting API is not enabled")
+	}
+
+	if cmd.From.After(cmd.To) {
+		return ErrResp(http.StatusBadRequest, nil, "From cannot be greater than To")
+	}
+
+	folder, err := srv.folderService.GetNamespaceByUID(c.Req.Context(), cmd.NamespaceUID, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return toNamespaceErrorResponse(dashboards.ErrFolderAccessDenied)
+	}
+
+	rules, err := ValidateRuleGroup(&cmd.RuleGroup, c.SignedInUser.GetOrgID(), folder.UID, RuleLimitsFromConfig(srv.cfg, srv.featureManager))
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+
+	includeFolder := !srv.cfg.ReservedLabels.IsReservedLabelDisabled(models.FolderTitleLabel)
+	group := backtesting.GroupTest{
+		Rules:       make([]*ngmodels.AlertRule, 0, len(rules)),
+		ExtraLabels: make(map[string]data.Labels, len(rules)),
+		From:        cmd.From,
+		To:          cmd.To,
+	}
+	for _, r := range rules {
+		rule := &r.AlertRule
+		if rule.UID == "" {
+			// prefix backtesting- is to distinguish between executions of regular rule and backtesting in logs
+			rule.UID = "backtesting-" + util.GenerateShortUID()
+		}
+		if err := srv.authz.AuthorizeDatasourceAccessForRule(c.Req.Context(), c.SignedInUser, rule); err != nil {
+			return errorToResponse(err)
+		}
+		group.Rules = append(group.Rules, rule)
+		group.ExtraLabels[rule.UID] = state.GetRuleExtraLabels(srv.log, rule, folder.Fullpath, includeFolder)
+	}
+
+	amConfig := cmd.AlertmanagerConfig
+	if amConfig == nil {
+		current, err := srv.amConfig.GetAlertmanagerConfiguration(c.Req.Context(), c.SignedInUser.GetOrgID(), true)
+		if err != nil {
+			return ErrResp(http.StatusInternalServerError, err, "Failed to get the Alertmanager configuration")
+		}
+		amConfig = &apimodels.PostableApiAlertingConfig{Config: current.AlertmanagerConfig.Config}
+	}
+	if amConfig.Route == nil {
+		return ErrResp(http.StatusBadRequest, nil, "Alertmanager configuration must have a root route")
+	}
+	policy := backtesting.NewNotificationPolicy(amConfig.Route.AsAMRoute(), amConfig.InhibitRules)
+
+	result, err := srv.backtesting.TestGroup(c.Req.Context(), c.SignedInUser, group, policy)
+	if err != nil {
+		if errors.Is(err, backtesting.ErrInvalidInputData) {
+			return ErrResp(http.StatusBadRequest, err, "Failed to evaluate")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "Failed to evaluate")
+	}
+
+	body := apimodels.BacktestGroupResult{
+		States:        make([]json.RawMessage, 0, len(result.Frames)),
+		Notifications: make([]apimodels.BacktestNotification, 0, len(result.Notifications)),
+	}
+	for _, frame := range result.Frames {
+		states, err := data.FrameToJSON(frame, data.IncludeAll)
+		if err != nil {
+			return ErrResp(http.StatusInternalServerError, err, "Failed to convert frame to JSON")
+		}
+		body.States = append(body.States, states)
+	}
+	for _, n := range result.Notifications {
+		body.Notifications = append(body.Notifications, apimodels.BacktestNotification{
+			Timestamp:   n.Timestamp,
+			Receiver:    n.Receiver,
+			GroupKey:    n.GroupKey,
+			GroupLabels: labelSetToMap(n.GroupLabels),
+			Firing:      labelSetsToMaps(n.Firing),
+			Resolved:    labelSetsToMaps(n.Resolved),
+		})
+	}
+	return response.JSON(http.StatusOK, body)
+}
+
+func labelSetToMap(ls model.LabelSet) map[string]string {
+	result := make(map[string]string, len(ls))
+	for k, v := range ls {
+		result[string(k)] = string(v)
+	}
+	return result
+}
+
+func labelSetsToMaps(sets []model.LabelSet) []map[string]string {
+	result := make([]map[string]string, 0, len(sets))
+	for _, ls := range sets {
+		result = append(result, labelSetToMap(ls))
+	}
+	return result
+}
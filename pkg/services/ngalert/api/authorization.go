@@ -41,7 +41,15 @@ func (api *API) authorize(method, path string) web.Handler {
 	case http.MethodGet + "/api/ruler/grafana/api/v1/rules",
 		http.MethodGet + "/api/ruler/grafana/api/v1/export/rules":
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
-	case http.MethodGet + "/api/ruler/grafana/api/v1/rule/{RuleUID}":
+	case http.MethodGet + "/api/ruler/grafana/api/v1/rule/{RuleUID}",
+		http.MethodGet + "/api/ruler/grafana/api/v1/rule/{RuleUID}/versions",
+		http.MethodGet + "/api/ruler/grafana/api/v1/rule/{RuleUID}/versions/diff":
+		eval = ac.EvalAll(
+			ac.EvalPermission(ac.ActionAlertingRuleRead),
+			ac.EvalPermission(dashboards.ActionFoldersRead),
+		)
+	case http.MethodPost + "/api/ruler/grafana/api/v1/rule/{RuleUID}/versions/{Version}/restore":
+		// more granular permissions are enforced by the handler via "authorizeRuleChanges"
 		eval = ac.EvalAll(
 			ac.EvalPermission(ac.ActionAlertingRuleRead),
 			ac.EvalPermission(dashboards.ActionFoldersRead),
@@ -66,7 +74,8 @@ func (api *API) authorize(method, path string) web.Handler {
 		)
 
 	// Grafana rule state history paths
-	case http.MethodGet + "/api/v1/rules/history":
+	case http.MethodGet + "/api/v1/rules/history",
+		http.MethodGet + "/api/v1/rules/history/stats":
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
 
 	// Grafana receivers paths
@@ -96,6 +105,12 @@ func (api *API) authorize(method, path string) web.Handler {
 	case http.MethodPost + "/api/v1/rule/backtest":
 		// additional authorization is done in the request handler
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
+	case http.MethodPost + "/api/v1/rule/backtest/group":
+		// additional authorization is done in the request handler
+		eval = ac.EvalAll(
+			ac.EvalPermission(ac.ActionAlertingRuleRead),
+			ac.EvalPermission(ac.ActionAlertingNotificationsRead),
+		)
 	case http.MethodPost + "/api/v1/eval":
 		// additional authorization is done in the request handler
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
@@ -328,6 +343,16 @@ func (api *API) authorize(method, path string) web.Handler {
 			ac.EvalPermission(ac.ActionAlertingProvisioningReadSecrets),
 			ac.EvalPermission(ac.ActionAlertingNotificationsRead),
 		)
+	case http.MethodGet + "/api/v1/provisioning/silence-templates",
+		http.MethodGet + "/api/v1/provisioning/silence-templates/{UID}",
+		http.MethodGet + "/api/v1/provisioning/recurring-silences",
+		http.MethodGet + "/api/v1/provisioning/recurring-silences/{UID}":
+		eval = ac.EvalAny(
+			ac.EvalPermission(ac.ActionAlertingProvisioningRead),
+			ac.EvalPermission(ac.ActionAlertingNotificationsProvisioningRead), // organization scope
+			ac.EvalPermission(ac.ActionAlertingProvisioningReadSecrets),
+			ac.EvalPermission(ac.ActionAlertingInstanceRead),
+		)
 
 	// Grafana-only Provisioning Write Paths
 	case http.MethodPost + "/api/v1/provisioning/alert-rules":
@@ -386,6 +411,15 @@ func (api *API) authorize(method, path string) web.Handler {
 			),
 		)
 
+	case http.MethodPost + "/api/v1/provisioning/import/hcl":
+		eval = ac.EvalAny(
+			ac.EvalPermission(ac.ActionAlertingProvisioningWrite), // organization scope
+			ac.EvalAll( // the document can contain both alert rules and notification resources
+				ac.EvalPermission(ac.ActionAlertingRulesProvisioningWrite),
+				ac.EvalPermission(ac.ActionAlertingNotificationsProvisioningWrite),
+			),
+		)
+
 	case http.MethodPut + "/api/v1/provisioning/policies",
 		http.MethodDelete + "/api/v1/provisioning/policies",
 		http.MethodPost + "/api/v1/provisioning/contact-points",
@@ -404,6 +438,21 @@ func (api *API) authorize(method, path string) web.Handler {
 				ac.EvalPermission(ac.ActionAlertingProvisioningSetStatus),
 			),
 		)
+	case http.MethodPost + "/api/v1/provisioning/silence-templates",
+		http.MethodPut + "/api/v1/provisioning/silence-templates/{UID}",
+		http.MethodDelete + "/api/v1/provisioning/silence-templates/{UID}",
+		http.MethodPost + "/api/v1/provisioning/recurring-silences",
+		http.MethodPut + "/api/v1/provisioning/recurring-silences/{UID}",
+		http.MethodDelete + "/api/v1/provisioning/recurring-silences/{UID}":
+		eval = ac.EvalAny(
+			ac.EvalPermission(ac.ActionAlertingProvisioningWrite),              // organization scope,
+			ac.EvalPermission(ac.ActionAlertingNotificationsProvisioningWrite), // organization scope
+			ac.EvalAll(
+				ac.EvalPermission(ac.ActionAlertingInstanceCreate),
+				ac.EvalPermission(ac.ActionAlertingInstanceUpdate),
+				ac.EvalPermission(ac.ActionAlertingProvisioningSetStatus),
+			),
+		)
 	case http.MethodGet + "/api/v1/notifications/time-intervals/{name}",
 		http.MethodGet + "/api/v1/notifications/time-intervals":
 		eval = ac.EvalAny(
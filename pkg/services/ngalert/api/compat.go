@@ -3,10 +3,12 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
 	amConfig "github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
 	"github.com/prometheus/common/model"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
@@ -29,6 +31,7 @@ func AlertRuleFromProvisionedAlertRule(a definitions.ProvisionedAlertRule) (mode
 		NoDataState:          models.NoDataState(a.NoDataState),          // TODO there must be a validation
 		ExecErrState:         models.ExecutionErrorState(a.ExecErrState), // TODO there must be a validation
 		For:                  time.Duration(a.For),
+		KeepFiringFor:        time.Duration(a.KeepFiringFor),
 		Annotations:          a.Annotations,
 		Labels:               a.Labels,
 		IsPaused:             a.IsPaused,
@@ -53,6 +56,7 @@ func ProvisionedAlertRuleFromAlertRule(rule models.AlertRule, provenance models.
 		RuleGroup:            rule.RuleGroup,
 		Title:                rule.Title,
 		For:                  model.Duration(rule.For),
+		KeepFiringFor:        model.Duration(rule.KeepFiringFor),
 		Condition:            rule.Condition,
 		Data:                 ApiAlertQueriesFromAlertQueries(rule.Data),
 		Updated:              rule.Updated,
@@ -206,6 +210,7 @@ func AlertRuleExportFromAlertRule(rule models.AlertRule) (definitions.AlertRuleE
 		UID:                  rule.UID,
 		Title:                rule.Title,
 		For:                  model.Duration(rule.For),
+		KeepFiringFor:        model.Duration(rule.KeepFiringFor),
 		Condition:            cPtr,
 		Data:                 data,
 		DashboardUID:         rule.DashboardUID,
@@ -219,6 +224,9 @@ func AlertRuleExportFromAlertRule(rule models.AlertRule) (definitions.AlertRuleE
 	if rule.For.Seconds() > 0 {
 		result.ForString = util.Pointer(model.Duration(rule.For).String())
 	}
+	if rule.KeepFiringFor.Seconds() > 0 {
+		result.KeepFiringForString = util.Pointer(model.Duration(rule.KeepFiringFor).String())
+	}
 	if rule.Annotations != nil {
 		result.Annotations = &rule.Annotations
 	}
@@ -270,6 +278,111 @@ func AlertQueryExportFromAlertQuery(query models.AlertQuery) (definitions.AlertQ
 	}, nil
 }
 
+// AlertRuleGroupFromAlertRuleGroupExport converts definitions.AlertRuleGroupExport decoded from HCL to models.AlertRuleGroup.
+// Only the fields that are present in HCL are converted.
+func AlertRuleGroupFromAlertRuleGroupExport(d definitions.AlertRuleGroupExport) (models.AlertRuleGroup, error) {
+	result := models.AlertRuleGroup{
+		Title:     d.Name,
+		FolderUID: d.FolderUID,
+		Interval:  d.IntervalSeconds,
+		Rules:     make([]models.AlertRule, 0, len(d.Rules)),
+	}
+	for _, r := range d.Rules {
+		rule, err := AlertRuleFromAlertRuleExport(r)
+		if err != nil {
+			return models.AlertRuleGroup{}, fmt.Errorf("invalid rule '%s': %w", r.Title, err)
+		}
+		rule.NamespaceUID = d.FolderUID
+		rule.RuleGroup = d.Name
+		rule.IntervalSeconds = d.IntervalSeconds
+		result.Rules = append(result.Rules, rule)
+	}
+	return result, nil
+}
+
+// AlertRuleFromAlertRuleExport converts definitions.AlertRuleExport decoded from HCL to models.AlertRule.
+// The states of alerting rules default to the ones of the Terraform provider if they are not defined.
+func AlertRuleFromAlertRuleExport(r definitions.AlertRuleExport) (models.AlertRule, error) {
+	data := make([]models.AlertQuery, 0, len(r.Data))
+	for _, q := range r.Data {
+		query, err := AlertQueryFromAlertQueryExport(q)
+		if err != nil {
+			return models.AlertRule{}, err
+		}
+		data = append(data, query)
+	}
+	parseDuration := func(s *string) (time.Duration, error) {
+		if s == nil || *s == "" {
+			return 0, nil
+		}
+		d, err := model.ParseDuration(*s)
+		return time.Duration(d), err
+	}
+	forDuration, err := parseDuration(r.ForString)
+	if err != nil {
+		return models.AlertRule{}, fmt.Errorf("invalid for: %w", err)
+	}
+	keepFiringFor, err := parseDuration(r.KeepFiringForString)
+	if err != nil {
+		return models.AlertRule{}, fmt.Errorf("invalid keep_firing_for: %w", err)
+	}
+	notificationSettings, err := NotificationSettingsFromAlertRuleNotificationSettingsExport(r.NotificationSettings)
+	if err != nil {
+		return models.AlertRule{}, err
+	}
+
+	rule := models.AlertRule{
+		Title:                r.Title,
+		Data:                 data,
+		NoDataState:          models.NoData,
+		ExecErrState:         models.AlertingErrState,
+		For:                  forDuration,
+		KeepFiringFor:        keepFiringFor,
+		IsPaused:             r.IsPaused,
+		NotificationSettings: notificationSettings,
+		Record:               ModelRecordFromAlertRuleRecordExport(r.Record),
+	}
+	if r.Condition != nil {
+		rule.Condition = *r.Condition
+	}
+	if r.NoDataState != nil {
+		rule.NoDataState = models.NoDataState(*r.NoDataState)
+	}
+	if r.ExecErrState != nil {
+		rule.ExecErrState = models.ExecutionErrorState(*r.ExecErrState)
+	}
+	if r.Annotations != nil {
+		rule.Annotations = *r.Annotations
+	}
+	if r.Labels != nil {
+		rule.Labels = *r.Labels
+	}
+	if rule.Type() == models.RuleTypeRecording {
+		models.ClearRecordingRuleIgnoredFields(&rule)
+	}
+	return rule, nil
+}
+
+// AlertQueryFromAlertQueryExport converts definitions.AlertQueryExport decoded from HCL to models.AlertQuery.
+func AlertQueryFromAlertQueryExport(q definitions.AlertQueryExport) (models.AlertQuery, error) {
+	if !json.Valid([]byte(q.ModelString)) {
+		return models.AlertQuery{}, fmt.Errorf("model of query '%s' is not valid JSON", q.RefID)
+	}
+	result := models.AlertQuery{
+		RefID: q.RefID,
+		RelativeTimeRange: models.RelativeTimeRange{
+			From: models.Duration(time.Duration(q.RelativeTimeRange.FromSeconds) * time.Second),
+			To:   models.Duration(time.Duration(q.RelativeTimeRange.ToSeconds) * time.Second),
+		},
+		DatasourceUID: q.DatasourceUID,
+		Model:         json.RawMessage(q.ModelString),
+	}
+	if q.QueryType != nil {
+		result.QueryType = *q.QueryType
+	}
+	return result, nil
+}
+
 // AlertingFileExportFromEmbeddedContactPoints creates a definitions.AlertingFileExport DTO from []definitions.EmbeddedContactPoint.
 func AlertingFileExportFromEmbeddedContactPoints(orgID int64, ecps []definitions.EmbeddedContactPoint) (definitions.AlertingFileExport, error) {
 	f := definitions.AlertingFileExport{APIVersion: 1}
@@ -371,6 +484,69 @@ func RouteExportFromRoute(route *definitions.Route) *definitions.RouteExport {
 	return &export
 }
 
+// RouteFromRouteExport converts definitions.RouteExport decoded from HCL to definitions.Route.
+// It is the reverse of RouteExportFromRoute for the fields that are present in HCL.
+func RouteFromRouteExport(export *definitions.RouteExport) (*definitions.Route, error) {
+	parseDuration := func(s *string) (*model.Duration, error) {
+		if s == nil {
+			return nil, nil
+		}
+		d, err := model.ParseDuration(*s)
+		if err != nil {
+			return nil, err
+		}
+		return &d, nil
+	}
+
+	route := definitions.Route{
+		Receiver: export.Receiver,
+	}
+	if export.GroupByStr != nil {
+		route.GroupByStr = *export.GroupByStr
+	}
+	if export.MuteTimeIntervals != nil {
+		route.MuteTimeIntervals = *export.MuteTimeIntervals
+	}
+	if export.Continue != nil {
+		route.Continue = *export.Continue
+	}
+	var err error
+	if route.GroupWait, err = parseDuration(export.GroupWait); err != nil {
+		return nil, fmt.Errorf("invalid group_wait: %w", err)
+	}
+	if route.GroupInterval, err = parseDuration(export.GroupInterval); err != nil {
+		return nil, fmt.Errorf("invalid group_interval: %w", err)
+	}
+	if route.RepeatInterval, err = parseDuration(export.RepeatInterval); err != nil {
+		return nil, fmt.Errorf("invalid repeat_interval: %w", err)
+	}
+
+	for _, m := range export.ObjectMatchersSlice {
+		matcher, err := matcherFromMatcherExport(m)
+		if err != nil {
+			return nil, err
+		}
+		route.ObjectMatchers = append(route.ObjectMatchers, matcher)
+	}
+	for _, r := range export.Routes {
+		child, err := RouteFromRouteExport(r)
+		if err != nil {
+			return nil, err
+		}
+		route.Routes = append(route.Routes, child)
+	}
+	return &route, nil
+}
+
+func matcherFromMatcherExport(m *definitions.MatcherExport) (*labels.Matcher, error) {
+	for _, t := range []labels.MatchType{labels.MatchEqual, labels.MatchNotEqual, labels.MatchRegexp, labels.MatchNotRegexp} {
+		if t.String() == m.Match {
+			return labels.NewMatcher(t, m.Label, m.Value)
+		}
+	}
+	return nil, fmt.Errorf("invalid match type '%s' of matcher '%s'", m.Match, m.Label)
+}
+
 // OmitDefault returns nil if the value is the default.
 func OmitDefault[T comparable](v *T) *T {
 	var def T
@@ -391,6 +567,14 @@ func NilIfEmpty[T any](v *[]T) *[]T {
 	return v
 }
 
+// NilIfEmptyMap returns nil if pointer to map points to the empty map.
+func NilIfEmptyMap[K comparable, V any](v *map[K]V) *map[K]V {
+	if v == nil || len(*v) == 0 {
+		return nil
+	}
+	return v
+}
+
 func AlertingFileExportFromMuteTimings(orgID int64, m []definitions.MuteTimeInterval) definitions.AlertingFileExport {
 	f := definitions.AlertingFileExport{
 		APIVersion:  1,
@@ -421,6 +605,19 @@ func MuteTimingIntervalToMuteTimeIntervalHclExport(m definitions.MuteTimeInterva
 	return result, err
 }
 
+// MuteTimingFromMuteTimeIntervalHclExport converts definitions.MuteTimeIntervalExportHcl decoded from HCL to definitions.MuteTimeInterval using JSON marshalling.
+// It is the reverse of MuteTimingIntervalToMuteTimeIntervalHclExport.
+func MuteTimingFromMuteTimeIntervalHclExport(m definitions.MuteTimeIntervalExportHcl) (definitions.MuteTimeInterval, error) {
+	result := definitions.MuteTimeInterval{}
+	j := jsoniter.ConfigCompatibleWithStandardLibrary
+	mdata, err := j.Marshal(m)
+	if err != nil {
+		return result, err
+	}
+	err = j.Unmarshal(mdata, &result.MuteTimeInterval)
+	return result, err
+}
+
 // AlertRuleEditorSettingsFromEditorSettings converts models.EditorSettings to definitions.AlertRuleEditorSettings
 func AlertRuleEditorSettingsFromModelEditorSettings(es models.EditorSettings) *definitions.AlertRuleEditorSettings {
 	return &definitions.AlertRuleEditorSettings{
@@ -493,6 +690,39 @@ func NotificationSettingsFromAlertRuleNotificationSettings(ns *definitions.Alert
 	}
 }
 
+// NotificationSettingsFromAlertRuleNotificationSettingsExport converts definitions.AlertRuleNotificationSettingsExport decoded from HCL to []models.NotificationSettings.
+func NotificationSettingsFromAlertRuleNotificationSettingsExport(ns *definitions.AlertRuleNotificationSettingsExport) ([]models.NotificationSettings, error) {
+	if ns == nil {
+		return nil, nil
+	}
+	parseDuration := func(s *string) (*model.Duration, error) {
+		if s == nil {
+			return nil, nil
+		}
+		d, err := model.ParseDuration(*s)
+		if err != nil {
+			return nil, err
+		}
+		return &d, nil
+	}
+	result := models.NotificationSettings{
+		Receiver:          ns.Receiver,
+		GroupBy:           ns.GroupBy,
+		MuteTimeIntervals: ns.MuteTimeIntervals,
+	}
+	var err error
+	if result.GroupWait, err = parseDuration(ns.GroupWait); err != nil {
+		return nil, fmt.Errorf("invalid group_wait: %w", err)
+	}
+	if result.GroupInterval, err = parseDuration(ns.GroupInterval); err != nil {
+		return nil, fmt.Errorf("invalid group_interval: %w", err)
+	}
+	if result.RepeatInterval, err = parseDuration(ns.RepeatInterval); err != nil {
+		return nil, fmt.Errorf("invalid repeat_interval: %w", err)
+	}
+	return []models.NotificationSettings{result}, nil
+}
+
 func AlertRuleRecordExportFromRecord(r *models.Record) *definitions.AlertRuleRecordExport {
 	if r == nil {
 		return nil
@@ -513,6 +743,17 @@ func ModelRecordFromApiRecord(r *definitions.Record) *models.Record {
 	}
 }
 
+// ModelRecordFromAlertRuleRecordExport converts definitions.AlertRuleRecordExport decoded from HCL to models.Record.
+func ModelRecordFromAlertRuleRecordExport(r *definitions.AlertRuleRecordExport) *models.Record {
+	if r == nil {
+		return nil
+	}
+	return &models.Record{
+		Metric: r.Metric,
+		From:   r.From,
+	}
+}
+
 func ApiRecordFromModelRecord(r *models.Record) *definitions.Record {
 	if r == nil {
 		return nil
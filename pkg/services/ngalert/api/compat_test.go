@@ -2,10 +2,13 @@ package api
 
 import (
 	"testing"
+	"time"
 
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
 )
 
 func TestToModel(t *testing.T) {
@@ -34,6 +37,32 @@ func TestToModel(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, tm.Rules, 1)
 	})
+	t.Run("should convert keep_firing_for", func(t *testing.T) {
+		ruleGroup := definitions.AlertRuleGroup{
+			Title:     "123",
+			FolderUID: "123",
+			Interval:  10,
+			Rules: []definitions.ProvisionedAlertRule{
+				{
+					UID:           "1",
+					Condition:     "A",
+					KeepFiringFor: model.Duration(5 * time.Minute),
+				},
+			},
+		}
+		tm, err := AlertRuleGroupFromApiAlertRuleGroup(ruleGroup)
+		require.NoError(t, err)
+		require.Len(t, tm.Rules, 1)
+		require.Equal(t, 5*time.Minute, tm.Rules[0].KeepFiringFor)
+
+		provisioned := ProvisionedAlertRuleFromAlertRule(tm.Rules[0], models.ProvenanceNone)
+		require.Equal(t, ruleGroup.Rules[0].KeepFiringFor, provisioned.KeepFiringFor)
+
+		exported, err := AlertRuleExportFromAlertRule(tm.Rules[0])
+		require.NoError(t, err)
+		require.Equal(t, ruleGroup.Rules[0].KeepFiringFor, exported.KeepFiringFor)
+		require.Equal(t, "5m", *exported.KeepFiringForString)
+	})
 	t.Run("should clear recording rule ignored fields correctly", func(t *testing.T) {
 		ruleGroup := definitions.AlertRuleGroup{
 			Title:     "123",
@@ -41,11 +70,12 @@ func TestToModel(t *testing.T) {
 			Interval:  10,
 			Rules: []definitions.ProvisionedAlertRule{
 				{
-					UID:          "1",
-					Condition:    "A",
-					ExecErrState: definitions.ErrorErrState,
-					NoDataState:  definitions.NoData,
-					For:          10,
+					UID:           "1",
+					Condition:     "A",
+					ExecErrState:  definitions.ErrorErrState,
+					NoDataState:   definitions.NoData,
+					For:           10,
+					KeepFiringFor: 10,
 					NotificationSettings: &definitions.AlertRuleNotificationSettings{
 						Receiver: "receiver",
 					},
@@ -62,6 +92,7 @@ func TestToModel(t *testing.T) {
 		rule := tm.Rules[0]
 		require.Empty(t, rule.NoDataState)
 		require.Empty(t, rule.For)
+		require.Empty(t, rule.KeepFiringFor)
 		require.Empty(t, rule.Condition)
 		require.Empty(t, rule.ExecErrState)
 		require.Nil(t, rule.NotificationSettings)
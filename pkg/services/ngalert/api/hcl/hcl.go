@@ -3,7 +3,9 @@ package hcl
 import (
 	"fmt"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 )
 
@@ -28,3 +30,42 @@ func Encode(resources ...Resource) (data []byte, err error) {
 	}
 	return f.Bytes(), nil
 }
+
+// DecodedResource is a resource block of an HCL document whose body has not been decoded yet.
+type DecodedResource struct {
+	Type string
+	Name string
+	body hcl.Body
+}
+
+// DecodeBody decodes the body of the resource into v, which must be a pointer to a struct with the same tags as the ones used by Encode.
+func (r DecodedResource) DecodeBody(v interface{}) error {
+	if diags := gohcl.DecodeBody(r.body, nil, v); diags.HasErrors() {
+		return fmt.Errorf("failed to decode resource %s.%s: %w", r.Type, r.Name, diags)
+	}
+	return nil
+}
+
+// Decode parses the resource blocks of an HCL document in the order they are defined. Other blocks, like provider or terraform, are ignored.
+// Expressions are evaluated without variables and functions, therefore the attributes must be literal values.
+func Decode(data []byte) ([]DecodedResource, error) {
+	file, diags := hclsyntax.ParseConfig(data, "import.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse HCL: %w", diags)
+	}
+	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+	})
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse HCL: %w", diags)
+	}
+	result := make([]DecodedResource, 0, len(content.Blocks))
+	for _, block := range content.Blocks {
+		result = append(result, DecodedResource{
+			Type: block.Labels[0],
+			Name: block.Labels[1],
+			body: block.Body,
+		})
+	}
+	return result, nil
+}
@@ -73,3 +73,80 @@ func TestEncode(t *testing.T) {
 }
 `, string(encoded))
 }
+
+func TestDecode(t *testing.T) {
+	type sub struct {
+		Name string `hcl:"name"`
+	}
+	type data struct {
+		Name      string   `hcl:"name"`
+		Number    float64  `hcl:"number"`
+		NumberRef *float64 `hcl:"numberRef"`
+		Blocks    []sub    `hcl:"blocks,block"`
+	}
+
+	t.Run("should decode resources in order", func(t *testing.T) {
+		resources, err := Decode([]byte(`
+terraform {
+  required_providers {
+    grafana = {
+      source = "grafana/grafana"
+    }
+  }
+}
+
+resource "grafana_test" "test-01" {
+  name   = "test"
+  number = 123
+
+  blocks {
+    name = "el-0"
+  }
+  blocks {
+    name = "el-1"
+  }
+}
+
+resource "grafana_other" "test-02" {
+  name   = "other"
+  number = 1
+}
+`))
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+		require.Equal(t, "grafana_test", resources[0].Type)
+		require.Equal(t, "test-01", resources[0].Name)
+		require.Equal(t, "grafana_other", resources[1].Type)
+
+		var d data
+		require.NoError(t, resources[0].DecodeBody(&d))
+		require.Equal(t, data{Name: "test", Number: 123, Blocks: []sub{{Name: "el-0"}, {Name: "el-1"}}}, d)
+	})
+
+	t.Run("should round trip encoded resources", func(t *testing.T) {
+		expected := data{Name: "test", Number: 1, NumberRef: func(f float64) *float64 { return &f }(2), Blocks: []sub{{Name: "el-0"}}}
+		encoded, err := Encode(Resource{Type: "grafana_test", Name: "test-01", Body: &expected})
+		require.NoError(t, err)
+
+		resources, err := Decode(encoded)
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+		var d data
+		require.NoError(t, resources[0].DecodeBody(&d))
+		require.Equal(t, expected, d)
+	})
+
+	t.Run("should fail if required attributes are missing", func(t *testing.T) {
+		resources, err := Decode([]byte(`resource "grafana_test" "test-01" {
+  name = "test"
+}`))
+		require.NoError(t, err)
+		var d data
+		require.ErrorContains(t, resources[0].DecodeBody(&d), "number")
+	})
+
+	t.Run("should fail if document is not valid", func(t *testing.T) {
+		_, err := Decode([]byte(`resource "grafana_test" {`))
+		require.Error(t, err)
+	})
+}
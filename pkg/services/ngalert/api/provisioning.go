@@ -84,6 +84,46 @@ func (f *ProvisioningApiHandler) handleRouteDeleteMuteTiming(ctx *contextmodel.R
 	return f.svc.RouteDeleteMuteTiming(ctx, name)
 }
 
+func (f *ProvisioningApiHandler) handleRouteGetSilenceTemplates(ctx *contextmodel.ReqContext) response.Response {
+	return f.svc.RouteGetSilenceTemplates(ctx)
+}
+
+func (f *ProvisioningApiHandler) handleRouteGetSilenceTemplate(ctx *contextmodel.ReqContext, uid string) response.Response {
+	return f.svc.RouteGetSilenceTemplate(ctx, uid)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePostSilenceTemplate(ctx *contextmodel.ReqContext, t apimodels.SilenceTemplate) response.Response {
+	return f.svc.RoutePostSilenceTemplate(ctx, t)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePutSilenceTemplate(ctx *contextmodel.ReqContext, t apimodels.SilenceTemplate, uid string) response.Response {
+	return f.svc.RoutePutSilenceTemplate(ctx, t, uid)
+}
+
+func (f *ProvisioningApiHandler) handleRouteDeleteSilenceTemplate(ctx *contextmodel.ReqContext, uid string) response.Response {
+	return f.svc.RouteDeleteSilenceTemplate(ctx, uid)
+}
+
+func (f *ProvisioningApiHandler) handleRouteGetRecurringSilences(ctx *contextmodel.ReqContext) response.Response {
+	return f.svc.RouteGetRecurringSilences(ctx)
+}
+
+func (f *ProvisioningApiHandler) handleRouteGetRecurringSilence(ctx *contextmodel.ReqContext, uid string) response.Response {
+	return f.svc.RouteGetRecurringSilence(ctx, uid)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePostRecurringSilence(ctx *contextmodel.ReqContext, s apimodels.RecurringSilence) response.Response {
+	return f.svc.RoutePostRecurringSilence(ctx, s)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePutRecurringSilence(ctx *contextmodel.ReqContext, s apimodels.RecurringSilence, uid string) response.Response {
+	return f.svc.RoutePutRecurringSilence(ctx, s, uid)
+}
+
+func (f *ProvisioningApiHandler) handleRouteDeleteRecurringSilence(ctx *contextmodel.ReqContext, uid string) response.Response {
+	return f.svc.RouteDeleteRecurringSilence(ctx, uid)
+}
+
 func (f *ProvisioningApiHandler) handleRouteGetAlertRules(ctx *contextmodel.ReqContext) response.Response {
 	return f.svc.RouteGetAlertRules(ctx)
 }
@@ -139,3 +179,7 @@ func (f *ProvisioningApiHandler) handleRouteExportMuteTimings(ctx *contextmodel.
 func (f *ProvisioningApiHandler) handleRouteDeleteAlertRuleGroup(ctx *contextmodel.ReqContext, folderUID, group string) response.Response {
 	return f.svc.RouteDeleteAlertRuleGroup(ctx, folderUID, group)
 }
+
+func (f *ProvisioningApiHandler) handleRoutePostImportHcl(ctx *contextmodel.ReqContext, body apimodels.ImportHcl) response.Response {
+	return f.svc.RoutePostImportHcl(ctx, body)
+}
@@ -18,3 +18,7 @@ func NewStateHistoryApi(svc *HistorySrv) *HistoryApiHandler {
 func (f *HistoryApiHandler) handleRouteGetStateHistory(ctx *contextmodel.ReqContext) response.Response {
 	return f.svc.RouteQueryStateHistory(ctx)
 }
+
+func (f *HistoryApiHandler) handleRouteGetStateHistoryStats(ctx *contextmodel.ReqContext) response.Response {
+	return f.svc.RouteQueryStateHistoryStats(ctx)
+}
@@ -32,3 +32,7 @@ func (f *TestingApiHandler) handleRouteEvalQueries(c *contextmodel.ReqContext, b
 func (f *TestingApiHandler) handleBacktestConfig(ctx *contextmodel.ReqContext, conf apimodels.BacktestConfig) response.Response {
 	return f.svc.BacktestAlertRule(ctx, conf)
 }
+
+func (f *TestingApiHandler) handleBacktestGroupConfig(ctx *contextmodel.ReqContext, conf apimodels.BacktestGroupConfig) response.Response {
+	return f.svc.BacktestAlertRuleGroup(ctx, conf)
+}
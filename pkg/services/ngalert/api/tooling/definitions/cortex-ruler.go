@@ -20,6 +20,44 @@ import (
 //       403: ForbiddenError
 //       404: description: Not found.
 
+// swagger:route Get /ruler/grafana/api/v1/rule/{RuleUID}/versions ruler RouteGetRuleVersionsByUID
+//
+// Get the versions of the rule, from the newest to the oldest
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: GettableRuleVersions
+//       403: ForbiddenError
+//       404: description: Not found.
+
+// swagger:route Get /ruler/grafana/api/v1/rule/{RuleUID}/versions/diff ruler RouteGetRuleVersionsDiff
+//
+// Get the changes between two versions of the rule
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: RuleVersionDiff
+//       400: ValidationError
+//       403: ForbiddenError
+//       404: description: Not found.
+
+// swagger:route POST /ruler/grafana/api/v1/rule/{RuleUID}/versions/{Version}/restore ruler RoutePostRestoreRuleVersion
+//
+// Restore a previous version of the rule as a new version
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       202: UpdateRuleGroupResponse
+//       400: ValidationError
+//       403: ForbiddenError
+//       404: description: Not found.
+
 // swagger:route Get /ruler/grafana/api/v1/rules ruler RouteGetGrafanaRulesConfig
 //
 // List rule groups
@@ -218,12 +256,52 @@ type PathGetRulesParams struct {
 	PanelID int64
 }
 
-// swagger:parameters RouteGetRuleByUID
+// swagger:parameters RouteGetRuleByUID RouteGetRuleVersionsByUID RouteGetRuleVersionsDiff
 type PathGetRuleByUIDParams struct {
 	// in: path
 	RuleUID string
 }
 
+// swagger:parameters RouteGetRuleVersionsDiff
+type RuleVersionsDiffParams struct {
+	// The version to compare from
+	// in: query
+	// required: true
+	From int64 `json:"from"`
+	// The version to compare to
+	// in: query
+	// required: true
+	To int64 `json:"to"`
+}
+
+// swagger:parameters RoutePostRestoreRuleVersion
+type PathRestoreRuleVersionParams struct {
+	// in: path
+	RuleUID string
+	// in: path
+	Version int64
+}
+
+// swagger:model
+type GettableRuleVersions []GettableExtendedRuleNode
+
+// swagger:model
+type RuleVersionDiff struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+	// Changed fields of the rule, empty if the versions are equal
+	Diff []RuleFieldDiff `json:"diff"`
+}
+
+type RuleFieldDiff struct {
+	// Path to the field, for example "Title" or "Labels[team]"
+	Path string `json:"path"`
+	// Value of the field in the from version, null if the field was added
+	From any `json:"from"`
+	// Value of the field in the to version, null if the field was removed
+	To any `json:"to"`
+}
+
 // swagger:model
 type RuleGroupConfigResponse struct {
 	GettableRuleGroupConfig
@@ -552,6 +630,8 @@ type GettableGrafanaRule struct {
 	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty"`
 	Record               *Record                        `json:"record,omitempty" yaml:"record,omitempty"`
 	Metadata             *AlertRuleMetadata             `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	// The user that made the latest change to the rule
+	UpdatedBy string `json:"updated_by,omitempty" yaml:"updated_by,omitempty"`
 }
 
 // AlertQuery represents a single query associated with an alert definition.
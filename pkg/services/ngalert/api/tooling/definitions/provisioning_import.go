@@ -0,0 +1,70 @@
+package definitions
+
+// swagger:route POST /v1/provisioning/import/hcl provisioning stable RoutePostImportHcl
+//
+// Import alert rule groups, contact points, notification policies and mute timings from Terraform HCL.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: ImportHclResult
+//       400: ValidationError
+
+// swagger:parameters RoutePostImportHcl
+type ImportHclPayload struct {
+	// in:body
+	Body ImportHcl
+}
+
+// swagger:parameters RoutePostImportHcl
+type ImportHclHeaders struct {
+	// in:header
+	XDisableProvenance string `json:"X-Disable-Provenance"`
+}
+
+// swagger:model
+type ImportHcl struct {
+	// HCL document in the format of the export endpoints. It can contain grafana_rule_group, grafana_contact_point,
+	// grafana_notification_policy and grafana_mute_timing resources. Other blocks are ignored.
+	// required: true
+	Hcl string `json:"hcl"`
+	// If true, nothing is changed and the result describes the changes that would be applied.
+	DryRun bool `json:"dryRun"`
+}
+
+// swagger:model
+type ImportHclResult struct {
+	DryRun    bool               `json:"dryRun"`
+	Resources []ImportedResource `json:"resources"`
+}
+
+// swagger:enum ImportAction
+type ImportAction string
+
+const (
+	ImportActionCreate ImportAction = "create"
+	ImportActionUpdate ImportAction = "update"
+	ImportActionNone   ImportAction = "none"
+)
+
+// swagger:model
+type ImportedResource struct {
+	// Type of the resource, for example grafana_rule_group.
+	Type string `json:"type"`
+	// Name of the resource in the HCL document.
+	Name   string       `json:"name"`
+	Action ImportAction `json:"action"`
+	// Fields of the existing resource that are changed. Empty if the resource is created.
+	Diff []ImportFieldDiff `json:"diff,omitempty"`
+}
+
+// swagger:model
+type ImportFieldDiff struct {
+	// Path to the field, for example Rules[0].Data[1].ModelString.
+	Path string `json:"path"`
+	// Current value of the field. Omitted if the field is added.
+	Current any `json:"current,omitempty"`
+	// Value of the field in the HCL document. Omitted if the field is removed.
+	Desired any `json:"desired,omitempty"`
+}
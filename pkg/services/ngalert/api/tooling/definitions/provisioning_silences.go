@@ -0,0 +1,173 @@
+package definitions
+
+import (
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+)
+
+// swagger:route GET /v1/provisioning/silence-templates provisioning stable RouteGetSilenceTemplates
+//
+// Get all silence templates.
+//
+//     Responses:
+//       200: SilenceTemplates
+
+// swagger:route GET /v1/provisioning/silence-templates/{UID} provisioning stable RouteGetSilenceTemplate
+//
+// Get a silence template.
+//
+//     Responses:
+//       200: SilenceTemplate
+//       404: PublicError
+
+// swagger:route POST /v1/provisioning/silence-templates provisioning stable RoutePostSilenceTemplate
+//
+// Create a new silence template.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       201: SilenceTemplate
+//       400: PublicError
+
+// swagger:route PUT /v1/provisioning/silence-templates/{UID} provisioning stable RoutePutSilenceTemplate
+//
+// Replace an existing silence template.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       202: SilenceTemplate
+//       400: PublicError
+//       404: PublicError
+//       409: PublicError
+
+// swagger:route DELETE /v1/provisioning/silence-templates/{UID} provisioning stable RouteDeleteSilenceTemplate
+//
+// Delete a silence template.
+//
+//     Responses:
+//       204: description: The silence template was deleted successfully.
+//       409: PublicError
+
+// swagger:route GET /v1/provisioning/recurring-silences provisioning stable RouteGetRecurringSilences
+//
+// Get all recurring silences.
+//
+//     Responses:
+//       200: RecurringSilences
+
+// swagger:route GET /v1/provisioning/recurring-silences/{UID} provisioning stable RouteGetRecurringSilence
+//
+// Get a recurring silence.
+//
+//     Responses:
+//       200: RecurringSilence
+//       404: PublicError
+
+// swagger:route POST /v1/provisioning/recurring-silences provisioning stable RoutePostRecurringSilence
+//
+// Create a new recurring silence.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       201: RecurringSilence
+//       400: PublicError
+
+// swagger:route PUT /v1/provisioning/recurring-silences/{UID} provisioning stable RoutePutRecurringSilence
+//
+// Replace an existing recurring silence.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       202: RecurringSilence
+//       400: PublicError
+//       404: PublicError
+//       409: PublicError
+
+// swagger:route DELETE /v1/provisioning/recurring-silences/{UID} provisioning stable RouteDeleteRecurringSilence
+//
+// Delete a recurring silence and expire its current silence.
+//
+//     Responses:
+//       204: description: The recurring silence was deleted successfully.
+//       409: PublicError
+
+// swagger:parameters RouteGetSilenceTemplate RoutePutSilenceTemplate RouteDeleteSilenceTemplate
+type SilenceTemplateUIDParam struct {
+	// Silence template UID
+	// in:path
+	UID string `json:"UID"`
+}
+
+// swagger:parameters RoutePostSilenceTemplate RoutePutSilenceTemplate
+type SilenceTemplatePayload struct {
+	// in:body
+	Body SilenceTemplate
+}
+
+// swagger:parameters RouteGetRecurringSilence RoutePutRecurringSilence RouteDeleteRecurringSilence
+type RecurringSilenceUIDParam struct {
+	// Recurring silence UID
+	// in:path
+	UID string `json:"UID"`
+}
+
+// swagger:parameters RoutePostRecurringSilence RoutePutRecurringSilence
+type RecurringSilencePayload struct {
+	// in:body
+	Body RecurringSilence
+}
+
+// swagger:parameters RoutePostSilenceTemplate RoutePutSilenceTemplate RouteDeleteSilenceTemplate RoutePostRecurringSilence RoutePutRecurringSilence RouteDeleteRecurringSilence
+type SilenceScheduleHeaders struct {
+	// in:header
+	XDisableProvenance string `json:"X-Disable-Provenance"`
+}
+
+// SilenceTemplate is a saved set of matchers with a default duration that silences can be created from.
+// swagger:model
+type SilenceTemplate struct {
+	UID      string         `json:"uid" yaml:"uid"`
+	Name     string         `json:"name" yaml:"name"`
+	Matchers ObjectMatchers `json:"matchers" yaml:"matchers"`
+	// Default duration of the silences created from the template.
+	Duration   model.Duration `json:"duration" yaml:"duration"`
+	Comment    string         `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Provenance Provenance     `json:"provenance,omitempty" yaml:"-"`
+}
+
+// swagger:model
+type SilenceTemplates []SilenceTemplate
+
+// RecurringSilence creates silences according to a cron expression or time intervals.
+// swagger:model
+type RecurringSilence struct {
+	UID  string `json:"uid" yaml:"uid"`
+	Name string `json:"name" yaml:"name"`
+	// UID of the silence template that provides the matchers, duration and comment that are not set.
+	TemplateUID string         `json:"templateUid,omitempty" yaml:"templateUid,omitempty"`
+	Matchers    ObjectMatchers `json:"matchers,omitempty" yaml:"matchers,omitempty"`
+	Comment     string         `json:"comment,omitempty" yaml:"comment,omitempty"`
+	// Cron expression that defines when the silences start.
+	Cron string `json:"cron,omitempty" yaml:"cron,omitempty"`
+	// Time zone of the cron expression. Defaults to UTC.
+	Location string `json:"location,omitempty" yaml:"location,omitempty"`
+	// Windows during which the silences are active, as in mute timings. Cannot be used together with cron.
+	TimeIntervals []timeinterval.TimeInterval `json:"time_intervals,omitempty" yaml:"time_intervals,omitempty"`
+	// Duration of the silences created by the cron expression.
+	Duration model.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+	// ID of the last silence created for the recurring silence.
+	// readonly: true
+	SilenceID  string     `json:"silenceId,omitempty" yaml:"-"`
+	Provenance Provenance `json:"provenance,omitempty" yaml:"-"`
+}
+
+// swagger:model
+type RecurringSilences []RecurringSilence
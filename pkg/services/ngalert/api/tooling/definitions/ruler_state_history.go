@@ -19,6 +19,25 @@ import "github.com/grafana/grafana-plugin-sdk-go/data"
 //       403: ForbiddenError
 //       500: Failure
 
+// swagger:route GET /v1/rules/history/stats history RouteGetStateHistoryStats
+//
+// Query statistics of state history.
+//
+// Aggregates the state history of alert instances into the time spent in each state, the number of transitions,
+// the mean time to resolve and a histogram of the durations of the firing periods. The statistics are returned per alert instance
+// and per group of instances that have the same values of the labels in the groupBy query parameter.
+// It accepts the same filters as RouteGetStateHistory.
+//   Example: /v1/rules/history/stats?groupBy=team&bucket=5m&bucket=1h
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: StateHistoryStats
+//       400: ValidationError
+//       403: ForbiddenError
+//       500: Failure
+
 // swagger:response StateHistory
 type StateHistory struct {
 	// in:body
@@ -27,7 +46,7 @@ type StateHistory struct {
 
 // StateHistoryParams is the struct used as parameters for the RouteGetStateHistory endpoint.
 //
-// swagger:parameters RouteGetStateHistory
+// swagger:parameters RouteGetStateHistory RouteGetStateHistoryStats
 type StateHistoryParams struct {
 	// The timestamp of the start point of the time range the history is obtained.
 	// in:query
@@ -50,4 +69,63 @@ type StateHistoryParams struct {
 	DashboardUID string
 	// Filter by dashboard's panel ID. Requires Dashboard UID to be specified.
 	PanelID int64
+	// Filter by JSON-encoded label matchers of the alert instances, as in the rules endpoint of the Prometheus API.
+	//   Example: {"name":"team","value":"db|web","isRegex":true,"isEqual":true}
+	// in:query
+	// required: false
+	Matcher []string `json:"matcher"`
+}
+
+// StateHistoryStatsParams is the struct used as additional parameters for the RouteGetStateHistoryStats endpoint.
+//
+// swagger:parameters RouteGetStateHistoryStats
+type StateHistoryStatsParams struct {
+	// Labels of the alert instances to group the statistics by.
+	// in:query
+	// required: false
+	GroupBy []string `json:"groupBy"`
+	// Upper bounds of the buckets of the firing duration histogram, for example 5m. Defaults to 1m, 5m, 15m, 1h, 4h and 1d.
+	// in:query
+	// required: false
+	Bucket []string `json:"bucket"`
+}
+
+// StateHistoryStats are statistics of the state history of alert instances.
+//
+// swagger:model
+type StateHistoryStats struct {
+	// Upper bounds of the buckets of the firing duration histograms, in seconds.
+	Buckets []float64                `json:"buckets"`
+	Groups  []StateHistoryStatsGroup `json:"groups"`
+	// True if the time range has more entries than can be read, the statistics then only account for the most recent ones.
+	Truncated bool `json:"truncated"`
+}
+
+// StateHistoryStatsGroup are the statistics of the alert instances that have the same values of the grouping labels.
+type StateHistoryStatsGroup struct {
+	Labels map[string]string `json:"labels"`
+	StateHistoryAggregate
+	Series []StateHistorySeriesStats `json:"series"`
+}
+
+// StateHistorySeriesStats are the statistics of a single alert instance.
+type StateHistorySeriesStats struct {
+	RuleUID string            `json:"ruleUID"`
+	Labels  map[string]string `json:"labels"`
+	StateHistoryAggregate
+}
+
+type StateHistoryAggregate struct {
+	// Number of state transitions.
+	Transitions int `json:"transitions"`
+	// Number of transitions into each state.
+	TransitionsByState map[string]int `json:"transitionsByState"`
+	// Time spent in each state, in seconds.
+	TimeInState map[string]float64 `json:"timeInState"`
+	// Number of firing periods that ended in the time range.
+	Resolved int `json:"resolved"`
+	// Mean time to resolve of the firing periods that ended in the time range, in seconds.
+	MTTR float64 `json:"mttr"`
+	// Number of firing periods per bucket. The last element counts the periods longer than the last bucket.
+	FiringHistogram []int `json:"firingHistogram"`
 }
@@ -68,6 +68,19 @@ import (
 //     Responses:
 //       200: BacktestResult
 
+// swagger:route Post /v1/rule/backtest/group testing BacktestGroupConfig
+//
+// Test rule group and the notifications it would send
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: BacktestGroupResult
+
 // swagger:parameters RouteTestReceiverConfig
 type TestReceiverRequest struct {
 	// in:body
@@ -234,3 +247,40 @@ type BacktestConfig struct {
 
 // swagger:model
 type BacktestResult data.Frame
+
+// swagger:parameters BacktestGroupConfig
+type BacktestGroupConfigRequest struct {
+	// in:body
+	Body BacktestGroupConfig
+}
+
+// swagger:model
+type BacktestGroupConfig struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	// example: okrd3I0Vz
+	NamespaceUID string                  `json:"folder_uid"`
+	RuleGroup    PostableRuleGroupConfig `json:"rule_group"`
+
+	// Alertmanager configuration whose routes and inhibition rules the alerts are sent through.
+	// If it is not set, the current configuration of the organization is used.
+	AlertmanagerConfig *PostableApiAlertingConfig `json:"alertmanager_config,omitempty"`
+}
+
+// swagger:model
+type BacktestGroupResult struct {
+	// States of the alert instances of each rule, in the same format as BacktestResult.
+	States []json.RawMessage `json:"states"`
+	// Notifications that would have been sent, ordered by time.
+	Notifications []BacktestNotification `json:"notifications"`
+}
+
+type BacktestNotification struct {
+	Timestamp   time.Time           `json:"timestamp"`
+	Receiver    string              `json:"receiver"`
+	GroupKey    string              `json:"group_key"`
+	GroupLabels map[string]string   `json:"group_labels"`
+	Firing      []map[string]string `json:"firing"`
+	Resolved    []map[string]string `json:"resolved"`
+}
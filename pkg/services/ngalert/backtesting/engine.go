@@ -11,6 +11,7 @@ import (
 	"github.com/benbjohnson/clock"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/common/model"
 
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -64,18 +65,96 @@ func NewEngine(appUrl *url.URL, evalFactory eval.EvaluatorFactory, tracer tracin
 }
 
 func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models.AlertRule, from, to time.Time) (*data.Frame, error) {
-	ruleCtx := models.WithRuleKey(ctx, rule.GetKey())
-	logger := logger.FromContext(ctx)
+	length, err := evaluationsInRange(from, to, rule.IntervalSeconds)
+	if err != nil {
+		return nil, err
+	}
+	stateManager := e.createStateManager()
+	return e.testRule(ctx, user, rule, stateManager, from, length, nil, nil)
+}
 
-	if !from.Before(to) {
-		return nil, fmt.Errorf("%w: invalid interval of the backtesting [%d,%d]", ErrInvalidInputData, from.Unix(), to.Unix())
+// GroupTest describes a rule group to backtest.
+type GroupTest struct {
+	Rules []*models.AlertRule
+	// ExtraLabels are the labels that the scheduler adds to the alerts of each rule, such as the folder title, by rule UID.
+	ExtraLabels map[string]data.Labels
+	From        time.Time
+	To          time.Time
+}
+
+// GroupResult is the result of the backtesting of a rule group.
+type GroupResult struct {
+	// Frames holds the states of each rule in the same format as Test, in the order of the rules.
+	Frames []*data.Frame
+	// Notifications are the notifications that would have been sent, ordered by time.
+	Notifications []Notification
+}
+
+// TestGroup evaluates all rules of the group over the range and sends the alerts they produce through the notification policy,
+// in the same way the Alertmanager would have dispatched them. All rules must have the same evaluation interval.
+// If the policy is nil, only the states of the rules are returned.
+func (e *Engine) TestGroup(ctx context.Context, user identity.Requester, group GroupTest, policy *NotificationPolicy) (*GroupResult, error) {
+	if len(group.Rules) == 0 {
+		return nil, fmt.Errorf("%w: rule group must have at least one rule", ErrInvalidInputData)
+	}
+	interval := group.Rules[0].IntervalSeconds
+	for _, rule := range group.Rules[1:] {
+		if rule.IntervalSeconds != interval {
+			return nil, fmt.Errorf("%w: all rules of the group must have the same evaluation interval", ErrInvalidInputData)
+		}
 	}
-	if to.Sub(from).Seconds() < float64(rule.IntervalSeconds) {
-		return nil, fmt.Errorf("%w: interval of the backtesting [%d,%d] is less than evaluation interval [%ds]", ErrInvalidInputData, from.Unix(), to.Unix(), rule.IntervalSeconds)
+	length, err := evaluationsInRange(group.From, group.To, interval)
+	if err != nil {
+		return nil, err
 	}
-	length := int(to.Sub(from).Seconds()) / int(rule.IntervalSeconds)
 
 	stateManager := e.createStateManager()
+	// alerts holds the alerts that are firing after each evaluation, across all rules of the group.
+	alerts := make([][]model.LabelSet, length)
+	result := &GroupResult{
+		Frames: make([]*data.Frame, 0, len(group.Rules)),
+	}
+	for _, rule := range group.Rules {
+		frame, err := e.testRule(ctx, user, rule, stateManager, group.From, length, group.ExtraLabels[rule.UID], func(idx int, states state.StateTransitions) {
+			alerts[idx] = append(alerts[idx], firingAlerts(states)...)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to test rule %s: %w", rule.UID, err)
+		}
+		frame.Name = rule.Title
+		result.Frames = append(result.Frames, frame)
+	}
+
+	if policy == nil {
+		return result, nil
+	}
+	step := time.Duration(interval) * time.Second
+	d := newDispatcher(policy)
+	for idx, firing := range alerts {
+		d.Process(group.From.Add(time.Duration(idx)*step), firing)
+	}
+	d.Flush(group.To)
+	result.Notifications = d.Notifications()
+	return result, nil
+}
+
+// evaluationsInRange returns the number of evaluations of a rule with the given interval in the range.
+func evaluationsInRange(from, to time.Time, intervalSeconds int64) (int, error) {
+	if !from.Before(to) {
+		return 0, fmt.Errorf("%w: invalid interval of the backtesting [%d,%d]", ErrInvalidInputData, from.Unix(), to.Unix())
+	}
+	if to.Sub(from).Seconds() < float64(intervalSeconds) {
+		return 0, fmt.Errorf("%w: interval of the backtesting [%d,%d] is less than evaluation interval [%ds]", ErrInvalidInputData, from.Unix(), to.Unix(), intervalSeconds)
+	}
+	return int(to.Sub(from).Seconds()) / int(intervalSeconds), nil
+}
+
+// testRule evaluates the rule the given number of times and returns a frame with the state of each alert instance at every evaluation.
+// If onStates is not nil, it is called with the state transitions of every evaluation.
+func (e *Engine) testRule(ctx context.Context, user identity.Requester, rule *models.AlertRule, stateManager stateManager, from time.Time, length int, extraLabels data.Labels, onStates func(idx int, states state.StateTransitions)) (*data.Frame, error) {
+	ruleCtx := models.WithRuleKey(ctx, rule.GetKey())
+	logger := logger.FromContext(ctx)
+	to := from.Add(time.Duration(length*int(rule.IntervalSeconds)) * time.Second)
 
 	evaluator, err := backtestingEvaluatorFactory(ruleCtx, e.evalFactory, user, rule.GetEvalCondition().WithSource("backtesting"), &schedule.AlertingResultsFromRuleState{
 		Manager: stateManager,
@@ -97,7 +176,10 @@ func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models
 			logger.Info("Unexpected evaluation. Skipping", "from", from, "to", to, "interval", rule.IntervalSeconds, "evaluationTime", currentTime, "evaluationIndex", idx, "expectedEvaluations", length)
 			return nil
 		}
-		states := stateManager.ProcessEvalResults(ruleCtx, currentTime, rule, results, nil, nil)
+		states := stateManager.ProcessEvalResults(ruleCtx, currentTime, rule, results, extraLabels, nil)
+		if onStates != nil {
+			onStates(idx, states)
+		}
 		tsField.Set(idx, currentTime)
 		for _, s := range states {
 			field, ok := valueFields[s.CacheID]
@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
@@ -382,6 +384,92 @@ func TestEvaluatorTest(t *testing.T) {
 	})
 }
 
+func TestEngineTestGroup(t *testing.T) {
+	evaluator := &fakeBacktestingEvaluator{
+		evalCallback: func(now time.Time) (eval.Results, error) {
+			return eval.GenerateResults(1, eval.ResultGen()), nil
+		},
+	}
+	backtestingEvaluatorFactory = func(ctx context.Context, evalFactory eval.EvaluatorFactory, user identity.Requester, condition models.Condition, r eval.AlertingResultsReader) (backtestingEvaluator, error) {
+		return evaluator, nil
+	}
+	t.Cleanup(func() {
+		backtestingEvaluatorFactory = newBacktestingEvaluator
+	})
+
+	from := time.Unix(0, 0)
+	firing := state.StateTransition{
+		State: &state.State{
+			CacheID: data.Labels{"alertname": "test"}.Fingerprint(),
+			Labels:  data.Labels{"alertname": "test"},
+			State:   eval.Alerting,
+		},
+	}
+	manager := &fakeStateManager{
+		stateCallback: func(now time.Time) []state.StateTransition {
+			if now.Before(from.Add(3 * time.Minute)) {
+				return []state.StateTransition{firing}
+			}
+			return nil
+		},
+	}
+	engine := &Engine{
+		createStateManager: func() stateManager {
+			return manager
+		},
+	}
+	gen := models.RuleGen
+	rules := gen.With(gen.WithInterval(time.Minute)).GenerateManyRef(2)
+	policy := NewNotificationPolicy(&config.Route{Receiver: "default"}, nil)
+
+	t.Run("should return the states of every rule and the notifications", func(t *testing.T) {
+		result, err := engine.TestGroup(context.Background(), nil, GroupTest{
+			Rules: rules,
+			From:  from,
+			To:    from.Add(10 * time.Minute),
+		}, policy)
+		require.NoError(t, err)
+		require.Len(t, result.Frames, len(rules))
+		for i, frame := range result.Frames {
+			require.Equal(t, rules[i].Title, frame.Name)
+			require.Equal(t, 10, frame.Rows())
+		}
+		require.Len(t, result.Notifications, 2)
+		require.Equal(t, "default", result.Notifications[0].Receiver)
+		require.Equal(t, []model.LabelSet{{"alertname": "test"}}, result.Notifications[0].Firing)
+		require.Equal(t, []model.LabelSet{{"alertname": "test"}}, result.Notifications[1].Resolved)
+	})
+
+	t.Run("should not return notifications without policy", func(t *testing.T) {
+		result, err := engine.TestGroup(context.Background(), nil, GroupTest{
+			Rules: rules,
+			From:  from,
+			To:    from.Add(10 * time.Minute),
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, result.Frames, len(rules))
+		require.Empty(t, result.Notifications)
+	})
+
+	t.Run("should fail if rules have different intervals", func(t *testing.T) {
+		other := gen.With(gen.WithInterval(2 * time.Minute)).GenerateRef()
+		_, err := engine.TestGroup(context.Background(), nil, GroupTest{
+			Rules: append([]*models.AlertRule{other}, rules...),
+			From:  from,
+			To:    from.Add(10 * time.Minute),
+		}, policy)
+		require.ErrorIs(t, err, ErrInvalidInputData)
+	})
+
+	t.Run("should fail if there are no rules", func(t *testing.T) {
+		_, err := engine.TestGroup(context.Background(), nil, GroupTest{
+			From: from,
+			To:   from.Add(10 * time.Minute),
+		}, policy)
+		require.ErrorIs(t, err, ErrInvalidInputData)
+	})
+}
+
 type fakeStateManager struct {
 	stateCallback func(now time.Time) []state.StateTransition
 }
@@ -0,0 +1,300 @@
+package backtesting
+
+import (
+	"sort"
+	"time"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/inhibit"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+// NotificationPolicy is the routing tree and the inhibition rules that the alerts produced by backtesting are sent through.
+type NotificationPolicy struct {
+	route        *dispatch.Route
+	inhibitRules []*inhibit.InhibitRule
+}
+
+// NewNotificationPolicy creates a NotificationPolicy from the root route and the inhibition rules of an Alertmanager configuration.
+func NewNotificationPolicy(route *config.Route, inhibitRules []config.InhibitRule) *NotificationPolicy {
+	rules := make([]*inhibit.InhibitRule, 0, len(inhibitRules))
+	for _, r := range inhibitRules {
+		rules = append(rules, inhibit.NewInhibitRule(r))
+	}
+	return &NotificationPolicy{
+		route:        dispatch.NewRoute(route, nil),
+		inhibitRules: rules,
+	}
+}
+
+// inhibited returns true if the alert is muted by one of the firing alerts.
+func (p *NotificationPolicy) inhibited(alert model.LabelSet, firing map[model.Fingerprint]model.LabelSet) bool {
+	fp := alert.Fingerprint()
+	for _, r := range p.inhibitRules {
+		if !r.TargetMatchers.Matches(alert) {
+			continue
+		}
+		// an alert that matches both sides of the rule cannot be muted by other alerts that match both sides too
+		twoSided := r.SourceMatchers.Matches(alert)
+		for sourceFp, source := range firing {
+			if sourceFp == fp || !r.SourceMatchers.Matches(source) {
+				continue
+			}
+			if twoSided && r.TargetMatchers.Matches(source) {
+				continue
+			}
+			if equalLabels(r.Equal, alert, source) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func equalLabels(names map[model.LabelName]struct{}, a, b model.LabelSet) bool {
+	for name := range names {
+		if a[name] != b[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// Notification is a notification that would have been sent to a receiver during the backtesting.
+type Notification struct {
+	Timestamp   time.Time
+	Receiver    string
+	GroupKey    string
+	GroupLabels model.LabelSet
+	Firing      []model.LabelSet
+	Resolved    []model.LabelSet
+}
+
+// firingAlerts returns the labels of the alerts that the state manager would send to the Alertmanager as firing.
+func firingAlerts(states state.StateTransitions) []model.LabelSet {
+	result := make([]model.LabelSet, 0, len(states))
+	for _, s := range states {
+		if s.State.State != eval.Alerting && s.State.State != eval.NoData && s.State.State != eval.Error {
+			continue
+		}
+		alert := state.StateToPostableAlert(s, nil)
+		labels := make(model.LabelSet, len(alert.Labels))
+		for k, v := range alert.Labels {
+			labels[model.LabelName(k)] = model.LabelValue(v)
+		}
+		result = append(result, labels)
+	}
+	return result
+}
+
+// groupAlert is an alert in an aggregation group.
+type groupAlert struct {
+	labels   model.LabelSet
+	resolved bool
+}
+
+// aggrGroup mirrors the aggregation group of the Alertmanager dispatcher: the alerts that share a route and the values of its group_by labels.
+type aggrGroup struct {
+	key    string
+	route  *dispatch.Route
+	labels model.LabelSet
+	alerts map[model.Fingerprint]*groupAlert
+	next   time.Time
+}
+
+// logEntry is the last notification sent for a group, like an entry of the Alertmanager notification log.
+type logEntry struct {
+	timestamp time.Time
+	firing    map[model.Fingerprint]struct{}
+	resolved  map[model.Fingerprint]struct{}
+}
+
+// dispatcher replays the alerts produced by the backtesting through the notification policy. It follows the grouping,
+// group_wait, group_interval and repeat_interval of the routes, the deduplication of the notification log and the inhibition rules,
+// assuming that every receiver sends resolved notifications. Silences and time intervals are not applied.
+type dispatcher struct {
+	policy        *NotificationPolicy
+	firing        map[model.Fingerprint]model.LabelSet
+	groups        map[string]*aggrGroup
+	log           map[string]*logEntry
+	notifications []Notification
+}
+
+func newDispatcher(policy *NotificationPolicy) *dispatcher {
+	return &dispatcher{
+		policy: policy,
+		firing: map[model.Fingerprint]model.LabelSet{},
+		groups: map[string]*aggrGroup{},
+		log:    map[string]*logEntry{},
+	}
+}
+
+// Process flushes the groups that are due before the evaluation at now, updates the groups with the alerts
+// that are firing after the evaluation, and flushes the groups that are due at now.
+func (d *dispatcher) Process(now time.Time, alerts []model.LabelSet) {
+	d.flushBefore(now, false)
+
+	firing := make(map[model.Fingerprint]model.LabelSet, len(alerts))
+	for _, a := range alerts {
+		firing[a.Fingerprint()] = a
+	}
+	for _, g := range d.groups {
+		for fp, a := range g.alerts {
+			_, ok := firing[fp]
+			a.resolved = !ok
+		}
+	}
+	for fp, a := range firing {
+		if _, ok := d.firing[fp]; ok {
+			continue
+		}
+		for _, r := range d.policy.route.Match(a) {
+			d.insert(now, r, fp, a)
+		}
+	}
+	d.firing = firing
+
+	d.flushBefore(now, true)
+}
+
+// Flush flushes all the groups that are due until the given time.
+func (d *dispatcher) Flush(until time.Time) {
+	d.flushBefore(until, true)
+}
+
+// Notifications returns the notifications sent so far.
+func (d *dispatcher) Notifications() []Notification {
+	return d.notifications
+}
+
+func (d *dispatcher) insert(now time.Time, route *dispatch.Route, fp model.Fingerprint, alert model.LabelSet) {
+	groupLabels := model.LabelSet{}
+	for name, value := range alert {
+		if _, ok := route.RouteOpts.GroupBy[name]; ok || route.RouteOpts.GroupByAll {
+			groupLabels[name] = value
+		}
+	}
+	key := route.Key() + ":" + groupLabels.String()
+	g, ok := d.groups[key]
+	if !ok {
+		g = &aggrGroup{
+			key:    key,
+			route:  route,
+			labels: groupLabels,
+			alerts: map[model.Fingerprint]*groupAlert{},
+			next:   now.Add(route.RouteOpts.GroupWait),
+		}
+		d.groups[key] = g
+	}
+	g.alerts[fp] = &groupAlert{labels: alert}
+}
+
+// flushBefore flushes the groups that are due before the given time, or at that time if inclusive is true, in order of time.
+func (d *dispatcher) flushBefore(t time.Time, inclusive bool) {
+	for {
+		var due *aggrGroup
+		for _, g := range d.groups {
+			if g.next.After(t) || (!inclusive && g.next.Equal(t)) {
+				continue
+			}
+			if due == nil || g.next.Before(due.next) || (g.next.Equal(due.next) && g.key < due.key) {
+				due = g
+			}
+		}
+		if due == nil {
+			return
+		}
+		d.flush(due)
+	}
+}
+
+// flush sends the notification of the group if it is needed, drops the resolved alerts from the group and schedules the next flush.
+func (d *dispatcher) flush(g *aggrGroup) {
+	now := g.next
+	firing := map[model.Fingerprint]struct{}{}
+	resolved := map[model.Fingerprint]struct{}{}
+	var firingLabels, resolvedLabels []model.LabelSet
+	for fp, a := range g.alerts {
+		if d.policy.inhibited(a.labels, d.firing) {
+			continue
+		}
+		if a.resolved {
+			resolved[fp] = struct{}{}
+			resolvedLabels = append(resolvedLabels, a.labels)
+		} else {
+			firing[fp] = struct{}{}
+			firingLabels = append(firingLabels, a.labels)
+		}
+	}
+
+	receiver := g.route.RouteOpts.Receiver
+	logKey := g.key + "/" + receiver
+	entry := d.log[logKey]
+	if needsUpdate(entry, firing, resolved, now, g.route.RouteOpts.RepeatInterval) {
+		sortLabelSets(firingLabels)
+		sortLabelSets(resolvedLabels)
+		d.notifications = append(d.notifications, Notification{
+			Timestamp:   now,
+			Receiver:    receiver,
+			GroupKey:    g.key,
+			GroupLabels: g.labels,
+			Firing:      firingLabels,
+			Resolved:    resolvedLabels,
+		})
+		d.log[logKey] = &logEntry{
+			timestamp: now,
+			firing:    firing,
+			resolved:  resolved,
+		}
+	}
+
+	for fp, a := range g.alerts {
+		if a.resolved {
+			delete(g.alerts, fp)
+		}
+	}
+	if len(g.alerts) == 0 {
+		delete(d.groups, g.key)
+		return
+	}
+	g.next = now.Add(g.route.RouteOpts.GroupInterval)
+}
+
+// needsUpdate follows the rules of the Alertmanager deduplication stage.
+func needsUpdate(entry *logEntry, firing, resolved map[model.Fingerprint]struct{}, now time.Time, repeat time.Duration) bool {
+	// notify right away about a group that was not notified before, unless it only has resolved alerts
+	if entry == nil {
+		return len(firing) > 0
+	}
+	if !isSubset(firing, entry.firing) {
+		return true
+	}
+	// notify about all alerts being resolved, unless the receiver was not notified about them while they were firing
+	if len(firing) == 0 {
+		return len(entry.firing) > 0
+	}
+	if !isSubset(resolved, entry.resolved) {
+		return true
+	}
+	// nothing changed, notify only if the repeat interval has passed
+	return entry.timestamp.Before(now.Add(-repeat))
+}
+
+func isSubset(subset, set map[model.Fingerprint]struct{}) bool {
+	for fp := range subset {
+		if _, ok := set[fp]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func sortLabelSets(sets []model.LabelSet) {
+	sort.Slice(sets, func(i, j int) bool {
+		return sets[i].String() < sets[j].String()
+	})
+}
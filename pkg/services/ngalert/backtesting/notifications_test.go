@@ -0,0 +1,155 @@
+package backtesting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+func TestDispatcher(t *testing.T) {
+	matcher := func(name, value string) *labels.Matcher {
+		m, err := labels.NewMatcher(labels.MatchEqual, name, value)
+		require.NoError(t, err)
+		return m
+	}
+	groupWait, groupInterval, repeatInterval := model.Duration(30*time.Second), model.Duration(5*time.Minute), model.Duration(time.Hour)
+	route := &config.Route{
+		Receiver:       "default",
+		GroupBy:        []model.LabelName{model.AlertNameLabel},
+		GroupWait:      &groupWait,
+		GroupInterval:  &groupInterval,
+		RepeatInterval: &repeatInterval,
+		Routes: []*config.Route{
+			{
+				Receiver: "db",
+				Matchers: config.Matchers{matcher("team", "db")},
+			},
+		},
+	}
+	inhibitRules := []config.InhibitRule{
+		{
+			SourceMatchers: config.Matchers{matcher(model.AlertNameLabel, "Critical")},
+			TargetMatchers: config.Matchers{matcher(model.AlertNameLabel, "A")},
+		},
+	}
+
+	at := func(d time.Duration) time.Time {
+		return time.Unix(0, 0).Add(d)
+	}
+	a1 := model.LabelSet{model.AlertNameLabel: "A", "instance": "1"}
+	a2 := model.LabelSet{model.AlertNameLabel: "A", "instance": "2"}
+	db := model.LabelSet{model.AlertNameLabel: "B", "team": "db"}
+	critical := model.LabelSet{model.AlertNameLabel: "Critical"}
+
+	t.Run("should group alerts and notify about changes after group interval", func(t *testing.T) {
+		d := newDispatcher(NewNotificationPolicy(route, nil))
+		d.Process(at(0), []model.LabelSet{a1})
+		d.Process(at(time.Minute), []model.LabelSet{a1, a2})
+		for m := 2; m <= 11; m++ {
+			d.Process(at(time.Duration(m)*time.Minute), []model.LabelSet{a2})
+		}
+		d.Flush(at(12 * time.Minute))
+
+		notifications := d.Notifications()
+		require.Len(t, notifications, 2)
+		require.Equal(t, at(30*time.Second), notifications[0].Timestamp)
+		require.Equal(t, "default", notifications[0].Receiver)
+		require.Equal(t, model.LabelSet{model.AlertNameLabel: "A"}, notifications[0].GroupLabels)
+		require.Equal(t, []model.LabelSet{a1}, notifications[0].Firing)
+		require.Empty(t, notifications[0].Resolved)
+
+		require.Equal(t, at(5*time.Minute+30*time.Second), notifications[1].Timestamp)
+		require.Equal(t, notifications[0].GroupKey, notifications[1].GroupKey)
+		require.Equal(t, []model.LabelSet{a2}, notifications[1].Firing)
+		require.Equal(t, []model.LabelSet{a1}, notifications[1].Resolved)
+	})
+
+	t.Run("should repeat notifications after repeat interval", func(t *testing.T) {
+		d := newDispatcher(NewNotificationPolicy(route, nil))
+		for m := 0; m < 70; m++ {
+			d.Process(at(time.Duration(m)*time.Minute), []model.LabelSet{a1})
+		}
+		d.Flush(at(70 * time.Minute))
+
+		notifications := d.Notifications()
+		require.Len(t, notifications, 2)
+		require.Equal(t, at(30*time.Second), notifications[0].Timestamp)
+		require.Equal(t, at(65*time.Minute+30*time.Second), notifications[1].Timestamp)
+		require.Equal(t, []model.LabelSet{a1}, notifications[1].Firing)
+	})
+
+	t.Run("should send alerts to the receiver of the matching route", func(t *testing.T) {
+		d := newDispatcher(NewNotificationPolicy(route, nil))
+		d.Process(at(0), []model.LabelSet{a1, db})
+		d.Flush(at(time.Minute))
+
+		notifications := d.Notifications()
+		require.Len(t, notifications, 2)
+		receivers := map[string][]model.LabelSet{}
+		for _, n := range notifications {
+			receivers[n.Receiver] = n.Firing
+		}
+		require.Equal(t, map[string][]model.LabelSet{
+			"default": {a1},
+			"db":      {db},
+		}, receivers)
+	})
+
+	t.Run("should not notify about alerts resolved before the group was flushed", func(t *testing.T) {
+		d := newDispatcher(NewNotificationPolicy(route, nil))
+		d.Process(at(0), []model.LabelSet{a1})
+		d.Process(at(10*time.Second), nil)
+		d.Flush(at(time.Hour))
+
+		require.Empty(t, d.Notifications())
+	})
+
+	t.Run("should not notify about inhibited alerts", func(t *testing.T) {
+		d := newDispatcher(NewNotificationPolicy(route, inhibitRules))
+		d.Process(at(0), []model.LabelSet{critical, a1})
+		d.Process(at(time.Minute), []model.LabelSet{a1})
+		d.Flush(at(6 * time.Minute))
+
+		notifications := d.Notifications()
+		require.Len(t, notifications, 3)
+		require.Equal(t, at(30*time.Second), notifications[0].Timestamp)
+		require.Equal(t, []model.LabelSet{critical}, notifications[0].Firing)
+
+		require.Equal(t, at(5*time.Minute+30*time.Second), notifications[1].Timestamp)
+		require.Equal(t, []model.LabelSet{a1}, notifications[1].Firing)
+
+		require.Equal(t, at(5*time.Minute+30*time.Second), notifications[2].Timestamp)
+		require.Empty(t, notifications[2].Firing)
+		require.Equal(t, []model.LabelSet{critical}, notifications[2].Resolved)
+	})
+}
+
+func TestFiringAlerts(t *testing.T) {
+	transition := func(s eval.State, name string) state.StateTransition {
+		return state.StateTransition{
+			State: &state.State{
+				State:  s,
+				Labels: map[string]string{model.AlertNameLabel: name},
+			},
+		}
+	}
+	alerts := firingAlerts(state.StateTransitions{
+		transition(eval.Normal, "normal"),
+		transition(eval.Pending, "pending"),
+		transition(eval.Alerting, "alerting"),
+		transition(eval.NoData, "nodata"),
+		transition(eval.Error, "error"),
+	})
+	require.Equal(t, []model.LabelSet{
+		{model.AlertNameLabel: "alerting"},
+		{model.AlertNameLabel: state.NoDataAlertName, state.Rulename: "nodata"},
+		{model.AlertNameLabel: state.ErrorAlertName, state.Rulename: "error"},
+	}, alerts)
+}
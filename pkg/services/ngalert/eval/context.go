@@ -2,6 +2,7 @@ package eval
 
 import (
 	"context"
+	"errors"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
@@ -14,11 +15,22 @@ type AlertingResultsReader interface {
 	Read() map[data.Fingerprint]struct{}
 }
 
+// RuleResultsReader provides the results of the rules that are used by the input queries of the evaluated rule.
+// It is used during the evaluation of queries.
+type RuleResultsReader interface {
+	Read(ruleUID string) (data.Frames, error)
+}
+
+// ErrRuleResultsUnavailable is returned when a condition that uses the results of other rules is evaluated without a RuleResultsReader,
+// e.g. when a rule is previewed or backtested. The results of other rules are only available to the scheduler.
+var ErrRuleResultsUnavailable = errors.New("the results of other rules are only available when the rule group is evaluated by the scheduler")
+
 // EvaluationContext represents the context in which a condition is evaluated.
 type EvaluationContext struct {
 	Ctx                   context.Context
 	User                  identity.Requester
 	AlertingResultsReader AlertingResultsReader
+	RuleResultsReader     RuleResultsReader
 }
 
 func NewContext(ctx context.Context, user identity.Requester) EvaluationContext {
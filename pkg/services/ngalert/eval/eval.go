@@ -413,6 +413,24 @@ func getExprRequest(ctx EvaluationContext, condition models.Condition, dsCacheSe
 			return nil, fmt.Errorf("failed to retrieve maxDatapoints from '%s': %w", q.RefID, err)
 		}
 
+		var frames data.Frames
+		if ds.Type == expr.InputDatasourceUID {
+			ruleUID, err := q.GetInputRuleUID()
+			if err != nil {
+				return nil, fmt.Errorf("failed to build query '%s': %w", q.RefID, err)
+			}
+			// the reader is not available when the condition is only validated, Create rejects input queries without it.
+			if ctx.RuleResultsReader != nil {
+				frames, err = ctx.RuleResultsReader.Read(ruleUID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get results of rule '%s' for query '%s': %w", ruleUID, q.RefID, err)
+				}
+				if frames == nil {
+					frames = data.Frames{}
+				}
+			}
+		}
+
 		req.Queries = append(req.Queries, expr.Query{
 			TimeRange:     q.RelativeTimeRange.ToTimeRange(),
 			DataSource:    ds,
@@ -421,6 +439,7 @@ func getExprRequest(ctx EvaluationContext, condition models.Condition, dsCacheSe
 			RefID:         q.RefID,
 			MaxDataPoints: maxDatapoints,
 			QueryType:     q.QueryType,
+			Frames:        frames,
 		})
 	}
 	return req, nil
@@ -830,6 +849,14 @@ func (e *evaluatorImpl) Create(ctx EvaluationContext, condition models.Condition
 	if len(condition.Condition) == 0 {
 		return nil, errors.New("condition must not be empty")
 	}
+	// input queries are only populated when the rule is evaluated by the scheduler along with the rules of its group.
+	if ctx.RuleResultsReader == nil {
+		for _, q := range condition.Data {
+			if q.IsInput() {
+				return nil, fmt.Errorf("%w: query '%s' uses the results of another rule", ErrRuleResultsUnavailable, q.RefID)
+			}
+		}
+	}
 	req, err := getExprRequest(ctx, condition, e.dataSourceCache, ctx.AlertingResultsReader)
 	if err != nil {
 		return nil, err
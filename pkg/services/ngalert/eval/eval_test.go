@@ -2,6 +2,7 @@ package eval
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -1294,6 +1295,75 @@ func TestCreate(t *testing.T) {
 
 		require.Equal(t, expectedHeaders, request.Headers)
 	})
+
+	t.Run("should populate input queries with the results of rules", func(t *testing.T) {
+		input := models.AlertQuery{
+			RefID:         "A",
+			DatasourceUID: expr.InputDatasourceUID,
+			Model:         json.RawMessage(`{"ruleUid": "recording"}`),
+		}
+		q := models.CreateClassicConditionExpression("B", "A", "avg", "gt", 1)
+		condition := models.Condition{
+			Condition: q.RefID,
+			Data:      []models.AlertQuery{input, q},
+		}
+		frames := data.Frames{data.NewFrame("test")}
+
+		var request *expr.Request
+		factory := evaluatorImpl{
+			expressionService: fakeExpressionService{
+				buildHook: func(req *expr.Request) (expr.DataPipeline, error) {
+					request = req
+					return expr.DataPipeline{
+						fakeNode{refID: q.RefID},
+					}, nil
+				},
+			},
+		}
+
+		evalCtx := NewContext(context.Background(), &user.SignedInUser{})
+		evalCtx.RuleResultsReader = fakeRuleResultsReader{"recording": frames}
+		_, err := factory.Create(evalCtx, condition)
+		require.NoError(t, err)
+		require.Len(t, request.Queries, 2)
+		require.Equal(t, expr.InputDatasourceUID, request.Queries[0].DataSource.UID)
+		require.Equal(t, frames, request.Queries[0].Frames)
+
+		evalCtx.RuleResultsReader = fakeRuleResultsReader{}
+		_, err = factory.Create(evalCtx, condition)
+		require.ErrorContains(t, err, "failed to get results of rule 'recording'")
+
+		input.Model = json.RawMessage(`{}`)
+		condition.Data = []models.AlertQuery{input, q}
+		_, err = factory.Create(evalCtx, condition)
+		require.ErrorIs(t, err, models.ErrNoInputRule)
+	})
+
+	t.Run("should reject input queries without the results of rules", func(t *testing.T) {
+		input := models.AlertQuery{
+			RefID:         "A",
+			DatasourceUID: expr.InputDatasourceUID,
+			Model:         json.RawMessage(`{"ruleUid": "recording"}`),
+		}
+		q := models.CreateClassicConditionExpression("B", "A", "avg", "gt", 1)
+		factory := evaluatorImpl{expressionService: fakeExpressionService{}}
+
+		_, err := factory.Create(NewContext(context.Background(), &user.SignedInUser{}), models.Condition{
+			Condition: q.RefID,
+			Data:      []models.AlertQuery{input, q},
+		})
+		require.ErrorIs(t, err, ErrRuleResultsUnavailable)
+	})
+}
+
+type fakeRuleResultsReader map[string]data.Frames
+
+func (f fakeRuleResultsReader) Read(ruleUID string) (data.Frames, error) {
+	frames, ok := f[ruleUID]
+	if !ok {
+		return nil, errors.New("rule is not evaluated")
+	}
+	return frames, nil
 }
 
 type fakeExpressionService struct {
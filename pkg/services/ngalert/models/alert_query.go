@@ -15,6 +15,7 @@ const defaultMaxDataPoints float64 = 43200 // 12 hours at 1sec interval
 const defaultIntervalMS float64 = 1000
 
 var ErrNoQuery = errors.New("no `expr` property in the query model")
+var ErrNoInputRule = errors.New("no `ruleUid` property in the input query model")
 
 // Duration is a type used for marshalling durations.
 type Duration time.Duration
@@ -118,6 +119,27 @@ func (aq *AlertQuery) IsExpression() (bool, error) {
 	return expr.NodeTypeFromDatasourceUID(aq.DatasourceUID) == expr.TypeCMDNode, nil
 }
 
+// IsInput returns true if the alert query uses the results of another rule of the same group.
+func (aq *AlertQuery) IsInput() bool {
+	return expr.NodeTypeFromDatasourceUID(aq.DatasourceUID) == expr.TypeInputNode
+}
+
+// GetInputRuleUID returns the UID of the rule defined by `ruleUid` within the model of an input query.
+// Returns an ErrNoInputRule if it is unable to find it.
+// The model is not cached because the rule's dependencies are read concurrently with its evaluation.
+func (aq *AlertQuery) GetInputRuleUID() (string, error) {
+	var model struct {
+		RuleUID string `json:"ruleUid"`
+	}
+	if err := json.Unmarshal(aq.Model, &model); err != nil {
+		return "", fmt.Errorf("failed to unmarshal query model: %w", err)
+	}
+	if model.RuleUID == "" {
+		return "", ErrNoInputRule
+	}
+	return model.RuleUID, nil
+}
+
 // IsHysteresisExpression returns true if the model describes a hysteresis command expression. Returns error if the Model is not a valid JSON
 func (aq *AlertQuery) IsHysteresisExpression() (bool, error) {
 	if aq.modelProps == nil {
@@ -302,7 +324,7 @@ func (aq *AlertQuery) PreSave() error {
 		return err
 	}
 
-	if ok := isExpression || aq.RelativeTimeRange.isValid(); !ok {
+	if ok := isExpression || aq.IsInput() || aq.RelativeTimeRange.isValid(); !ok {
 		return ErrInvalidRelativeTimeRange(aq.RefID, aq.RelativeTimeRange)
 	}
 	return nil
@@ -355,6 +355,14 @@ func TestAlertQuery_PreSave(t *testing.T) {
 			}`,
 			errContains: "Invalid alert rule query B: invalid relative time range [From: 16m40s, To: 16m40s]",
 		},
+		{
+			desc: "no error when range is not set for input query",
+			blob: `{
+				"refId": "B",
+				"datasourceUid": "__input__",
+				"model": {"ruleUid": "test"}
+			}`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -407,3 +415,32 @@ func TestAlertQuery_GetQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestAlertQuery_GetInputRuleUID(t *testing.T) {
+	tc := []struct {
+		name       string
+		alertQuery AlertQuery
+		expected   string
+		err        error
+	}{
+		{
+			name:       "when a rule is present",
+			alertQuery: AlertQuery{DatasourceUID: expr.InputDatasourceUID, Model: json.RawMessage(`{"ruleUid": "test"}`)},
+			expected:   "test",
+		},
+		{
+			name:       "when no rule is found",
+			alertQuery: AlertQuery{DatasourceUID: expr.InputDatasourceUID, Model: json.RawMessage(`{"expr": "sum by (job) (up)"}`)},
+			err:        ErrNoInputRule,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			require.True(t, tt.alertQuery.IsInput())
+			actual, err := tt.alertQuery.GetInputRuleUID()
+			require.Equal(t, tt.err, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"maps"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -262,16 +263,22 @@ type AlertRule struct {
 	Record          *Record
 	NoDataState     NoDataState
 	ExecErrState    ExecutionErrorState
-	// ideally this field should have been apimodels.ApiDuration
+	// ideally these fields should have been apimodels.ApiDuration
 	// but this is currently not possible because of circular dependencies
 	For                  time.Duration
+	KeepFiringFor        time.Duration
 	Annotations          map[string]string
 	Labels               map[string]string
 	IsPaused             bool
 	NotificationSettings []NotificationSettings
 	Metadata             AlertRuleMetadata
+	// UpdatedBy is the user that made the latest change to the rule. Nil if it is unknown.
+	UpdatedBy *UserUID
 }
 
+// UserUID is the typed UID of a user, for example "user:abc".
+type UserUID string
+
 type AlertRuleMetadata struct {
 	EditorSettings EditorSettings `json:"editor_settings"`
 }
@@ -397,6 +404,22 @@ func (alertRule *AlertRule) GetEvalCondition() Condition {
 	}
 }
 
+// GetDependencies returns the UIDs of the rules whose results are used by the input queries of the rule.
+func (alertRule *AlertRule) GetDependencies() []string {
+	var result []string
+	for _, q := range alertRule.Data {
+		if !q.IsInput() {
+			continue
+		}
+		uid, err := q.GetInputRuleUID()
+		if err != nil || slices.Contains(result, uid) {
+			continue
+		}
+		result = append(result, uid)
+	}
+	return result
+}
+
 // Diff calculates diff between two alert rules. Returns nil if two rules are equal. Otherwise, returns cmputil.DiffReport
 func (alertRule *AlertRule) Diff(rule *AlertRule, ignore ...string) cmputil.DiffReport {
 	var reporter cmputil.DiffReporter
@@ -581,6 +604,10 @@ func (alertRule *AlertRule) ValidateAlertRule(cfg setting.UnifiedAlertingSetting
 		return fmt.Errorf("%w: field `for` cannot be negative", ErrAlertRuleFailedValidation)
 	}
 
+	if alertRule.KeepFiringFor < 0 {
+		return fmt.Errorf("%w: field `keep_firing_for` cannot be negative", ErrAlertRuleFailedValidation)
+	}
+
 	if len(alertRule.Labels) > 0 {
 		for label := range alertRule.Labels {
 			if _, ok := LabelsUserCannotSpecify[label]; ok {
@@ -657,6 +684,7 @@ func ClearRecordingRuleIgnoredFields(rule *AlertRule) {
 	rule.ExecErrState = ""
 	rule.Condition = ""
 	rule.For = 0
+	rule.KeepFiringFor = 0
 	rule.NotificationSettings = nil
 }
 
@@ -802,6 +830,9 @@ func PatchPartialAlertRule(existingRule *AlertRule, ruleToPatch *AlertRuleWithOp
 	if ruleToPatch.For == -1 {
 		ruleToPatch.For = existingRule.For
 	}
+	if ruleToPatch.KeepFiringFor == -1 {
+		ruleToPatch.KeepFiringFor = existingRule.KeepFiringFor
+	}
 	if !ruleToPatch.HasPause {
 		ruleToPatch.IsPaused = existingRule.IsPaused
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 
+	"github.com/grafana/grafana/pkg/expr"
 	"github.com/grafana/grafana/pkg/util"
 	"github.com/grafana/grafana/pkg/util/cmputil"
 )
@@ -908,3 +909,24 @@ func TestAlertRuleGetKeyWithGroup(t *testing.T) {
 		require.Equal(t, expected, rule.GetKeyWithGroup())
 	})
 }
+
+func TestAlertRuleGetDependencies(t *testing.T) {
+	input := func(refID, ruleUID string) AlertQuery {
+		return AlertQuery{
+			RefID:         refID,
+			DatasourceUID: expr.InputDatasourceUID,
+			Model:         json.RawMessage(fmt.Sprintf(`{"ruleUid": %q}`, ruleUID)),
+		}
+	}
+
+	t.Run("should return nil if rule has no input queries", func(t *testing.T) {
+		rule := RuleGen.GenerateRef()
+		require.Nil(t, rule.GetDependencies())
+	})
+
+	t.Run("should return unique rules of input queries", func(t *testing.T) {
+		rule := RuleGen.GenerateRef()
+		rule.Data = append(rule.Data, input("X", "rule-1"), input("Y", "rule-2"), input("Z", "rule-1"))
+		require.Equal(t, []string{"rule-1", "rule-2"}, rule.GetDependencies())
+	})
+}
@@ -3,6 +3,8 @@ package models
 import (
 	"time"
 
+	"github.com/prometheus/alertmanager/pkg/labels"
+
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 )
 
@@ -13,8 +15,68 @@ type HistoryQuery struct {
 	DashboardUID string
 	PanelID      int64
 	Labels       map[string]string
+	// Matchers filter the history by the labels of the alert instances in addition to Labels.
+	Matchers     labels.Matchers
 	From         time.Time
 	To           time.Time
 	Limit        int
 	SignedInUser identity.Requester
 }
+
+// DefaultHistoryStatsBuckets are the buckets of the firing duration histogram used when a query does not define them.
+var DefaultHistoryStatsBuckets = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+	4 * time.Hour,
+	24 * time.Hour,
+}
+
+// HistoryStatsQuery represents a query for statistics aggregated from alert state history.
+type HistoryStatsQuery struct {
+	HistoryQuery
+	// GroupBy is the list of instance labels the series are grouped by. If empty, all series are in a single group.
+	GroupBy []string
+	// Buckets are the upper bounds of the buckets of the firing duration histogram, in ascending order.
+	Buckets []time.Duration
+}
+
+// HistoryStats are statistics of the state transitions of a set of alert instances over a time range.
+type HistoryStats struct {
+	// Transitions is the number of state transitions.
+	Transitions int
+	// TransitionsByState is the number of transitions into each state.
+	TransitionsByState map[string]int
+	// TimeInState is the time spent in each state. It only accounts for the series that had at least one transition in the time range.
+	TimeInState map[string]time.Duration
+	// Resolved is the number of firing periods that ended in the time range.
+	Resolved int
+	// FiringDuration is the total duration of the resolved firing periods.
+	FiringDuration time.Duration
+	// FiringHistogram is the number of resolved firing periods per bucket of HistoryStatsQuery.Buckets.
+	// The last element counts the periods longer than the last bucket.
+	FiringHistogram []int
+}
+
+// MTTR returns the mean time to resolve of the resolved firing periods.
+func (s HistoryStats) MTTR() time.Duration {
+	if s.Resolved == 0 {
+		return 0
+	}
+	return s.FiringDuration / time.Duration(s.Resolved)
+}
+
+// HistorySeriesStats are the statistics of a single alert instance.
+type HistorySeriesStats struct {
+	RuleUID string
+	Labels  map[string]string
+	HistoryStats
+}
+
+// HistoryStatsGroup are the statistics of the series that share the values of the labels they are grouped by.
+type HistoryStatsGroup struct {
+	Labels map[string]string
+	HistoryStats
+	Series []HistorySeriesStats
+}
@@ -0,0 +1,225 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/robfig/cron/v3"
+
+	"github.com/grafana/alerting/notify"
+)
+
+var (
+	ErrSilenceTemplateNotFound  = errors.New("silence template not found")
+	ErrRecurringSilenceNotFound = errors.New("recurring silence not found")
+	// ErrRecurringSilenceStateChanged is returned when the last silence of a recurring silence was updated concurrently.
+	ErrRecurringSilenceStateChanged = errors.New("state of the recurring silence was changed concurrently")
+)
+
+// maxRecurringSilenceWindow is the maximum length of a window of a recurring silence defined by time intervals.
+// Longer windows are extended as time passes.
+const maxRecurringSilenceWindow = 7 * 24 * time.Hour
+
+// SilenceTemplate is a saved set of matchers with a default duration that silences can be created from.
+type SilenceTemplate struct {
+	UID      string
+	OrgID    int64
+	Name     string
+	Matchers labels.Matchers
+	Duration time.Duration
+	Comment  string
+	Updated  time.Time
+}
+
+func (t *SilenceTemplate) ResourceType() string {
+	return "silenceTemplate"
+}
+
+func (t *SilenceTemplate) ResourceID() string {
+	return t.UID
+}
+
+// Validate returns an error if the template cannot be used to create silences.
+func (t *SilenceTemplate) Validate() error {
+	var errs []error
+	if t.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if len(t.Matchers) == 0 {
+		errs = append(errs, errors.New("at least one matcher is required"))
+	}
+	if t.Duration <= 0 {
+		errs = append(errs, errors.New("duration must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// RecurringSilenceSchedule defines when the silences of a recurring silence are active.
+// Exactly one of Cron and TimeIntervals must be set.
+type RecurringSilenceSchedule struct {
+	// Cron is a cron expression that defines when the silences start. Each silence lasts for the duration of the recurring silence.
+	Cron string `json:"cron,omitempty"`
+	// Location is the name of the time zone the cron expression is evaluated in. Defaults to UTC.
+	Location string `json:"location,omitempty"`
+	// TimeIntervals define the windows during which the silences are active, the same way as mute timings do.
+	TimeIntervals []timeinterval.TimeInterval `json:"time_intervals,omitempty"`
+}
+
+// RecurringSilence creates silences according to a schedule.
+type RecurringSilence struct {
+	UID         string
+	OrgID       int64
+	Name        string
+	TemplateUID string
+	Matchers    labels.Matchers
+	Comment     string
+	CreatedBy   string
+	Schedule    RecurringSilenceSchedule
+	// Duration is the duration of the silences created by a cron schedule.
+	Duration time.Duration
+	Updated  time.Time
+
+	// SilenceID is the ID of the last silence created for the recurring silence.
+	SilenceID string
+	// WindowStart is the start of the window of the last silence created for the recurring silence.
+	WindowStart time.Time
+}
+
+func (s *RecurringSilence) ResourceType() string {
+	return "recurringSilence"
+}
+
+func (s *RecurringSilence) ResourceID() string {
+	return s.UID
+}
+
+// Validate returns an error if the recurring silence cannot create silences.
+func (s *RecurringSilence) Validate() error {
+	var errs []error
+	if s.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if len(s.Matchers) == 0 {
+		errs = append(errs, errors.New("at least one matcher is required"))
+	}
+	switch {
+	case s.Schedule.Cron != "" && len(s.Schedule.TimeIntervals) > 0:
+		errs = append(errs, errors.New("schedule must have either a cron expression or time intervals, not both"))
+	case s.Schedule.Cron != "":
+		if _, err := cron.ParseStandard(s.Schedule.Cron); err != nil {
+			errs = append(errs, fmt.Errorf("invalid cron expression: %w", err))
+		}
+		if s.Duration <= 0 {
+			errs = append(errs, errors.New("duration must be positive"))
+		}
+		if _, err := time.LoadLocation(s.Schedule.Location); err != nil {
+			errs = append(errs, fmt.Errorf("invalid location: %w", err))
+		}
+	case len(s.Schedule.TimeIntervals) > 0:
+		if s.Schedule.Location != "" {
+			errs = append(errs, errors.New("location is not supported with time intervals, set it in each time interval instead"))
+		}
+	default:
+		errs = append(errs, errors.New("schedule must have either a cron expression or time intervals"))
+	}
+	return errors.Join(errs...)
+}
+
+// Window returns the window of the silence that is active at the given time or that starts within the lookahead.
+// It returns false if there is no such window.
+func (s *RecurringSilence) Window(now time.Time, lookahead time.Duration) (time.Time, time.Time, bool) {
+	if s.Schedule.Cron != "" {
+		return s.cronWindow(now, lookahead)
+	}
+	return s.timeIntervalsWindow(now, lookahead)
+}
+
+func (s *RecurringSilence) cronWindow(now time.Time, lookahead time.Duration) (time.Time, time.Time, bool) {
+	schedule, err := cron.ParseStandard(s.Schedule.Cron)
+	if err != nil || s.Duration <= 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	loc, err := time.LoadLocation(s.Schedule.Location)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	// the first occurrence after now - duration is the one whose window has not ended yet.
+	start := schedule.Next(now.In(loc).Add(-s.Duration))
+	if start.After(now.Add(lookahead)) {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, start.Add(s.Duration), true
+}
+
+func (s *RecurringSilence) timeIntervalsWindow(now time.Time, lookahead time.Duration) (time.Time, time.Time, bool) {
+	// time intervals have a resolution of a minute.
+	start := now.Truncate(time.Minute)
+	for !s.activeAt(start) {
+		start = start.Add(time.Minute)
+		if start.After(now.Add(lookahead)) {
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	// find the actual start of an active window so that it is the same at every tick.
+	for limit := now.Add(-maxRecurringSilenceWindow); start.After(limit) && s.activeAt(start.Add(-time.Minute)); {
+		start = start.Add(-time.Minute)
+	}
+	end := start.Add(time.Minute)
+	for limit := now.Add(maxRecurringSilenceWindow); end.Before(limit) && s.activeAt(end); {
+		end = end.Add(time.Minute)
+	}
+	return start, end, true
+}
+
+func (s *RecurringSilence) activeAt(t time.Time) bool {
+	for _, interval := range s.Schedule.TimeIntervals {
+		if interval.ContainsTime(t.UTC()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Silence returns the silence of the recurring silence for the given window.
+func (s *RecurringSilence) Silence(startsAt, endsAt time.Time) Silence {
+	comment := s.Comment
+	if comment == "" {
+		comment = fmt.Sprintf("Created by recurring silence %q", s.Name)
+	}
+	createdBy := s.CreatedBy
+	if createdBy == "" {
+		createdBy = "Grafana"
+	}
+	start := strfmt.DateTime(startsAt)
+	end := strfmt.DateTime(endsAt)
+	return Silence{
+		Silence: notify.Silence{
+			Comment:   &comment,
+			CreatedBy: &createdBy,
+			Matchers:  MatchersToSilenceMatchers(s.Matchers),
+			StartsAt:  &start,
+			EndsAt:    &end,
+		},
+	}
+}
+
+// MatchersToSilenceMatchers converts label matchers to the matchers of a silence.
+func MatchersToSilenceMatchers(matchers labels.Matchers) amv2.Matchers {
+	result := make(amv2.Matchers, 0, len(matchers))
+	for _, m := range matchers {
+		isEqual := m.Type == labels.MatchEqual || m.Type == labels.MatchRegexp
+		isRegex := m.Type == labels.MatchRegexp || m.Type == labels.MatchNotRegexp
+		result = append(result, &amv2.Matcher{
+			Name:    &m.Name,
+			Value:   &m.Value,
+			IsEqual: &isEqual,
+			IsRegex: &isRegex,
+		})
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurringSilenceValidate(t *testing.T) {
+	matchers := labels.Matchers{labels.MustNewMatcher(labels.MatchEqual, "env", "prod")}
+	sundays := []timeinterval.TimeInterval{{Weekdays: []timeinterval.WeekdayRange{{InclusiveRange: timeinterval.InclusiveRange{Begin: 0, End: 0}}}}}
+
+	testCases := []struct {
+		name     string
+		silence  RecurringSilence
+		expected string
+	}{
+		{
+			name:    "valid cron",
+			silence: RecurringSilence{Name: "test", Matchers: matchers, Schedule: RecurringSilenceSchedule{Cron: "0 2 * * 0"}, Duration: time.Hour},
+		},
+		{
+			name:    "valid time intervals",
+			silence: RecurringSilence{Name: "test", Matchers: matchers, Schedule: RecurringSilenceSchedule{TimeIntervals: sundays}},
+		},
+		{
+			name:     "missing name and matchers",
+			silence:  RecurringSilence{Schedule: RecurringSilenceSchedule{TimeIntervals: sundays}},
+			expected: "name is required\nat least one matcher is required",
+		},
+		{
+			name:     "missing schedule",
+			silence:  RecurringSilence{Name: "test", Matchers: matchers},
+			expected: "schedule must have either a cron expression or time intervals",
+		},
+		{
+			name:     "both cron and time intervals",
+			silence:  RecurringSilence{Name: "test", Matchers: matchers, Schedule: RecurringSilenceSchedule{Cron: "0 2 * * 0", TimeIntervals: sundays}, Duration: time.Hour},
+			expected: "not both",
+		},
+		{
+			name:     "invalid cron",
+			silence:  RecurringSilence{Name: "test", Matchers: matchers, Schedule: RecurringSilenceSchedule{Cron: "every sunday"}, Duration: time.Hour},
+			expected: "invalid cron expression",
+		},
+		{
+			name:     "cron without duration",
+			silence:  RecurringSilence{Name: "test", Matchers: matchers, Schedule: RecurringSilenceSchedule{Cron: "0 2 * * 0"}},
+			expected: "duration must be positive",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.silence.Validate()
+			if tc.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
+
+func TestRecurringSilenceWindow(t *testing.T) {
+	// 2024-01-07 is a Sunday
+	sunday := time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	schedules := map[string]RecurringSilence{
+		"cron": {
+			Schedule: RecurringSilenceSchedule{Cron: "0 2 * * 0"},
+			Duration: 2 * time.Hour,
+		},
+		"time intervals": {
+			Schedule: RecurringSilenceSchedule{TimeIntervals: []timeinterval.TimeInterval{{
+				Weekdays: []timeinterval.WeekdayRange{{InclusiveRange: timeinterval.InclusiveRange{Begin: 0, End: 0}}},
+				Times:    []timeinterval.TimeRange{{StartMinute: 2 * 60, EndMinute: 4 * 60}},
+			}}},
+		},
+	}
+
+	for name, s := range schedules {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should return the active window", func(t *testing.T) {
+				start, end, ok := s.Window(sunday.Add(3*time.Hour+15*time.Second), time.Hour)
+				require.True(t, ok)
+				require.Equal(t, sunday.Add(2*time.Hour), start.UTC())
+				require.Equal(t, sunday.Add(4*time.Hour), end.UTC())
+			})
+
+			t.Run("should return the window that starts within the lookahead", func(t *testing.T) {
+				start, end, ok := s.Window(sunday.Add(90*time.Minute), time.Hour)
+				require.True(t, ok)
+				require.Equal(t, sunday.Add(2*time.Hour), start.UTC())
+				require.Equal(t, sunday.Add(4*time.Hour), end.UTC())
+			})
+
+			t.Run("should return false if no window starts within the lookahead", func(t *testing.T) {
+				_, _, ok := s.Window(sunday.Add(-time.Hour), time.Hour)
+				require.False(t, ok)
+				_, _, ok = s.Window(sunday.Add(4*time.Hour), time.Hour)
+				require.False(t, ok)
+			})
+		})
+	}
+}
+
+func TestMatchersToSilenceMatchers(t *testing.T) {
+	matchers := labels.Matchers{
+		labels.MustNewMatcher(labels.MatchEqual, "a", "1"),
+		labels.MustNewMatcher(labels.MatchNotEqual, "b", "2"),
+		labels.MustNewMatcher(labels.MatchRegexp, "c", "3"),
+		labels.MustNewMatcher(labels.MatchNotRegexp, "d", "4"),
+	}
+
+	result := MatchersToSilenceMatchers(matchers)
+
+	require.Len(t, result, 4)
+	expected := []struct{ isEqual, isRegex bool }{{true, false}, {false, false}, {true, true}, {false, true}}
+	for i, m := range result {
+		require.Equal(t, matchers[i].Name, *m.Name)
+		require.Equal(t, matchers[i].Value, *m.Value)
+		require.Equal(t, expected[i].isEqual, *m.IsEqual)
+		require.Equal(t, expected[i].isRegex, *m.IsRegex)
+	}
+}
@@ -314,6 +314,12 @@ func (a *AlertRuleMutators) WithFor(duration time.Duration) AlertRuleMutator {
 	}
 }
 
+func (a *AlertRuleMutators) WithKeepFiringFor(duration time.Duration) AlertRuleMutator {
+	return func(rule *AlertRule) {
+		rule.KeepFiringFor = duration
+	}
+}
+
 func (a *AlertRuleMutators) WithForNTimes(timesOfInterval int64) AlertRuleMutator {
 	return func(rule *AlertRule) {
 		rule.For = time.Duration(rule.IntervalSeconds*timesOfInterval) * time.Second
@@ -621,6 +627,7 @@ func CopyRule(r *AlertRule, mutators ...AlertRuleMutator) *AlertRule {
 		NoDataState:     r.NoDataState,
 		ExecErrState:    r.ExecErrState,
 		For:             r.For,
+		KeepFiringFor:   r.KeepFiringFor,
 		Record:          r.Record,
 	}
 
@@ -670,6 +677,11 @@ func CopyRule(r *AlertRule, mutators ...AlertRuleMutator) *AlertRule {
 		result.NotificationSettings = append(result.NotificationSettings, CopyNotificationSettings(s))
 	}
 
+	if r.UpdatedBy != nil {
+		updatedBy := *r.UpdatedBy
+		result.UpdatedBy = &updatedBy
+	}
+
 	if len(mutators) > 0 {
 		for _, mutator := range mutators {
 			mutator(&result)
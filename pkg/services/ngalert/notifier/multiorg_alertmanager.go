@@ -103,6 +103,8 @@ type MultiOrgAlertmanager struct {
 	ns      notifications.Service
 
 	receiverResourcePermissions ac.ReceiverPermissionsService
+
+	recurringSilences RecurringSilenceStore
 }
 
 type OrgAlertmanagerFactory func(ctx context.Context, orgID int64) (Alertmanager, error)
@@ -238,15 +240,24 @@ func (moa *MultiOrgAlertmanager) setupClustering(cfg *setting.Cfg) error {
 func (moa *MultiOrgAlertmanager) Run(ctx context.Context) error {
 	moa.logger.Info("Starting MultiOrg Alertmanager")
 
+	// The timer is reset only after a sync so that syncing recurring silences does not delay it.
+	syncTimer := time.NewTimer(moa.settings.UnifiedAlerting.AlertmanagerConfigPollInterval)
+	defer syncTimer.Stop()
+	recurringSilencesTicker := time.NewTicker(recurringSilencesInterval)
+	defer recurringSilencesTicker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			moa.StopAndWait()
 			return nil
-		case <-time.After(moa.settings.UnifiedAlerting.AlertmanagerConfigPollInterval):
+		case <-syncTimer.C:
 			if err := moa.LoadAndSyncAlertmanagersForOrgs(ctx); err != nil {
 				moa.logger.Error("Error while synchronizing Alertmanager orgs", "error", err)
 			}
+			syncTimer.Reset(moa.settings.UnifiedAlerting.AlertmanagerConfigPollInterval)
+		case now := <-recurringSilencesTicker.C:
+			moa.SyncRecurringSilences(ctx, now)
 		}
 	}
 }
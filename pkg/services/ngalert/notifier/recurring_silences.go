@@ -0,0 +1,149 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const (
+	// recurringSilencesInterval is how often the silences of recurring silences are synchronized.
+	recurringSilencesInterval = time.Minute
+	// recurringSilencesLookahead is how long before the start of a window its silence is created,
+	// so that it is visible as pending in the UI.
+	recurringSilencesLookahead = time.Hour
+)
+
+// RecurringSilenceStore is the store of the recurring silences the multi-org Alertmanager creates silences for.
+type RecurringSilenceStore interface {
+	ListRecurringSilences(ctx context.Context, orgID int64) ([]models.RecurringSilence, error)
+	UpdateRecurringSilenceState(ctx context.Context, orgID int64, uid string, expectedSilenceID string, silenceID string, windowStart time.Time) error
+}
+
+// WithRecurringSilences makes the multi-org Alertmanager create and expire the silences of the recurring silences in the store.
+func WithRecurringSilences(store RecurringSilenceStore) Option {
+	return func(moa *MultiOrgAlertmanager) {
+		moa.recurringSilences = store
+	}
+}
+
+// SyncRecurringSilences creates the silences of the recurring silences whose window is active or starts soon,
+// updates them when the recurring silence changes and expires them when the window moves.
+func (moa *MultiOrgAlertmanager) SyncRecurringSilences(ctx context.Context, now time.Time) {
+	if moa.recurringSilences == nil {
+		return
+	}
+	// Silences are replicated between the instances of a cluster, only one of them should create them.
+	if moa.peer.Position() != 0 {
+		return
+	}
+
+	moa.alertmanagersMtx.RLock()
+	orgIDs := make([]int64, 0, len(moa.alertmanagers))
+	for orgID := range moa.alertmanagers {
+		orgIDs = append(orgIDs, orgID)
+	}
+	moa.alertmanagersMtx.RUnlock()
+
+	for _, orgID := range orgIDs {
+		recurring, err := moa.recurringSilences.ListRecurringSilences(ctx, orgID)
+		if err != nil {
+			moa.logger.Error("Failed to list recurring silences", "org", orgID, "error", err)
+			continue
+		}
+		for _, rs := range recurring {
+			if err := moa.syncRecurringSilence(ctx, now, rs); err != nil {
+				moa.logger.Error("Failed to synchronize silence of recurring silence", "org", orgID, "uid", rs.UID, "error", err)
+			}
+		}
+	}
+}
+
+func (moa *MultiOrgAlertmanager) syncRecurringSilence(ctx context.Context, now time.Time, rs models.RecurringSilence) error {
+	start, end, ok := rs.Window(now, recurringSilencesLookahead)
+	sameWindow := ok && rs.WindowStart.Equal(start)
+
+	if rs.SilenceID != "" {
+		current, err := moa.GetSilence(ctx, rs.OrgID, rs.SilenceID)
+		if err != nil && !errors.Is(err, ErrSilenceNotFound) {
+			return err
+		}
+		switch {
+		case current != nil && !isSilenceExpired(current) && sameWindow:
+			return moa.updateRecurringSilence(ctx, rs, current, end)
+		case current != nil && !isSilenceExpired(current):
+			// The window ended early or the schedule changed.
+			if err := moa.DeleteSilence(ctx, rs.OrgID, rs.SilenceID); err != nil && !errors.Is(err, ErrSilenceNotFound) {
+				return err
+			}
+		case sameWindow:
+			// The silence of the current window was expired by a user, it must not be created again.
+			return nil
+		}
+	}
+	if !ok {
+		return nil
+	}
+
+	startsAt := start
+	if startsAt.Before(now) {
+		startsAt = now
+	}
+	silenceID, err := moa.CreateSilence(ctx, rs.OrgID, rs.Silence(startsAt, end))
+	if err != nil {
+		return fmt.Errorf("failed to create silence: %w", err)
+	}
+	err = moa.recurringSilences.UpdateRecurringSilenceState(ctx, rs.OrgID, rs.UID, rs.SilenceID, silenceID, start)
+	if err != nil {
+		if errors.Is(err, models.ErrRecurringSilenceStateChanged) {
+			// Another instance created a silence for this window.
+			return moa.DeleteSilence(ctx, rs.OrgID, silenceID)
+		}
+		return err
+	}
+	moa.logger.Debug("Created silence of recurring silence", "org", rs.OrgID, "uid", rs.UID, "silence", silenceID, "startsAt", startsAt, "endsAt", end)
+	return nil
+}
+
+// updateRecurringSilence updates the silence of the current window if the recurring silence or the end of the window changed.
+func (moa *MultiOrgAlertmanager) updateRecurringSilence(ctx context.Context, rs models.RecurringSilence, current *models.Silence, end time.Time) error {
+	desired := rs.Silence(time.Time(*current.StartsAt), end)
+	if time.Time(*current.EndsAt).Equal(end) &&
+		*current.Comment == *desired.Comment &&
+		silenceMatchersEqual(current.Matchers, desired.Matchers) {
+		return nil
+	}
+	desired.ID = current.ID
+	// The Alertmanager replaces the silence if its matchers changed while it was active.
+	silenceID, err := moa.UpdateSilence(ctx, rs.OrgID, desired)
+	if err != nil {
+		return fmt.Errorf("failed to update silence: %w", err)
+	}
+	if silenceID == rs.SilenceID {
+		return nil
+	}
+	return moa.recurringSilences.UpdateRecurringSilenceState(ctx, rs.OrgID, rs.UID, rs.SilenceID, silenceID, rs.WindowStart)
+}
+
+func isSilenceExpired(s *models.Silence) bool {
+	return s.Status != nil && s.Status.State != nil && *s.Status.State == string(types.SilenceStateExpired)
+}
+
+func silenceMatchersEqual(a, b amv2.Matchers) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if *a[i].Name != *b[i].Name || *a[i].Value != *b[i].Value ||
+			*a[i].IsRegex != *b[i].IsRegex || (a[i].IsEqual == nil || *a[i].IsEqual) != *b[i].IsEqual {
+			return false
+		}
+	}
+	return true
+}
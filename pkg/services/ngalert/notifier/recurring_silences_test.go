@@ -0,0 +1,128 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+type fakeRecurringSilenceStore struct {
+	silences []models.RecurringSilence
+}
+
+func (f *fakeRecurringSilenceStore) ListRecurringSilences(_ context.Context, orgID int64) ([]models.RecurringSilence, error) {
+	var result []models.RecurringSilence
+	for _, s := range f.silences {
+		if s.OrgID == orgID {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeRecurringSilenceStore) UpdateRecurringSilenceState(_ context.Context, orgID int64, uid string, expectedSilenceID string, silenceID string, windowStart time.Time) error {
+	for i, s := range f.silences {
+		if s.OrgID != orgID || s.UID != uid {
+			continue
+		}
+		if s.SilenceID != expectedSilenceID {
+			return models.ErrRecurringSilenceStateChanged
+		}
+		f.silences[i].SilenceID = silenceID
+		f.silences[i].WindowStart = windowStart
+		return nil
+	}
+	return models.ErrRecurringSilenceNotFound
+}
+
+func TestMultiOrgAlertmanager_SyncRecurringSilences(t *testing.T) {
+	ctx := context.Background()
+	mam := setupMam(t, nil)
+	require.NoError(t, mam.LoadAndSyncAlertmanagersForOrgs(ctx))
+
+	store := &fakeRecurringSilenceStore{}
+	WithRecurringSilences(store)(mam)
+
+	// Windows start every day at the start of the next hour and last for two hours.
+	now := time.Now().UTC().Truncate(time.Minute)
+	nextHour := now.Truncate(time.Hour).Add(time.Hour)
+	store.silences = []models.RecurringSilence{{
+		UID:      "daily",
+		OrgID:    1,
+		Name:     "daily",
+		Matchers: labels.Matchers{labels.MustNewMatcher(labels.MatchEqual, "env", "prod")},
+		Schedule: models.RecurringSilenceSchedule{Cron: nextHour.Format("4 15 * * *")},
+		Duration: 2 * time.Hour,
+	}}
+
+	getSilence := func(t *testing.T) *models.Silence {
+		t.Helper()
+		require.NotEmpty(t, store.silences[0].SilenceID)
+		s, err := mam.GetSilence(ctx, 1, store.silences[0].SilenceID)
+		require.NoError(t, err)
+		return s
+	}
+
+	t.Run("should not create silence before the lookahead", func(t *testing.T) {
+		mam.SyncRecurringSilences(ctx, nextHour.Add(-recurringSilencesLookahead-time.Minute))
+		require.Empty(t, store.silences[0].SilenceID)
+	})
+
+	t.Run("should create pending silence within the lookahead", func(t *testing.T) {
+		mam.SyncRecurringSilences(ctx, now)
+		s := getSilence(t)
+		require.Equal(t, string(types.SilenceStatePending), *s.Status.State)
+		require.Equal(t, nextHour, time.Time(*s.StartsAt).UTC())
+		require.Equal(t, nextHour.Add(2*time.Hour), time.Time(*s.EndsAt).UTC())
+		require.True(t, nextHour.Equal(store.silences[0].WindowStart))
+	})
+
+	t.Run("should not create another silence for the same window", func(t *testing.T) {
+		silenceID := store.silences[0].SilenceID
+		mam.SyncRecurringSilences(ctx, now.Add(time.Minute))
+		require.Equal(t, silenceID, store.silences[0].SilenceID)
+		silences, err := mam.ListSilences(ctx, 1, nil)
+		require.NoError(t, err)
+		require.Len(t, silences, 1)
+	})
+
+	t.Run("should update the silence when the recurring silence changes", func(t *testing.T) {
+		store.silences[0].Comment = "maintenance"
+		mam.SyncRecurringSilences(ctx, now.Add(2*time.Minute))
+		s := getSilence(t)
+		require.Equal(t, "maintenance", *s.Comment)
+	})
+
+	t.Run("should not create the silence again if it was expired by a user", func(t *testing.T) {
+		silenceID := store.silences[0].SilenceID
+		require.NoError(t, mam.DeleteSilence(ctx, 1, silenceID))
+		mam.SyncRecurringSilences(ctx, now.Add(3*time.Minute))
+		require.Equal(t, silenceID, store.silences[0].SilenceID)
+		s := getSilence(t)
+		require.Equal(t, string(types.SilenceStateExpired), *s.Status.State)
+	})
+
+	t.Run("should expire the silence when the schedule changes and create the new one", func(t *testing.T) {
+		store.silences[0].SilenceID = ""
+		mam.SyncRecurringSilences(ctx, now.Add(4*time.Minute))
+		oldID := store.silences[0].SilenceID
+
+		// the new window is already active.
+		store.silences[0].Schedule.Cron = now.Add(-10 * time.Minute).Format("4 15 * * *")
+		mam.SyncRecurringSilences(ctx, now.Add(5*time.Minute))
+		require.NotEqual(t, oldID, store.silences[0].SilenceID)
+
+		old, err := mam.GetSilence(ctx, 1, oldID)
+		require.NoError(t, err)
+		require.Equal(t, string(types.SilenceStateExpired), *old.Status.State)
+		s := getSilence(t)
+		require.Equal(t, now.Add(5*time.Minute), time.Time(*s.StartsAt).UTC())
+		require.Equal(t, now.Add(110*time.Minute), time.Time(*s.EndsAt).UTC())
+	})
+}
@@ -29,6 +29,20 @@ var (
 	ErrContactPointReferenced = errutil.Conflict("alerting.notifications.contact-points.referenced", errutil.WithPublicMessage("Contact point is currently referenced by a notification policy."))
 	ErrContactPointUsedInRule = errutil.Conflict("alerting.notifications.contact-points.used-by-rule", errutil.WithPublicMessage("Contact point is currently used in the notification settings of one or many alert rules."))
 
+	ErrSilenceTemplateNotFound = errutil.NotFound("alerting.notifications.silence-templates.notFound")
+	ErrSilenceTemplateExists   = errutil.BadRequest("alerting.notifications.silence-templates.nameExists", errutil.WithPublicMessage("Silence template with this name already exists. Use a different name or update existing one."))
+	ErrSilenceTemplateInvalid  = errutil.BadRequest("alerting.notifications.silence-templates.invalidFormat").MustTemplate(
+		"Invalid format of the submitted silence template",
+		errutil.WithPublic("Invalid format of the submitted silence template: {{.Public.Error}}. Correct the payload and try again."),
+	)
+
+	ErrRecurringSilenceNotFound = errutil.NotFound("alerting.notifications.recurring-silences.notFound")
+	ErrRecurringSilenceExists   = errutil.BadRequest("alerting.notifications.recurring-silences.nameExists", errutil.WithPublicMessage("Recurring silence with this name already exists. Use a different name or update existing one."))
+	ErrRecurringSilenceInvalid  = errutil.BadRequest("alerting.notifications.recurring-silences.invalidFormat").MustTemplate(
+		"Invalid format of the submitted recurring silence",
+		errutil.WithPublic("Invalid format of the submitted recurring silence: {{.Public.Error}}. Correct the payload and try again."),
+	)
+
 	ErrRouteInvalidFormat = errutil.BadRequest("alerting.notifications.routes.invalidFormat").MustTemplate(
 		"Invalid format of the submitted route.",
 		errutil.WithPublic("Invalid format of the submitted route: {{.Public.Error}}. Correct the payload and try again."),
@@ -104,3 +118,21 @@ func MakeErrRouteInvalidFormat(err error) error {
 		Error: err,
 	})
 }
+
+func MakeErrSilenceTemplateInvalid(err error) error {
+	return ErrSilenceTemplateInvalid.Build(errutil.TemplateData{
+		Public: map[string]any{
+			"Error": err.Error(),
+		},
+		Error: err,
+	})
+}
+
+func MakeErrRecurringSilenceInvalid(err error) error {
+	return ErrRecurringSilenceInvalid.Build(errutil.TemplateData{
+		Public: map[string]any{
+			"Error": err.Error(),
+		},
+		Error: err,
+	})
+}
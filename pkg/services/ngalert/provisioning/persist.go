@@ -45,3 +45,20 @@ type RuleStore interface {
 type QuotaChecker interface {
 	CheckQuotaReached(ctx context.Context, target quota.TargetSrv, scopeParams *quota.ScopeParameters) (bool, error)
 }
+
+// SilenceScheduleStore represents the ability to persist and query silence templates and recurring silences.
+type SilenceScheduleStore interface {
+	ListSilenceTemplates(ctx context.Context, orgID int64) ([]models.SilenceTemplate, error)
+	GetSilenceTemplate(ctx context.Context, orgID int64, uid string) (models.SilenceTemplate, error)
+	SaveSilenceTemplate(ctx context.Context, t models.SilenceTemplate) error
+	DeleteSilenceTemplate(ctx context.Context, orgID int64, uid string) error
+	ListRecurringSilences(ctx context.Context, orgID int64) ([]models.RecurringSilence, error)
+	GetRecurringSilence(ctx context.Context, orgID int64, uid string) (models.RecurringSilence, error)
+	SaveRecurringSilence(ctx context.Context, s models.RecurringSilence) error
+	DeleteRecurringSilence(ctx context.Context, orgID int64, uid string) error
+}
+
+// SilenceExpirer represents the ability to expire a silence.
+type SilenceExpirer interface {
+	DeleteSilence(ctx context.Context, orgID int64, silenceID string) error
+}
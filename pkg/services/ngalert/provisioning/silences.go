@@ -0,0 +1,447 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning/validation"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+type SilenceTemplateService struct {
+	store           SilenceScheduleStore
+	provenanceStore ProvisioningStore
+	xact            TransactionManager
+	log             log.Logger
+	validator       validation.ProvenanceStatusTransitionValidator
+}
+
+func NewSilenceTemplateService(store SilenceScheduleStore, prov ProvisioningStore, xact TransactionManager, log log.Logger) *SilenceTemplateService {
+	return &SilenceTemplateService{
+		store:           store,
+		provenanceStore: prov,
+		xact:            xact,
+		log:             log,
+		validator:       validation.ValidateProvenanceRelaxed,
+	}
+}
+
+// GetSilenceTemplates returns all silence templates within the specified org ordered by name.
+func (svc *SilenceTemplateService) GetSilenceTemplates(ctx context.Context, orgID int64) ([]definitions.SilenceTemplate, error) {
+	templates, err := svc.store.ListSilenceTemplates(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	provenances, err := svc.provenanceStore.GetProvenances(ctx, orgID, (&models.SilenceTemplate{}).ResourceType())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]definitions.SilenceTemplate, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, silenceTemplateToDefinition(t, provenances[t.UID]))
+	}
+	return result, nil
+}
+
+// GetSilenceTemplate returns the silence template with the given UID. If it does not exist, ErrSilenceTemplateNotFound is returned.
+func (svc *SilenceTemplateService) GetSilenceTemplate(ctx context.Context, uid string, orgID int64) (definitions.SilenceTemplate, error) {
+	t, err := svc.get(ctx, uid, orgID)
+	if err != nil {
+		return definitions.SilenceTemplate{}, err
+	}
+	prov, err := svc.provenanceStore.GetProvenance(ctx, &t, orgID)
+	if err != nil {
+		return definitions.SilenceTemplate{}, err
+	}
+	return silenceTemplateToDefinition(t, prov), nil
+}
+
+// CreateSilenceTemplate adds a new silence template within the specified org. The created silence template is returned.
+func (svc *SilenceTemplateService) CreateSilenceTemplate(ctx context.Context, def definitions.SilenceTemplate, orgID int64) (definitions.SilenceTemplate, error) {
+	if def.UID == "" {
+		def.UID = util.GenerateShortUID()
+	} else if err := util.ValidateUID(def.UID); err != nil {
+		return definitions.SilenceTemplate{}, MakeErrSilenceTemplateInvalid(err)
+	}
+	t := silenceTemplateFromDefinition(def, orgID)
+	if err := t.Validate(); err != nil {
+		return definitions.SilenceTemplate{}, MakeErrSilenceTemplateInvalid(err)
+	}
+
+	existing, err := svc.store.ListSilenceTemplates(ctx, orgID)
+	if err != nil {
+		return definitions.SilenceTemplate{}, err
+	}
+	for _, e := range existing {
+		if e.UID == t.UID || e.Name == t.Name {
+			return definitions.SilenceTemplate{}, ErrSilenceTemplateExists.Errorf("")
+		}
+	}
+
+	err = svc.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := svc.store.SaveSilenceTemplate(ctx, t); err != nil {
+			return err
+		}
+		return svc.provenanceStore.SetProvenance(ctx, &t, orgID, models.Provenance(def.Provenance))
+	})
+	if err != nil {
+		return definitions.SilenceTemplate{}, err
+	}
+	return silenceTemplateToDefinition(t, models.Provenance(def.Provenance)), nil
+}
+
+// UpdateSilenceTemplate replaces an existing silence template within the specified org. The replaced silence template is returned.
+// If the silence template does not exist, ErrSilenceTemplateNotFound is returned.
+func (svc *SilenceTemplateService) UpdateSilenceTemplate(ctx context.Context, def definitions.SilenceTemplate, orgID int64) (definitions.SilenceTemplate, error) {
+	t := silenceTemplateFromDefinition(def, orgID)
+	if err := t.Validate(); err != nil {
+		return definitions.SilenceTemplate{}, MakeErrSilenceTemplateInvalid(err)
+	}
+
+	existing, err := svc.store.ListSilenceTemplates(ctx, orgID)
+	if err != nil {
+		return definitions.SilenceTemplate{}, err
+	}
+	found := false
+	for _, e := range existing {
+		if e.UID == t.UID {
+			found = true
+		} else if e.Name == t.Name {
+			return definitions.SilenceTemplate{}, ErrSilenceTemplateExists.Errorf("")
+		}
+	}
+	if !found {
+		return definitions.SilenceTemplate{}, ErrSilenceTemplateNotFound.Errorf("")
+	}
+
+	// check that provenance is not changed in an invalid way
+	storedProvenance, err := svc.provenanceStore.GetProvenance(ctx, &t, orgID)
+	if err != nil {
+		return definitions.SilenceTemplate{}, err
+	}
+	if err := svc.validator(storedProvenance, models.Provenance(def.Provenance)); err != nil {
+		return definitions.SilenceTemplate{}, err
+	}
+
+	err = svc.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := svc.store.SaveSilenceTemplate(ctx, t); err != nil {
+			return err
+		}
+		return svc.provenanceStore.SetProvenance(ctx, &t, orgID, models.Provenance(def.Provenance))
+	})
+	if err != nil {
+		return definitions.SilenceTemplate{}, err
+	}
+	return silenceTemplateToDefinition(t, models.Provenance(def.Provenance)), nil
+}
+
+// DeleteSilenceTemplate deletes the silence template with the given UID in the given org. If the silence template does not exist, no error is returned.
+// Recurring silences created from the template are not affected.
+func (svc *SilenceTemplateService) DeleteSilenceTemplate(ctx context.Context, uid string, orgID int64, provenance definitions.Provenance) error {
+	t, err := svc.get(ctx, uid, orgID)
+	if err != nil {
+		if errors.Is(err, ErrSilenceTemplateNotFound) {
+			svc.log.FromContext(ctx).Debug("Silence template was not found. Skip deleting", "uid", uid)
+			return nil
+		}
+		return err
+	}
+
+	// check that provenance is not changed in an invalid way
+	storedProvenance, err := svc.provenanceStore.GetProvenance(ctx, &t, orgID)
+	if err != nil {
+		return err
+	}
+	if err := svc.validator(storedProvenance, models.Provenance(provenance)); err != nil {
+		return err
+	}
+
+	return svc.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := svc.store.DeleteSilenceTemplate(ctx, orgID, uid); err != nil {
+			return err
+		}
+		return svc.provenanceStore.DeleteProvenance(ctx, &t, orgID)
+	})
+}
+
+func (svc *SilenceTemplateService) get(ctx context.Context, uid string, orgID int64) (models.SilenceTemplate, error) {
+	t, err := svc.store.GetSilenceTemplate(ctx, orgID, uid)
+	if err != nil {
+		if errors.Is(err, models.ErrSilenceTemplateNotFound) {
+			return models.SilenceTemplate{}, ErrSilenceTemplateNotFound.Errorf("")
+		}
+		return models.SilenceTemplate{}, err
+	}
+	return t, nil
+}
+
+type RecurringSilenceService struct {
+	store           SilenceScheduleStore
+	silences        SilenceExpirer
+	provenanceStore ProvisioningStore
+	xact            TransactionManager
+	log             log.Logger
+	validator       validation.ProvenanceStatusTransitionValidator
+}
+
+// NewRecurringSilenceService creates a new RecurringSilenceService. The silences are used to expire the current silence
+// of deleted recurring silences, and can be nil if the Alertmanager is not available.
+func NewRecurringSilenceService(store SilenceScheduleStore, silences SilenceExpirer, prov ProvisioningStore, xact TransactionManager, log log.Logger) *RecurringSilenceService {
+	return &RecurringSilenceService{
+		store:           store,
+		silences:        silences,
+		provenanceStore: prov,
+		xact:            xact,
+		log:             log,
+		validator:       validation.ValidateProvenanceRelaxed,
+	}
+}
+
+// GetRecurringSilences returns all recurring silences within the specified org ordered by name.
+func (svc *RecurringSilenceService) GetRecurringSilences(ctx context.Context, orgID int64) ([]definitions.RecurringSilence, error) {
+	silences, err := svc.store.ListRecurringSilences(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	provenances, err := svc.provenanceStore.GetProvenances(ctx, orgID, (&models.RecurringSilence{}).ResourceType())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]definitions.RecurringSilence, 0, len(silences))
+	for _, s := range silences {
+		result = append(result, recurringSilenceToDefinition(s, provenances[s.UID]))
+	}
+	return result, nil
+}
+
+// GetRecurringSilence returns the recurring silence with the given UID. If it does not exist, ErrRecurringSilenceNotFound is returned.
+func (svc *RecurringSilenceService) GetRecurringSilence(ctx context.Context, uid string, orgID int64) (definitions.RecurringSilence, error) {
+	s, err := svc.get(ctx, uid, orgID)
+	if err != nil {
+		return definitions.RecurringSilence{}, err
+	}
+	prov, err := svc.provenanceStore.GetProvenance(ctx, &s, orgID)
+	if err != nil {
+		return definitions.RecurringSilence{}, err
+	}
+	return recurringSilenceToDefinition(s, prov), nil
+}
+
+// CreateRecurringSilence adds a new recurring silence within the specified org. The created recurring silence is returned.
+// The matchers, duration and comment that are not set are copied from the silence template, if any.
+func (svc *RecurringSilenceService) CreateRecurringSilence(ctx context.Context, def definitions.RecurringSilence, orgID int64) (definitions.RecurringSilence, error) {
+	if def.UID == "" {
+		def.UID = util.GenerateShortUID()
+	} else if err := util.ValidateUID(def.UID); err != nil {
+		return definitions.RecurringSilence{}, MakeErrRecurringSilenceInvalid(err)
+	}
+	s, err := svc.fromDefinition(ctx, def, orgID)
+	if err != nil {
+		return definitions.RecurringSilence{}, err
+	}
+
+	existing, err := svc.store.ListRecurringSilences(ctx, orgID)
+	if err != nil {
+		return definitions.RecurringSilence{}, err
+	}
+	for _, e := range existing {
+		if e.UID == s.UID || e.Name == s.Name {
+			return definitions.RecurringSilence{}, ErrRecurringSilenceExists.Errorf("")
+		}
+	}
+
+	err = svc.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := svc.store.SaveRecurringSilence(ctx, s); err != nil {
+			return err
+		}
+		return svc.provenanceStore.SetProvenance(ctx, &s, orgID, models.Provenance(def.Provenance))
+	})
+	if err != nil {
+		return definitions.RecurringSilence{}, err
+	}
+	return recurringSilenceToDefinition(s, models.Provenance(def.Provenance)), nil
+}
+
+// UpdateRecurringSilence replaces an existing recurring silence within the specified org. The replaced recurring silence is returned.
+// If the recurring silence does not exist, ErrRecurringSilenceNotFound is returned. The current silence is updated or replaced
+// in the background.
+func (svc *RecurringSilenceService) UpdateRecurringSilence(ctx context.Context, def definitions.RecurringSilence, orgID int64) (definitions.RecurringSilence, error) {
+	s, err := svc.fromDefinition(ctx, def, orgID)
+	if err != nil {
+		return definitions.RecurringSilence{}, err
+	}
+
+	existing, err := svc.store.ListRecurringSilences(ctx, orgID)
+	if err != nil {
+		return definitions.RecurringSilence{}, err
+	}
+	var old *models.RecurringSilence
+	for i, e := range existing {
+		if e.UID == s.UID {
+			old = &existing[i]
+		} else if e.Name == s.Name {
+			return definitions.RecurringSilence{}, ErrRecurringSilenceExists.Errorf("")
+		}
+	}
+	if old == nil {
+		return definitions.RecurringSilence{}, ErrRecurringSilenceNotFound.Errorf("")
+	}
+	s.SilenceID = old.SilenceID
+
+	// check that provenance is not changed in an invalid way
+	storedProvenance, err := svc.provenanceStore.GetProvenance(ctx, &s, orgID)
+	if err != nil {
+		return definitions.RecurringSilence{}, err
+	}
+	if err := svc.validator(storedProvenance, models.Provenance(def.Provenance)); err != nil {
+		return definitions.RecurringSilence{}, err
+	}
+
+	err = svc.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := svc.store.SaveRecurringSilence(ctx, s); err != nil {
+			return err
+		}
+		return svc.provenanceStore.SetProvenance(ctx, &s, orgID, models.Provenance(def.Provenance))
+	})
+	if err != nil {
+		return definitions.RecurringSilence{}, err
+	}
+	return recurringSilenceToDefinition(s, models.Provenance(def.Provenance)), nil
+}
+
+// DeleteRecurringSilence deletes the recurring silence with the given UID in the given org and expires its current silence.
+// If the recurring silence does not exist, no error is returned.
+func (svc *RecurringSilenceService) DeleteRecurringSilence(ctx context.Context, uid string, orgID int64, provenance definitions.Provenance) error {
+	s, err := svc.get(ctx, uid, orgID)
+	if err != nil {
+		if errors.Is(err, ErrRecurringSilenceNotFound) {
+			svc.log.FromContext(ctx).Debug("Recurring silence was not found. Skip deleting", "uid", uid)
+			return nil
+		}
+		return err
+	}
+
+	// check that provenance is not changed in an invalid way
+	storedProvenance, err := svc.provenanceStore.GetProvenance(ctx, &s, orgID)
+	if err != nil {
+		return err
+	}
+	if err := svc.validator(storedProvenance, models.Provenance(provenance)); err != nil {
+		return err
+	}
+
+	err = svc.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := svc.store.DeleteRecurringSilence(ctx, orgID, uid); err != nil {
+			return err
+		}
+		return svc.provenanceStore.DeleteProvenance(ctx, &s, orgID)
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.SilenceID != "" && svc.silences != nil {
+		// the silence may already be expired, it's not a reason to fail the request.
+		if err := svc.silences.DeleteSilence(ctx, orgID, s.SilenceID); err != nil {
+			svc.log.FromContext(ctx).Warn("Failed to expire the silence of the deleted recurring silence", "uid", uid, "silence", s.SilenceID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (svc *RecurringSilenceService) get(ctx context.Context, uid string, orgID int64) (models.RecurringSilence, error) {
+	s, err := svc.store.GetRecurringSilence(ctx, orgID, uid)
+	if err != nil {
+		if errors.Is(err, models.ErrRecurringSilenceNotFound) {
+			return models.RecurringSilence{}, ErrRecurringSilenceNotFound.Errorf("")
+		}
+		return models.RecurringSilence{}, err
+	}
+	return s, nil
+}
+
+// fromDefinition converts the definition to the model, copying the fields that are not set from the silence template.
+func (svc *RecurringSilenceService) fromDefinition(ctx context.Context, def definitions.RecurringSilence, orgID int64) (models.RecurringSilence, error) {
+	s := models.RecurringSilence{
+		UID:         def.UID,
+		OrgID:       orgID,
+		Name:        def.Name,
+		TemplateUID: def.TemplateUID,
+		Matchers:    labels.Matchers(def.Matchers),
+		Comment:     def.Comment,
+		Schedule: models.RecurringSilenceSchedule{
+			Cron:          def.Cron,
+			Location:      def.Location,
+			TimeIntervals: def.TimeIntervals,
+		},
+		Duration: time.Duration(def.Duration),
+	}
+	if s.TemplateUID != "" {
+		t, err := svc.store.GetSilenceTemplate(ctx, orgID, s.TemplateUID)
+		if err != nil {
+			if errors.Is(err, models.ErrSilenceTemplateNotFound) {
+				return models.RecurringSilence{}, MakeErrRecurringSilenceInvalid(fmt.Errorf("silence template %s does not exist", s.TemplateUID))
+			}
+			return models.RecurringSilence{}, err
+		}
+		if len(s.Matchers) == 0 {
+			s.Matchers = t.Matchers
+		}
+		if s.Comment == "" {
+			s.Comment = t.Comment
+		}
+		if s.Duration == 0 && s.Schedule.Cron != "" {
+			s.Duration = t.Duration
+		}
+	}
+	if err := s.Validate(); err != nil {
+		return models.RecurringSilence{}, MakeErrRecurringSilenceInvalid(err)
+	}
+	return s, nil
+}
+
+func silenceTemplateFromDefinition(def definitions.SilenceTemplate, orgID int64) models.SilenceTemplate {
+	return models.SilenceTemplate{
+		UID:      def.UID,
+		OrgID:    orgID,
+		Name:     def.Name,
+		Matchers: labels.Matchers(def.Matchers),
+		Duration: time.Duration(def.Duration),
+		Comment:  def.Comment,
+	}
+}
+
+func silenceTemplateToDefinition(t models.SilenceTemplate, provenance models.Provenance) definitions.SilenceTemplate {
+	return definitions.SilenceTemplate{
+		UID:        t.UID,
+		Name:       t.Name,
+		Matchers:   definitions.ObjectMatchers(t.Matchers),
+		Duration:   model.Duration(t.Duration),
+		Comment:    t.Comment,
+		Provenance: definitions.Provenance(provenance),
+	}
+}
+
+func recurringSilenceToDefinition(s models.RecurringSilence, provenance models.Provenance) definitions.RecurringSilence {
+	return definitions.RecurringSilence{
+		UID:           s.UID,
+		Name:          s.Name,
+		TemplateUID:   s.TemplateUID,
+		Matchers:      definitions.ObjectMatchers(s.Matchers),
+		Comment:       s.Comment,
+		Cron:          s.Schedule.Cron,
+		Location:      s.Schedule.Location,
+		TimeIntervals: s.Schedule.TimeIntervals,
+		Duration:      model.Duration(s.Duration),
+		SilenceID:     s.SilenceID,
+		Provenance:    definitions.Provenance(provenance),
+	}
+}
@@ -0,0 +1,276 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestSilenceTemplateService(t *testing.T) {
+	orgID := int64(1)
+	template := definitions.SilenceTemplate{
+		Name:     "maintenance",
+		Matchers: definitions.ObjectMatchers{labels.MustNewMatcher(labels.MatchEqual, "env", "prod")},
+		Duration: model.Duration(2 * time.Hour),
+		Comment:  "Database maintenance",
+	}
+
+	t.Run("create generates uid and saves provenance", func(t *testing.T) {
+		sut, store, prov := createSilenceTemplateSvcSut()
+		prov.EXPECT().SaveSucceeds()
+
+		created, err := sut.CreateSilenceTemplate(context.Background(), template, orgID)
+		require.NoError(t, err)
+		require.NotEmpty(t, created.UID)
+		require.Contains(t, store.templates, created.UID)
+		prov.AssertCalled(t, "SetProvenance", mock.Anything, mock.MatchedBy(func(t *models.SilenceTemplate) bool {
+			return t.UID == created.UID
+		}), orgID, models.ProvenanceNone)
+	})
+
+	t.Run("create rejects invalid and duplicate templates", func(t *testing.T) {
+		sut, _, prov := createSilenceTemplateSvcSut()
+		prov.EXPECT().SaveSucceeds()
+
+		_, err := sut.CreateSilenceTemplate(context.Background(), definitions.SilenceTemplate{Name: "invalid"}, orgID)
+		require.ErrorIs(t, err, ErrSilenceTemplateInvalid)
+
+		_, err = sut.CreateSilenceTemplate(context.Background(), template, orgID)
+		require.NoError(t, err)
+		_, err = sut.CreateSilenceTemplate(context.Background(), template, orgID)
+		require.ErrorIs(t, err, ErrSilenceTemplateExists)
+	})
+
+	t.Run("update fails if the template does not exist", func(t *testing.T) {
+		sut, _, _ := createSilenceTemplateSvcSut()
+
+		update := template
+		update.UID = "missing"
+		_, err := sut.UpdateSilenceTemplate(context.Background(), update, orgID)
+		require.ErrorIs(t, err, ErrSilenceTemplateNotFound)
+	})
+
+	t.Run("update and delete check provenance", func(t *testing.T) {
+		sut, _, prov := createSilenceTemplateSvcSut()
+		prov.EXPECT().SaveSucceeds()
+		prov.EXPECT().GetProvenance(mock.Anything, mock.Anything, mock.Anything).Return(models.ProvenanceFile, nil)
+		expectedErr := errors.New("test")
+		sut.validator = func(from, to models.Provenance) error {
+			require.Equal(t, models.ProvenanceFile, from)
+			require.Equal(t, models.ProvenanceAPI, to)
+			return expectedErr
+		}
+
+		created, err := sut.CreateSilenceTemplate(context.Background(), template, orgID)
+		require.NoError(t, err)
+
+		created.Provenance = definitions.Provenance(models.ProvenanceAPI)
+		_, err = sut.UpdateSilenceTemplate(context.Background(), created, orgID)
+		require.ErrorIs(t, err, expectedErr)
+
+		err = sut.DeleteSilenceTemplate(context.Background(), created.UID, orgID, definitions.Provenance(models.ProvenanceAPI))
+		require.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("delete of missing template succeeds", func(t *testing.T) {
+		sut, _, _ := createSilenceTemplateSvcSut()
+
+		require.NoError(t, sut.DeleteSilenceTemplate(context.Background(), "missing", orgID, definitions.Provenance(models.ProvenanceNone)))
+	})
+}
+
+func TestRecurringSilenceService(t *testing.T) {
+	orgID := int64(1)
+	sundays := []timeinterval.TimeInterval{{Weekdays: []timeinterval.WeekdayRange{{InclusiveRange: timeinterval.InclusiveRange{Begin: 0, End: 0}}}}}
+
+	t.Run("create copies unset fields from the template", func(t *testing.T) {
+		sut, store, prov := createRecurringSilenceSvcSut(nil)
+		prov.EXPECT().SaveSucceeds()
+		store.templates["maintenance"] = models.SilenceTemplate{
+			UID:      "maintenance",
+			OrgID:    orgID,
+			Name:     "maintenance",
+			Matchers: labels.Matchers{labels.MustNewMatcher(labels.MatchEqual, "env", "prod")},
+			Duration: 2 * time.Hour,
+			Comment:  "Database maintenance",
+		}
+
+		created, err := sut.CreateRecurringSilence(context.Background(), definitions.RecurringSilence{
+			Name:        "weekly",
+			TemplateUID: "maintenance",
+			Cron:        "0 2 * * 0",
+			Comment:     "Weekly maintenance",
+		}, orgID)
+		require.NoError(t, err)
+		require.Equal(t, `{env="prod"}`, labels.Matchers(created.Matchers).String())
+		require.Equal(t, model.Duration(2*time.Hour), created.Duration)
+		require.Equal(t, "Weekly maintenance", created.Comment)
+		require.Equal(t, "maintenance", store.recurring[created.UID].TemplateUID)
+	})
+
+	t.Run("create fails if the template does not exist", func(t *testing.T) {
+		sut, _, _ := createRecurringSilenceSvcSut(nil)
+
+		_, err := sut.CreateRecurringSilence(context.Background(), definitions.RecurringSilence{
+			Name:          "weekly",
+			TemplateUID:   "missing",
+			TimeIntervals: sundays,
+		}, orgID)
+		require.ErrorIs(t, err, ErrRecurringSilenceInvalid)
+	})
+
+	t.Run("update keeps the current silence", func(t *testing.T) {
+		sut, store, prov := createRecurringSilenceSvcSut(nil)
+		prov.EXPECT().SaveSucceeds()
+		prov.EXPECT().GetProvenance(mock.Anything, mock.Anything, mock.Anything).Return(models.ProvenanceNone, nil)
+
+		created, err := sut.CreateRecurringSilence(context.Background(), definitions.RecurringSilence{
+			Name:          "weekly",
+			Matchers:      definitions.ObjectMatchers{labels.MustNewMatcher(labels.MatchEqual, "env", "prod")},
+			TimeIntervals: sundays,
+		}, orgID)
+		require.NoError(t, err)
+		s := store.recurring[created.UID]
+		s.SilenceID = "silence"
+		store.recurring[created.UID] = s
+
+		created.Comment = "updated"
+		updated, err := sut.UpdateRecurringSilence(context.Background(), created, orgID)
+		require.NoError(t, err)
+		require.Equal(t, "updated", updated.Comment)
+		require.Equal(t, "silence", updated.SilenceID)
+	})
+
+	t.Run("delete expires the current silence", func(t *testing.T) {
+		expirer := &fakeSilenceExpirer{}
+		sut, store, prov := createRecurringSilenceSvcSut(expirer)
+		prov.EXPECT().SaveSucceeds()
+		prov.EXPECT().GetProvenance(mock.Anything, mock.Anything, mock.Anything).Return(models.ProvenanceNone, nil)
+		store.recurring["weekly"] = models.RecurringSilence{UID: "weekly", OrgID: orgID, Name: "weekly", SilenceID: "silence"}
+
+		require.NoError(t, sut.DeleteRecurringSilence(context.Background(), "weekly", orgID, definitions.Provenance(models.ProvenanceNone)))
+		require.Empty(t, store.recurring)
+		require.Equal(t, []string{"silence"}, expirer.expired)
+	})
+}
+
+func createSilenceTemplateSvcSut() (*SilenceTemplateService, *fakeSilenceScheduleStore, *MockProvisioningStore) {
+	store := newFakeSilenceScheduleStore()
+	prov := &MockProvisioningStore{}
+	return &SilenceTemplateService{
+		store:           store,
+		provenanceStore: prov,
+		xact:            newNopTransactionManager(),
+		log:             log.NewNopLogger(),
+		validator: func(from, to models.Provenance) error {
+			return nil
+		},
+	}, store, prov
+}
+
+func createRecurringSilenceSvcSut(silences SilenceExpirer) (*RecurringSilenceService, *fakeSilenceScheduleStore, *MockProvisioningStore) {
+	store := newFakeSilenceScheduleStore()
+	prov := &MockProvisioningStore{}
+	return &RecurringSilenceService{
+		store:           store,
+		silences:        silences,
+		provenanceStore: prov,
+		xact:            newNopTransactionManager(),
+		log:             log.NewNopLogger(),
+		validator: func(from, to models.Provenance) error {
+			return nil
+		},
+	}, store, prov
+}
+
+type fakeSilenceExpirer struct {
+	expired []string
+}
+
+func (f *fakeSilenceExpirer) DeleteSilence(_ context.Context, _ int64, silenceID string) error {
+	f.expired = append(f.expired, silenceID)
+	return nil
+}
+
+type fakeSilenceScheduleStore struct {
+	templates map[string]models.SilenceTemplate
+	recurring map[string]models.RecurringSilence
+}
+
+func newFakeSilenceScheduleStore() *fakeSilenceScheduleStore {
+	return &fakeSilenceScheduleStore{
+		templates: map[string]models.SilenceTemplate{},
+		recurring: map[string]models.RecurringSilence{},
+	}
+}
+
+func (f *fakeSilenceScheduleStore) ListSilenceTemplates(_ context.Context, orgID int64) ([]models.SilenceTemplate, error) {
+	var result []models.SilenceTemplate
+	for _, t := range f.templates {
+		if t.OrgID == orgID {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeSilenceScheduleStore) GetSilenceTemplate(_ context.Context, orgID int64, uid string) (models.SilenceTemplate, error) {
+	t, ok := f.templates[uid]
+	if !ok || t.OrgID != orgID {
+		return models.SilenceTemplate{}, models.ErrSilenceTemplateNotFound
+	}
+	return t, nil
+}
+
+func (f *fakeSilenceScheduleStore) SaveSilenceTemplate(_ context.Context, t models.SilenceTemplate) error {
+	f.templates[t.UID] = t
+	return nil
+}
+
+func (f *fakeSilenceScheduleStore) DeleteSilenceTemplate(_ context.Context, _ int64, uid string) error {
+	delete(f.templates, uid)
+	return nil
+}
+
+func (f *fakeSilenceScheduleStore) ListRecurringSilences(_ context.Context, orgID int64) ([]models.RecurringSilence, error) {
+	var result []models.RecurringSilence
+	for _, s := range f.recurring {
+		if s.OrgID == orgID {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeSilenceScheduleStore) GetRecurringSilence(_ context.Context, orgID int64, uid string) (models.RecurringSilence, error) {
+	s, ok := f.recurring[uid]
+	if !ok || s.OrgID != orgID {
+		return models.RecurringSilence{}, models.ErrRecurringSilenceNotFound
+	}
+	return s, nil
+}
+
+func (f *fakeSilenceScheduleStore) SaveRecurringSilence(_ context.Context, s models.RecurringSilence) error {
+	if old, ok := f.recurring[s.UID]; ok {
+		s.SilenceID = old.SilenceID
+		s.WindowStart = old.WindowStart
+	}
+	f.recurring[s.UID] = s
+	return nil
+}
+
+func (f *fakeSilenceScheduleStore) DeleteRecurringSilence(_ context.Context, _ int64, uid string) error {
+	delete(f.recurring, uid)
+	return nil
+}
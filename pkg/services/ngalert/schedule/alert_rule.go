@@ -372,6 +372,7 @@ func (a *alertRule) evaluate(ctx context.Context, e *Evaluation, span trace.Span
 	start := a.clock.Now()
 
 	evalCtx := eval.NewContextWithPreviousResults(ctx, SchedulerUserFor(e.rule.OrgID), a.newLoadedMetricsReader(e.rule))
+	evalCtx.RuleResultsReader = e.ruleResultsReader(ctx)
 	ruleEval, err := a.evalFactory.Create(evalCtx, e.rule.GetEvalCondition().WithSource("scheduler").WithFolder(e.folderTitle))
 	var results eval.Results
 	var dur time.Duration
@@ -0,0 +1,142 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+var errRuleNotEvaluated = errors.New("rule was not evaluated at this tick")
+
+// groupResults holds the results of the recording rules of a group that are evaluated at the same tick.
+// Rules that use the results of other rules of the group via input queries wait for these rules to be evaluated,
+// which makes the group evaluated as a directed acyclic graph ordered by the position of the rules in the group.
+type groupResults struct {
+	// results is populated before the evaluations are dispatched and must not be modified after that.
+	results map[string]*ruleResults
+}
+
+type ruleResults struct {
+	index  int
+	once   sync.Once
+	done   chan struct{}
+	frames data.Frames
+	err    error
+}
+
+func newGroupResults() *groupResults {
+	return &groupResults{
+		results: make(map[string]*ruleResults),
+	}
+}
+
+// expect registers a recording rule whose results will be published at the current tick.
+func (g *groupResults) expect(rule *ngmodels.AlertRule) {
+	g.results[rule.UID] = &ruleResults{
+		index: rule.RuleGroupIndex,
+		done:  make(chan struct{}),
+	}
+}
+
+// publish stores the results of the rule and releases the rules that wait for them.
+// Only the first call has effect. The frames are used even if there is an error, e.g. when they could not be written.
+// Nil frames without an error mean that the rule was not evaluated.
+func (g *groupResults) publish(uid string, frames data.Frames, err error) {
+	res, ok := g.results[uid]
+	if !ok {
+		return
+	}
+	res.once.Do(func() {
+		res.frames = frames
+		res.err = err
+		close(res.done)
+	})
+}
+
+// reader returns a reader of results for the given rule.
+// The reader waits for the results until the context is done or the evaluation interval of the rule elapses.
+func (g *groupResults) reader(ctx context.Context, rule *ngmodels.AlertRule) eval.RuleResultsReader {
+	return groupResultsReader{
+		ctx:     ctx,
+		results: g,
+		index:   rule.RuleGroupIndex,
+		timeout: time.Duration(rule.IntervalSeconds) * time.Second,
+	}
+}
+
+type groupResultsReader struct {
+	ctx     context.Context
+	results *groupResults
+	index   int
+	timeout time.Duration
+}
+
+func (r groupResultsReader) Read(ruleUID string) (data.Frames, error) {
+	res, ok := r.results.results[ruleUID]
+	if !ok {
+		return nil, errors.New("rule is not a recording rule of the group that is evaluated at this tick")
+	}
+	// only the rules that precede the rule in the group are waited for, which makes cycles impossible.
+	if res.index >= r.index {
+		return nil, errors.New("rule does not precede the dependent rule in the group")
+	}
+
+	timer := time.NewTimer(r.timeout)
+	defer timer.Stop()
+	select {
+	case <-res.done:
+	case <-r.ctx.Done():
+		return nil, fmt.Errorf("evaluation was cancelled while waiting for results: %w", context.Cause(r.ctx))
+	case <-timer.C:
+		return nil, fmt.Errorf("results were not available within %s", r.timeout)
+	}
+
+	if res.frames != nil {
+		return res.frames, nil
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+	return nil, errRuleNotEvaluated
+}
+
+// groupsWithDependencies returns the keys of the groups that have rules that depend on other rules.
+func groupsWithDependencies(rules []*ngmodels.AlertRule) map[ngmodels.AlertRuleGroupKey]struct{} {
+	result := make(map[ngmodels.AlertRuleGroupKey]struct{})
+	for _, rule := range rules {
+		if len(rule.GetDependencies()) > 0 {
+			result[rule.GetGroupKey()] = struct{}{}
+		}
+	}
+	return result
+}
+
+// chainRuleGroups makes the rules of the given groups share the results of the recording rules evaluated at the same tick.
+func chainRuleGroups(items []readyToRunItem, groups map[ngmodels.AlertRuleGroupKey]struct{}) {
+	if len(groups) == 0 {
+		return
+	}
+	results := make(map[ngmodels.AlertRuleGroupKey]*groupResults, len(groups))
+	for i := range items {
+		key := items[i].rule.GetGroupKey()
+		if _, ok := groups[key]; !ok {
+			continue
+		}
+		res, ok := results[key]
+		if !ok {
+			res = newGroupResults()
+			results[key] = res
+		}
+		if items[i].rule.Type() == ngmodels.RuleTypeRecording {
+			res.expect(items[i].rule)
+		}
+		items[i].results = res
+	}
+}
@@ -0,0 +1,153 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestGroupResults(t *testing.T) {
+	gen := models.RuleGen
+	groupKey := models.GenerateGroupKey(1)
+	source := gen.With(gen.WithAllRecordingRules(), gen.WithGroupKey(groupKey), gen.WithGroupIndex(1), gen.WithIntervalSeconds(10)).GenerateRef()
+	dependent := gen.With(gen.WithGroupKey(groupKey), gen.WithGroupIndex(2), gen.WithIntervalSeconds(10)).GenerateRef()
+	frames := data.Frames{data.NewFrame("test", data.NewField("value", nil, []float64{1}))}
+
+	t.Run("should wait for the results of the rule", func(t *testing.T) {
+		results := newGroupResults()
+		results.expect(source)
+
+		go results.publish(source.UID, frames, nil)
+		actual, err := results.reader(context.Background(), dependent).Read(source.UID)
+		require.NoError(t, err)
+		require.Equal(t, frames, actual)
+	})
+
+	t.Run("should use only the first published results", func(t *testing.T) {
+		results := newGroupResults()
+		results.expect(source)
+
+		results.publish(source.UID, frames, nil)
+		results.publish(source.UID, nil, errors.New("test"))
+		actual, err := results.reader(context.Background(), dependent).Read(source.UID)
+		require.NoError(t, err)
+		require.Equal(t, frames, actual)
+	})
+
+	t.Run("should return frames even if there is an error", func(t *testing.T) {
+		results := newGroupResults()
+		results.expect(source)
+
+		results.publish(source.UID, frames, errors.New("remote write failed"))
+		actual, err := results.reader(context.Background(), dependent).Read(source.UID)
+		require.NoError(t, err)
+		require.Equal(t, frames, actual)
+	})
+
+	t.Run("should return error if the rule failed", func(t *testing.T) {
+		results := newGroupResults()
+		results.expect(source)
+		expectedErr := errors.New("test")
+
+		results.publish(source.UID, nil, expectedErr)
+		_, err := results.reader(context.Background(), dependent).Read(source.UID)
+		require.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("should return error if the rule was not evaluated", func(t *testing.T) {
+		results := newGroupResults()
+		results.expect(source)
+
+		results.publish(source.UID, nil, nil)
+		_, err := results.reader(context.Background(), dependent).Read(source.UID)
+		require.ErrorIs(t, err, errRuleNotEvaluated)
+	})
+
+	t.Run("should return error if the rule is not expected", func(t *testing.T) {
+		results := newGroupResults()
+
+		_, err := results.reader(context.Background(), dependent).Read(source.UID)
+		require.ErrorContains(t, err, "is not a recording rule of the group")
+	})
+
+	t.Run("should return error if the rule does not precede the reading rule", func(t *testing.T) {
+		results := newGroupResults()
+		results.expect(source)
+		results.expect(dependent)
+
+		_, err := results.reader(context.Background(), source).Read(dependent.UID)
+		require.ErrorContains(t, err, "does not precede")
+		_, err = results.reader(context.Background(), source).Read(source.UID)
+		require.ErrorContains(t, err, "does not precede")
+	})
+
+	t.Run("should stop waiting when context is cancelled", func(t *testing.T) {
+		results := newGroupResults()
+		results.expect(source)
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cause := errors.New("test")
+		cancel(cause)
+
+		_, err := results.reader(ctx, dependent).Read(source.UID)
+		require.ErrorIs(t, err, cause)
+	})
+
+	t.Run("should stop waiting after the interval of the rule", func(t *testing.T) {
+		results := newGroupResults()
+		results.expect(source)
+		reader := results.reader(context.Background(), dependent).(groupResultsReader)
+		reader.timeout = time.Millisecond
+
+		_, err := reader.Read(source.UID)
+		require.ErrorContains(t, err, "results were not available")
+	})
+}
+
+func TestChainRuleGroups(t *testing.T) {
+	gen := models.RuleGen
+	chainedKey := models.GenerateGroupKey(1)
+	source := gen.With(gen.WithAllRecordingRules(), gen.WithGroupKey(chainedKey), gen.WithGroupIndex(1)).GenerateRef()
+	dependent := gen.With(withDependencyOn(source.UID), gen.WithGroupKey(chainedKey), gen.WithGroupIndex(2)).GenerateRef()
+	other := gen.With(gen.WithGroupKey(models.GenerateGroupKey(1))).GenerateRef()
+
+	groups := groupsWithDependencies([]*models.AlertRule{source, dependent, other})
+	require.Equal(t, map[models.AlertRuleGroupKey]struct{}{chainedKey: {}}, groups)
+
+	items := []readyToRunItem{
+		{Evaluation: Evaluation{rule: source}},
+		{Evaluation: Evaluation{rule: dependent}},
+		{Evaluation: Evaluation{rule: other}},
+	}
+	chainRuleGroups(items, groups)
+
+	require.NotNil(t, items[0].results)
+	require.Same(t, items[0].results, items[1].results)
+	require.Nil(t, items[2].results)
+	require.Contains(t, items[0].results.results, source.UID)
+	require.NotContains(t, items[0].results.results, dependent.UID)
+
+	items[0].publishResults(data.Frames{}, nil)
+	frames, err := items[1].ruleResultsReader(context.Background()).Read(source.UID)
+	require.NoError(t, err)
+	require.Empty(t, frames)
+	require.Nil(t, items[2].ruleResultsReader(context.Background()))
+}
+
+func withDependencyOn(ruleUID string) models.AlertRuleMutator {
+	return func(rule *models.AlertRule) {
+		rule.Data = append(rule.Data, models.AlertQuery{
+			RefID:         "INPUT",
+			DatasourceUID: expr.InputDatasourceUID,
+			Model:         json.RawMessage(fmt.Sprintf(`{"ruleUid": %q}`, ruleUID)),
+		})
+	}
+}
@@ -23,6 +23,9 @@ type RuleStateProvider interface {
 
 // AlertingResultsFromRuleState implements eval.AlertingResultsReader that gets the data from state manager.
 // It returns results fingerprints only for Alerting and Pending states that have empty StateReason.
+// The state manager restores the result fingerprints of the states from the database on startup, so the results keep
+// being evaluated against the recovery thresholds after a restart. States that were saved without a result fingerprint
+// are skipped because they cannot be matched to a result.
 type AlertingResultsFromRuleState struct {
 	Manager RuleStateProvider
 	Rule    *ngmodels.AlertRule
@@ -33,7 +36,7 @@ func (n AlertingResultsFromRuleState) Read() map[data.Fingerprint]struct{} {
 
 	active := map[data.Fingerprint]struct{}{}
 	for _, st := range states {
-		if st.StateReason != "" {
+		if st.StateReason != "" || st.ResultFingerprint == 0 {
 			continue
 		}
 		if st.State == eval.Alerting || st.State == eval.Pending {
@@ -38,6 +38,13 @@ func TestLoadedResultsFromRuleState(t *testing.T) {
 		require.Contains(t, loaded, data.Fingerprint(2))
 	})
 
+	t.Run("should not return states without result fingerprint", func(t *testing.T) {
+		p.states[rule.GetKey()] = append(p.states[rule.GetKey()], &state.State{State: eval.Alerting})
+		loaded := reader.Read()
+		require.Len(t, loaded, 2)
+		require.NotContains(t, loaded, data.Fingerprint(0))
+	})
+
 	t.Run("should not return any states with reason", func(t *testing.T) {
 		for _, s := range p.states[rule.GetKey()] {
 			s.StateReason = uuid.NewString()
@@ -2,6 +2,7 @@ package schedule
 
 import (
 	context "context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -22,6 +23,8 @@ import (
 	"github.com/grafana/grafana/pkg/util"
 )
 
+var errRecordingRulesDisabled = errors.New("recording rules are disabled")
+
 type RuleStatus struct {
 	Health              string
 	LastError           error
@@ -134,6 +137,8 @@ func (r *recordingRule) Run() error {
 			}
 			if !r.cfg.Enabled {
 				r.logger.Warn("Recording rule scheduled but subsystem is not enabled. Skipping")
+				// the rules that depend on the rule must not wait for results that will never be published
+				eval.publishResults(nil, errRecordingRulesDisabled)
 				return nil
 			}
 			// TODO: Skipping the "evalRunning" guard that the alert rule routine does, because it seems to be dead code and impossible to hit.
@@ -157,7 +162,10 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 	evalTotalFailures := r.metrics.EvalFailures.WithLabelValues(orgID)
 	evalStart := r.clock.Now()
 
+	var frames data.Frames
+	var latestError error
 	defer func() {
+		ev.publishResults(frames, latestError)
 		evalTotal.Inc()
 		end := r.clock.Now()
 		dur := end.Sub(evalStart)
@@ -182,7 +190,6 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 	))
 	defer span.End()
 
-	var latestError error
 	for attempt := int64(1); attempt <= r.maxAttempts; attempt++ {
 		logger := logger.New("attempt", attempt)
 		if ctx.Err() != nil {
@@ -192,7 +199,8 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 		}
 
 		evalAttemptTotal.Inc()
-		err := r.tryEvaluation(ctx, ev, logger)
+		result, err := r.tryEvaluation(ctx, ev, logger)
+		frames = result
 		latestError = err
 		if err == nil {
 			break
@@ -233,18 +241,21 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 	r.health.Store("ok")
 }
 
-func (r *recordingRule) tryEvaluation(ctx context.Context, ev *Evaluation, logger log.Logger) error {
+// tryEvaluation evaluates the rule and writes the results. It returns the frames of the query, which are empty if the query returned no data.
+// The frames are returned even if writing them failed, so the rules that depend on the rule can use them.
+func (r *recordingRule) tryEvaluation(ctx context.Context, ev *Evaluation, logger log.Logger) (data.Frames, error) {
 	evalStart := r.clock.Now()
 	evalCtx := eval.NewContext(ctx, SchedulerUserFor(ev.rule.OrgID))
+	evalCtx.RuleResultsReader = ev.ruleResultsReader(ctx)
 	result, err := r.buildAndExecutePipeline(ctx, evalCtx, ev, logger)
 	evalDur := r.clock.Now().Sub(evalStart)
 	if err != nil {
-		return fmt.Errorf("server side expressions pipeline returned an error: %w", err)
+		return nil, fmt.Errorf("server side expressions pipeline returned an error: %w", err)
 	}
 
 	// There might be errors in the pipeline results, even if the query succeeded.
 	if err := eval.FindConditionError(result, ev.rule.Record.From); err != nil {
-		return fmt.Errorf("the query failed with an error: %w", err)
+		return nil, fmt.Errorf("the query failed with an error: %w", err)
 	}
 	// TODO: This is missing dedicated logic for NoData. If NoData we can skip the write.
 
@@ -261,7 +272,7 @@ func (r *recordingRule) tryEvaluation(ctx context.Context, ev *Evaluation, logge
 		))
 		logger.Debug("Query returned no data", "reason", err)
 		r.health.Store("nodata")
-		return nil
+		return data.Frames{}, nil
 	}
 
 	writeStart := r.clock.Now()
@@ -271,7 +282,7 @@ func (r *recordingRule) tryEvaluation(ctx context.Context, ev *Evaluation, logge
 	if err != nil {
 		span.SetStatus(codes.Error, "failed to write metrics")
 		span.RecordError(err)
-		return fmt.Errorf("remote write failed: %w", err)
+		return frames, fmt.Errorf("remote write failed: %w", err)
 	}
 
 	logger.Debug("Metrics written", "duration", writeDur)
@@ -279,7 +290,7 @@ func (r *recordingRule) tryEvaluation(ctx context.Context, ev *Evaluation, logge
 		attribute.Int64("frames", int64(len(frames))),
 	))
 
-	return nil
+	return frames, nil
 }
 
 func (r *recordingRule) buildAndExecutePipeline(ctx context.Context, evalCtx eval.EvaluationContext, ev *Evaluation, logger log.Logger) (*backend.QueryDataResponse, error) {
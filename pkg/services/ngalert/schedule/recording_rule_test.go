@@ -150,6 +150,30 @@ func TestRecordingRule(t *testing.T) {
 			t.Fatal("Run() never exited")
 		}
 	})
+
+	t.Run("Run should publish an error when recording rules are disabled", func(t *testing.T) {
+		rule := newRecordingRule(context.Background(), models.AlertRuleKey{}, 0, nil, nil, setting.RecordingRuleSettings{}, log.NewNopLogger(), nil, nil, writer.FakeWriter{}, nil, nil)
+		source := gen.With(gen.WithGroupIndex(1)).GenerateRef()
+		results := newGroupResults()
+		results.expect(source)
+
+		runResult := make(chan error)
+		go func() {
+			runResult <- rule.Run()
+		}()
+		rule.Eval(&Evaluation{scheduledAt: time.Now(), rule: source, results: results})
+
+		select {
+		case err := <-runResult:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Run() never exited")
+		}
+
+		reader := results.reader(context.Background(), gen.With(gen.WithGroupKey(source.GetGroupKey()), gen.WithGroupIndex(2)).GenerateRef())
+		_, err := reader.Read(source.UID)
+		require.ErrorIs(t, err, errRecordingRulesDisabled)
+	})
 }
 
 func blankRecordingRuleForTests(ctx context.Context) *recordingRule {
@@ -487,6 +511,63 @@ func TestRecordingRule_Integration(t *testing.T) {
 			require.Equal(t, "error", status.Health)
 		})
 	})
+
+	t.Run("rule that uses the results of another rule of the group", func(t *testing.T) {
+		groupKey := models.GenerateGroupKey(123)
+		source := gen.With(withQueryForHealth("ok"), gen.WithGroupKey(groupKey), gen.WithGroupIndex(1)).GenerateRef()
+		dependent := gen.With(withInputQuery(source.UID), gen.WithGroupKey(groupKey), gen.WithGroupIndex(2)).GenerateRef()
+		ruleStore.PutRule(context.Background(), source, dependent)
+		folderTitle := ruleStore.getNamespaceTitle(source.NamespaceUID)
+		ruleFactory := ruleFactoryFromScheduler(sch)
+
+		results := newGroupResults()
+		results.expect(source)
+		results.expect(dependent)
+
+		evalDoneChan := make(chan time.Time)
+		processes := make([]*recordingRule, 0, 2)
+		for _, rule := range []*models.AlertRule{dependent, source} {
+			process := ruleFactory.new(context.Background(), rule).(*recordingRule)
+			process.evalAppliedHook = func(_ models.AlertRuleKey, t time.Time) {
+				evalDoneChan <- t
+			}
+			go func() {
+				_ = process.Run()
+			}()
+			processes = append(processes, process)
+		}
+
+		now := time.Now()
+		// the dependent rule is dispatched first and waits for the results of the source rule
+		for i, rule := range []*models.AlertRule{dependent, source} {
+			processes[i].Eval(&Evaluation{
+				scheduledAt: now,
+				rule:        rule,
+				folderTitle: folderTitle,
+				results:     results,
+			})
+		}
+		_ = waitForTimeChannel(t, evalDoneChan)
+		_ = waitForTimeChannel(t, evalDoneChan)
+
+		t.Run("status shows evaluation", func(t *testing.T) {
+			for _, process := range processes {
+				status := process.Status()
+				require.Equal(t, "ok", status.Health)
+				require.Nil(t, status.LastError)
+			}
+		})
+
+		t.Run("dependent rule is evaluated against the results of the source rule", func(t *testing.T) {
+			reader := results.reader(context.Background(), gen.With(gen.WithGroupKey(groupKey), gen.WithGroupIndex(3)).GenerateRef())
+			frames, err := reader.Read(dependent.UID)
+			require.NoError(t, err)
+			require.Len(t, frames, 1)
+			v, ok := frames[0].Fields[0].ConcreteAt(0)
+			require.True(t, ok)
+			require.Equal(t, 6.0, v)
+		})
+	})
 }
 
 func withQueryForHealth(health string) models.AlertRuleMutator {
@@ -530,6 +611,29 @@ func withQueryForHealth(health string) models.AlertRuleMutator {
 	}
 }
 
+// withInputQuery makes the recording rule double the results of the given rule.
+func withInputQuery(ruleUID string) models.AlertRuleMutator {
+	return func(rule *models.AlertRule) {
+		rule.Record.From = "B"
+		rule.Data = []models.AlertQuery{
+			{
+				DatasourceUID: expr.InputDatasourceUID,
+				Model:         json.RawMessage(fmt.Sprintf(`{"ruleUid": %q}`, ruleUID)),
+				RefID:         "A",
+			},
+			{
+				DatasourceUID: expr.DatasourceUID,
+				Model: json.RawMessage(`{
+					"datasourceUid": "__expr__",
+					"type":"math",
+					"expression":"$A * 2"
+				}`),
+				RefID: "B",
+			},
+		}
+	}
+}
+
 func setupWriter(t *testing.T, target *writer.TestRemoteWriteTarget, reg prometheus.Registerer) *writer.PrometheusWriter {
 	provider := testClientProvider{}
 	m := metrics.NewNGAlert(reg)
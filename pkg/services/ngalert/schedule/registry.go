@@ -12,6 +12,9 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 )
 
@@ -96,12 +99,30 @@ type Evaluation struct {
 	scheduledAt time.Time
 	rule        *models.AlertRule
 	folderTitle string
+	// results are shared by the rules of the group that are evaluated at the same tick. It is nil if the rules of the group do not depend on each other.
+	results *groupResults
 }
 
 func (e *Evaluation) Fingerprint() fingerprint {
 	return ruleWithFolder{e.rule, e.folderTitle}.Fingerprint()
 }
 
+// ruleResultsReader returns a reader of the results of the rules the evaluated rule depends on, or nil if it does not depend on any rule.
+func (e *Evaluation) ruleResultsReader(ctx context.Context) eval.RuleResultsReader {
+	if e.results == nil {
+		return nil
+	}
+	return e.results.reader(ctx, e.rule)
+}
+
+// publishResults makes the results of the evaluated rule available to the rules of the group that depend on it.
+func (e *Evaluation) publishResults(frames data.Frames, err error) {
+	if e.results == nil {
+		return
+	}
+	e.results.publish(e.rule.UID, frames, err)
+}
+
 type alertRulesRegistry struct {
 	rules        map[models.AlertRuleKey]*models.AlertRule
 	folderTitles map[models.FolderKey]string
@@ -218,7 +239,7 @@ type ruleWithFolder struct {
 	folderTitle string
 }
 
-// fingerprint calculates a fingerprint that includes all fields except rule's Version and Update timestamp.
+// fingerprint calculates a fingerprint that includes all fields except rule's Version, Update timestamp and the user that updated it.
 func (r ruleWithFolder) Fingerprint() fingerprint {
 	rule := r.rule
 
@@ -317,6 +338,7 @@ func (r ruleWithFolder) Fingerprint() fingerprint {
 	writeInt(rule.ID)
 	writeInt(rule.OrgID)
 	writeInt(int64(rule.For))
+	writeInt(int64(rule.KeepFiringFor))
 	if rule.DashboardUID != nil {
 		writeString(*rule.DashboardUID)
 	}
@@ -260,6 +260,7 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 			"Updated":         {},
 			"IntervalSeconds": {},
 			"Annotations":     {},
+			"UpdatedBy":       {},
 		}
 
 		tp := reflect.TypeOf(rule).Elem()
@@ -256,6 +256,9 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 
 	sch.updateRulesMetrics(alertRules)
 
+	// rules of these groups use the results of other rules of the group, and therefore the group must be evaluated at the same tick
+	chainedGroups := groupsWithDependencies(alertRules)
+
 	readyToRun := make([]readyToRunItem, 0)
 	updatedRules := make([]ngmodels.AlertRuleKeyWithVersion, 0, len(updated)) // this is needed for tests only
 	restartedRules := make([]Rule, 0)
@@ -312,7 +315,11 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		}
 
 		itemFrequency := item.IntervalSeconds / int64(sch.baseInterval.Seconds())
-		offset := jitterOffsetInTicks(item, sch.baseInterval, sch.jitterEvaluations)
+		jitterStrategy := sch.jitterEvaluations
+		if _, ok := chainedGroups[item.GetGroupKey()]; ok && jitterStrategy == JitterByRule {
+			jitterStrategy = JitterByGroup
+		}
+		offset := jitterOffsetInTicks(item, sch.baseInterval, jitterStrategy)
 		isReadyToRun := item.IntervalSeconds != 0 && (tickNum%itemFrequency)-offset == 0
 
 		var folderTitle string
@@ -356,6 +363,8 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		sch.log.Warn("Unable to obtain folder titles for some rules", "missingFolderUIDToRuleUID", missingFolder)
 	}
 
+	chainRuleGroups(readyToRun, chainedGroups)
+
 	var step int64 = 0
 	if len(readyToRun) > 0 {
 		step = sch.baseInterval.Nanoseconds() / int64(len(readyToRun))
@@ -372,9 +381,12 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 			success, dropped := item.ruleRoutine.Eval(&item.Evaluation)
 			if !success {
 				sch.log.Debug("Scheduled evaluation was canceled because evaluation routine was stopped", append(key.LogContext(), "time", tick)...)
+				item.publishResults(nil, nil)
 				return
 			}
 			if dropped != nil {
+				// the rules that depend on the dropped evaluation must not wait for it
+				dropped.publishResults(nil, nil)
 				sch.log.Warn("Tick dropped because alert rule evaluation is too slow", append(key.LogContext(), "time", tick, "droppedTick", dropped.scheduledAt)...)
 				orgID := fmt.Sprint(key.OrgID)
 				sch.metrics.EvaluationMissed.WithLabelValues(orgID, item.rule.Title).Inc()
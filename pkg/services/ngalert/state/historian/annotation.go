@@ -115,10 +115,6 @@ func (h *AnnotationBackend) Query(ctx context.Context, query ngmodels.HistoryQue
 		return nil, fmt.Errorf("ruleUID is required to query annotations")
 	}
 
-	if query.Labels != nil {
-		logger.Warn("Annotation state history backend does not support label queries, ignoring that filter")
-	}
-
 	rq := ngmodels.GetAlertRuleByUIDQuery{
 		UID:   query.RuleUID,
 		OrgID: query.OrgID,
@@ -175,13 +171,18 @@ func (h *AnnotationBackend) Query(ctx context.Context, query ngmodels.HistoryQue
 	prevStates := make([]string, 0, len(items))
 	nextStates := make([]string, 0, len(items))
 	values := make([]string, 0, len(items))
+	filterLabels := len(query.Labels) > 0 || len(query.Matchers) > 0
 	for _, item := range items {
+		// Annotations don't store the labels of the instance, they are parsed back from the text.
+		if filterLabels && !matchesLabels(parseAnnotationLabels(item.Text), query) {
+			continue
+		}
 		data, err := json.Marshal(item.Data)
 		if err != nil {
 			logger.Error("Annotation service gave an annotation with unparseable data, skipping", "id", item.ID, "err", err)
 			continue
 		}
-		times = append(times, time.Unix(item.Time, 0))
+		times = append(times, time.UnixMilli(item.Time))
 		texts = append(texts, item.Text)
 		prevStates = append(prevStates, item.PrevState)
 		nextStates = append(nextStates, item.NewState)
@@ -256,6 +257,40 @@ func BuildAnnotationTextAndData(rule history_model.RuleMeta, currentState *state
 	return fmt.Sprintf("%s {%s} - %s", rule.Title, labels.String(), value), jsonData
 }
 
+// parseAnnotationLabels returns the labels of the alert instance from the text of an annotation built by BuildAnnotationTextAndData.
+// The text is not escaped, so label values that contain ", " or "=" are not parsed correctly.
+func parseAnnotationLabels(text string) data.Labels {
+	result := data.Labels{}
+	start := strings.Index(text, " {")
+	end := strings.LastIndex(text, "} - ")
+	if start < 0 || end < start+2 {
+		return result
+	}
+	for _, pair := range strings.Split(text[start+2:end], ", ") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// matchesLabels returns true if the labels match both the label equality filters and the matchers of the query.
+func matchesLabels(lbls map[string]string, query ngmodels.HistoryQuery) bool {
+	for k, v := range query.Labels {
+		if lbls[k] != v {
+			return false
+		}
+	}
+	for _, m := range query.Matchers {
+		if !m.Matches(lbls[m.Name]) {
+			return false
+		}
+	}
+	return true
+}
+
 func jsonifyValues(vs map[string]float64) *simplejson.Json {
 	if vs == nil {
 		return nil
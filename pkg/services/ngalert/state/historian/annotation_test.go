@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/alertmanager/pkg/labels"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
@@ -53,6 +54,32 @@ func TestAnnotationHistorian(t *testing.T) {
 		}
 	})
 
+	t.Run("alert annotations are filtered by labels", func(t *testing.T) {
+		store := &interceptingAnnotationStore{items: []*annotations.ItemDTO{
+			{ID: 1, Text: "MyAlert {a=b} - No data", Time: 1000},
+			{ID: 2, Text: "MyAlert {a=c, team=db} - No data", Time: 2000},
+		}}
+		anns := createTestAnnotationSutWithStore(t, store)
+
+		q := models.HistoryQuery{
+			RuleUID:  "my-rule",
+			OrgID:    1,
+			Matchers: labels.Matchers{labels.MustNewMatcher(labels.MatchRegexp, "a", "c|d")},
+		}
+		frame, err := anns.Query(context.Background(), q)
+		require.NoError(t, err)
+		require.Equal(t, 1, frame.Rows())
+		require.Equal(t, "MyAlert {a=c, team=db} - No data", frame.Fields[1].At(0))
+		require.Equal(t, time.UnixMilli(2000), frame.Fields[0].At(0))
+
+		q.Matchers = nil
+		q.Labels = map[string]string{"a": "b"}
+		frame, err = anns.Query(context.Background(), q)
+		require.NoError(t, err)
+		require.Equal(t, 1, frame.Rows())
+		require.Equal(t, "MyAlert {a=b} - No data", frame.Fields[1].At(0))
+	})
+
 	t.Run("alert annotations are authorized", func(t *testing.T) {
 		anns := createTestAnnotationBackendSut(t)
 		ac := &acfakes.FakeRuleService{}
@@ -276,11 +303,12 @@ func assertValidJSON(t *testing.T, j *simplejson.Json) string {
 
 type interceptingAnnotationStore struct {
 	lastQuery *annotations.ItemQuery
+	items     []*annotations.ItemDTO
 }
 
 func (i *interceptingAnnotationStore) Find(ctx context.Context, query *annotations.ItemQuery) ([]*annotations.ItemDTO, error) {
 	i.lastQuery = query
-	return []*annotations.ItemDTO{}, nil
+	return i.items, nil
 }
 
 func (i *interceptingAnnotationStore) Save(ctx context.Context, panel *PanelKey, annotations []annotations.Item, orgID int64, logger log.Logger) error {
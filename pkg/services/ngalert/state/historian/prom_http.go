@@ -0,0 +1,204 @@
+package historian
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	prometheus "github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/ngalert/client"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+type PrometheusConfig struct {
+	ReadPathURL       *url.URL
+	WritePathURL      *url.URL
+	BasicAuthUser     string
+	BasicAuthPassword string
+	TenantID          string
+	ExternalLabels    map[string]string
+}
+
+func NewPrometheusConfig(cfg setting.UnifiedAlertingStateHistorySettings) (PrometheusConfig, error) {
+	if cfg.PrometheusReadURL == "" {
+		return PrometheusConfig{}, fmt.Errorf("read path URL must be provided")
+	}
+	if cfg.PrometheusWriteURL == "" {
+		return PrometheusConfig{}, fmt.Errorf("remote write URL must be provided")
+	}
+
+	readURL, err := url.Parse(cfg.PrometheusReadURL)
+	if err != nil {
+		return PrometheusConfig{}, fmt.Errorf("failed to parse prometheus read URL: %w", err)
+	}
+	writeURL, err := url.Parse(cfg.PrometheusWriteURL)
+	if err != nil {
+		return PrometheusConfig{}, fmt.Errorf("failed to parse prometheus remote write URL: %w", err)
+	}
+
+	return PrometheusConfig{
+		ReadPathURL:       readURL,
+		WritePathURL:      writeURL,
+		BasicAuthUser:     cfg.PrometheusBasicAuthUsername,
+		BasicAuthPassword: cfg.PrometheusBasicAuthPassword,
+		TenantID:          cfg.PrometheusTenantID,
+		ExternalLabels:    cfg.ExternalLabels,
+	}, nil
+}
+
+// WriterSettings returns the settings of the remote writer that writes the state history series.
+func (c PrometheusConfig) WriterSettings() setting.RecordingRuleSettings {
+	headers := make(map[string]string)
+	if c.TenantID != "" {
+		headers["X-Scope-OrgID"] = c.TenantID
+	}
+	return setting.RecordingRuleSettings{
+		Enabled:           true,
+		URL:               c.WritePathURL.String(),
+		BasicAuthUsername: c.BasicAuthUser,
+		BasicAuthPassword: c.BasicAuthPassword,
+		CustomHeaders:     headers,
+		Timeout:           StateHistoryWriteTimeout,
+	}
+}
+
+// HttpPrometheusClient reads state history series with the Prometheus HTTP API.
+type HttpPrometheusClient struct {
+	client client.Requester
+	cfg    PrometheusConfig
+	log    log.Logger
+}
+
+func NewPrometheusClient(cfg PrometheusConfig, req client.Requester, metrics *metrics.Historian, logger log.Logger, tracer tracing.Tracer) *HttpPrometheusClient {
+	tc := client.NewTimedClient(req, metrics.WriteDuration)
+	trc := client.NewTracedClient(tc, tracer, "ngalert.historian.client")
+	return &HttpPrometheusClient{
+		client: trc,
+		cfg:    cfg,
+		log:    logger.New("protocol", "http"),
+	}
+}
+
+func (c *HttpPrometheusClient) Ping(ctx context.Context) error {
+	log := c.log.FromContext(ctx)
+	uri := c.cfg.ReadPathURL.JoinPath("/api/v1/status/buildinfo")
+	req, err := http.NewRequest(http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	c.setAuthAndTenantHeaders(req)
+
+	req = req.WithContext(ctx)
+	res, err := c.client.Do(req)
+	if res != nil {
+		defer func() {
+			if err := res.Body.Close(); err != nil {
+				log.Warn("Failed to close response body", "err", err)
+			}
+		}()
+	}
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("ping request to prometheus endpoint returned a non-200 status code: %d", res.StatusCode)
+	}
+	log.Debug("Ping request to Prometheus endpoint succeeded", "status", res.StatusCode)
+	return nil
+}
+
+// Query runs an instant query at the given time. The query is expected to return a range vector.
+func (c *HttpPrometheusClient) Query(ctx context.Context, promQL string, t time.Time) (prometheus.Matrix, error) {
+	log := c.log.FromContext(ctx)
+	queryURL := c.cfg.ReadPathURL.JoinPath("/api/v1/query")
+
+	values := url.Values{}
+	values.Set("query", promQL)
+	values.Set("time", strconv.FormatFloat(float64(t.UnixMilli())/1000, 'f', -1, 64))
+
+	queryURL.RawQuery = values.Encode()
+	log.Debug("Sending query request", "query", promQL, "time", t)
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	req = req.WithContext(ctx)
+	c.setAuthAndTenantHeaders(req)
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			log.Warn("Failed to close response body", "err", err)
+		}
+	}()
+
+	data, err := c.handlePrometheusResponse(log, res)
+	if err != nil {
+		return nil, err
+	}
+
+	result := PrometheusQueryRes{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("error parsing request response: %w", err)
+	}
+	if result.Data.ResultType != prometheus.ValMatrix.String() {
+		return nil, fmt.Errorf("unexpected result type %q, expected %q", result.Data.ResultType, prometheus.ValMatrix.String())
+	}
+
+	return result.Data.Result, nil
+}
+
+type PrometheusQueryRes struct {
+	Data PrometheusQueryData `json:"data"`
+}
+
+type PrometheusQueryData struct {
+	ResultType string            `json:"resultType"`
+	Result     prometheus.Matrix `json:"result"`
+}
+
+func (c *HttpPrometheusClient) setAuthAndTenantHeaders(req *http.Request) {
+	if c.cfg.BasicAuthUser != "" || c.cfg.BasicAuthPassword != "" {
+		req.SetBasicAuth(c.cfg.BasicAuthUser, c.cfg.BasicAuthPassword)
+	}
+
+	if c.cfg.TenantID != "" {
+		req.Header.Add("X-Scope-OrgID", c.cfg.TenantID)
+	}
+}
+
+func (c *HttpPrometheusClient) handlePrometheusResponse(log log.Logger, res *http.Response) ([]byte, error) {
+	if res == nil {
+		return nil, fmt.Errorf("response is nil")
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading request response: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		if len(data) > 0 {
+			log.Error("Error response from Prometheus", "response", string(data), "status", res.StatusCode)
+		} else {
+			log.Error("Error response from Prometheus with an empty body", "status", res.StatusCode)
+		}
+		return nil, fmt.Errorf("received a non-200 response from prometheus, status: %d", res.StatusCode)
+	}
+
+	return data, nil
+}
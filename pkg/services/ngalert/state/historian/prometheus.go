@@ -0,0 +1,449 @@
+package historian
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	prometheus "github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/ngalert/client"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	history_model "github.com/grafana/grafana/pkg/services/ngalert/state/historian/model"
+	"github.com/grafana/grafana/pkg/services/ngalert/writer"
+)
+
+const (
+	// AlertsMetricName is the name of the series that has a sample for every evaluation of an active alert instance,
+	// like the ALERTS series of Prometheus.
+	AlertsMetricName = "GRAFANA_ALERTS"
+	// AlertsForStateMetricName is the name of the series that has the time an alert instance became active as its value,
+	// like the ALERTS_FOR_STATE series of Prometheus.
+	AlertsForStateMetricName = "GRAFANA_ALERTS_FOR_STATE"
+
+	PromAlertStateLabel   = "alertstate"
+	PromStateLabel        = "grafana_alertstate"
+	PromOrgIDLabel        = "grafana_org_id"
+	PromRuleUIDLabel      = "grafana_rule_uid"
+	PromGroupLabel        = "grafana_rule_group"
+	PromFolderUIDLabel    = "grafana_folder_uid"
+	PromDashboardUIDLabel = "grafana_dashboard_uid"
+	PromPanelIDLabel      = "grafana_panel_id"
+
+	promAlertStatePending  = "pending"
+	promAlertStateFiring   = "firing"
+	promAlertStateInactive = "inactive"
+
+	// promQueryLookback is how long before the start of the queried range the series are read
+	// to learn the state the alert instances were in when the range starts.
+	promQueryLookback = time.Hour
+)
+
+// promSystemLabels are the labels that the backend adds to the series. They are not part of the labels of the alert instance.
+var promSystemLabels = []string{
+	prometheus.MetricNameLabel,
+	PromAlertStateLabel,
+	PromStateLabel,
+	PromOrgIDLabel,
+	PromRuleUIDLabel,
+	PromGroupLabel,
+	PromFolderUIDLabel,
+	PromDashboardUIDLabel,
+	PromPanelIDLabel,
+}
+
+type remotePrometheusWriter interface {
+	WritePoints(ctx context.Context, points []writer.Point, orgID int64) error
+}
+
+type remotePrometheusClient interface {
+	Ping(context.Context) error
+	Query(ctx context.Context, promQL string, t time.Time) (prometheus.Matrix, error)
+}
+
+// PrometheusBackend is a state.Historian that records state history as series written to a Prometheus remote write endpoint,
+// and reads it back with the Prometheus HTTP API.
+type PrometheusBackend struct {
+	writer         remotePrometheusWriter
+	client         remotePrometheusClient
+	externalLabels map[string]string
+	clock          clock.Clock
+	metrics        *metrics.Historian
+	log            log.Logger
+	ac             AccessControl
+	ruleStore      RuleStore
+}
+
+func NewPrometheusBackend(logger log.Logger, cfg PrometheusConfig, w remotePrometheusWriter, req client.Requester, metrics *metrics.Historian, tracer tracing.Tracer, ruleStore RuleStore, ac AccessControl) *PrometheusBackend {
+	return &PrometheusBackend{
+		writer:         w,
+		client:         NewPrometheusClient(cfg, req, metrics, logger, tracer),
+		externalLabels: cfg.ExternalLabels,
+		clock:          clock.New(),
+		metrics:        metrics,
+		log:            logger,
+		ac:             ac,
+		ruleStore:      ruleStore,
+	}
+}
+
+func (h *PrometheusBackend) TestConnection(ctx context.Context) error {
+	return h.client.Ping(ctx)
+}
+
+// Record writes the series of the given states of a rule to the remote write endpoint.
+func (h *PrometheusBackend) Record(ctx context.Context, rule history_model.RuleMeta, states []state.StateTransition) <-chan error {
+	points, transitions := StatesToPoints(rule, states, h.externalLabels)
+
+	errCh := make(chan error, 1)
+	if len(points) == 0 {
+		close(errCh)
+		return errCh
+	}
+
+	// This is a new background job, so let's create a brand new context for it.
+	// We want it to be isolated, i.e. we don't want grafana shutdowns to interrupt this work
+	// immediately but rather try to flush writes.
+	writeCtx := context.Background()
+	writeCtx, cancel := context.WithTimeout(writeCtx, StateHistoryWriteTimeout)
+	writeCtx = history_model.WithRuleData(writeCtx, rule)
+	writeCtx = trace.ContextWithSpan(writeCtx, trace.SpanFromContext(ctx))
+
+	go func(ctx context.Context) {
+		defer cancel()
+		defer close(errCh)
+		logger := h.log.FromContext(ctx)
+		logger.Debug("Saving state history batch", "samples", len(points), "transitions", transitions)
+		org := fmt.Sprint(rule.OrgID)
+		h.metrics.WritesTotal.WithLabelValues(org, BackendTypePrometheus.String()).Inc()
+		h.metrics.TransitionsTotal.WithLabelValues(org).Add(float64(transitions))
+
+		if err := h.writer.WritePoints(ctx, points, rule.OrgID); err != nil {
+			logger.Error("Failed to save alert state history batch", "error", err)
+			h.metrics.WritesFailed.WithLabelValues(org, BackendTypePrometheus.String()).Inc()
+			h.metrics.TransitionsFailed.WithLabelValues(org).Add(float64(transitions))
+			errCh <- fmt.Errorf("failed to save alert state history batch: %w", err)
+		}
+	}(writeCtx)
+	return errCh
+}
+
+// Query reads the series of the alert instances and reconstructs their state transitions into a dataframe
+// in the same format as the one returned by the Loki backend.
+func (h *PrometheusBackend) Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error) {
+	uids, err := getFolderUIDsForFilter(ctx, query, h.ac, h.ruleStore)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := BuildPromSelector(query)
+	if err != nil {
+		return nil, err
+	}
+
+	now := h.clock.Now().UTC()
+	if query.To.IsZero() {
+		query.To = now
+	}
+	if query.From.IsZero() {
+		query.From = now.Add(-defaultQueryRange)
+	}
+	if query.From.After(query.To) {
+		return nil, fmt.Errorf("start time cannot be after end time")
+	}
+
+	lookback := query.To.Sub(query.From) + promQueryLookback
+	promQL := fmt.Sprintf("%s[%ds]", selector, int64(math.Ceil(lookback.Seconds())))
+	matrix, err := h.client.Query(ctx, promQL, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	streams, err := h.seriesToStreams(matrix, query.From, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return merge(streams, uids)
+}
+
+// StatesToPoints converts the state transitions of a rule to the samples of the alert series. Every instance that is not Normal
+// gets a sample of both series. When an instance changes its state, the series of the previous state are marked as stale, and
+// an instance that becomes Normal gets a single "inactive" sample so that the transition can be read back.
+// It returns the samples and the number of transitions that were recorded.
+func StatesToPoints(rule history_model.RuleMeta, states []state.StateTransition, externalLabels map[string]string) ([]writer.Point, int) {
+	systemLabels := map[string]string{
+		PromOrgIDLabel:     fmt.Sprint(rule.OrgID),
+		PromRuleUIDLabel:   rule.UID,
+		PromGroupLabel:     rule.Group,
+		PromFolderUIDLabel: rule.NamespaceUID,
+	}
+	if rule.DashboardUID != "" {
+		systemLabels[PromDashboardUIDLabel] = rule.DashboardUID
+		systemLabels[PromPanelIDLabel] = fmt.Sprint(rule.PanelID)
+	}
+
+	staleNaN := math.Float64frombits(value.StaleNaN)
+	points := make([]writer.Point, 0, len(states))
+	transitions := 0
+	for _, s := range states {
+		// User-defined external labels take the lowest precedence, and system-defined labels the highest.
+		lbls := mergeLabels(make(map[string]string), externalLabels)
+		for k, v := range removePrivateLabels(s.Labels) {
+			if prometheus.LabelName(k).IsValid() {
+				lbls[k] = v
+			}
+		}
+		lbls = mergeLabels(lbls, systemLabels)
+
+		t := s.State.LastEvaluationTime
+		changed := shouldRecord(s)
+		if changed {
+			transitions++
+			if s.PreviousState != eval.Normal {
+				points = append(points, alertsPoint(lbls, s.PreviousState, s.PreviousFormatted(), t, staleNaN))
+				if s.State.State == eval.Normal {
+					points = append(points, writer.Point{Name: AlertsForStateMetricName, Labels: lbls, Metric: writer.Metric{T: t, V: staleNaN}})
+				}
+			}
+		}
+
+		if s.State.State == eval.Normal {
+			if changed {
+				points = append(points,
+					alertsPoint(lbls, s.State.State, s.Formatted(), t, 0),
+					alertsPoint(lbls, s.State.State, s.Formatted(), t.Add(time.Millisecond), staleNaN),
+				)
+			}
+			continue
+		}
+
+		points = append(points,
+			alertsPoint(lbls, s.State.State, s.Formatted(), t, 1),
+			writer.Point{Name: AlertsForStateMetricName, Labels: lbls, Metric: writer.Metric{T: t, V: float64(s.StartsAt.Unix())}},
+		)
+	}
+	return points, transitions
+}
+
+func alertsPoint(lbls map[string]string, s eval.State, formatted string, t time.Time, v float64) writer.Point {
+	result := make(map[string]string, len(lbls)+2)
+	for name, val := range lbls {
+		result[name] = val
+	}
+	result[PromAlertStateLabel] = promAlertState(s)
+	result[PromStateLabel] = formatted
+	return writer.Point{Name: AlertsMetricName, Labels: result, Metric: writer.Metric{T: t, V: v}}
+}
+
+// promAlertState maps the state to the values of the alertstate label that Prometheus uses.
+func promAlertState(s eval.State) string {
+	switch s {
+	case eval.Normal:
+		return promAlertStateInactive
+	case eval.Pending:
+		return promAlertStatePending
+	default:
+		return promAlertStateFiring
+	}
+}
+
+// BuildPromSelector converts models.HistoryQuery to a selector of the alert series. Folders are not part of the selector,
+// the results are filtered by folder after the query.
+func BuildPromSelector(query models.HistoryQuery) (string, error) {
+	matchers := []string{fmt.Sprintf("%s=%q", PromOrgIDLabel, fmt.Sprint(query.OrgID))}
+	if query.RuleUID != "" {
+		matchers = append(matchers, fmt.Sprintf("%s=%q", PromRuleUIDLabel, query.RuleUID))
+	}
+	if query.DashboardUID != "" {
+		matchers = append(matchers, fmt.Sprintf("%s=%q", PromDashboardUIDLabel, query.DashboardUID))
+	}
+	if query.PanelID != 0 {
+		matchers = append(matchers, fmt.Sprintf("%s=%q", PromPanelIDLabel, fmt.Sprint(query.PanelID)))
+	}
+
+	labelKeys := make([]string, 0, len(query.Labels))
+	for k := range query.Labels {
+		if !prometheus.LabelName(k).IsValid() {
+			return "", fmt.Errorf("invalid label name %q", k)
+		}
+		labelKeys = append(labelKeys, k)
+	}
+	// Ensure that all queries we build are deterministic.
+	sort.Strings(labelKeys)
+	for _, k := range labelKeys {
+		matchers = append(matchers, fmt.Sprintf("%s=%q", k, query.Labels[k]))
+	}
+	for _, m := range query.Matchers {
+		if !prometheus.LabelName(m.Name).IsValid() {
+			return "", fmt.Errorf("invalid label name %q", m.Name)
+		}
+		matchers = append(matchers, fmt.Sprintf("%s%s%q", m.Name, m.Type, m.Value))
+	}
+	return AlertsMetricName + "{" + strings.Join(matchers, ",") + "}", nil
+}
+
+// promSample is a sample of the alert series of an instance.
+type promSample struct {
+	t     time.Time
+	state string
+}
+
+// promInstance is the history of an alert instance read from the alert series.
+type promInstance struct {
+	stream   map[string]string
+	rule     string
+	dashUID  string
+	panelID  int64
+	instance map[string]string
+	samples  []promSample
+}
+
+// seriesToStreams reconstructs the state transitions of every alert instance from the samples of its series,
+// and returns them as streams of LokiEntry lines. Only the transitions that happened at or after from are returned.
+// If limit is positive, only the latest transitions are kept.
+func (h *PrometheusBackend) seriesToStreams(matrix prometheus.Matrix, from time.Time, limit int) ([]Stream, error) {
+	instances := make(map[string]*promInstance)
+	keys := make([]string, 0)
+	for _, series := range matrix {
+		instanceLabels := make(map[string]string, len(series.Metric))
+		for k, v := range series.Metric {
+			instanceLabels[string(k)] = string(v)
+		}
+		for _, k := range promSystemLabels {
+			delete(instanceLabels, k)
+		}
+		for k, v := range h.externalLabels {
+			if instanceLabels[k] == v {
+				delete(instanceLabels, k)
+			}
+		}
+
+		// The series of all the states of an instance have the same labels, except for the state labels.
+		key := labelFingerprint(seriesWithoutState(series.Metric))
+		inst, ok := instances[key]
+		if !ok {
+			var panelID int64
+			if p, ok := series.Metric[PromPanelIDLabel]; ok {
+				id, err := strconv.ParseInt(string(p), 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid panel ID %q: %w", p, err)
+				}
+				panelID = id
+			}
+			inst = &promInstance{
+				stream: mergeLabels(mergeLabels(make(map[string]string), h.externalLabels), map[string]string{
+					StateHistoryLabelKey: StateHistoryLabelValue,
+					OrgIDLabel:           string(series.Metric[PromOrgIDLabel]),
+					GroupLabel:           string(series.Metric[PromGroupLabel]),
+					FolderUIDLabel:       string(series.Metric[PromFolderUIDLabel]),
+				}),
+				rule:     string(series.Metric[PromRuleUIDLabel]),
+				dashUID:  string(series.Metric[PromDashboardUIDLabel]),
+				panelID:  panelID,
+				instance: instanceLabels,
+			}
+			instances[key] = inst
+			keys = append(keys, key)
+		}
+		for _, v := range series.Values {
+			if value.IsStaleNaN(float64(v.Value)) {
+				continue
+			}
+			inst.samples = append(inst.samples, promSample{t: v.Timestamp.Time(), state: string(series.Metric[PromStateLabel])})
+		}
+	}
+	sort.Strings(keys)
+
+	type transition struct {
+		t     time.Time
+		entry LokiEntry
+	}
+	transitions := make(map[string][]transition, len(instances))
+	var times []time.Time
+	for _, key := range keys {
+		inst := instances[key]
+		sort.SliceStable(inst.samples, func(i, j int) bool {
+			return inst.samples[i].t.Before(inst.samples[j].t)
+		})
+		previous := eval.Normal.String()
+		for _, s := range inst.samples {
+			if s.state == previous {
+				continue
+			}
+			if !s.t.Before(from) {
+				transitions[key] = append(transitions[key], transition{
+					t: s.t,
+					entry: LokiEntry{
+						SchemaVersion:  1,
+						Previous:       previous,
+						Current:        s.state,
+						Values:         simplejson.New(),
+						DashboardUID:   inst.dashUID,
+						PanelID:        inst.panelID,
+						Fingerprint:    labelFingerprint(inst.instance),
+						RuleTitle:      inst.instance[prometheus.AlertNameLabel],
+						RuleUID:        inst.rule,
+						InstanceLabels: inst.instance,
+					},
+				})
+				times = append(times, s.t)
+			}
+			previous = s.state
+		}
+	}
+
+	// Keep the latest transitions. Transitions that happened at the same time as the oldest one that is kept are kept too.
+	var cutoff time.Time
+	if limit > 0 && len(times) > limit {
+		sort.Slice(times, func(i, j int) bool {
+			return times[i].After(times[j])
+		})
+		cutoff = times[limit-1]
+	}
+
+	streams := make([]Stream, 0, len(transitions))
+	for _, key := range keys {
+		samples := make([]Sample, 0, len(transitions[key]))
+		for _, tr := range transitions[key] {
+			if tr.t.Before(cutoff) {
+				continue
+			}
+			line, err := json.Marshal(tr.entry)
+			if err != nil {
+				return nil, fmt.Errorf("failed to serialize state history entry: %w", err)
+			}
+			samples = append(samples, Sample{T: tr.t, V: string(line)})
+		}
+		if len(samples) == 0 {
+			continue
+		}
+		streams = append(streams, Stream{Stream: instances[key].stream, Values: samples})
+	}
+	return streams, nil
+}
+
+func seriesWithoutState(metric prometheus.Metric) data.Labels {
+	result := make(data.Labels, len(metric))
+	for k, v := range metric {
+		result[string(k)] = string(v)
+	}
+	delete(result, prometheus.MetricNameLabel)
+	delete(result, PromAlertStateLabel)
+	delete(result, PromStateLabel)
+	return result
+}
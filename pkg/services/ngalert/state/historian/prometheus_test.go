@@ -0,0 +1,334 @@
+package historian
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/folder"
+	acfakes "github.com/grafana/grafana/pkg/services/ngalert/accesscontrol/fakes"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+	"github.com/grafana/grafana/pkg/services/ngalert/writer"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestPrometheusConfig(t *testing.T) {
+	t.Run("should require both URLs", func(t *testing.T) {
+		_, err := NewPrometheusConfig(setting.UnifiedAlertingStateHistorySettings{PrometheusWriteURL: "http://write.url.com"})
+		require.ErrorContains(t, err, "read path URL")
+		_, err = NewPrometheusConfig(setting.UnifiedAlertingStateHistorySettings{PrometheusReadURL: "http://read.url.com"})
+		require.ErrorContains(t, err, "remote write URL")
+	})
+
+	t.Run("should pass the tenant and credentials to the writer", func(t *testing.T) {
+		cfg, err := NewPrometheusConfig(setting.UnifiedAlertingStateHistorySettings{
+			PrometheusReadURL:           "http://read.url.com/prometheus",
+			PrometheusWriteURL:          "http://write.url.com/api/v1/push",
+			PrometheusTenantID:          "tenant",
+			PrometheusBasicAuthUsername: "user",
+			PrometheusBasicAuthPassword: "pass",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "http://read.url.com/prometheus", cfg.ReadPathURL.String())
+		require.Equal(t, setting.RecordingRuleSettings{
+			Enabled:           true,
+			URL:               "http://write.url.com/api/v1/push",
+			BasicAuthUsername: "user",
+			BasicAuthPassword: "pass",
+			CustomHeaders:     map[string]string{"X-Scope-OrgID": "tenant"},
+			Timeout:           StateHistoryWriteTimeout,
+		}, cfg.WriterSettings())
+	})
+}
+
+func TestStatesToPoints(t *testing.T) {
+	rule := createTestRule()
+	now := time.Unix(1000, 0)
+	startsAt := time.Unix(400, 0)
+	transition := func(previous eval.State, current eval.State) state.StateTransition {
+		return state.StateTransition{
+			PreviousState: previous,
+			State: &state.State{
+				State:              current,
+				Labels:             data.Labels{"instance": "a", "__private__": "b", PromRuleUIDLabel: "override", "cluster": "dev"},
+				StartsAt:           startsAt,
+				LastEvaluationTime: now,
+			},
+		}
+	}
+	lbls := func(extra ...string) map[string]string {
+		result := map[string]string{
+			"instance":            "a",
+			"cluster":             "dev",
+			"region":              "eu",
+			PromOrgIDLabel:        "1",
+			PromRuleUIDLabel:      "rule-uid",
+			PromGroupLabel:        "my-group",
+			PromFolderUIDLabel:    "my-folder",
+			PromDashboardUIDLabel: "dash-uid",
+			PromPanelIDLabel:      "123",
+		}
+		for i := 0; i < len(extra); i += 2 {
+			result[extra[i]] = extra[i+1]
+		}
+		return result
+	}
+	external := map[string]string{"cluster": "prod", "region": "eu"}
+
+	t.Run("active instances have a sample of both series on every evaluation", func(t *testing.T) {
+		points, transitions := StatesToPoints(rule, []state.StateTransition{
+			transition(eval.Normal, eval.Pending),
+			transition(eval.Alerting, eval.Alerting),
+		}, external)
+
+		require.Equal(t, 1, transitions)
+		require.Equal(t, []writer.Point{
+			{Name: AlertsMetricName, Labels: lbls(PromAlertStateLabel, "pending", PromStateLabel, "Pending"), Metric: writer.Metric{T: now, V: 1}},
+			{Name: AlertsForStateMetricName, Labels: lbls(), Metric: writer.Metric{T: now, V: 400}},
+			{Name: AlertsMetricName, Labels: lbls(PromAlertStateLabel, "firing", PromStateLabel, "Alerting"), Metric: writer.Metric{T: now, V: 1}},
+			{Name: AlertsForStateMetricName, Labels: lbls(), Metric: writer.Metric{T: now, V: 400}},
+		}, points)
+	})
+
+	t.Run("series of the previous state are marked stale", func(t *testing.T) {
+		points, transitions := StatesToPoints(rule, []state.StateTransition{
+			transition(eval.Pending, eval.Alerting),
+		}, external)
+
+		require.Equal(t, 1, transitions)
+		require.Len(t, points, 3)
+		require.Equal(t, lbls(PromAlertStateLabel, "pending", PromStateLabel, "Pending"), points[0].Labels)
+		require.True(t, value.IsStaleNaN(points[0].Metric.V))
+		require.Equal(t, lbls(PromAlertStateLabel, "firing", PromStateLabel, "Alerting"), points[1].Labels)
+		require.Equal(t, AlertsForStateMetricName, points[2].Name)
+	})
+
+	t.Run("instances that become normal get a single inactive sample", func(t *testing.T) {
+		points, transitions := StatesToPoints(rule, []state.StateTransition{
+			transition(eval.Alerting, eval.Normal),
+			transition(eval.Normal, eval.Normal),
+		}, external)
+
+		require.Equal(t, 1, transitions)
+		require.Len(t, points, 4)
+		require.Equal(t, lbls(PromAlertStateLabel, "firing", PromStateLabel, "Alerting"), points[0].Labels)
+		require.True(t, value.IsStaleNaN(points[0].Metric.V))
+		require.Equal(t, AlertsForStateMetricName, points[1].Name)
+		require.True(t, value.IsStaleNaN(points[1].Metric.V))
+		require.Equal(t, writer.Point{Name: AlertsMetricName, Labels: lbls(PromAlertStateLabel, "inactive", PromStateLabel, "Normal"), Metric: writer.Metric{T: now, V: 0}}, points[2])
+		require.Equal(t, now.Add(time.Millisecond), points[3].Metric.T)
+		require.True(t, value.IsStaleNaN(points[3].Metric.V))
+	})
+}
+
+func TestBuildPromSelector(t *testing.T) {
+	selector, err := BuildPromSelector(models.HistoryQuery{
+		OrgID:        1,
+		RuleUID:      "rule-uid",
+		DashboardUID: "dash-uid",
+		PanelID:      2,
+		Labels:       map[string]string{"b": "2", "a": `"quoted"`},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `GRAFANA_ALERTS{grafana_org_id="1",grafana_rule_uid="rule-uid",grafana_dashboard_uid="dash-uid",grafana_panel_id="2",a="\"quoted\"",b="2"}`, selector)
+
+	_, err = BuildPromSelector(models.HistoryQuery{OrgID: 1, Labels: map[string]string{"not a label": "1"}})
+	require.Error(t, err)
+
+	selector, err = BuildPromSelector(models.HistoryQuery{
+		OrgID:    1,
+		Matchers: labels.Matchers{labels.MustNewMatcher(labels.MatchNotRegexp, "team", `a|\d`)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `GRAFANA_ALERTS{grafana_org_id="1",team!~"a|\\d"}`, selector)
+}
+
+func TestPrometheusBackendQuery(t *testing.T) {
+	series := func(state, formatted string, folder string, values ...model.SamplePair) *model.SampleStream {
+		return &model.SampleStream{
+			Metric: model.Metric{
+				model.MetricNameLabel: AlertsMetricName,
+				PromAlertStateLabel:   model.LabelValue(state),
+				PromStateLabel:        model.LabelValue(formatted),
+				PromOrgIDLabel:        "1",
+				PromRuleUIDLabel:      "rule-uid",
+				PromGroupLabel:        "my-group",
+				PromFolderUIDLabel:    model.LabelValue(folder),
+				model.AlertNameLabel:  "my-title",
+				"instance":            "a",
+				"cluster":             "prod",
+			},
+			Values: values,
+		}
+	}
+	at := func(sec int64, v float64) model.SamplePair {
+		return model.SamplePair{Timestamp: model.TimeFromUnix(sec), Value: model.SampleValue(v)}
+	}
+	matrix := model.Matrix{
+		series("pending", "Pending", "my-folder", at(100, 1), at(160, 1)),
+		series("firing", "Alerting", "my-folder", at(220, 1), at(280, 1)),
+		series("inactive", "Normal", "my-folder", at(340, 0)),
+		series("firing", "Alerting", "other-folder", at(400, 1)),
+	}
+
+	createBackend := func(ac AccessControl) (*PrometheusBackend, *fakePrometheusClient) {
+		cl := &fakePrometheusClient{result: matrix}
+		u, _ := url.Parse("http://some.url")
+		backend := NewPrometheusBackend(log.NewNopLogger(), PrometheusConfig{ReadPathURL: u, WritePathURL: u, ExternalLabels: map[string]string{"cluster": "prod"}},
+			&fakePrometheusWriter{}, NewFakeRequester(), metrics.NewHistorianMetrics(prometheus.NewRegistry(), metrics.Subsystem), tracing.InitializeTracerForTest(), fakes.NewRuleStore(t), ac)
+		backend.client = cl
+		return backend, cl
+	}
+	canReadAll := &acfakes.FakeRuleService{
+		CanReadAllRulesFunc: func(ctx context.Context, user identity.Requester) (bool, error) {
+			return true, nil
+		},
+	}
+
+	t.Run("should reconstruct transitions from the series", func(t *testing.T) {
+		backend, cl := createBackend(canReadAll)
+		frame, err := backend.Query(context.Background(), models.HistoryQuery{
+			OrgID:   1,
+			RuleUID: "rule-uid",
+			From:    time.Unix(150, 0),
+			To:      time.Unix(400, 0),
+		})
+		require.NoError(t, err)
+		require.Equal(t, `GRAFANA_ALERTS{grafana_org_id="1",grafana_rule_uid="rule-uid"}[3850s]`, cl.lastQuery)
+		require.Equal(t, time.Unix(400, 0), cl.lastTime)
+
+		// the transition to Pending happened before the start of the range
+		require.Equal(t, 3, frame.Rows())
+		var entries []LokiEntry
+		for i := 0; i < frame.Rows(); i++ {
+			var entry LokiEntry
+			require.NoError(t, json.Unmarshal(frame.Fields[1].At(i).(json.RawMessage), &entry))
+			entries = append(entries, entry)
+		}
+		require.Equal(t, time.Unix(220, 0), frame.Fields[0].At(0))
+		require.Equal(t, "Pending", entries[0].Previous)
+		require.Equal(t, "Alerting", entries[0].Current)
+		require.Equal(t, "Normal", entries[1].Current)
+		require.Equal(t, "Normal", entries[2].Previous)
+		require.Equal(t, "Alerting", entries[2].Current)
+
+		require.Equal(t, "my-title", entries[0].RuleTitle)
+		require.Equal(t, "rule-uid", entries[0].RuleUID)
+		require.Equal(t, map[string]string{model.AlertNameLabel: "my-title", "instance": "a"}, entries[0].InstanceLabels)
+
+		var stream map[string]string
+		require.NoError(t, json.Unmarshal(frame.Fields[2].At(0).(json.RawMessage), &stream))
+		require.Equal(t, "my-folder", stream[FolderUIDLabel])
+		require.Equal(t, "prod", stream["cluster"])
+	})
+
+	t.Run("should keep only the latest transitions", func(t *testing.T) {
+		backend, _ := createBackend(canReadAll)
+		frame, err := backend.Query(context.Background(), models.HistoryQuery{
+			OrgID: 1,
+			From:  time.Unix(0, 0),
+			To:    time.Unix(400, 0),
+			Limit: 2,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, frame.Rows())
+		require.Equal(t, time.Unix(340, 0), frame.Fields[0].At(0))
+		require.Equal(t, time.Unix(400, 0), frame.Fields[0].At(1))
+	})
+
+	t.Run("should filter by folders the user can read", func(t *testing.T) {
+		ac := &acfakes.FakeRuleService{
+			HasAccessInFolderFunc: func(ctx context.Context, user identity.Requester, namespaced models.Namespaced) (bool, error) {
+				return namespaced.GetNamespaceUID() == "my-folder", nil
+			},
+		}
+		backend, _ := createBackend(ac)
+		rules := backend.ruleStore.(*fakes.RuleStore)
+		rules.Folders = map[int64][]*folder.Folder{
+			1: {{UID: "my-folder", OrgID: 1}, {UID: "other-folder", OrgID: 1}},
+		}
+		rules.Rules = map[int64][]*models.AlertRule{
+			1: {},
+		}
+		frame, err := backend.Query(context.Background(), models.HistoryQuery{
+			OrgID: 1,
+			From:  time.Unix(0, 0),
+			To:    time.Unix(400, 0),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, frame.Rows())
+	})
+}
+
+func TestPrometheusBackendRecord(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	met := metrics.NewHistorianMetrics(reg, metrics.Subsystem)
+	w := &fakePrometheusWriter{}
+	u, _ := url.Parse("http://some.url")
+	backend := NewPrometheusBackend(log.NewNopLogger(), PrometheusConfig{ReadPathURL: u, WritePathURL: u}, w, NewFakeRequester(), met, tracing.InitializeTracerForTest(), fakes.NewRuleStore(t), &acfakes.FakeRuleService{})
+
+	err := <-backend.Record(context.Background(), createTestRule(), singleFromNormal(&state.State{
+		State:  eval.Alerting,
+		Labels: data.Labels{"a": "b"},
+	}))
+	require.NoError(t, err)
+	require.Len(t, w.points, 2)
+	require.Equal(t, int64(1), w.orgID)
+
+	err = <-backend.Record(context.Background(), createTestRule(), singleFromNormal(&state.State{
+		State: eval.Normal,
+	}))
+	require.NoError(t, err)
+	require.Len(t, w.points, 2, "nothing should be written for normal instances that did not change")
+
+	require.NoError(t, testutil.GatherAndCompare(reg, bytes.NewBufferString(`
+# HELP grafana_alerting_state_history_transitions_total The total number of state transitions processed.
+# TYPE grafana_alerting_state_history_transitions_total counter
+grafana_alerting_state_history_transitions_total{org="1"} 1
+`), "grafana_alerting_state_history_transitions_total"))
+}
+
+type fakePrometheusClient struct {
+	result    model.Matrix
+	lastQuery string
+	lastTime  time.Time
+}
+
+func (c *fakePrometheusClient) Ping(context.Context) error {
+	return nil
+}
+
+func (c *fakePrometheusClient) Query(_ context.Context, promQL string, t time.Time) (model.Matrix, error) {
+	c.lastQuery = promQL
+	c.lastTime = t
+	return c.result, nil
+}
+
+type fakePrometheusWriter struct {
+	points []writer.Point
+	orgID  int64
+}
+
+func (w *fakePrometheusWriter) WritePoints(_ context.Context, points []writer.Point, orgID int64) error {
+	w.points = append(w.points, points...)
+	w.orgID = orgID
+	return nil
+}
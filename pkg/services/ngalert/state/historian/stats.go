@@ -0,0 +1,268 @@
+package historian
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+// Name of the columns of the dataframe returned by the annotation backend.
+const (
+	dfAnnotationText = "text"
+	dfAnnotationPrev = "prev"
+	dfAnnotationNext = "next"
+)
+
+// transition is a state transition of an alert instance read back from a state history dataframe.
+type transition struct {
+	time     time.Time
+	ruleUID  string
+	labels   data.Labels
+	previous eval.State
+	current  eval.State
+}
+
+// AggregateStateHistory computes statistics of the state transitions in a dataframe returned by the Query method of any of the backends.
+// The statistics are computed per alert instance, and summed up per group of instances that have the same values of the labels in query.GroupBy.
+func AggregateStateHistory(frame *data.Frame, query models.HistoryStatsQuery) ([]models.HistoryStatsGroup, error) {
+	transitions, err := transitionsFromFrame(frame)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].time.Before(transitions[j].time)
+	})
+
+	to := query.To
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	from := query.From
+	if from.IsZero() {
+		from = to.Add(-defaultQueryRange)
+	}
+
+	seriesKeys := make([]string, 0)
+	series := make(map[string][]transition)
+	for _, t := range transitions {
+		key := t.ruleUID + labelFingerprint(t.labels)
+		if _, ok := series[key]; !ok {
+			seriesKeys = append(seriesKeys, key)
+		}
+		series[key] = append(series[key], t)
+	}
+
+	groupKeys := make([]string, 0)
+	groups := make(map[string]*models.HistoryStatsGroup)
+	for _, key := range seriesKeys {
+		ts := series[key]
+		s := models.HistorySeriesStats{
+			RuleUID:      ts[0].ruleUID,
+			Labels:       ts[0].labels,
+			HistoryStats: seriesStats(ts, from, to, query.Buckets),
+		}
+
+		groupLabels := make(data.Labels, len(query.GroupBy))
+		for _, name := range query.GroupBy {
+			groupLabels[name] = s.Labels[name]
+		}
+		groupKey := groupLabels.String()
+		g, ok := groups[groupKey]
+		if !ok {
+			g = &models.HistoryStatsGroup{
+				Labels:       groupLabels,
+				HistoryStats: newHistoryStats(query.Buckets),
+			}
+			groups[groupKey] = g
+			groupKeys = append(groupKeys, groupKey)
+		}
+		addHistoryStats(&g.HistoryStats, s.HistoryStats)
+		g.Series = append(g.Series, s)
+	}
+
+	sort.Strings(groupKeys)
+	result := make([]models.HistoryStatsGroup, 0, len(groupKeys))
+	for _, key := range groupKeys {
+		g := groups[key]
+		sort.Slice(g.Series, func(i, j int) bool {
+			if g.Series[i].RuleUID != g.Series[j].RuleUID {
+				return g.Series[i].RuleUID < g.Series[j].RuleUID
+			}
+			return data.Labels(g.Series[i].Labels).String() < data.Labels(g.Series[j].Labels).String()
+		})
+		result = append(result, *g)
+	}
+	return result, nil
+}
+
+// seriesStats computes the statistics of the transitions of a single alert instance, sorted by time.
+// The state of the instance before the first transition is its previous state. Firing periods that started before the time range
+// are accounted in the time in state, but not in the number of resolved periods because their duration is unknown.
+func seriesStats(ts []transition, from, to time.Time, buckets []time.Duration) models.HistoryStats {
+	stats := newHistoryStats(buckets)
+	since := from
+	var firingSince time.Time
+	for _, t := range ts {
+		at := clampTime(t.time, from, to)
+		stats.TimeInState[t.previous.String()] += at.Sub(since)
+		since = at
+
+		stats.Transitions++
+		stats.TransitionsByState[t.current.String()]++
+
+		switch {
+		case t.current == eval.Alerting && t.previous != eval.Alerting:
+			firingSince = t.time
+		case t.previous == eval.Alerting && t.current != eval.Alerting:
+			if !firingSince.IsZero() {
+				addFiringPeriod(&stats, t.time.Sub(firingSince), buckets)
+			}
+			firingSince = time.Time{}
+		}
+	}
+	stats.TimeInState[ts[len(ts)-1].current.String()] += to.Sub(since)
+	return stats
+}
+
+func newHistoryStats(buckets []time.Duration) models.HistoryStats {
+	return models.HistoryStats{
+		TransitionsByState: make(map[string]int),
+		TimeInState:        make(map[string]time.Duration),
+		FiringHistogram:    make([]int, len(buckets)+1),
+	}
+}
+
+func addFiringPeriod(stats *models.HistoryStats, d time.Duration, buckets []time.Duration) {
+	stats.Resolved++
+	stats.FiringDuration += d
+	idx := sort.Search(len(buckets), func(i int) bool {
+		return d <= buckets[i]
+	})
+	stats.FiringHistogram[idx]++
+}
+
+func addHistoryStats(dst *models.HistoryStats, src models.HistoryStats) {
+	dst.Transitions += src.Transitions
+	for k, v := range src.TransitionsByState {
+		dst.TransitionsByState[k] += v
+	}
+	for k, v := range src.TimeInState {
+		dst.TimeInState[k] += v
+	}
+	dst.Resolved += src.Resolved
+	dst.FiringDuration += src.FiringDuration
+	for i, v := range src.FiringHistogram {
+		dst.FiringHistogram[i] += v
+	}
+}
+
+func clampTime(t, from, to time.Time) time.Time {
+	if t.Before(from) {
+		return from
+	}
+	if t.After(to) {
+		return to
+	}
+	return t
+}
+
+// transitionsFromFrame reads the state transitions from a dataframe in the format of either the Loki or the annotation backend.
+// Rows whose states cannot be parsed are skipped.
+func transitionsFromFrame(frame *data.Frame) ([]transition, error) {
+	if frame == nil || len(frame.Fields) == 0 {
+		return nil, nil
+	}
+	timeField := frameField(frame, dfTime)
+	if timeField == nil {
+		return nil, fmt.Errorf("state history dataframe has no %q field", dfTime)
+	}
+	if lines := frameField(frame, dfLine); lines != nil {
+		return transitionsFromLines(timeField, lines)
+	}
+	texts, prev, next := frameField(frame, dfAnnotationText), frameField(frame, dfAnnotationPrev), frameField(frame, dfAnnotationNext)
+	if texts != nil && prev != nil && next != nil {
+		return transitionsFromAnnotations(timeField, texts, prev, next)
+	}
+	return nil, fmt.Errorf("unsupported format of state history dataframe")
+}
+
+func transitionsFromLines(times, lines *data.Field) ([]transition, error) {
+	result := make([]transition, 0, times.Len())
+	for i := 0; i < times.Len(); i++ {
+		t, ok := times.At(i).(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type %T of field %q", times.At(i), dfTime)
+		}
+		line, ok := lines.At(i).(json.RawMessage)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type %T of field %q", lines.At(i), dfLine)
+		}
+		var entry LokiEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal entry: %w", err)
+		}
+		previous, _, err := state.ParseFormattedState(entry.Previous)
+		if err != nil {
+			continue
+		}
+		current, _, err := state.ParseFormattedState(entry.Current)
+		if err != nil {
+			continue
+		}
+		result = append(result, transition{
+			time:     t,
+			ruleUID:  entry.RuleUID,
+			labels:   entry.InstanceLabels,
+			previous: previous,
+			current:  current,
+		})
+	}
+	return result, nil
+}
+
+func transitionsFromAnnotations(times, texts, prev, next *data.Field) ([]transition, error) {
+	// The annotation backend only returns the history of a single rule.
+	ruleUID := times.Labels["ruleUID"]
+	result := make([]transition, 0, times.Len())
+	for i := 0; i < times.Len(); i++ {
+		t, ok := times.At(i).(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type %T of field %q", times.At(i), dfTime)
+		}
+		text, _ := texts.At(i).(string)
+		prevState, _ := prev.At(i).(string)
+		nextState, _ := next.At(i).(string)
+		previous, _, err := state.ParseFormattedState(prevState)
+		if err != nil {
+			continue
+		}
+		current, _, err := state.ParseFormattedState(nextState)
+		if err != nil {
+			continue
+		}
+		result = append(result, transition{
+			time:     t,
+			ruleUID:  ruleUID,
+			labels:   parseAnnotationLabels(text),
+			previous: previous,
+			current:  current,
+		})
+	}
+	return result, nil
+}
+
+func frameField(frame *data.Frame, name string) *data.Field {
+	for _, f := range frame.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
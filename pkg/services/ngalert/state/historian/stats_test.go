@@ -0,0 +1,138 @@
+package historian
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestAggregateStateHistory(t *testing.T) {
+	from := time.Unix(0, 0).UTC()
+	to := from.Add(10 * time.Hour)
+	at := func(d time.Duration) time.Time {
+		return from.Add(d)
+	}
+	sample := func(t *testing.T, ts time.Time, ruleUID string, lbls map[string]string, previous, current string) Sample {
+		t.Helper()
+		line, err := json.Marshal(LokiEntry{
+			SchemaVersion:  1,
+			Previous:       previous,
+			Current:        current,
+			RuleUID:        ruleUID,
+			InstanceLabels: lbls,
+		})
+		require.NoError(t, err)
+		return Sample{T: ts, V: string(line)}
+	}
+	dbA := map[string]string{"team": "db", "instance": "a"}
+	dbB := map[string]string{"team": "db", "instance": "b"}
+	web := map[string]string{"team": "web", "instance": "a"}
+
+	frame, err := merge([]Stream{{
+		Stream: map[string]string{"orgID": "1"},
+		Values: []Sample{
+			// Fires twice for 30 minutes and 2 hours.
+			sample(t, at(time.Hour), "rule-1", dbA, "Normal", "Pending"),
+			sample(t, at(2*time.Hour), "rule-1", dbA, "Pending", "Alerting"),
+			sample(t, at(150*time.Minute), "rule-1", dbA, "Alerting", "Normal"),
+			sample(t, at(5*time.Hour), "rule-1", dbA, "Normal", "Alerting"),
+			sample(t, at(7*time.Hour), "rule-1", dbA, "Alerting", "Normal (MissingSeries)"),
+			// Fires before the time range, and resolves after 1 hour.
+			sample(t, at(time.Hour), "rule-1", dbB, "Alerting", "Normal"),
+			// Fires and is not resolved.
+			sample(t, at(9*time.Hour), "rule-2", web, "Normal", "Alerting"),
+		},
+	}}, nil)
+	require.NoError(t, err)
+
+	t.Run("should aggregate all series when not grouped", func(t *testing.T) {
+		groups, err := AggregateStateHistory(frame, models.HistoryStatsQuery{
+			HistoryQuery: models.HistoryQuery{From: from, To: to},
+			Buckets:      []time.Duration{time.Hour},
+		})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		g := groups[0]
+		require.Empty(t, g.Labels)
+		require.Len(t, g.Series, 3)
+		require.Equal(t, 7, g.Transitions)
+		require.Equal(t, map[string]int{"Pending": 1, "Alerting": 3, "Normal": 3}, g.TransitionsByState)
+		require.Equal(t, 2, g.Resolved)
+		require.Equal(t, 75*time.Minute, g.MTTR())
+		require.Equal(t, []int{1, 1}, g.FiringHistogram)
+		require.Equal(t, 30*time.Hour, g.TimeInState["Normal"]+g.TimeInState["Pending"]+g.TimeInState["Alerting"])
+	})
+
+	t.Run("should compute statistics per series", func(t *testing.T) {
+		groups, err := AggregateStateHistory(frame, models.HistoryStatsQuery{
+			HistoryQuery: models.HistoryQuery{From: from, To: to},
+			GroupBy:      []string{"team"},
+			Buckets:      []time.Duration{time.Hour},
+		})
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+		require.Equal(t, map[string]string{"team": "db"}, groups[0].Labels)
+		require.Equal(t, map[string]string{"team": "web"}, groups[1].Labels)
+
+		require.Len(t, groups[0].Series, 2)
+		a := groups[0].Series[0]
+		require.Equal(t, "rule-1", a.RuleUID)
+		require.Equal(t, dbA, a.Labels)
+		require.Equal(t, 5, a.Transitions)
+		require.Equal(t, map[string]time.Duration{
+			"Normal":   6*time.Hour + 30*time.Minute,
+			"Pending":  time.Hour,
+			"Alerting": 2*time.Hour + 30*time.Minute,
+		}, a.TimeInState)
+		require.Equal(t, 2, a.Resolved)
+		require.Equal(t, 75*time.Minute, a.MTTR())
+
+		b := groups[0].Series[1]
+		require.Equal(t, dbB, b.Labels)
+		require.Equal(t, 0, b.Resolved)
+		require.Equal(t, time.Hour, b.TimeInState["Alerting"])
+
+		w := groups[1].Series[0]
+		require.Equal(t, "rule-2", w.RuleUID)
+		require.Equal(t, 0, w.Resolved)
+		require.Equal(t, time.Hour, w.TimeInState["Alerting"])
+		require.Equal(t, time.Duration(0), w.MTTR())
+	})
+
+	t.Run("should read annotation dataframes", func(t *testing.T) {
+		lbls := data.Labels{"from": "state-history", "ruleUID": "rule-1"}
+		frame := data.NewFrame("states",
+			data.NewField("time", lbls, []time.Time{at(time.Hour), at(3 * time.Hour)}),
+			data.NewField("text", lbls, []string{"rule {instance=a, team=db} - A=1.000000", "rule {instance=a, team=db} - A=0.000000"}),
+			data.NewField("prev", lbls, []string{"Normal", "Alerting"}),
+			data.NewField("next", lbls, []string{"Alerting", "Normal"}),
+			data.NewField("data", lbls, []string{"{}", "{}"}),
+		)
+
+		groups, err := AggregateStateHistory(frame, models.HistoryStatsQuery{
+			HistoryQuery: models.HistoryQuery{From: from, To: to},
+			GroupBy:      []string{"team"},
+		})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		require.Equal(t, map[string]string{"team": "db"}, groups[0].Labels)
+		require.Len(t, groups[0].Series, 1)
+		s := groups[0].Series[0]
+		require.Equal(t, "rule-1", s.RuleUID)
+		require.Equal(t, dbA, s.Labels)
+		require.Equal(t, 2*time.Hour, s.MTTR())
+		require.Equal(t, 8*time.Hour, s.TimeInState["Normal"])
+		require.Equal(t, []int{1}, s.FiringHistogram)
+	})
+
+	t.Run("should return no groups for empty history", func(t *testing.T) {
+		groups, err := AggregateStateHistory(data.NewFrame("states"), models.HistoryStatsQuery{})
+		require.NoError(t, err)
+		require.Empty(t, groups)
+	})
+}
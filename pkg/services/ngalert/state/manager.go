@@ -587,10 +587,32 @@ func translateInstanceState(state ngModels.InstanceStateType) eval.State {
 func (st *Manager) deleteStaleStatesFromCache(ctx context.Context, logger log.Logger, evaluatedAt time.Time, alertRule *ngModels.AlertRule) []StateTransition {
 	// If we are removing two or more stale series it makes sense to share the resolved image as the alert rule is the same.
 	// TODO: We will need to change this when we support images without screenshots as each series will have a different image
+	var keptFiring []*State
 	staleStates := st.cache.deleteRuleStates(alertRule.GetKey(), func(s *State) bool {
-		return stateIsStale(evaluatedAt, s.LastEvaluationTime, alertRule.IntervalSeconds)
+		// alerts already kept firing because of keep_firing_for are checked as soon as their series is missing, and
+		// stale alerts keep firing too until keep_firing_for has passed.
+		isKeptFiring := s.State == eval.Alerting && !s.KeptFiringSince.IsZero() && s.LastEvaluationTime.Before(evaluatedAt)
+		if !isKeptFiring && !stateIsStale(evaluatedAt, s.LastEvaluationTime, alertRule.IntervalSeconds) {
+			return false
+		}
+		if s.State == eval.Alerting && keepFiring(s, alertRule, evaluatedAt) {
+			keptFiring = append(keptFiring, s)
+			return false
+		}
+		return true
 	})
-	resolvedStates := make([]StateTransition, 0, len(staleStates))
+	transitions := make([]StateTransition, 0, len(staleStates)+len(keptFiring))
+
+	for _, s := range keptFiring {
+		logger.Debug("Keeping stale state entry firing because of keep_firing_for", "cacheID", s.CacheID, "kept_firing_since", s.KeptFiringSince)
+		s.LastEvaluationTime = evaluatedAt
+		s.Maintain(alertRule.IntervalSeconds, evaluatedAt)
+		transitions = append(transitions, StateTransition{
+			State:               s,
+			PreviousState:       s.State,
+			PreviousStateReason: s.StateReason,
+		})
+	}
 
 	for _, s := range staleStates {
 		logger.Info("Detected stale state entry", "cacheID", s.CacheID, "state", s.State, "reason", s.StateReason)
@@ -601,6 +623,7 @@ func (st *Manager) deleteStaleStatesFromCache(ctx context.Context, logger log.Lo
 		s.StateReason = ngModels.StateReasonMissingSeries
 		s.EndsAt = evaluatedAt
 		s.LastEvaluationTime = evaluatedAt
+		s.KeptFiringSince = time.Time{}
 
 		if oldState == eval.Alerting {
 			s.ResolvedAt = &evaluatedAt
@@ -620,9 +643,9 @@ func (st *Manager) deleteStaleStatesFromCache(ctx context.Context, logger log.Lo
 			PreviousState:       oldState,
 			PreviousStateReason: oldReason,
 		}
-		resolvedStates = append(resolvedStates, record)
+		transitions = append(transitions, record)
 	}
-	return resolvedStates
+	return transitions
 }
 
 func stateIsStale(evaluatedAt time.Time, lastEval time.Time, intervalSeconds int64) bool {
@@ -117,6 +117,32 @@ func (st DBstore) GetAlertRuleByUID(ctx context.Context, query *ngmodels.GetAler
 	return result, err
 }
 
+// GetAlertRuleVersions returns the stored versions of the rule, from the newest to the oldest.
+// It returns models.ErrAlertRuleNotFound if the rule has no versions.
+func (st DBstore) GetAlertRuleVersions(ctx context.Context, key ngmodels.AlertRuleKey) ([]*ngmodels.AlertRule, error) {
+	var result []*ngmodels.AlertRule
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		versions := make([]alertRuleVersion, 0)
+		err := sess.Table(alertRuleVersion{}).Where("rule_org_id = ? AND rule_uid = ?", key.OrgID, key.UID).Desc("version", "id").Find(&versions)
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			return ngmodels.ErrAlertRuleNotFound
+		}
+		result = make([]*ngmodels.AlertRule, 0, len(versions))
+		for _, v := range versions {
+			converted, err := alertRuleVersionToModelsAlertRule(v, st.Logger)
+			if err != nil {
+				return fmt.Errorf("failed to convert version %d of alert rule %q: %w", v.Version, v.RuleUID, err)
+			}
+			result = append(result, &converted)
+		}
+		return nil
+	})
+	return result, err
+}
+
 // GetRuleByID retrieves models.AlertRule by ID.
 // It returns models.ErrAlertRuleNotFound if no alert rule is found for the provided ID.
 func (st DBstore) GetRuleByID(ctx context.Context, query ngmodels.GetAlertRuleByIDQuery) (result *ngmodels.AlertRule, err error) {
@@ -1684,3 +1684,52 @@ func (f *fakeBus) Publish(ctx context.Context, msg bus.Msg) error {
 
 	return nil
 }
+
+func TestIntegration_GetAlertRuleVersions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	cfg := setting.NewCfg()
+	cfg.UnifiedAlerting = setting.UnifiedAlertingSettings{
+		BaseInterval: time.Duration(rand.Int63n(100)+1) * time.Second,
+	}
+	sqlStore := db.InitTestDB(t)
+	folderService := setupFolderService(t, sqlStore, cfg, featuremgmt.WithFeatures())
+	b := &fakeBus{}
+	store := createTestStore(sqlStore, folderService, &logtest.Fake{}, cfg.UnifiedAlerting, b)
+	generator := models.RuleGen
+	generator = generator.With(generator.WithIntervalMatching(store.Cfg.BaseInterval), generator.WithUniqueOrgID())
+
+	t.Run("should return all versions of the rule from the newest", func(t *testing.T) {
+		ids, err := store.InsertAlertRules(context.Background(), []models.AlertRule{generator.Generate()})
+		require.NoError(t, err)
+		rule, err := store.GetAlertRuleByUID(context.Background(), &models.GetAlertRuleByUIDQuery{OrgID: ids[0].OrgID, UID: ids[0].UID})
+		require.NoError(t, err)
+
+		updated := models.CopyRule(rule)
+		updated.Title = util.GenerateShortUID()
+		updated.UpdatedBy = util.Pointer(models.UserUID("user:test"))
+		err = store.UpdateAlertRules(context.Background(), []models.UpdateRule{{
+			Existing: rule,
+			New:      *updated,
+		}})
+		require.NoError(t, err)
+
+		versions, err := store.GetAlertRuleVersions(context.Background(), rule.GetKey())
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+
+		assert.Equal(t, rule.Version+1, versions[0].Version)
+		assert.Equal(t, updated.Title, versions[0].Title)
+		assert.Equal(t, updated.UpdatedBy, versions[0].UpdatedBy)
+
+		assert.Equal(t, rule.Version, versions[1].Version)
+		assert.Equal(t, rule.Title, versions[1].Title)
+		assert.Nil(t, versions[1].UpdatedBy)
+	})
+
+	t.Run("should return ErrAlertRuleNotFound if the rule has no versions", func(t *testing.T) {
+		_, err := store.GetAlertRuleVersions(context.Background(), models.AlertRuleKey{OrgID: 1, UID: "not-found"})
+		require.ErrorIs(t, err, models.ErrAlertRuleNotFound)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/log"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/util"
 )
 
 func alertRuleToModelsAlertRule(ar alertRule, l log.Logger) (models.AlertRule, error) {
@@ -32,6 +33,7 @@ func alertRuleToModelsAlertRule(ar alertRule, l log.Logger) (models.AlertRule, e
 		RuleGroup:       ar.RuleGroup,
 		RuleGroupIndex:  ar.RuleGroupIndex,
 		For:             ar.For,
+		KeepFiringFor:   ar.KeepFiringFor,
 		IsPaused:        ar.IsPaused,
 	}
 
@@ -88,9 +90,41 @@ func alertRuleToModelsAlertRule(ar alertRule, l log.Logger) (models.AlertRule, e
 		}
 	}
 
+	if ar.UpdatedBy != nil {
+		result.UpdatedBy = util.Pointer(models.UserUID(*ar.UpdatedBy))
+	}
+
 	return result, nil
 }
 
+// alertRuleVersionToModelsAlertRule converts a version of a rule to the rule as it was at that version.
+func alertRuleVersionToModelsAlertRule(v alertRuleVersion, l log.Logger) (models.AlertRule, error) {
+	return alertRuleToModelsAlertRule(alertRule{
+		OrgID:                v.RuleOrgID,
+		Title:                v.Title,
+		Condition:            v.Condition,
+		Data:                 v.Data,
+		Updated:              v.Created,
+		IntervalSeconds:      v.IntervalSeconds,
+		Version:              v.Version,
+		UID:                  v.RuleUID,
+		NamespaceUID:         v.RuleNamespaceUID,
+		RuleGroup:            v.RuleGroup,
+		RuleGroupIndex:       v.RuleGroupIndex,
+		Record:               v.Record,
+		NoDataState:          v.NoDataState,
+		ExecErrState:         v.ExecErrState,
+		For:                  v.For,
+		KeepFiringFor:        v.KeepFiringFor,
+		Annotations:          v.Annotations,
+		Labels:               v.Labels,
+		IsPaused:             v.IsPaused,
+		NotificationSettings: v.NotificationSettings,
+		Metadata:             v.Metadata,
+		UpdatedBy:            v.CreatedBy,
+	}, l)
+}
+
 func parseNotificationSettings(s string) ([]models.NotificationSettings, error) {
 	var result []models.NotificationSettings
 	if err := json.Unmarshal([]byte(s), &result); err != nil {
@@ -117,6 +151,7 @@ func alertRuleFromModelsAlertRule(ar models.AlertRule) (alertRule, error) {
 		NoDataState:     ar.NoDataState.String(),
 		ExecErrState:    ar.ExecErrState.String(),
 		For:             ar.For,
+		KeepFiringFor:   ar.KeepFiringFor,
 		IsPaused:        ar.IsPaused,
 	}
 
@@ -165,6 +200,10 @@ func alertRuleFromModelsAlertRule(ar models.AlertRule) (alertRule, error) {
 	}
 	result.Metadata = string(metadata)
 
+	if ar.UpdatedBy != nil {
+		result.UpdatedBy = util.Pointer(string(*ar.UpdatedBy))
+	}
+
 	return result, nil
 }
 
@@ -187,10 +226,12 @@ func alertRuleToAlertRuleVersion(rule alertRule) alertRuleVersion {
 		NoDataState:          rule.NoDataState,
 		ExecErrState:         rule.ExecErrState,
 		For:                  rule.For,
+		KeepFiringFor:        rule.KeepFiringFor,
 		Annotations:          rule.Annotations,
 		Labels:               rule.Labels,
 		IsPaused:             rule.IsPaused,
 		NotificationSettings: rule.NotificationSettings,
 		Metadata:             rule.Metadata,
+		CreatedBy:            rule.UpdatedBy,
 	}
 }
@@ -2,6 +2,7 @@ package store
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -14,7 +15,7 @@ func TestAlertRuleToModelsAlertRule(t *testing.T) {
 	g := ngmodels.RuleGen
 
 	t.Run("make sure no data is lost between conversions", func(t *testing.T) {
-		for _, rule := range g.GenerateMany(100) {
+		for _, rule := range g.With(g.WithKeepFiringFor(5 * time.Minute)).GenerateMany(100) {
 			r, err := alertRuleFromModelsAlertRule(rule)
 			require.NoError(t, err)
 			clone, err := alertRuleToModelsAlertRule(r, &logtest.Fake{})
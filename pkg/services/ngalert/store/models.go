@@ -22,11 +22,13 @@ type alertRule struct {
 	NoDataState          string
 	ExecErrState         string
 	For                  time.Duration
+	KeepFiringFor        time.Duration
 	Annotations          string
 	Labels               string
 	IsPaused             bool
-	NotificationSettings string `xorm:"notification_settings"`
-	Metadata             string `xorm:"metadata"`
+	NotificationSettings string  `xorm:"notification_settings"`
+	Metadata             string  `xorm:"metadata"`
+	UpdatedBy            *string `xorm:"updated_by"`
 }
 
 func (a alertRule) TableName() string {
@@ -56,13 +58,52 @@ type alertRuleVersion struct {
 	// ideally this field should have been apimodels.ApiDuration
 	// but this is currently not possible because of circular dependencies
 	For                  time.Duration
+	KeepFiringFor        time.Duration
 	Annotations          string
 	Labels               string
 	IsPaused             bool
-	NotificationSettings string `xorm:"notification_settings"`
-	Metadata             string `xorm:"metadata"`
+	NotificationSettings string  `xorm:"notification_settings"`
+	Metadata             string  `xorm:"metadata"`
+	CreatedBy            *string `xorm:"created_by"`
 }
 
 func (a alertRuleVersion) TableName() string {
 	return "alert_rule_version"
 }
+
+// silenceTemplate represents a record in alert_silence_template table
+type silenceTemplate struct {
+	ID       int64  `xorm:"pk autoincr 'id'"`
+	OrgID    int64  `xorm:"org_id"`
+	UID      string `xorm:"uid"`
+	Name     string
+	Matchers string
+	Duration time.Duration
+	Comment  string
+	Updated  time.Time
+}
+
+func (t silenceTemplate) TableName() string {
+	return "alert_silence_template"
+}
+
+// recurringSilence represents a record in alert_recurring_silence table
+type recurringSilence struct {
+	ID          int64  `xorm:"pk autoincr 'id'"`
+	OrgID       int64  `xorm:"org_id"`
+	UID         string `xorm:"uid"`
+	Name        string
+	TemplateUID string `xorm:"template_uid"`
+	Matchers    string
+	Comment     string
+	CreatedBy   string
+	Schedule    string
+	Duration    time.Duration
+	Updated     time.Time
+	SilenceID   string     `xorm:"silence_id"`
+	WindowStart *time.Time `xorm:"window_start"`
+}
+
+func (s recurringSilence) TableName() string {
+	return "alert_recurring_silence"
+}
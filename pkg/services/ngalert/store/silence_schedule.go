@@ -0,0 +1,249 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+
+	"github.com/grafana/alerting/definition"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// ListSilenceTemplates returns the silence templates of the organization ordered by name.
+func (st DBstore) ListSilenceTemplates(ctx context.Context, orgID int64) ([]models.SilenceTemplate, error) {
+	var result []models.SilenceTemplate
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var records []silenceTemplate
+		if err := sess.Where("org_id = ?", orgID).Asc("name").Find(&records); err != nil {
+			return fmt.Errorf("failed to list silence templates: %w", err)
+		}
+		result = make([]models.SilenceTemplate, 0, len(records))
+		for _, r := range records {
+			t, err := silenceTemplateToModel(r)
+			if err != nil {
+				return err
+			}
+			result = append(result, t)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// GetSilenceTemplate returns the silence template with the given UID or models.ErrSilenceTemplateNotFound.
+func (st DBstore) GetSilenceTemplate(ctx context.Context, orgID int64, uid string) (models.SilenceTemplate, error) {
+	var result models.SilenceTemplate
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var record silenceTemplate
+		has, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Get(&record)
+		if err != nil {
+			return fmt.Errorf("failed to get silence template: %w", err)
+		}
+		if !has {
+			return models.ErrSilenceTemplateNotFound
+		}
+		result, err = silenceTemplateToModel(record)
+		return err
+	})
+	return result, err
+}
+
+// SaveSilenceTemplate inserts the silence template or updates the existing one with the same UID.
+func (st DBstore) SaveSilenceTemplate(ctx context.Context, t models.SilenceTemplate) error {
+	matchers, err := json.Marshal(definition.ObjectMatchers(t.Matchers))
+	if err != nil {
+		return fmt.Errorf("failed to marshal matchers: %w", err)
+	}
+	record := silenceTemplate{
+		OrgID:    t.OrgID,
+		UID:      t.UID,
+		Name:     t.Name,
+		Matchers: string(matchers),
+		Duration: t.Duration,
+		Comment:  t.Comment,
+		Updated:  TimeNow(),
+	}
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		exists, err := sess.Table(silenceTemplate{}).Where("org_id = ? AND uid = ?", t.OrgID, t.UID).Exist()
+		if err != nil {
+			return fmt.Errorf("failed to check if silence template exists: %w", err)
+		}
+		if exists {
+			if _, err := sess.Where("org_id = ? AND uid = ?", t.OrgID, t.UID).AllCols().Omit("id").Update(&record); err != nil {
+				return fmt.Errorf("failed to update silence template: %w", err)
+			}
+			return nil
+		}
+		if _, err := sess.Insert(&record); err != nil {
+			return fmt.Errorf("failed to insert silence template: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteSilenceTemplate deletes the silence template with the given UID.
+func (st DBstore) DeleteSilenceTemplate(ctx context.Context, orgID int64, uid string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Delete(&silenceTemplate{})
+		return err
+	})
+}
+
+// ListRecurringSilences returns the recurring silences of the organization ordered by name.
+func (st DBstore) ListRecurringSilences(ctx context.Context, orgID int64) ([]models.RecurringSilence, error) {
+	var result []models.RecurringSilence
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var records []recurringSilence
+		if err := sess.Where("org_id = ?", orgID).Asc("name").Find(&records); err != nil {
+			return fmt.Errorf("failed to list recurring silences: %w", err)
+		}
+		result = make([]models.RecurringSilence, 0, len(records))
+		for _, r := range records {
+			s, err := recurringSilenceToModel(r)
+			if err != nil {
+				return err
+			}
+			result = append(result, s)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// GetRecurringSilence returns the recurring silence with the given UID or models.ErrRecurringSilenceNotFound.
+func (st DBstore) GetRecurringSilence(ctx context.Context, orgID int64, uid string) (models.RecurringSilence, error) {
+	var result models.RecurringSilence
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var record recurringSilence
+		has, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Get(&record)
+		if err != nil {
+			return fmt.Errorf("failed to get recurring silence: %w", err)
+		}
+		if !has {
+			return models.ErrRecurringSilenceNotFound
+		}
+		result, err = recurringSilenceToModel(record)
+		return err
+	})
+	return result, err
+}
+
+// SaveRecurringSilence inserts the recurring silence or updates the existing one with the same UID.
+// The last silence created for the recurring silence is not changed.
+func (st DBstore) SaveRecurringSilence(ctx context.Context, s models.RecurringSilence) error {
+	matchers, err := json.Marshal(definition.ObjectMatchers(s.Matchers))
+	if err != nil {
+		return fmt.Errorf("failed to marshal matchers: %w", err)
+	}
+	schedule, err := json.Marshal(s.Schedule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal schedule: %w", err)
+	}
+	record := recurringSilence{
+		OrgID:       s.OrgID,
+		UID:         s.UID,
+		Name:        s.Name,
+		TemplateUID: s.TemplateUID,
+		Matchers:    string(matchers),
+		Comment:     s.Comment,
+		CreatedBy:   s.CreatedBy,
+		Schedule:    string(schedule),
+		Duration:    s.Duration,
+		Updated:     TimeNow(),
+	}
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		exists, err := sess.Table(recurringSilence{}).Where("org_id = ? AND uid = ?", s.OrgID, s.UID).Exist()
+		if err != nil {
+			return fmt.Errorf("failed to check if recurring silence exists: %w", err)
+		}
+		if exists {
+			if _, err := sess.Where("org_id = ? AND uid = ?", s.OrgID, s.UID).AllCols().Omit("id", "silence_id", "window_start").Update(&record); err != nil {
+				return fmt.Errorf("failed to update recurring silence: %w", err)
+			}
+			return nil
+		}
+		if _, err := sess.Insert(&record); err != nil {
+			return fmt.Errorf("failed to insert recurring silence: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteRecurringSilence deletes the recurring silence with the given UID.
+func (st DBstore) DeleteRecurringSilence(ctx context.Context, orgID int64, uid string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Delete(&recurringSilence{})
+		return err
+	})
+}
+
+// UpdateRecurringSilenceState stores the last silence created for the recurring silence.
+// It returns models.ErrRecurringSilenceStateChanged if the last silence is not the expected one anymore,
+// for example because another instance created a silence for the same window.
+func (st DBstore) UpdateRecurringSilenceState(ctx context.Context, orgID int64, uid string, expectedSilenceID string, silenceID string, windowStart time.Time) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		query := sess.Table(recurringSilence{}).Where("org_id = ? AND uid = ?", orgID, uid)
+		if expectedSilenceID == "" {
+			query = query.And("(silence_id IS NULL OR silence_id = '')")
+		} else {
+			query = query.And("silence_id = ?", expectedSilenceID)
+		}
+		updated, err := query.Cols("silence_id", "window_start").Update(&recurringSilence{SilenceID: silenceID, WindowStart: &windowStart})
+		if err != nil {
+			return fmt.Errorf("failed to update state of recurring silence: %w", err)
+		}
+		if updated == 0 {
+			return models.ErrRecurringSilenceStateChanged
+		}
+		return nil
+	})
+}
+
+func silenceTemplateToModel(r silenceTemplate) (models.SilenceTemplate, error) {
+	var matchers definition.ObjectMatchers
+	if err := json.Unmarshal([]byte(r.Matchers), &matchers); err != nil {
+		return models.SilenceTemplate{}, fmt.Errorf("failed to parse matchers of silence template %s: %w", r.UID, err)
+	}
+	return models.SilenceTemplate{
+		UID:      r.UID,
+		OrgID:    r.OrgID,
+		Name:     r.Name,
+		Matchers: labels.Matchers(matchers),
+		Duration: r.Duration,
+		Comment:  r.Comment,
+		Updated:  r.Updated,
+	}, nil
+}
+
+func recurringSilenceToModel(r recurringSilence) (models.RecurringSilence, error) {
+	var matchers definition.ObjectMatchers
+	if err := json.Unmarshal([]byte(r.Matchers), &matchers); err != nil {
+		return models.RecurringSilence{}, fmt.Errorf("failed to parse matchers of recurring silence %s: %w", r.UID, err)
+	}
+	var schedule models.RecurringSilenceSchedule
+	if err := json.Unmarshal([]byte(r.Schedule), &schedule); err != nil {
+		return models.RecurringSilence{}, fmt.Errorf("failed to parse schedule of recurring silence %s: %w", r.UID, err)
+	}
+	result := models.RecurringSilence{
+		UID:         r.UID,
+		OrgID:       r.OrgID,
+		Name:        r.Name,
+		TemplateUID: r.TemplateUID,
+		Matchers:    labels.Matchers(matchers),
+		Comment:     r.Comment,
+		CreatedBy:   r.CreatedBy,
+		Schedule:    schedule,
+		Duration:    r.Duration,
+		Updated:     r.Updated,
+		SilenceID:   r.SilenceID,
+	}
+	if r.WindowStart != nil {
+		result.WindowStart = *r.WindowStart
+	}
+	return result, nil
+}
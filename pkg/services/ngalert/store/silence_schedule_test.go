@@ -0,0 +1,106 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+)
+
+func TestIntegrationSilenceTemplates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	template := models.SilenceTemplate{
+		UID:      "maintenance",
+		OrgID:    1,
+		Name:     "Maintenance",
+		Matchers: labels.Matchers{labels.MustNewMatcher(labels.MatchRegexp, "env", "prod|staging")},
+		Duration: 2 * time.Hour,
+		Comment:  "Database maintenance",
+	}
+	require.NoError(t, dbstore.SaveSilenceTemplate(ctx, template))
+
+	actual, err := dbstore.GetSilenceTemplate(ctx, 1, template.UID)
+	require.NoError(t, err)
+	require.Equal(t, template.Name, actual.Name)
+	require.Equal(t, template.Duration, actual.Duration)
+	require.Equal(t, template.Comment, actual.Comment)
+	require.Equal(t, template.Matchers.String(), actual.Matchers.String())
+	require.True(t, actual.Matchers[0].Matches("staging"))
+
+	_, err = dbstore.GetSilenceTemplate(ctx, 2, template.UID)
+	require.ErrorIs(t, err, models.ErrSilenceTemplateNotFound)
+
+	template.Name = "Weekly maintenance"
+	require.NoError(t, dbstore.SaveSilenceTemplate(ctx, template))
+	list, err := dbstore.ListSilenceTemplates(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "Weekly maintenance", list[0].Name)
+
+	require.NoError(t, dbstore.DeleteSilenceTemplate(ctx, 1, template.UID))
+	_, err = dbstore.GetSilenceTemplate(ctx, 1, template.UID)
+	require.ErrorIs(t, err, models.ErrSilenceTemplateNotFound)
+}
+
+func TestIntegrationRecurringSilences(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	silence := models.RecurringSilence{
+		UID:      "weekly",
+		OrgID:    1,
+		Name:     "Weekly maintenance",
+		Matchers: labels.Matchers{labels.MustNewMatcher(labels.MatchEqual, "env", "prod")},
+		Schedule: models.RecurringSilenceSchedule{TimeIntervals: []timeinterval.TimeInterval{{
+			Weekdays: []timeinterval.WeekdayRange{{InclusiveRange: timeinterval.InclusiveRange{Begin: 0, End: 0}}},
+			Times:    []timeinterval.TimeRange{{StartMinute: 120, EndMinute: 240}},
+		}}},
+	}
+	require.NoError(t, dbstore.SaveRecurringSilence(ctx, silence))
+
+	actual, err := dbstore.GetRecurringSilence(ctx, 1, silence.UID)
+	require.NoError(t, err)
+	require.Equal(t, silence.Schedule, actual.Schedule)
+	require.Empty(t, actual.SilenceID)
+
+	t.Run("state is updated only if the last silence is the expected one", func(t *testing.T) {
+		windowStart := time.Date(2024, 1, 7, 2, 0, 0, 0, time.UTC)
+		require.NoError(t, dbstore.UpdateRecurringSilenceState(ctx, 1, silence.UID, "", "silence-1", windowStart))
+		err := dbstore.UpdateRecurringSilenceState(ctx, 1, silence.UID, "", "silence-2", windowStart)
+		require.ErrorIs(t, err, models.ErrRecurringSilenceStateChanged)
+
+		actual, err := dbstore.GetRecurringSilence(ctx, 1, silence.UID)
+		require.NoError(t, err)
+		require.Equal(t, "silence-1", actual.SilenceID)
+		require.True(t, windowStart.Equal(actual.WindowStart))
+	})
+
+	t.Run("saving the recurring silence does not change its state", func(t *testing.T) {
+		silence.Comment = "updated"
+		require.NoError(t, dbstore.SaveRecurringSilence(ctx, silence))
+
+		list, err := dbstore.ListRecurringSilences(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		require.Equal(t, "updated", list[0].Comment)
+		require.Equal(t, "silence-1", list[0].SilenceID)
+	})
+
+	require.NoError(t, dbstore.DeleteRecurringSilence(ctx, 1, silence.UID))
+	_, err = dbstore.GetRecurringSilence(ctx, 1, silence.UID)
+	require.ErrorIs(t, err, models.ErrRecurringSilenceNotFound)
+}
@@ -25,6 +25,8 @@ type RuleStore struct {
 	Hook        func(cmd any) error // use Hook if you need to intercept some query and return an error
 	RecordedOps []any
 	Folders     map[int64][]*folder.Folder
+	// History contains the versions of rules, from the newest to the oldest
+	History map[models.AlertRuleKey][]*models.AlertRule
 }
 
 type GenericRecordedQuery struct {
@@ -40,6 +42,7 @@ func NewRuleStore(t *testing.T) *RuleStore {
 			return nil
 		},
 		Folders: map[int64][]*folder.Folder{},
+		History: map[models.AlertRuleKey][]*models.AlertRule{},
 	}
 }
 
@@ -144,6 +147,23 @@ func (f *RuleStore) GetAlertRuleByUID(_ context.Context, q *models.GetAlertRuleB
 	return nil, models.ErrAlertRuleNotFound
 }
 
+func (f *RuleStore) GetAlertRuleVersions(_ context.Context, key models.AlertRuleKey) ([]*models.AlertRule, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.RecordedOps = append(f.RecordedOps, GenericRecordedQuery{
+		Name:   "GetAlertRuleVersions",
+		Params: []any{key},
+	})
+	if err := f.Hook(key); err != nil {
+		return nil, err
+	}
+	versions, ok := f.History[key]
+	if !ok || len(versions) == 0 {
+		return nil, models.ErrAlertRuleNotFound
+	}
+	return versions, nil
+}
+
 func (f *RuleStore) GetAlertRulesGroupByRuleUID(_ context.Context, q *models.GetAlertRulesGroupByRuleUIDQuery) ([]*models.AlertRule, error) {
 	f.mtx.Lock()
 	defer f.mtx.Unlock()
@@ -19,6 +19,8 @@ type ProvisionerConfig struct {
 	NotificiationPolicyService provisioning.NotificationPolicyService
 	MuteTimingService          provisioning.MuteTimingService
 	TemplateService            provisioning.TemplateService
+	SilenceTemplateService     provisioning.SilenceTemplateService
+	RecurringSilenceService    provisioning.RecurringSilenceService
 }
 
 func Provision(ctx context.Context, cfg ProvisionerConfig) error {
@@ -45,6 +47,11 @@ func Provision(ctx context.Context, cfg ProvisionerConfig) error {
 	if err != nil {
 		return fmt.Errorf("text templates: %w", err)
 	}
+	silencesProvisioner := NewSilencesProvisioner(logger, cfg.SilenceTemplateService, cfg.RecurringSilenceService)
+	err = silencesProvisioner.Provision(ctx, files)
+	if err != nil {
+		return fmt.Errorf("silences: %w", err)
+	}
 	npProvisioner := NewNotificationPolicyProvisoner(logger, cfg.NotificiationPolicyService)
 	err = npProvisioner.Provision(ctx, files)
 	if err != nil {
@@ -62,6 +69,10 @@ func Provision(ctx context.Context, cfg ProvisionerConfig) error {
 	if err != nil {
 		return fmt.Errorf("text templates: %w", err)
 	}
+	err = silencesProvisioner.Unprovision(ctx, files)
+	if err != nil {
+		return fmt.Errorf("silences: %w", err)
+	}
 	ruleProvisioner := NewAlertRuleProvisioner(
 		logger,
 		cfg.FolderService,
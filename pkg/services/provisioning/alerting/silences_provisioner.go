@@ -0,0 +1,119 @@
+package alerting
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+)
+
+type SilencesProvisioner interface {
+	Provision(ctx context.Context, files []*AlertingFile) error
+	Unprovision(ctx context.Context, files []*AlertingFile) error
+}
+
+type defaultSilencesProvisioner struct {
+	logger                  log.Logger
+	silenceTemplateService  provisioning.SilenceTemplateService
+	recurringSilenceService provisioning.RecurringSilenceService
+}
+
+func NewSilencesProvisioner(logger log.Logger,
+	silenceTemplateService provisioning.SilenceTemplateService,
+	recurringSilenceService provisioning.RecurringSilenceService) SilencesProvisioner {
+	return &defaultSilencesProvisioner{
+		logger:                  logger,
+		silenceTemplateService:  silenceTemplateService,
+		recurringSilenceService: recurringSilenceService,
+	}
+}
+
+// Provision creates or updates the silence templates and then the recurring silences, which can use them.
+// Existing objects are matched by UID, or by name if the UID is not set.
+func (c *defaultSilencesProvisioner) Provision(ctx context.Context,
+	files []*AlertingFile) error {
+	templates := map[int64][]definitions.SilenceTemplate{}
+	for _, file := range files {
+		for _, template := range file.SilenceTemplates {
+			if _, exists := templates[template.OrgID]; !exists {
+				existing, err := c.silenceTemplateService.GetSilenceTemplates(ctx, template.OrgID)
+				if err != nil {
+					return err
+				}
+				templates[template.OrgID] = existing
+			}
+			template.Template.Provenance = definitions.Provenance(models.ProvenanceFile)
+			if uid, exists := findExisting(templates[template.OrgID], template.Template.UID, template.Template.Name, func(t definitions.SilenceTemplate) (string, string) {
+				return t.UID, t.Name
+			}); exists {
+				template.Template.UID = uid
+				if _, err := c.silenceTemplateService.UpdateSilenceTemplate(ctx, template.Template, template.OrgID); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := c.silenceTemplateService.CreateSilenceTemplate(ctx, template.Template, template.OrgID); err != nil {
+				return err
+			}
+		}
+	}
+
+	silences := map[int64][]definitions.RecurringSilence{}
+	for _, file := range files {
+		for _, silence := range file.RecurringSilences {
+			if _, exists := silences[silence.OrgID]; !exists {
+				existing, err := c.recurringSilenceService.GetRecurringSilences(ctx, silence.OrgID)
+				if err != nil {
+					return err
+				}
+				silences[silence.OrgID] = existing
+			}
+			silence.Silence.Provenance = definitions.Provenance(models.ProvenanceFile)
+			if uid, exists := findExisting(silences[silence.OrgID], silence.Silence.UID, silence.Silence.Name, func(s definitions.RecurringSilence) (string, string) {
+				return s.UID, s.Name
+			}); exists {
+				silence.Silence.UID = uid
+				if _, err := c.recurringSilenceService.UpdateRecurringSilence(ctx, silence.Silence, silence.OrgID); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := c.recurringSilenceService.CreateRecurringSilence(ctx, silence.Silence, silence.OrgID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *defaultSilencesProvisioner) Unprovision(ctx context.Context,
+	files []*AlertingFile) error {
+	for _, file := range files {
+		for _, deleteSilence := range file.DeleteRecurringSilences {
+			err := c.recurringSilenceService.DeleteRecurringSilence(ctx, deleteSilence.UID, deleteSilence.OrgID, definitions.Provenance(models.ProvenanceFile))
+			if err != nil {
+				return err
+			}
+		}
+		for _, deleteTemplate := range file.DeleteSilenceTemplates {
+			err := c.silenceTemplateService.DeleteSilenceTemplate(ctx, deleteTemplate.UID, deleteTemplate.OrgID, definitions.Provenance(models.ProvenanceFile))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// findExisting returns the UID of the object with the given UID, or with the given name if the UID is empty.
+func findExisting[T any](existing []T, uid, name string, key func(T) (string, string)) (string, bool) {
+	for _, e := range existing {
+		eUID, eName := key(e)
+		if (uid != "" && eUID == uid) || (uid == "" && eName == name) {
+			return eUID, true
+		}
+	}
+	return "", false
+}
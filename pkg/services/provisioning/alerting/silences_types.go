@@ -0,0 +1,101 @@
+package alerting
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/provisioning/values"
+)
+
+type SilenceTemplateV1 struct {
+	OrgID    values.Int64Value           `json:"orgId" yaml:"orgId"`
+	Template definitions.SilenceTemplate `json:",inline" yaml:",inline"`
+}
+
+func (v1 *SilenceTemplateV1) mapToModel() SilenceTemplate {
+	orgID := v1.OrgID.Value()
+	if orgID < 1 {
+		orgID = 1
+	}
+	return SilenceTemplate{
+		OrgID:    orgID,
+		Template: v1.Template,
+	}
+}
+
+type SilenceTemplate struct {
+	OrgID    int64
+	Template definitions.SilenceTemplate
+}
+
+type DeleteSilenceTemplateV1 struct {
+	OrgID values.Int64Value  `json:"orgId" yaml:"orgId"`
+	UID   values.StringValue `json:"uid" yaml:"uid"`
+}
+
+func (v1 *DeleteSilenceTemplateV1) mapToModel() (DeleteSilenceTemplate, error) {
+	uid := strings.TrimSpace(v1.UID.Value())
+	if uid == "" {
+		return DeleteSilenceTemplate{}, errors.New("delete silence template missing uid")
+	}
+	orgID := v1.OrgID.Value()
+	if orgID < 1 {
+		orgID = 1
+	}
+	return DeleteSilenceTemplate{
+		OrgID: orgID,
+		UID:   uid,
+	}, nil
+}
+
+type DeleteSilenceTemplate struct {
+	OrgID int64
+	UID   string
+}
+
+type RecurringSilenceV1 struct {
+	OrgID   values.Int64Value            `json:"orgId" yaml:"orgId"`
+	Silence definitions.RecurringSilence `json:",inline" yaml:",inline"`
+}
+
+func (v1 *RecurringSilenceV1) mapToModel() RecurringSilence {
+	orgID := v1.OrgID.Value()
+	if orgID < 1 {
+		orgID = 1
+	}
+	return RecurringSilence{
+		OrgID:   orgID,
+		Silence: v1.Silence,
+	}
+}
+
+type RecurringSilence struct {
+	OrgID   int64
+	Silence definitions.RecurringSilence
+}
+
+type DeleteRecurringSilenceV1 struct {
+	OrgID values.Int64Value  `json:"orgId" yaml:"orgId"`
+	UID   values.StringValue `json:"uid" yaml:"uid"`
+}
+
+func (v1 *DeleteRecurringSilenceV1) mapToModel() (DeleteRecurringSilence, error) {
+	uid := strings.TrimSpace(v1.UID.Value())
+	if uid == "" {
+		return DeleteRecurringSilence{}, errors.New("delete recurring silence missing uid")
+	}
+	orgID := v1.OrgID.Value()
+	if orgID < 1 {
+		orgID = 1
+	}
+	return DeleteRecurringSilence{
+		OrgID: orgID,
+		UID:   uid,
+	}, nil
+}
+
+type DeleteRecurringSilence struct {
+	OrgID int64
+	UID   string
+}
@@ -15,32 +15,40 @@ type OrgID int64
 
 type AlertingFile struct {
 	configVersion
-	Filename            string
-	Groups              []models.AlertRuleGroupWithFolderFullpath
-	DeleteRules         []RuleDelete
-	ContactPoints       []ContactPoint
-	DeleteContactPoints []DeleteContactPoint
-	Policies            []NotificiationPolicy
-	ResetPolicies       []OrgID
-	MuteTimes           []MuteTime
-	DeleteMuteTimes     []DeleteMuteTime
-	Templates           []Template
-	DeleteTemplates     []DeleteTemplate
+	Filename                string
+	Groups                  []models.AlertRuleGroupWithFolderFullpath
+	DeleteRules             []RuleDelete
+	ContactPoints           []ContactPoint
+	DeleteContactPoints     []DeleteContactPoint
+	Policies                []NotificiationPolicy
+	ResetPolicies           []OrgID
+	MuteTimes               []MuteTime
+	DeleteMuteTimes         []DeleteMuteTime
+	Templates               []Template
+	DeleteTemplates         []DeleteTemplate
+	SilenceTemplates        []SilenceTemplate
+	DeleteSilenceTemplates  []DeleteSilenceTemplate
+	RecurringSilences       []RecurringSilence
+	DeleteRecurringSilences []DeleteRecurringSilence
 }
 
 type AlertingFileV1 struct {
 	configVersion
-	Filename            string
-	Groups              []AlertRuleGroupV1      `json:"groups" yaml:"groups"`
-	DeleteRules         []RuleDeleteV1          `json:"deleteRules" yaml:"deleteRules"`
-	ContactPoints       []ContactPointV1        `json:"contactPoints" yaml:"contactPoints"`
-	DeleteContactPoints []DeleteContactPointV1  `json:"deleteContactPoints" yaml:"deleteContactPoints"`
-	Policies            []NotificiationPolicyV1 `json:"policies" yaml:"policies"`
-	ResetPolicies       []values.Int64Value     `json:"resetPolicies" yaml:"resetPolicies"`
-	MuteTimes           []MuteTimeV1            `json:"muteTimes" yaml:"muteTimes"`
-	DeleteMuteTimes     []DeleteMuteTimeV1      `json:"deleteMuteTimes" yaml:"deleteMuteTimes"`
-	Templates           []TemplateV1            `json:"templates" yaml:"templates"`
-	DeleteTemplates     []DeleteTemplateV1      `json:"deleteTemplates" yaml:"deleteTemplates"`
+	Filename                string
+	Groups                  []AlertRuleGroupV1         `json:"groups" yaml:"groups"`
+	DeleteRules             []RuleDeleteV1             `json:"deleteRules" yaml:"deleteRules"`
+	ContactPoints           []ContactPointV1           `json:"contactPoints" yaml:"contactPoints"`
+	DeleteContactPoints     []DeleteContactPointV1     `json:"deleteContactPoints" yaml:"deleteContactPoints"`
+	Policies                []NotificiationPolicyV1    `json:"policies" yaml:"policies"`
+	ResetPolicies           []values.Int64Value        `json:"resetPolicies" yaml:"resetPolicies"`
+	MuteTimes               []MuteTimeV1               `json:"muteTimes" yaml:"muteTimes"`
+	DeleteMuteTimes         []DeleteMuteTimeV1         `json:"deleteMuteTimes" yaml:"deleteMuteTimes"`
+	Templates               []TemplateV1               `json:"templates" yaml:"templates"`
+	DeleteTemplates         []DeleteTemplateV1         `json:"deleteTemplates" yaml:"deleteTemplates"`
+	SilenceTemplates        []SilenceTemplateV1        `json:"silenceTemplates" yaml:"silenceTemplates"`
+	DeleteSilenceTemplates  []DeleteSilenceTemplateV1  `json:"deleteSilenceTemplates" yaml:"deleteSilenceTemplates"`
+	RecurringSilences       []RecurringSilenceV1       `json:"recurringSilences" yaml:"recurringSilences"`
+	DeleteRecurringSilences []DeleteRecurringSilenceV1 `json:"deleteRecurringSilences" yaml:"deleteRecurringSilences"`
 }
 
 func (fileV1 *AlertingFileV1) MapToModel() (AlertingFile, error) {
@@ -61,6 +69,9 @@ func (fileV1 *AlertingFileV1) MapToModel() (AlertingFile, error) {
 	if err := fileV1.mapTemplates(&alertingFile); err != nil {
 		return AlertingFile{}, fmt.Errorf("failure parsing templates: %w", err)
 	}
+	if err := fileV1.mapSilences(&alertingFile); err != nil {
+		return AlertingFile{}, fmt.Errorf("failure parsing silences: %w", err)
+	}
 	return alertingFile, nil
 }
 
@@ -78,6 +89,30 @@ func (fileV1 *AlertingFileV1) mapTemplates(alertingFile *AlertingFile) error {
 	return nil
 }
 
+func (fileV1 *AlertingFileV1) mapSilences(alertingFile *AlertingFile) error {
+	for _, stV1 := range fileV1.SilenceTemplates {
+		alertingFile.SilenceTemplates = append(alertingFile.SilenceTemplates, stV1.mapToModel())
+	}
+	for _, deleteV1 := range fileV1.DeleteSilenceTemplates {
+		delReq, err := deleteV1.mapToModel()
+		if err != nil {
+			return err
+		}
+		alertingFile.DeleteSilenceTemplates = append(alertingFile.DeleteSilenceTemplates, delReq)
+	}
+	for _, rsV1 := range fileV1.RecurringSilences {
+		alertingFile.RecurringSilences = append(alertingFile.RecurringSilences, rsV1.mapToModel())
+	}
+	for _, deleteV1 := range fileV1.DeleteRecurringSilences {
+		delReq, err := deleteV1.mapToModel()
+		if err != nil {
+			return err
+		}
+		alertingFile.DeleteRecurringSilences = append(alertingFile.DeleteRecurringSilences, delReq)
+	}
+	return nil
+}
+
 func (fileV1 *AlertingFileV1) mapMuteTimes(alertingFile *AlertingFile) error {
 	for _, mtV1 := range fileV1.MuteTimes {
 		alertingFile.MuteTimes = append(alertingFile.MuteTimes, mtV1.mapToModel())
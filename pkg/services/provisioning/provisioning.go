@@ -299,6 +299,9 @@ func (ps *ProvisioningServiceImpl) ProvisionAlerting(ctx context.Context) error
 		ps.alertingStore, ps.SQLStore, ps.Cfg.UnifiedAlerting, ps.log)
 	mutetimingsService := provisioning.NewMuteTimingService(configStore, ps.alertingStore, ps.alertingStore, ps.log, ps.alertingStore)
 	templateService := provisioning.NewTemplateService(configStore, ps.alertingStore, ps.alertingStore, ps.log)
+	silenceTemplateService := provisioning.NewSilenceTemplateService(ps.alertingStore, ps.alertingStore, ps.SQLStore, ps.log)
+	// the Alertmanager is not available yet, silences of deleted recurring silences expire at the end of their window.
+	recurringSilenceService := provisioning.NewRecurringSilenceService(ps.alertingStore, nil, ps.alertingStore, ps.SQLStore, ps.log)
 	cfg := prov_alerting.ProvisionerConfig{
 		Path:                       alertingPath,
 		RuleService:                *ruleService,
@@ -308,6 +311,8 @@ func (ps *ProvisioningServiceImpl) ProvisionAlerting(ctx context.Context) error
 		NotificiationPolicyService: *notificationPolicyService,
 		MuteTimingService:          *mutetimingsService,
 		TemplateService:            *templateService,
+		SilenceTemplateService:     *silenceTemplateService,
+		RecurringSilenceService:    *recurringSilenceService,
 	}
 	return ps.provisionAlerting(ctx, cfg)
 }
@@ -73,9 +73,11 @@ func (api *Api) RegisterAPIEndpoints() {
 	// because it is deeply dependent on the HTTPServer.Index() method and would result in a
 	// circular dependency
 	api.routeRegister.Group("/api/public/dashboards/:accessToken", func(apiRoute routing.RouteRegister) {
-		apiRoute.Get("/", routing.Wrap(api.ViewPublicDashboard))
-		apiRoute.Get("/annotations", routing.Wrap(api.GetPublicAnnotations))
-		apiRoute.Post("/panels/:panelId/query", routing.Wrap(api.QueryPublicDashboard))
+		requiresPassphrase := RequiresPassphrase(api.PublicDashboardService, api.cfg.SecretKey)
+		apiRoute.Get("/", requiresPassphrase, routing.Wrap(api.ViewPublicDashboard))
+		apiRoute.Get("/annotations", requiresPassphrase, routing.Wrap(api.GetPublicAnnotations))
+		apiRoute.Post("/panels/:panelId/query", requiresPassphrase, routing.Wrap(api.QueryPublicDashboard))
+		apiRoute.Post("/passphrase", routing.Wrap(api.UnlockPublicDashboard))
 	}, api.Middleware.HandleApi)
 
 	// Auth endpoints
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -75,6 +76,11 @@ func setupTestServer(
 		cfg.PublicDashboardsEnabled = true
 	}
 
+	// public dashboards are not passphrase protected
+	if fakeService, ok := service.(*publicdashboards.FakePublicDashboardService); ok {
+		fakeService.On("FindByAccessToken", mock.Anything, mock.Anything).Return(&publicdashboardModels.PublicDashboard{}, nil).Maybe()
+	}
+
 	// build api, this will mount the routes at the same time if the feature is enabled
 	license := licensingtest.NewFakeLicensing()
 	license.On("FeatureEnabled", publicdashboardModels.FeaturePublicDashboardsEmailSharing).Return(false)
@@ -1,15 +1,59 @@
 package api
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/infra/metrics"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/publicdashboards"
+	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/grafana/grafana/pkg/services/publicdashboards/validation"
 	"github.com/grafana/grafana/pkg/web"
 )
 
+// PassphraseCookieName is the cookie set when a viewer enters the passphrase of a public dashboard. Its path is
+// limited to the api of the public dashboard, so viewers can unlock several public dashboards
+const PassphraseCookieName = "grafana_public_dashboard_passphrase"
+
+// passphraseTokenTTL is how long viewers can access a public dashboard after entering its passphrase
+const passphraseTokenTTL = 24 * time.Hour
+
+// newPassphraseToken returns the token of the passphrase cookie. It is signed with the secret key over the access
+// token, the salt of the passphrase and the expiry, so it is only valid for the public dashboard until it expires
+// or the passphrase changes, as a new passphrase gets a new salt
+func newPassphraseToken(secretKey string, accessToken string, pubdash *PublicDashboard, expiresAt time.Time) string {
+	expiry := strconv.FormatInt(expiresAt.Unix(), 10)
+	return expiry + "." + signPassphraseToken(secretKey, accessToken, pubdash.PassphraseSalt, expiry)
+}
+
+// verifyPassphraseToken reports whether the token was issued for the current passphrase of the public dashboard
+// and has not expired
+func verifyPassphraseToken(secretKey string, token string, accessToken string, pubdash *PublicDashboard, now time.Time) bool {
+	expiry, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || now.Unix() >= expiresAt {
+		return false
+	}
+	expected := signPassphraseToken(secretKey, accessToken, pubdash.PassphraseSalt, expiry)
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
+
+func signPassphraseToken(secretKey string, accessToken string, salt string, expiry string) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(accessToken + "\x00" + salt + "\x00" + expiry))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
 // SetPublicDashboardOrgIdOnContext Adds orgId to context based on org of public dashboard
 func SetPublicDashboardOrgIdOnContext(publicDashboardService publicdashboards.Service) func(c *contextmodel.ReqContext) {
 	return func(c *contextmodel.ReqContext) {
@@ -62,6 +106,30 @@ func RequiresExistingAccessToken(publicDashboardService publicdashboards.Service
 	}
 }
 
+// RequiresPassphrase Middleware to enforce that viewers entered the passphrase of passphrase protected public
+// dashboards before continuing to handler. The passphrase cookie holds a token signed with the secret key, that
+// expires and is no longer valid once the passphrase changes
+func RequiresPassphrase(publicDashboardService publicdashboards.Service, secretKey string) func(c *contextmodel.ReqContext) {
+	return func(c *contextmodel.ReqContext) {
+		accessToken, ok := web.Params(c.Req)[":accessToken"]
+		if !ok || !validation.IsValidAccessToken(accessToken) {
+			return
+		}
+
+		// let the handler respond when the public dashboard is not found
+		pubdash, err := publicDashboardService.FindByAccessToken(c.Req.Context(), accessToken)
+		if err != nil || !pubdash.PassphraseEnabled {
+			return
+		}
+
+		cookie := c.GetCookie(PassphraseCookieName)
+		if cookie == "" || !verifyPassphraseToken(secretKey, cookie, accessToken, pubdash, time.Now()) {
+			response.Err(ErrPublicDashboardPassphraseRequired.Errorf("RequiresPassphrase: passphrase required accessToken: %s", accessToken)).WriteTo(c)
+			return
+		}
+	}
+}
+
 func CountPublicDashboardRequest() func(c *contextmodel.ReqContext) {
 	return func(c *contextmodel.ReqContext) {
 		metrics.MPublicDashboardRequestCount.Inc()
@@ -5,11 +5,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"errors"
 
+	"github.com/grafana/grafana/pkg/infra/log"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/publicdashboards"
+	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/grafana/grafana/pkg/services/publicdashboards/service"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/web"
@@ -144,6 +147,96 @@ func TestSetPublicDashboardOrgIdOnContext(t *testing.T) {
 	}
 }
 
+func TestRequiresPassphrase(t *testing.T) {
+	secretKey := "secret"
+	pubdash := &PublicDashboard{PassphraseHash: "hash", PassphraseSalt: "salt", PassphraseEnabled: true}
+	validToken := newPassphraseToken(secretKey, validAccessToken, pubdash, time.Now().Add(time.Hour))
+
+	tests := []struct {
+		Name                 string
+		PublicDashboard      *PublicDashboard
+		FindErr              error
+		Cookie               string
+		ExpectedResponseCode int
+	}{
+		{
+			Name:                 "Continues when public dashboard has no passphrase",
+			PublicDashboard:      &PublicDashboard{},
+			ExpectedResponseCode: http.StatusOK,
+		},
+		{
+			Name:                 "Continues when passphrase cookie holds a valid token",
+			PublicDashboard:      pubdash,
+			Cookie:               validToken,
+			ExpectedResponseCode: http.StatusOK,
+		},
+		{
+			Name:                 "Continues when public dashboard is not found",
+			FindErr:              ErrPublicDashboardNotFound.Errorf(""),
+			ExpectedResponseCode: http.StatusOK,
+		},
+		{
+			Name:                 "Returns 401 when passphrase cookie is missing",
+			PublicDashboard:      pubdash,
+			ExpectedResponseCode: http.StatusUnauthorized,
+		},
+		{
+			Name:                 "Returns 401 when passphrase cookie holds the passphrase hash",
+			PublicDashboard:      pubdash,
+			Cookie:               "hash",
+			ExpectedResponseCode: http.StatusUnauthorized,
+		},
+		{
+			Name:                 "Returns 401 when the passphrase changed",
+			PublicDashboard:      &PublicDashboard{PassphraseHash: "newHash", PassphraseSalt: "newSalt", PassphraseEnabled: true},
+			Cookie:               validToken,
+			ExpectedResponseCode: http.StatusUnauthorized,
+		},
+		{
+			Name:                 "Returns 401 when the token expired",
+			PublicDashboard:      pubdash,
+			Cookie:               newPassphraseToken(secretKey, validAccessToken, pubdash, time.Now().Add(-time.Minute)),
+			ExpectedResponseCode: http.StatusUnauthorized,
+		},
+		{
+			Name:                 "Returns 401 when the token was issued for another public dashboard",
+			PublicDashboard:      pubdash,
+			Cookie:               newPassphraseToken(secretKey, "e71950f3e7e4402196f1c7a3d1fb7be9", pubdash, time.Now().Add(time.Hour)),
+			ExpectedResponseCode: http.StatusUnauthorized,
+		},
+		{
+			Name:                 "Returns 401 when the token was signed with another secret key",
+			PublicDashboard:      pubdash,
+			Cookie:               newPassphraseToken("other", validAccessToken, pubdash, time.Now().Add(time.Hour)),
+			ExpectedResponseCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			publicdashboardService := &publicdashboards.FakePublicDashboardService{}
+			publicdashboardService.On("FindByAccessToken", mock.Anything, validAccessToken).Return(tt.PublicDashboard, tt.FindErr)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/public/dashboards/"+validAccessToken, nil)
+			if tt.Cookie != "" {
+				request.AddCookie(&http.Cookie{Name: PassphraseCookieName, Value: tt.Cookie})
+			}
+			response := httptest.NewRecorder()
+			ctx := &contextmodel.ReqContext{
+				Context: &web.Context{
+					Req:  web.SetURLParams(request, map[string]string{":accessToken": validAccessToken}),
+					Resp: web.NewResponseWriter(http.MethodGet, response),
+				},
+				SignedInUser: &user.SignedInUser{},
+				Logger:       log.New("publicdashboards-test"),
+			}
+
+			RequiresPassphrase(publicdashboardService, secretKey)(ctx)
+			require.Equal(t, tt.ExpectedResponseCode, response.Code)
+		})
+	}
+}
+
 func TestSetPublicDashboardFlag(t *testing.T) {
 	t.Run("Adds context.PublicDashboardAccessToken to request", func(t *testing.T) {
 		ctx := &contextmodel.ReqContext{Context: &web.Context{Req: web.SetURLParams(&http.Request{}, map[string]string{":accessToken": "asdfasdfasdfsadfasdfsfd"})}}
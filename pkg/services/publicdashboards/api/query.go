@@ -2,12 +2,15 @@ package api
 
 import (
 	"net/http"
+	"path"
 	"strconv"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/middleware/cookies"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/grafana/grafana/pkg/services/publicdashboards/validation"
@@ -50,6 +53,7 @@ func (api *Api) ViewPublicDashboard(c *contextmodel.ReqContext) response.Respons
 // 404: panelNotFoundPublicError
 // 404: notFoundPublicError
 // 403: forbiddenPublicError
+// 429: tooManyRequestsPublicError
 // 500: internalServerPublicError
 func (api *Api) QueryPublicDashboard(c *contextmodel.ReqContext) response.Response {
 	accessToken := web.Params(c.Req)[":accessToken"]
@@ -105,6 +109,53 @@ func (api *Api) GetPublicAnnotations(c *contextmodel.ReqContext) response.Respon
 	return response.JSON(http.StatusOK, annotations)
 }
 
+// swagger:route POST /public/dashboards/{accessToken}/passphrase dashboard_public unlockPublicDashboard
+//
+//	Unlock a passphrase protected public dashboard
+//
+// Sets a cookie that gives access to the public dashboard for 24 hours, until the passphrase changes or the public dashboard expires.
+//
+// Responses:
+// 200: okResponse
+// 400: badRequestPublicError
+// 401: unauthorisedPublicError
+// 403: forbiddenPublicError
+// 404: notFoundPublicError
+// 429: tooManyRequestsPublicError
+// 500: internalServerPublicError
+func (api *Api) UnlockPublicDashboard(c *contextmodel.ReqContext) response.Response {
+	accessToken := web.Params(c.Req)[":accessToken"]
+	if !validation.IsValidAccessToken(accessToken) {
+		return response.Err(ErrInvalidAccessToken.Errorf("UnlockPublicDashboard: invalid access token"))
+	}
+
+	reqDTO := PublicDashboardPassphraseDTO{}
+	if err := web.Bind(c.Req, &reqDTO); err != nil {
+		return response.Err(ErrBadRequest.Errorf("UnlockPublicDashboard: error parsing request: %v", err))
+	}
+
+	pubdash, err := api.PublicDashboardService.ValidatePassphrase(c.Req.Context(), accessToken, reqDTO.Passphrase, c.RemoteAddr())
+	if err != nil {
+		return response.Err(err)
+	}
+
+	if pubdash.PassphraseEnabled {
+		expiresAt := time.Now().Add(passphraseTokenTTL)
+		if pubdash.ExpiresAt != nil && pubdash.ExpiresAt.Before(expiresAt) {
+			expiresAt = *pubdash.ExpiresAt
+		}
+		token := newPassphraseToken(api.cfg.SecretKey, accessToken, pubdash, expiresAt)
+		maxAge := int(time.Until(expiresAt).Seconds())
+		cookies.WriteCookie(c.Resp, PassphraseCookieName, token, maxAge, func() cookies.CookieOptions {
+			options := cookies.NewCookieOptions()
+			options.Path = path.Join(options.Path, "api/public/dashboards", accessToken)
+			return options
+		})
+	}
+
+	return response.Success("Public dashboard unlocked")
+}
+
 // swagger:response viewPublicDashboardResponse
 type ViewPublicDashboardResponse struct {
 	// in: body
@@ -142,3 +193,12 @@ type GetPublicAnnotationsParams struct {
 	// in: path
 	AccessToken string `json:"accessToken"`
 }
+
+// swagger:parameters unlockPublicDashboard
+type UnlockPublicDashboardParams struct {
+	// in: path
+	AccessToken string `json:"accessToken"`
+	// in: body
+	// required: true
+	Body PublicDashboardPassphraseDTO
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -42,6 +43,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/quota/quotatest"
 	"github.com/grafana/grafana/pkg/services/tag/tagimpl"
 	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/web"
 )
 
@@ -251,6 +253,53 @@ func getValidQueryPath(accessToken string) string {
 	return fmt.Sprintf("/api/public/dashboards/%s/panels/2/query", accessToken)
 }
 
+func TestAPIUnlockPublicDashboard(t *testing.T) {
+	setup := func() (*web.Mux, *publicdashboards.FakePublicDashboardService) {
+		service := publicdashboards.NewFakePublicDashboardService(t)
+		cfg := setting.NewCfg()
+		cfg.PublicDashboardsEnabled = true
+		cfg.SecretKey = "secret"
+		testServer := setupTestServer(t, cfg, service, anonymousUser, true)
+
+		return testServer, service
+	}
+	path := fmt.Sprintf("/api/public/dashboards/%s/passphrase", validAccessToken)
+
+	t.Run("Sets passphrase cookie when the passphrase is right", func(t *testing.T) {
+		server, fakeDashboardService := setup()
+		pubdash := &PublicDashboard{PassphraseHash: "hash", PassphraseSalt: "salt", PassphraseEnabled: true}
+		fakeDashboardService.On("ValidatePassphrase", mock.Anything, validAccessToken, "incident-42", mock.Anything).Return(pubdash, nil)
+
+		resp := callAPI(server, http.MethodPost, path, strings.NewReader(`{"passphrase":"incident-42"}`), t)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		cookies := resp.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, PassphraseCookieName, cookies[0].Name)
+		assert.NotContains(t, cookies[0].Value, "hash")
+		assert.True(t, verifyPassphraseToken("secret", cookies[0].Value, validAccessToken, pubdash, time.Now()))
+		assert.False(t, verifyPassphraseToken("secret", cookies[0].Value, validAccessToken, pubdash, time.Now().Add(25*time.Hour)))
+		assert.Equal(t, "/api/public/dashboards/"+validAccessToken, cookies[0].Path)
+		assert.True(t, cookies[0].HttpOnly)
+	})
+
+	t.Run("Status code is 401 when the passphrase is wrong", func(t *testing.T) {
+		server, fakeDashboardService := setup()
+		fakeDashboardService.On("ValidatePassphrase", mock.Anything, validAccessToken, "wrong", mock.Anything).
+			Return(nil, ErrPublicDashboardWrongPassphrase.Errorf(""))
+
+		resp := callAPI(server, http.MethodPost, path, strings.NewReader(`{"passphrase":"wrong"}`), t)
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.Empty(t, resp.Result().Cookies())
+	})
+
+	t.Run("Status code is 400 when the access token is invalid", func(t *testing.T) {
+		server, _ := setup()
+		resp := callAPI(server, http.MethodPost, "/api/public/dashboards/SomeInvalidAccessToken/passphrase", strings.NewReader(`{}`), t)
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
 func TestIntegrationUnauthenticatedUserCanGetPubdashPanelQueryData(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -187,13 +188,13 @@ func (d *PublicDashboardStoreImpl) ExistsEnabledByDashboardUid(ctx context.Conte
 	return hasPublicDashboard, err
 }
 
-// ExistsEnabledByAccessToken Responds true if the accessToken exists and the public dashboard is enabled
+// ExistsEnabledByAccessToken Responds true if the accessToken exists and the public dashboard is enabled and not expired
 func (d *PublicDashboardStoreImpl) ExistsEnabledByAccessToken(ctx context.Context, accessToken string) (bool, error) {
 	hasPublicDashboard := false
 	err := d.sqlStore.WithDbSession(ctx, func(dbSession *db.Session) error {
-		sql := "SELECT COUNT(*) FROM dashboard_public WHERE access_token=? AND is_enabled=true"
+		sql := "SELECT COUNT(*) FROM dashboard_public WHERE access_token=? AND is_enabled=true AND (expires_at IS NULL OR expires_at > ?)"
 
-		result, err := dbSession.SQL(sql, accessToken).Count()
+		result, err := dbSession.SQL(sql, accessToken, time.Now().UTC().Format("2006-01-02 15:04:05")).Count()
 		if err != nil {
 			return err
 		}
@@ -247,12 +248,27 @@ func (d *PublicDashboardStoreImpl) Update(ctx context.Context, cmd SavePublicDas
 			return err
 		}
 
-		sqlResult, err := sess.Exec("UPDATE dashboard_public SET is_enabled = ?, annotations_enabled = ?, time_selection_enabled = ?, share = ?, time_settings = ?, updated_by = ?, updated_at = ? WHERE uid = ?",
+		templateVariablesJSON, err := json.Marshal(cmd.PublicDashboard.TemplateVariables)
+		if err != nil {
+			return err
+		}
+
+		var expiresAt any
+		if cmd.PublicDashboard.ExpiresAt != nil {
+			expiresAt = cmd.PublicDashboard.ExpiresAt.UTC().Format("2006-01-02 15:04:05")
+		}
+
+		sqlResult, err := sess.Exec("UPDATE dashboard_public SET is_enabled = ?, annotations_enabled = ?, time_selection_enabled = ?, share = ?, time_settings = ?, template_variables = ?, expires_at = ?, passphrase_hash = ?, passphrase_salt = ?, query_rate_limit = ?, updated_by = ?, updated_at = ? WHERE uid = ?",
 			cmd.PublicDashboard.IsEnabled,
 			cmd.PublicDashboard.AnnotationsEnabled,
 			cmd.PublicDashboard.TimeSelectionEnabled,
 			cmd.PublicDashboard.Share,
 			string(timeSettingsJSON),
+			string(templateVariablesJSON),
+			expiresAt,
+			cmd.PublicDashboard.PassphraseHash,
+			cmd.PublicDashboard.PassphraseSalt,
+			cmd.PublicDashboard.QueryRateLimit,
 			cmd.PublicDashboard.UpdatedBy,
 			cmd.PublicDashboard.UpdatedAt.UTC().Format("2006-01-02 15:04:05"),
 			cmd.PublicDashboard.Uid)
@@ -269,6 +285,14 @@ func (d *PublicDashboardStoreImpl) Update(ctx context.Context, cmd SavePublicDas
 	return affectedRows, err
 }
 
+// IncrementUsage adds views and queries to the usage counters of a public dashboard
+func (d *PublicDashboardStoreImpl) IncrementUsage(ctx context.Context, uid string, views int64, queries int64) error {
+	return d.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("UPDATE dashboard_public SET view_count = view_count + ?, query_count = query_count + ? WHERE uid = ?", views, queries, uid)
+		return err
+	})
+}
+
 // Deletes a public dashboard
 func (d *PublicDashboardStoreImpl) Delete(ctx context.Context, uid string) (int64, error) {
 	dashboard := &PublicDashboard{Uid: uid}
@@ -230,6 +230,30 @@ func TestIntegrationExistsEnabledByAccessToken(t *testing.T) {
 		require.False(t, res)
 	})
 
+	t.Run("ExistsEnabledByAccessToken will return false when the public dashboard is expired", func(t *testing.T) {
+		setup()
+
+		expiresAt := time.Now().Add(-time.Hour)
+		_, err := publicdashboardStore.Create(context.Background(), SavePublicDashboardCommand{
+			PublicDashboard: PublicDashboard{
+				IsEnabled:    true,
+				Uid:          "abc123",
+				DashboardUid: savedDashboard.UID,
+				OrgId:        savedDashboard.OrgID,
+				CreatedAt:    time.Now(),
+				CreatedBy:    7,
+				AccessToken:  "accessToken",
+				ExpiresAt:    &expiresAt,
+			},
+		})
+		require.NoError(t, err)
+
+		res, err := publicdashboardStore.ExistsEnabledByAccessToken(context.Background(), "accessToken")
+		require.NoError(t, err)
+
+		require.False(t, res)
+	})
+
 	t.Run("ExistsEnabledByAccessToken will return false when no public dashboard has matching access token", func(t *testing.T) {
 		setup()
 
@@ -584,6 +608,7 @@ func TestIntegrationUpdatePublicDashboard(t *testing.T) {
 		require.NoError(t, err)
 		assert.EqualValues(t, affectedRows, 1)
 
+		expiresAt := time.Now().Add(time.Hour).UTC().Round(time.Second)
 		updatedPublicDashboard := PublicDashboard{
 			Uid:                  pdUid,
 			DashboardUid:         savedDashboard.UID,
@@ -593,6 +618,11 @@ func TestIntegrationUpdatePublicDashboard(t *testing.T) {
 			TimeSelectionEnabled: true,
 			Share:                EmailShareType,
 			TimeSettings:         &TimeSettings{From: "now-8", To: "now"},
+			TemplateVariables:    &TemplateVariables{{Name: "customer", AllowedValues: []string{"a", "b"}}},
+			ExpiresAt:            &expiresAt,
+			PassphraseHash:       "hash",
+			PassphraseSalt:       "salt",
+			QueryRateLimit:       60,
 			UpdatedAt:            time.Now().UTC().Round(time.Second),
 			UpdatedBy:            8,
 		}
@@ -614,6 +644,12 @@ func TestIntegrationUpdatePublicDashboard(t *testing.T) {
 		assert.Equal(t, updatedPublicDashboard.AnnotationsEnabled, pdRetrieved.AnnotationsEnabled)
 		assert.Equal(t, updatedPublicDashboard.TimeSelectionEnabled, pdRetrieved.TimeSelectionEnabled)
 		assert.Equal(t, updatedPublicDashboard.Share, pdRetrieved.Share)
+		assert.Equal(t, updatedPublicDashboard.TemplateVariables, pdRetrieved.TemplateVariables)
+		assert.Equal(t, updatedPublicDashboard.ExpiresAt.Unix(), pdRetrieved.ExpiresAt.Unix())
+		assert.Equal(t, updatedPublicDashboard.PassphraseHash, pdRetrieved.PassphraseHash)
+		assert.Equal(t, updatedPublicDashboard.PassphraseSalt, pdRetrieved.PassphraseSalt)
+		assert.True(t, pdRetrieved.PassphraseEnabled)
+		assert.Equal(t, updatedPublicDashboard.QueryRateLimit, pdRetrieved.QueryRateLimit)
 
 		// not updated dashboard shouldn't have changed
 		pdNotUpdatedRetrieved, err := publicdashboardStore.FindByDashboardUid(context.Background(), anotherSavedDashboard.OrgID, anotherSavedDashboard.UID)
@@ -625,6 +661,28 @@ func TestIntegrationUpdatePublicDashboard(t *testing.T) {
 	})
 }
 
+func TestIntegrationIncrementUsage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	sqlStore, cfg := db.InitTestDBWithCfg(t)
+	dashboardStore, err := dashboardsDB.ProvideDashboardStore(sqlStore, cfg, featuremgmt.WithFeatures(), tagimpl.ProvideService(sqlStore), quotatest.New(false, nil))
+	require.NoError(t, err)
+	publicdashboardStore := ProvideStore(sqlStore, cfg, featuremgmt.WithFeatures())
+	savedDashboard := insertTestDashboard(t, dashboardStore, "testDashie", 1, "", true)
+	pubdash := insertPublicDashboard(t, publicdashboardStore, savedDashboard.UID, savedDashboard.OrgID, true, PublicShareType)
+
+	err = publicdashboardStore.IncrementUsage(context.Background(), pubdash.Uid, 1, 0)
+	require.NoError(t, err)
+	err = publicdashboardStore.IncrementUsage(context.Background(), pubdash.Uid, 0, 3)
+	require.NoError(t, err)
+
+	pubdash, err = publicdashboardStore.Find(context.Background(), pubdash.Uid)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pubdash.ViewCount)
+	assert.Equal(t, int64(3), pubdash.QueryCount)
+}
+
 func TestIntegrationGetOrgIdByAccessToken(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -16,6 +16,8 @@ var (
 	ErrInvalidUid                          = errutil.BadRequest("publicdashboards.invalidUid", errutil.WithPublicMessage("Invalid Uid"))
 	ErrPublicDashboardIdentifierNotSet     = errutil.BadRequest("publicdashboards.identifierNotSet", errutil.WithPublicMessage("No Uid for dashboard specified"))
 	ErrPublicDashboardHasTemplateVariables = errutil.BadRequest("publicdashboards.hasTemplateVariables", errutil.WithPublicMessage("Dashboard has template variables"))
+	ErrInvalidTemplateVariable             = errutil.BadRequest("publicdashboards.invalidTemplateVariable", errutil.WithPublicMessage("Invalid template variable"))
+	ErrInvalidTemplateVariableValue        = errutil.BadRequest("publicdashboards.invalidTemplateVariableValue", errutil.WithPublicMessage("Template variable value is not allowed"))
 	ErrInvalidInterval                     = errutil.BadRequest("publicdashboards.invalidInterval", errutil.WithPublicMessage("intervalMS should be greater than 0"))
 	ErrInvalidMaxDataPoints                = errutil.BadRequest("publicdashboards.maxDataPoints", errutil.WithPublicMessage("maxDataPoints should be greater than 0"))
 	ErrInvalidTimeRange                    = errutil.BadRequest("publicdashboards.invalidTimeRange", errutil.WithPublicMessage("Invalid time range"))
@@ -23,6 +25,16 @@ var (
 	ErrDashboardIsPublic                   = errutil.BadRequest("publicdashboards.dashboardIsPublic", errutil.WithPublicMessage("Dashboard is already public"))
 	ErrPublicDashboardUidExists            = errutil.BadRequest("publicdashboards.uidExists", errutil.WithPublicMessage("Dashboard Uid already exists"))
 	ErrPublicDashboardAccessTokenExists    = errutil.BadRequest("publicdashboards.accessTokenExists", errutil.WithPublicMessage("Dashboard Access Token already exists"))
+	ErrInvalidExpiresAt                    = errutil.BadRequest("publicdashboards.invalidExpiresAt", errutil.WithPublicMessage("Expiration time should be in the future"))
+	ErrInvalidPassphrase                   = errutil.BadRequest("publicdashboards.invalidPassphrase", errutil.WithPublicMessage("Passphrase should have between 8 and 128 characters"))
+	ErrInvalidQueryRateLimit               = errutil.BadRequest("publicdashboards.invalidQueryRateLimit", errutil.WithPublicMessage("Query rate limit should be greater than or equal to 0"))
+
+	ErrPublicDashboardPassphraseRequired = errutil.Unauthorized("publicdashboards.passphraseRequired", errutil.WithPublicMessage("Passphrase required"))
+	ErrPublicDashboardWrongPassphrase    = errutil.Unauthorized("publicdashboards.wrongPassphrase", errutil.WithPublicMessage("Wrong passphrase"))
+	ErrPassphraseAttemptsExceeded        = errutil.TooManyRequests("publicdashboards.passphraseAttemptsExceeded", errutil.WithPublicMessage("Too many passphrase attempts, try again later"))
 
 	ErrPublicDashboardNotEnabled = errutil.Forbidden("publicdashboards.notEnabled", errutil.WithPublicMessage("Dashboard paused"))
+	ErrPublicDashboardExpired    = errutil.Forbidden("publicdashboards.expired", errutil.WithPublicMessage("Dashboard link expired"))
+
+	ErrQueryRateLimitExceeded = errutil.TooManyRequests("publicdashboards.queryRateLimitExceeded", errutil.WithPublicMessage("Too many queries, try again later"))
 )
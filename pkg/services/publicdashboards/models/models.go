@@ -48,12 +48,21 @@ type PublicDashboard struct {
 	CreatedAt    time.Time `json:"createdAt" xorm:"created_at"`
 	UpdatedAt    time.Time `json:"updatedAt" xorm:"updated_at"`
 	//config fields
-	TimeSettings         *TimeSettings `json:"-" xorm:"time_settings"`
-	TimeSelectionEnabled bool          `json:"timeSelectionEnabled" xorm:"time_selection_enabled"`
-	IsEnabled            bool          `json:"isEnabled" xorm:"is_enabled"`
-	AnnotationsEnabled   bool          `json:"annotationsEnabled" xorm:"annotations_enabled"`
-	Share                ShareType     `json:"share" xorm:"share"`
-	Recipients           []EmailDTO    `json:"recipients,omitempty" xorm:"-"`
+	TimeSettings         *TimeSettings      `json:"-" xorm:"time_settings"`
+	TimeSelectionEnabled bool               `json:"timeSelectionEnabled" xorm:"time_selection_enabled"`
+	IsEnabled            bool               `json:"isEnabled" xorm:"is_enabled"`
+	AnnotationsEnabled   bool               `json:"annotationsEnabled" xorm:"annotations_enabled"`
+	Share                ShareType          `json:"share" xorm:"share"`
+	TemplateVariables    *TemplateVariables `json:"templateVariables,omitempty" xorm:"template_variables"`
+	ExpiresAt            *time.Time         `json:"expiresAt,omitempty" xorm:"expires_at"`
+	PassphraseHash       string             `json:"-" xorm:"passphrase_hash"`
+	PassphraseSalt       string             `json:"-" xorm:"passphrase_salt"`
+	PassphraseEnabled    bool               `json:"passphraseEnabled" xorm:"-"`
+	QueryRateLimit       int                `json:"queryRateLimit" xorm:"query_rate_limit"`
+	Recipients           []EmailDTO         `json:"recipients,omitempty" xorm:"-"`
+	//usage fields
+	ViewCount  int64 `json:"viewCount" xorm:"view_count"`
+	QueryCount int64 `json:"queryCount" xorm:"query_count"`
 }
 
 type PublicDashboardDTO struct {
@@ -63,6 +72,19 @@ type PublicDashboardDTO struct {
 	IsEnabled            *bool     `json:"isEnabled"`
 	AnnotationsEnabled   *bool     `json:"annotationsEnabled"`
 	Share                ShareType `json:"share"`
+	// TemplateVariables replaces the template variables viewers can change if set.
+	TemplateVariables *TemplateVariables `json:"templateVariables"`
+	// ExpiresAt replaces the expiration time if set, the zero time removes it.
+	ExpiresAt *time.Time `json:"expiresAt"`
+	// Passphrase replaces the passphrase viewers have to enter if set, an empty passphrase removes it.
+	Passphrase *string `json:"passphrase"`
+	// QueryRateLimit is the maximum number of queries per minute, 0 means unlimited.
+	QueryRateLimit *int `json:"queryRateLimit"`
+}
+
+// PublicDashboardPassphraseDTO is the passphrase entered by a viewer to unlock a public dashboard.
+type PublicDashboardPassphraseDTO struct {
+	Passphrase string `json:"passphrase"`
 }
 
 type EmailDTO struct {
@@ -96,6 +118,16 @@ func (pd PublicDashboard) TableName() string {
 	return "dashboard_public"
 }
 
+// AfterLoad sets the fields derived from the database columns.
+func (pd *PublicDashboard) AfterLoad() {
+	pd.PassphraseEnabled = pd.PassphraseHash != ""
+}
+
+// IsExpired returns true if the public dashboard has an expiration time before t.
+func (pd PublicDashboard) IsExpired(t time.Time) bool {
+	return pd.ExpiresAt != nil && !pd.ExpiresAt.After(t)
+}
+
 type PublicDashboardListQuery struct {
 	OrgID  int64
 	Query  string
@@ -134,6 +166,39 @@ func (ts *TimeSettings) ToDB() ([]byte, error) {
 	return json.Marshal(ts)
 }
 
+// TemplateVariable is a dashboard template variable that viewers of the public dashboard can change
+// to one or more of the allowed values.
+type TemplateVariable struct {
+	Name          string   `json:"name"`
+	AllowedValues []string `json:"allowedValues"`
+}
+
+type TemplateVariables []TemplateVariable
+
+// Find returns the template variable with the name.
+func (tv *TemplateVariables) Find(name string) (TemplateVariable, bool) {
+	if tv == nil {
+		return TemplateVariable{}, false
+	}
+	for _, v := range *tv {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return TemplateVariable{}, false
+}
+
+func (tv *TemplateVariables) FromDB(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, tv)
+}
+
+func (tv *TemplateVariables) ToDB() ([]byte, error) {
+	return json.Marshal(tv)
+}
+
 // DTO for transforming user input in the api
 type SavePublicDashboardDTO struct {
 	Uid             string
@@ -154,6 +219,8 @@ type PublicDashboardQueryDTO struct {
 	MaxDataPoints   int64
 	QueryCachingTTL int64
 	TimeRange       TimeRangeDTO
+	// Variables are the values of template variables selected by the viewer.
+	Variables map[string][]string
 }
 
 type AnnotationsQueryDTO struct {
@@ -2,6 +2,7 @@ package models
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -9,3 +10,14 @@ import (
 func TestPublicDashboardTableName(t *testing.T) {
 	assert.Equal(t, "dashboard_public", PublicDashboard{}.TableName())
 }
+
+func TestPublicDashboardIsExpired(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	assert.False(t, PublicDashboard{}.IsExpired(now))
+	assert.True(t, PublicDashboard{ExpiresAt: &past}.IsExpired(now))
+	assert.True(t, PublicDashboard{ExpiresAt: &now}.IsExpired(now))
+	assert.False(t, PublicDashboard{ExpiresAt: &future}.IsExpired(now))
+}
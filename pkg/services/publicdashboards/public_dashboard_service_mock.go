@@ -525,6 +525,32 @@ func (_m *FakePublicDashboardService) Update(ctx context.Context, u *user.Signed
 	return r0, r1
 }
 
+// ValidatePassphrase provides a mock function with given fields: ctx, accessToken, passphrase, clientIP
+func (_m *FakePublicDashboardService) ValidatePassphrase(ctx context.Context, accessToken string, passphrase string, clientIP string) (*models.PublicDashboard, error) {
+	ret := _m.Called(ctx, accessToken, passphrase, clientIP)
+
+	var r0 *models.PublicDashboard
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.PublicDashboard, error)); ok {
+		return rf(ctx, accessToken, passphrase, clientIP)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.PublicDashboard); ok {
+		r0 = rf(ctx, accessToken, passphrase, clientIP)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PublicDashboard)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, accessToken, passphrase, clientIP)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewFakePublicDashboardService creates a new instance of FakePublicDashboardService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewFakePublicDashboardService(t interface {
@@ -290,6 +290,20 @@ func (_m *FakePublicDashboardStore) GetOrgIdByAccessToken(ctx context.Context, a
 	return r0, r1
 }
 
+// IncrementUsage provides a mock function with given fields: ctx, uid, views, queries
+func (_m *FakePublicDashboardStore) IncrementUsage(ctx context.Context, uid string, views int64, queries int64) error {
+	ret := _m.Called(ctx, uid, views, queries)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) error); ok {
+		r0 = rf(ctx, uid, views, queries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, cmd
 func (_m *FakePublicDashboardStore) Update(ctx context.Context, cmd models.SavePublicDashboardCommand) (int64, error) {
 	ret := _m.Called(ctx, cmd)
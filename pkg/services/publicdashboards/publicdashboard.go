@@ -35,6 +35,7 @@ type Service interface {
 	GetOrgIdByAccessToken(ctx context.Context, accessToken string) (int64, error)
 	NewPublicDashboardAccessToken(ctx context.Context) (string, error)
 	NewPublicDashboardUid(ctx context.Context) (string, error)
+	ValidatePassphrase(ctx context.Context, accessToken string, passphrase string, clientIP string) (*PublicDashboard, error)
 
 	ExistsEnabledByAccessToken(ctx context.Context, accessToken string) (bool, error)
 	ExistsEnabledByDashboardUid(ctx context.Context, dashboardUid string) (bool, error)
@@ -57,6 +58,7 @@ type Store interface {
 	Create(ctx context.Context, cmd SavePublicDashboardCommand) (int64, error)
 	Update(ctx context.Context, cmd SavePublicDashboardCommand) (int64, error)
 	Delete(ctx context.Context, uid string) (int64, error)
+	IncrementUsage(ctx context.Context, uid string, views int64, queries int64) error
 
 	GetOrgIdByAccessToken(ctx context.Context, accessToken string) (int64, error)
 	FindByFolder(ctx context.Context, orgId int64, folderUid string) ([]*PublicDashboard, error)
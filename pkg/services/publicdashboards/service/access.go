@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	// maximum number of passphrase attempts per minute from a client for a public dashboard, to slow down brute force attacks
+	passphraseAttemptsPerMinute = 10
+	// maximum number of passphrase attempts per minute for a public dashboard, to slow down brute force attacks from many clients
+	passphraseAttemptsPerMinuteTotal = 100
+)
+
+// usageFlushInterval is how often the usage counted in memory is written to the database
+const usageFlushInterval = time.Minute
+
+// ValidatePassphrase checks the passphrase entered by a viewer matches the passphrase of the public dashboard.
+// The attempts are limited per client IP and per public dashboard.
+func (pd *PublicDashboardServiceImpl) ValidatePassphrase(ctx context.Context, accessToken string, passphrase string, clientIP string) (*PublicDashboard, error) {
+	ctx, span := tracer.Start(ctx, "publicdashboards.ValidatePassphrase")
+	defer span.End()
+	pubdash, _, err := pd.FindEnabledPublicDashboardAndDashboardByAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pubdash.PassphraseEnabled {
+		return pubdash, nil
+	}
+
+	if !pd.rateLimiter.Allow("passphrase:"+accessToken+":"+clientIP, passphraseAttemptsPerMinute) ||
+		!pd.rateLimiter.Allow("passphrase:"+accessToken, passphraseAttemptsPerMinuteTotal) {
+		return nil, ErrPassphraseAttemptsExceeded.Errorf("ValidatePassphrase: too many passphrase attempts accessToken: %s", accessToken)
+	}
+
+	hash, err := util.EncodePassword(passphrase, pubdash.PassphraseSalt)
+	if err != nil {
+		return nil, ErrInternalServerError.Errorf("ValidatePassphrase: failed to encode passphrase: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(pubdash.PassphraseHash)) != 1 {
+		return nil, ErrPublicDashboardWrongPassphrase.Errorf("ValidatePassphrase: wrong passphrase accessToken: %s", accessToken)
+	}
+
+	return pubdash, nil
+}
+
+// encodePassphrase returns the hash and the salt of the passphrase, or empty values to remove the passphrase
+func encodePassphrase(passphrase string) (string, string, error) {
+	if passphrase == "" {
+		return "", "", nil
+	}
+
+	salt, err := util.GetRandomString(10)
+	if err != nil {
+		return "", "", err
+	}
+
+	hash, err := util.EncodePassword(passphrase, salt)
+	if err != nil {
+		return "", "", err
+	}
+
+	return hash, salt, nil
+}
+
+// recordUsage adds views and queries to the usage counters of the public dashboard. They are counted in memory
+// and written to the database by Run
+func (pd *PublicDashboardServiceImpl) recordUsage(pubdash *PublicDashboard, views int64, queries int64) {
+	pd.usage.add(pubdash.Uid, views, queries)
+}
+
+// Run writes the usage of the public dashboards to the database periodically, and when the service stops
+func (pd *PublicDashboardServiceImpl) Run(ctx context.Context) error {
+	ticker := time.NewTicker(usageFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			pd.flushUsage(context.Background())
+			return ctx.Err()
+		case <-ticker.C:
+			pd.flushUsage(ctx)
+		}
+	}
+}
+
+// IsDisabled returns true when public dashboards are disabled, nothing is recorded then
+func (pd *PublicDashboardServiceImpl) IsDisabled() bool {
+	return !pd.cfg.PublicDashboardsEnabled
+}
+
+// flushUsage writes the usage counted since the last flush to the database. Failing to record usage should not
+// fail requests so errors are only logged, and the usage is kept for the next flush
+func (pd *PublicDashboardServiceImpl) flushUsage(ctx context.Context) {
+	for uid, u := range pd.usage.take() {
+		if err := pd.store.IncrementUsage(ctx, uid, u.views, u.queries); err != nil {
+			pd.log.Warn("Failed to record public dashboard usage", "publicDashboardUid", uid, "error", err)
+			pd.usage.add(uid, u.views, u.queries)
+		}
+	}
+}
+
+// usageCounter counts the views and queries of each public dashboard. The zero value is ready to use
+type usageCounter struct {
+	mu     sync.Mutex
+	counts map[string]usage
+}
+
+type usage struct {
+	views   int64
+	queries int64
+}
+
+func (c *usageCounter) add(uid string, views int64, queries int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts == nil {
+		c.counts = make(map[string]usage)
+	}
+	u := c.counts[uid]
+	u.views += views
+	u.queries += queries
+	c.counts[uid] = u
+}
+
+// take returns the usage counted so far and resets the counters
+func (c *usageCounter) take() map[string]usage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := c.counts
+	c.counts = nil
+	return counts
+}
+
+// rateLimiter limits the number of events per minute of each key. The zero value is ready to use.
+// The limits are enforced by each Grafana instance: with several instances behind a load balancer, up to
+// the number of instances times the limit is allowed.
+type rateLimiter struct {
+	mu        sync.Mutex
+	limiters  map[string]*limiterEntry
+	lastSweep time.Time
+}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// Allow reports whether an event of the key may happen now, perMinute being the maximum number of events
+// per minute. A limit of 0 or less allows all events
+func (l *rateLimiter) Allow(key string, perMinute int) bool {
+	if perMinute <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.limiters == nil {
+		l.limiters = make(map[string]*limiterEntry)
+	}
+	// limiters unused for a minute are full again, so they can be dropped
+	if now.Sub(l.lastSweep) > time.Minute {
+		for k, entry := range l.limiters {
+			if now.Sub(entry.lastUsed) > time.Minute {
+				delete(l.limiters, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	entry, ok := l.limiters[key]
+	// the limit of the public dashboard might have been updated since the limiter was created
+	if !ok || entry.limiter.Burst() != perMinute {
+		entry = &limiterEntry{limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(perMinute)), perMinute)}
+		l.limiters[key] = entry
+	}
+	entry.lastUsed = now
+
+	return entry.limiter.AllowN(now, 1)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashboardsDB "github.com/grafana/grafana/pkg/services/dashboards/database"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/publicdashboards"
+	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
+	"github.com/grafana/grafana/pkg/services/quota/quotatest"
+	"github.com/grafana/grafana/pkg/services/tag/tagimpl"
+)
+
+func TestPublicDashboardAccessSettings(t *testing.T) {
+	fakeDashboardService := &dashboards.FakeDashboardService{}
+	service, sqlStore, cfg := newPublicDashboardServiceImpl(t, nil, fakeDashboardService, nil)
+
+	dashboardStore, err := dashboardsDB.ProvideDashboardStore(sqlStore, cfg, featuremgmt.WithFeatures(), tagimpl.ProvideService(sqlStore), quotatest.New(false, nil))
+	require.NoError(t, err)
+	dashboard := insertTestDashboard(t, dashboardStore, "testDashie", 1, 0, "", true, []map[string]any{}, nil)
+	fakeDashboardService.On("GetDashboard", mock.Anything, mock.Anything, mock.Anything).Return(dashboard, nil)
+
+	isEnabled := true
+	passphrase := "incident-42"
+	rateLimit := 30
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Round(time.Second)
+
+	savedPubdash, err := service.Create(context.Background(), SignedInUser, &SavePublicDashboardDTO{
+		DashboardUid: dashboard.UID,
+		UserId:       7,
+		PublicDashboard: &PublicDashboardDTO{
+			IsEnabled:      &isEnabled,
+			ExpiresAt:      &expiresAt,
+			Passphrase:     &passphrase,
+			QueryRateLimit: &rateLimit,
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("Create saves the access settings", func(t *testing.T) {
+		require.NotNil(t, savedPubdash.ExpiresAt)
+		assert.True(t, expiresAt.Equal(*savedPubdash.ExpiresAt))
+		assert.True(t, savedPubdash.PassphraseEnabled)
+		assert.NotEqual(t, passphrase, savedPubdash.PassphraseHash)
+		assert.Equal(t, rateLimit, savedPubdash.QueryRateLimit)
+	})
+
+	t.Run("ValidatePassphrase returns the public dashboard when the passphrase is right", func(t *testing.T) {
+		pubdash, err := service.ValidatePassphrase(context.Background(), savedPubdash.AccessToken, passphrase, "10.0.0.1")
+		require.NoError(t, err)
+		assert.Equal(t, savedPubdash.PassphraseHash, pubdash.PassphraseHash)
+	})
+
+	t.Run("ValidatePassphrase returns error when the passphrase is wrong", func(t *testing.T) {
+		_, err := service.ValidatePassphrase(context.Background(), savedPubdash.AccessToken, "wrong-passphrase", "10.0.0.1")
+		require.ErrorIs(t, err, ErrPublicDashboardWrongPassphrase)
+	})
+
+	t.Run("ValidatePassphrase limits the attempts of each client", func(t *testing.T) {
+		for i := 0; i < passphraseAttemptsPerMinute; i++ {
+			_, err := service.ValidatePassphrase(context.Background(), savedPubdash.AccessToken, "wrong-passphrase", "10.0.0.2")
+			require.ErrorIs(t, err, ErrPublicDashboardWrongPassphrase)
+		}
+		_, err := service.ValidatePassphrase(context.Background(), savedPubdash.AccessToken, passphrase, "10.0.0.2")
+		require.ErrorIs(t, err, ErrPassphraseAttemptsExceeded)
+
+		_, err = service.ValidatePassphrase(context.Background(), savedPubdash.AccessToken, passphrase, "10.0.0.3")
+		require.NoError(t, err)
+	})
+
+	t.Run("Update keeps the access settings that are not set", func(t *testing.T) {
+		updatedPubdash, err := service.Update(context.Background(), SignedInUser, &SavePublicDashboardDTO{
+			Uid:             savedPubdash.Uid,
+			DashboardUid:    dashboard.UID,
+			UserId:          8,
+			PublicDashboard: &PublicDashboardDTO{},
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, updatedPubdash.ExpiresAt)
+		assert.True(t, savedPubdash.ExpiresAt.Equal(*updatedPubdash.ExpiresAt))
+		assert.Equal(t, savedPubdash.PassphraseHash, updatedPubdash.PassphraseHash)
+		assert.Equal(t, savedPubdash.QueryRateLimit, updatedPubdash.QueryRateLimit)
+	})
+
+	t.Run("Update removes the expiration time and the passphrase", func(t *testing.T) {
+		noPassphrase := ""
+		noRateLimit := 0
+		updatedPubdash, err := service.Update(context.Background(), SignedInUser, &SavePublicDashboardDTO{
+			Uid:          savedPubdash.Uid,
+			DashboardUid: dashboard.UID,
+			UserId:       8,
+			PublicDashboard: &PublicDashboardDTO{
+				ExpiresAt:      &time.Time{},
+				Passphrase:     &noPassphrase,
+				QueryRateLimit: &noRateLimit,
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Nil(t, updatedPubdash.ExpiresAt)
+		assert.False(t, updatedPubdash.PassphraseEnabled)
+		assert.Equal(t, 0, updatedPubdash.QueryRateLimit)
+
+		_, err = service.ValidatePassphrase(context.Background(), savedPubdash.AccessToken, "", "10.0.0.1")
+		require.NoError(t, err)
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("allows all events without limit", func(t *testing.T) {
+		var limiter rateLimiter
+		for i := 0; i < 100; i++ {
+			require.True(t, limiter.Allow("token", 0))
+		}
+	})
+
+	t.Run("limits the events of each key", func(t *testing.T) {
+		var limiter rateLimiter
+		assert.True(t, limiter.Allow("token", 2))
+		assert.True(t, limiter.Allow("token", 2))
+		assert.False(t, limiter.Allow("token", 2))
+		assert.True(t, limiter.Allow("anotherToken", 2))
+	})
+
+	t.Run("applies an updated limit", func(t *testing.T) {
+		var limiter rateLimiter
+		assert.True(t, limiter.Allow("token", 1))
+		assert.False(t, limiter.Allow("token", 1))
+		assert.True(t, limiter.Allow("token", 5))
+	})
+}
+
+func TestRecordUsage(t *testing.T) {
+	fakeStore := &publicdashboards.FakePublicDashboardStore{}
+	service := &PublicDashboardServiceImpl{store: fakeStore, log: log.New("test.logger")}
+
+	service.recordUsage(&PublicDashboard{Uid: "one"}, 1, 0)
+	service.recordUsage(&PublicDashboard{Uid: "one"}, 0, 1)
+	service.recordUsage(&PublicDashboard{Uid: "one"}, 0, 1)
+	service.recordUsage(&PublicDashboard{Uid: "two"}, 1, 0)
+
+	fakeStore.On("IncrementUsage", mock.Anything, "one", int64(1), int64(2)).Return(nil).Once()
+	fakeStore.On("IncrementUsage", mock.Anything, "two", int64(1), int64(0)).Return(errors.New("database is locked")).Once()
+	service.flushUsage(context.Background())
+	fakeStore.AssertExpectations(t)
+
+	// the usage that failed to be written is kept for the next flush
+	fakeStore.On("IncrementUsage", mock.Anything, "two", int64(1), int64(0)).Return(nil).Once()
+	service.flushUsage(context.Background())
+	fakeStore.AssertExpectations(t)
+
+	// nothing is written when there is no usage
+	service.flushUsage(context.Background())
+	fakeStore.AssertNumberOfCalls(t, "IncrementUsage", 3)
+}
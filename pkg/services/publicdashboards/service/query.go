@@ -130,6 +130,12 @@ func (pd *PublicDashboardServiceImpl) GetQueryDataResponse(ctx context.Context,
 		return nil, err
 	}
 
+	if !pd.rateLimiter.Allow(accessToken, publicDashboard.QueryRateLimit) {
+		return nil, models.ErrQueryRateLimitExceeded.Errorf("GetQueryDataResponse: query rate limit of %d per minute exceeded accessToken: %s", publicDashboard.QueryRateLimit, accessToken)
+	}
+
+	pd.recordUsage(publicDashboard, 0, 1)
+
 	metricReq, err := pd.GetMetricRequest(ctx, dashboard, publicDashboard, panelId, queryDto)
 	if err != nil {
 		return nil, err
@@ -163,11 +169,19 @@ func (pd *PublicDashboardServiceImpl) buildMetricRequest(dashboard *dashboards.D
 		return dtos.MetricRequest{}, models.ErrPanelNotFound.Errorf("buildMetricRequest: public dashboard panel not found")
 	}
 
+	variables, err := getTemplateVariableValues(dashboard.Data, publicDashboard, reqDTO)
+	if err != nil {
+		return dtos.MetricRequest{}, err
+	}
+
 	ts := buildTimeSettings(dashboard, reqDTO, publicDashboard)
 
 	// determine safe resolution to query data at
 	safeInterval, safeResolution := pd.getSafeIntervalAndMaxDataPoints(reqDTO, ts)
 	for i := range queries {
+		if err := interpolateTemplateVariables(queries[i], variables); err != nil {
+			return dtos.MetricRequest{}, err
+		}
 		queries[i].Set("intervalMs", safeInterval)
 		queries[i].Set("maxDataPoints", safeResolution)
 		queries[i].Set("queryCachingTTL", reqDTO.QueryCachingTTL)
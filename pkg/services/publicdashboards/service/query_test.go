@@ -727,6 +727,50 @@ func TestGetQueryDataResponse(t *testing.T) {
 		resp, _ := service.GetQueryDataResponse(context.Background(), true, publicDashboardQueryDTO, 1, pubdashDto.AccessToken)
 		require.NotNil(t, resp)
 	})
+
+	t.Run("Counts queries and returns error when the query rate limit is exceeded", func(t *testing.T) {
+		customPanels := []interface{}{
+			map[string]interface{}{
+				"id": 1,
+				"datasource": map[string]interface{}{
+					"uid": "ds1",
+				},
+				"targets": []interface{}{map[string]interface{}{
+					"datasource": map[string]interface{}{
+						"uid": "ds1",
+					},
+					"refId": "A",
+				}},
+			}}
+
+		dashboard := insertTestDashboard(t, dashboardStore, "testDashWithRateLimit", 1, 0, "", true, []map[string]interface{}{}, customPanels)
+		fakeDashboardService.ExpectedCalls = nil
+		fakeDashboardService.On("GetDashboard", mock.Anything, mock.Anything, mock.Anything).Return(dashboard, nil)
+
+		isEnabled := true
+		rateLimit := 1
+		dto := &SavePublicDashboardDTO{
+			DashboardUid: dashboard.UID,
+			UserId:       7,
+			OrgID:        dashboard.OrgID,
+			PublicDashboard: &PublicDashboardDTO{
+				IsEnabled:      &isEnabled,
+				QueryRateLimit: &rateLimit,
+			},
+		}
+		pubdashDto, err := service.Create(context.Background(), SignedInUser, dto)
+		require.NoError(t, err)
+
+		_, err = service.GetQueryDataResponse(context.Background(), true, publicDashboardQueryDTO, 1, pubdashDto.AccessToken)
+		require.NoError(t, err)
+
+		_, err = service.GetQueryDataResponse(context.Background(), true, publicDashboardQueryDTO, 1, pubdashDto.AccessToken)
+		require.ErrorIs(t, err, ErrQueryRateLimitExceeded)
+
+		pubdash, err := service.store.Find(context.Background(), pubdashDto.Uid)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), pubdash.QueryCount)
+	})
 }
 
 func TestFindAnnotations(t *testing.T) {
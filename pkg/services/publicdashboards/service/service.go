@@ -43,6 +43,8 @@ type PublicDashboardServiceImpl struct {
 	serviceWrapper     publicdashboards.ServiceWrapper
 	dashboardService   dashboards.DashboardService
 	license            licensing.Licensing
+	rateLimiter        rateLimiter
+	usage              usageCounter
 }
 
 var LogPrefix = "publicdashboards.service"
@@ -89,6 +91,8 @@ func (pd *PublicDashboardServiceImpl) GetPublicDashboardForView(ctx context.Cont
 		return nil, err
 	}
 
+	pd.recordUsage(pubdash, 1, 0)
+
 	metrics.MFolderIDsServiceCount.WithLabelValues(metrics.PublicDashboards).Inc()
 	meta := dtos.DashboardMeta{
 		Slug:                   dash.Slug,
@@ -109,6 +113,7 @@ func (pd *PublicDashboardServiceImpl) GetPublicDashboardForView(ctx context.Cont
 	dash.Data.Get("timepicker").Set("hidden", !pubdash.TimeSelectionEnabled)
 
 	sanitizeData(dash.Data)
+	sanitizeTemplateVariables(dash.Data, pubdash)
 
 	return &dtos.DashboardFullWithMeta{Meta: meta, Dashboard: dash.Data}, nil
 }
@@ -164,7 +169,7 @@ func (pd *PublicDashboardServiceImpl) FindByAccessToken(ctx context.Context, acc
 	return pubdash, nil
 }
 
-// FindEnabledPublicDashboardAndDashboardByAccessToken Gets public dashboard and a dashboard by access token if public dashboard is enabled
+// FindEnabledPublicDashboardAndDashboardByAccessToken Gets public dashboard and a dashboard by access token if public dashboard is enabled and not expired
 func (pd *PublicDashboardServiceImpl) FindEnabledPublicDashboardAndDashboardByAccessToken(ctx context.Context, accessToken string) (*PublicDashboard, *dashboards.Dashboard, error) {
 	ctx, span := tracer.Start(ctx, "publicdashboards.FindEnabledPublicDashboardAndDashboardByAccessToken")
 	defer span.End()
@@ -177,6 +182,10 @@ func (pd *PublicDashboardServiceImpl) FindEnabledPublicDashboardAndDashboardByAc
 		return nil, nil, ErrPublicDashboardNotEnabled.Errorf("FindEnabledPublicDashboardAndDashboardByAccessToken: Public dashboard is not enabled accessToken: %s", accessToken)
 	}
 
+	if pubdash.IsExpired(time.Now()) {
+		return nil, nil, ErrPublicDashboardExpired.Errorf("FindEnabledPublicDashboardAndDashboardByAccessToken: Public dashboard expired accessToken: %s", accessToken)
+	}
+
 	if !pd.license.FeatureEnabled(FeaturePublicDashboardsEmailSharing) && pubdash.Share == EmailShareType {
 		return nil, nil, ErrPublicDashboardNotFound.Errorf("FindEnabledPublicDashboardAndDashboardByAccessToken: Dashboard not found accessToken: %s", accessToken)
 	}
@@ -216,7 +225,12 @@ func (pd *PublicDashboardServiceImpl) Create(ctx context.Context, u *user.Signed
 	}
 
 	// ensure dashboard exists
-	_, err = pd.FindDashboard(ctx, u.OrgID, dto.DashboardUid)
+	dash, err := pd.FindDashboard(ctx, u.OrgID, dto.DashboardUid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateTemplateVariables(dash.Data, dto.PublicDashboard.TemplateVariables)
 	if err != nil {
 		return nil, err
 	}
@@ -275,7 +289,12 @@ func (pd *PublicDashboardServiceImpl) Update(ctx context.Context, u *user.Signed
 	}
 
 	// validate dashboard exists
-	_, err = pd.FindDashboard(ctx, u.OrgID, dto.DashboardUid)
+	dash, err := pd.FindDashboard(ctx, u.OrgID, dto.DashboardUid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateTemplateVariables(dash.Data, dto.PublicDashboard.TemplateVariables)
 	if err != nil {
 		return nil, err
 	}
@@ -293,7 +312,10 @@ func (pd *PublicDashboardServiceImpl) Update(ctx context.Context, u *user.Signed
 		return nil, ErrInvalidUid.Errorf("Update: the public dashboard does not belong to the dashboard")
 	}
 
-	publicDashboard := newUpdatePublicDashboard(dto, existingPubdash)
+	publicDashboard, err := newUpdatePublicDashboard(dto, existingPubdash)
+	if err != nil {
+		return nil, err
+	}
 
 	// set values to update
 	cmd := SavePublicDashboardCommand{
@@ -542,6 +564,29 @@ func (pd *PublicDashboardServiceImpl) newCreatePublicDashboard(ctx context.Conte
 		share = PublicShareType
 	}
 
+	templateVariables := dto.PublicDashboard.TemplateVariables
+	if templateVariables == nil {
+		templateVariables = &TemplateVariables{}
+	}
+
+	var expiresAt *time.Time
+	if dto.PublicDashboard.ExpiresAt != nil && !dto.PublicDashboard.ExpiresAt.IsZero() {
+		expiresAt = dto.PublicDashboard.ExpiresAt
+	}
+
+	var passphraseHash, passphraseSalt string
+	if dto.PublicDashboard.Passphrase != nil {
+		passphraseHash, passphraseSalt, err = encodePassphrase(*dto.PublicDashboard.Passphrase)
+		if err != nil {
+			return nil, ErrInternalServerError.Errorf("Create: failed to encode passphrase: %w", err)
+		}
+	}
+
+	queryRateLimit := 0
+	if dto.PublicDashboard.QueryRateLimit != nil {
+		queryRateLimit = *dto.PublicDashboard.QueryRateLimit
+	}
+
 	now := time.Now()
 
 	return &PublicDashboard{
@@ -552,6 +597,11 @@ func (pd *PublicDashboardServiceImpl) newCreatePublicDashboard(ctx context.Conte
 		AnnotationsEnabled:   annotationsEnabled,
 		TimeSelectionEnabled: timeSelectionEnabled,
 		TimeSettings:         &TimeSettings{},
+		TemplateVariables:    templateVariables,
+		ExpiresAt:            expiresAt,
+		PassphraseHash:       passphraseHash,
+		PassphraseSalt:       passphraseSalt,
+		QueryRateLimit:       queryRateLimit,
 		Share:                share,
 		CreatedBy:            dto.UserId,
 		CreatedAt:            now,
@@ -561,7 +611,7 @@ func (pd *PublicDashboardServiceImpl) newCreatePublicDashboard(ctx context.Conte
 	}, nil
 }
 
-func newUpdatePublicDashboard(dto *SavePublicDashboardDTO, pd *PublicDashboard) *PublicDashboard {
+func newUpdatePublicDashboard(dto *SavePublicDashboardDTO, pd *PublicDashboard) (*PublicDashboard, error) {
 	pubdashDTO := dto.PublicDashboard
 	timeSelectionEnabled := returnValueOrDefault(pubdashDTO.TimeSelectionEnabled, pd.TimeSelectionEnabled)
 	isEnabled := returnValueOrDefault(pubdashDTO.IsEnabled, pd.IsEnabled)
@@ -572,16 +622,48 @@ func newUpdatePublicDashboard(dto *SavePublicDashboardDTO, pd *PublicDashboard)
 		share = pd.Share
 	}
 
+	templateVariables := pubdashDTO.TemplateVariables
+	if templateVariables == nil {
+		templateVariables = pd.TemplateVariables
+	}
+
+	expiresAt := pd.ExpiresAt
+	if pubdashDTO.ExpiresAt != nil {
+		expiresAt = pubdashDTO.ExpiresAt
+		if expiresAt.IsZero() {
+			expiresAt = nil
+		}
+	}
+
+	passphraseHash, passphraseSalt := pd.PassphraseHash, pd.PassphraseSalt
+	if pubdashDTO.Passphrase != nil {
+		var err error
+		passphraseHash, passphraseSalt, err = encodePassphrase(*pubdashDTO.Passphrase)
+		if err != nil {
+			return nil, ErrInternalServerError.Errorf("Update: failed to encode passphrase: %w", err)
+		}
+	}
+
+	queryRateLimit := pd.QueryRateLimit
+	if pubdashDTO.QueryRateLimit != nil {
+		queryRateLimit = *pubdashDTO.QueryRateLimit
+	}
+
 	return &PublicDashboard{
 		Uid:                  pd.Uid,
 		IsEnabled:            isEnabled,
 		AnnotationsEnabled:   annotationsEnabled,
 		TimeSelectionEnabled: timeSelectionEnabled,
 		TimeSettings:         pd.TimeSettings,
+		TemplateVariables:    templateVariables,
+		ExpiresAt:            expiresAt,
+		PassphraseHash:       passphraseHash,
+		PassphraseSalt:       passphraseSalt,
+		QueryRateLimit:       queryRateLimit,
 		Share:                share,
 		UpdatedBy:            dto.UserId,
 		UpdatedAt:            time.Now(),
-	}
+	}, nil
 }
 
 func returnValueOrDefault(value *bool, defaultValue bool) bool {
@@ -521,6 +521,7 @@ func TestGetEnabledPublicDashboard(t *testing.T) {
 		d   *dashboards.Dashboard
 		err error
 	}
+	expiredAt := time.Now().Add(-time.Hour)
 
 	testCases := []struct {
 		Name        string
@@ -551,6 +552,17 @@ func TestGetEnabledPublicDashboard(t *testing.T) {
 			ErrResp:  ErrPublicDashboardNotFound,
 			DashResp: nil,
 		},
+		{
+			Name:        "returns ErrPublicDashboardExpired when the expiration time has passed",
+			AccessToken: "abc123",
+			StoreResp: &storeResp{
+				pd:  &PublicDashboard{AccessToken: "abcdToken", IsEnabled: true, ExpiresAt: &expiredAt},
+				d:   &dashboards.Dashboard{UID: "mydashboard"},
+				err: nil,
+			},
+			ErrResp:  ErrPublicDashboardExpired,
+			DashResp: nil,
+		},
 	}
 
 	for _, test := range testCases {
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
+)
+
+const allTemplateVariableValue = "$__all"
+
+// matches $var, ${var}, ${var:format}, [[var]] and [[var:format]]
+var templateVariableRegex = regexp.MustCompile(`\$(\w+)|\[\[(\w+?)(?::(\w+))?\]\]|\$\{(\w+)(?::(\w+))?\}`)
+
+// defaultTemplateVariableFormats are the formats the data sources use by default for multi-value template
+// variables, as in the frontend. Other data sources use the glob format.
+var defaultTemplateVariableFormats = map[string]string{
+	"prometheus":                    "regex",
+	"loki":                          "regex",
+	"mysql":                         "sqlstring",
+	"postgres":                      "sqlstring",
+	"grafana-postgresql-datasource": "sqlstring",
+	"mssql":                         "sqlstring",
+	"elasticsearch":                 "lucene",
+}
+
+// templateVariableValue is the value of a template variable to interpolate in queries
+type templateVariableValue struct {
+	values []string
+	// multi is true for template variables that allow multiple values, their values are formatted as a list
+	multi bool
+	// raw is true for the custom all value of a template variable, which is interpolated as is
+	raw bool
+}
+
+// getTemplateVariableValues returns the values of the dashboard template variables to interpolate in queries.
+// Viewers can only change the template variables configured in the public dashboard, other template
+// variables use their value saved in the dashboard, where the all value is their custom all value or the values
+// of their saved options.
+func getTemplateVariableValues(dashboardData *simplejson.Json, publicDashboard *PublicDashboard, reqDTO PublicDashboardQueryDTO) (map[string]templateVariableValue, error) {
+	values := make(map[string]templateVariableValue)
+	for _, v := range dashboardData.GetPath("templating", "list").MustArray() {
+		variable := simplejson.NewFromAny(v)
+		name := variable.Get("name").MustString()
+		switch variable.Get("type").MustString() {
+		case "adhoc", "datasource":
+			continue
+		}
+
+		multi := variable.Get("multi").MustBool()
+		configured, isConfigured := publicDashboard.TemplateVariables.Find(name)
+		selected := reqDTO.Variables[name]
+		if !isConfigured || len(selected) == 0 {
+			selected = getCurrentTemplateVariableValue(variable)
+		}
+
+		if slices.Contains(selected, allTemplateVariableValue) {
+			switch allValue := variable.Get("allValue").MustString(); {
+			case isConfigured:
+				selected = configured.AllowedValues
+			case allValue != "":
+				values[name] = templateVariableValue{values: []string{allValue}, raw: true}
+				continue
+			default:
+				// the options of query template variables are not saved in the dashboard
+				selected = getTemplateVariableOptions(variable)
+				if len(selected) == 0 {
+					return nil, ErrInvalidTemplateVariableValue.Errorf("getTemplateVariableValues: the values of template variable %s are unknown", name)
+				}
+			}
+			multi = true
+		} else if len(selected) > 1 && !multi {
+			return nil, ErrInvalidTemplateVariableValue.Errorf("getTemplateVariableValues: template variable %s does not allow multiple values", name)
+		}
+
+		if len(selected) > 0 {
+			values[name] = templateVariableValue{values: selected, multi: multi}
+		}
+	}
+	return values, nil
+}
+
+func getCurrentTemplateVariableValue(variable *simplejson.Json) []string {
+	current := variable.GetPath("current", "value")
+	if value, err := current.String(); err == nil {
+		return []string{value}
+	}
+	return current.MustStringArray()
+}
+
+// getTemplateVariableOptions returns the values of the options saved in the dashboard for the template variable,
+// without the all option.
+func getTemplateVariableOptions(variable *simplejson.Json) []string {
+	options := []string{}
+	for _, o := range variable.Get("options").MustArray() {
+		value, err := simplejson.NewFromAny(o).Get("value").String()
+		if err == nil && value != allTemplateVariableValue {
+			options = append(options, value)
+		}
+	}
+	return options
+}
+
+// interpolateTemplateVariables replaces template variables in all string values of the query but its data source.
+// Multi-value template variables without a format use the default format of the data source of the query, or
+// the glob format for data sources without a default format. They are rejected if the query has no data source type.
+func interpolateTemplateVariables(query *simplejson.Json, values map[string]templateVariableValue) error {
+	if len(values) == 0 {
+		return nil
+	}
+	dsType := query.GetPath("datasource", "type").MustString()
+	for key, value := range query.MustMap() {
+		if key == "datasource" {
+			continue
+		}
+		interpolated, err := interpolateTemplateVariablesInValue(value, values, dsType)
+		if err != nil {
+			return err
+		}
+		query.Set(key, interpolated)
+	}
+	return nil
+}
+
+func interpolateTemplateVariablesInValue(value any, values map[string]templateVariableValue, dsType string) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return interpolateTemplateVariablesInString(v, values, dsType)
+	case map[string]any:
+		for key, item := range v {
+			interpolated, err := interpolateTemplateVariablesInValue(item, values, dsType)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = interpolated
+		}
+		return v, nil
+	case []any:
+		for i, item := range v {
+			interpolated, err := interpolateTemplateVariablesInValue(item, values, dsType)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = interpolated
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+func interpolateTemplateVariablesInString(s string, values map[string]templateVariableValue, dsType string) (string, error) {
+	var err error
+	interpolated := templateVariableRegex.ReplaceAllStringFunc(s, func(match string) string {
+		groups := templateVariableRegex.FindStringSubmatch(match)
+		name := groups[1] + groups[2] + groups[4]
+		format := groups[3] + groups[5]
+		value, ok := values[name]
+		if !ok {
+			return match
+		}
+		if value.raw {
+			return value.values[0]
+		}
+		if format == "" && value.multi {
+			if dsType == "" {
+				err = ErrInvalidTemplateVariable.Errorf("interpolateTemplateVariables: template variable %s allows multiple values and needs a format", name)
+				return match
+			}
+			format = defaultTemplateVariableFormats[dsType]
+		}
+		return formatTemplateVariableValue(value.values, format)
+	})
+	return interpolated, err
+}
+
+// formatTemplateVariableValue formats the value with the same formats as the frontend, multiple values
+// are formatted as a glob by default.
+func formatTemplateVariableValue(value []string, format string) string {
+	switch format {
+	case "csv", "raw":
+		return strings.Join(value, ",")
+	case "pipe":
+		return strings.Join(value, "|")
+	case "regex":
+		escaped := make([]string, len(value))
+		for i, v := range value {
+			escaped[i] = regexp.QuoteMeta(v)
+		}
+		if len(escaped) == 1 {
+			return escaped[0]
+		}
+		return "(" + strings.Join(escaped, "|") + ")"
+	case "singlequote":
+		return quoteTemplateVariableValue(value, "'", `\'`)
+	case "doublequote":
+		return quoteTemplateVariableValue(value, `"`, `\"`)
+	case "sqlstring":
+		return quoteTemplateVariableValue(value, "'", "''")
+	case "lucene":
+		if len(value) == 1 {
+			return luceneEscape(value[0])
+		}
+		quoted := make([]string, len(value))
+		for i, v := range value {
+			quoted[i] = `"` + luceneEscape(v) + `"`
+		}
+		return "(" + strings.Join(quoted, " OR ") + ")"
+	case "json":
+		var b []byte
+		if len(value) == 1 {
+			b, _ = json.Marshal(value[0])
+		} else {
+			b, _ = json.Marshal(value)
+		}
+		return string(b)
+	default:
+		if len(value) == 1 {
+			return value[0]
+		}
+		return "{" + strings.Join(value, ",") + "}"
+	}
+}
+
+var luceneSpecialCharacters = regexp.MustCompile(`([+\-=&|><!(){}\[\]^"~*?:\\/])`)
+
+func luceneEscape(value string) string {
+	return luceneSpecialCharacters.ReplaceAllString(value, `\$1`)
+}
+
+func quoteTemplateVariableValue(value []string, quote string, escapedQuote string) string {
+	quoted := make([]string, len(value))
+	for i, v := range value {
+		quoted[i] = quote + strings.ReplaceAll(v, quote, escapedQuote) + quote
+	}
+	return strings.Join(quoted, ",")
+}
+
+// sanitizeTemplateVariables replaces the dashboard template variables by custom variables, so that their
+// queries are not exposed. Template variables configured in the public dashboard have the allowed values
+// as options, and other template variables are hidden.
+func sanitizeTemplateVariables(dashboardData *simplejson.Json, publicDashboard *PublicDashboard) {
+	for _, v := range dashboardData.GetPath("templating", "list").MustArray() {
+		variable := simplejson.NewFromAny(v)
+		switch variable.Get("type").MustString() {
+		case "adhoc", "datasource":
+			variable.Set("hide", 2)
+			continue
+		}
+
+		current := getCurrentTemplateVariableValue(variable)
+		options := current
+		configured, isConfigured := publicDashboard.TemplateVariables.Find(variable.Get("name").MustString())
+		if isConfigured {
+			options = configured.AllowedValues
+			current = slices.DeleteFunc(current, func(value string) bool {
+				return !slices.Contains(options, value)
+			})
+			if len(current) == 0 && len(options) > 0 {
+				current = options[:1]
+			}
+		} else {
+			variable.Set("hide", 2)
+		}
+
+		escaped := make([]string, len(options))
+		optionsJSON := make([]any, len(options))
+		for i, option := range options {
+			escaped[i] = strings.ReplaceAll(option, ",", `\,`)
+			optionsJSON[i] = map[string]any{"text": option, "value": option, "selected": slices.Contains(current, option)}
+		}
+
+		for _, key := range []string{"datasource", "definition", "regex", "refresh", "allValue"} {
+			variable.Del(key)
+		}
+		variable.Set("type", "custom")
+		variable.Set("query", strings.Join(escaped, ","))
+		variable.Set("options", optionsJSON)
+		variable.Set("includeAll", false)
+		if len(current) == 1 {
+			variable.Set("current", map[string]any{"text": current[0], "value": current[0]})
+		} else {
+			variable.Set("current", map[string]any{"text": current, "value": current})
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
+)
+
+func newTemplateVariablesDashboardData() *simplejson.Json {
+	return simplejson.NewFromAny(map[string]any{
+		"templating": map[string]any{
+			"list": []any{
+				map[string]any{
+					"name":       "customer",
+					"type":       "query",
+					"definition": "label_values(customer)",
+					"query":      "label_values(customer)",
+					"datasource": map[string]any{"uid": "prom"},
+					"current":    map[string]any{"text": "acme", "value": "acme"},
+				},
+				map[string]any{
+					"name":    "region",
+					"type":    "custom",
+					"multi":   true,
+					"query":   "eu,us,ap",
+					"current": map[string]any{"text": []any{"eu"}, "value": []any{"eu"}},
+				},
+				map[string]any{
+					"name":    "env",
+					"type":    "constant",
+					"query":   "prod",
+					"current": map[string]any{"text": "prod", "value": "prod"},
+				},
+			},
+		},
+	})
+}
+
+func TestGetTemplateVariableValues(t *testing.T) {
+	pubdash := &PublicDashboard{TemplateVariables: &TemplateVariables{
+		{Name: "customer", AllowedValues: []string{"acme", "globex"}},
+		{Name: "region", AllowedValues: []string{"eu", "us"}},
+	}}
+
+	t.Run("uses the dashboard values by default", func(t *testing.T) {
+		values, err := getTemplateVariableValues(newTemplateVariablesDashboardData(), pubdash, PublicDashboardQueryDTO{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]templateVariableValue{
+			"customer": {values: []string{"acme"}},
+			"region":   {values: []string{"eu"}, multi: true},
+			"env":      {values: []string{"prod"}},
+		}, values)
+	})
+
+	t.Run("uses the values selected by the viewer for configured template variables", func(t *testing.T) {
+		values, err := getTemplateVariableValues(newTemplateVariablesDashboardData(), pubdash, PublicDashboardQueryDTO{
+			Variables: map[string][]string{"customer": {"globex"}, "region": {"eu", "us"}, "env": {"dev"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]templateVariableValue{
+			"customer": {values: []string{"globex"}},
+			"region":   {values: []string{"eu", "us"}, multi: true},
+			"env":      {values: []string{"prod"}},
+		}, values)
+	})
+
+	t.Run("returns error when several values are selected for a single value template variable", func(t *testing.T) {
+		_, err := getTemplateVariableValues(newTemplateVariablesDashboardData(), pubdash, PublicDashboardQueryDTO{
+			Variables: map[string][]string{"customer": {"acme", "globex"}},
+		})
+		require.ErrorIs(t, err, ErrInvalidTemplateVariableValue)
+	})
+
+	t.Run("uses the custom all value or the saved options for the all value of other template variables", func(t *testing.T) {
+		all := map[string]any{"text": "All", "value": []any{"$__all"}}
+		dashboardData := simplejson.NewFromAny(map[string]any{
+			"templating": map[string]any{
+				"list": []any{
+					map[string]any{"name": "host", "type": "query", "multi": true, "allValue": ".*", "current": all},
+					map[string]any{
+						"name":    "zone",
+						"type":    "custom",
+						"query":   "a,b",
+						"current": all,
+						"options": []any{
+							map[string]any{"text": "All", "value": "$__all"},
+							map[string]any{"text": "a", "value": "a"},
+							map[string]any{"text": "b", "value": "b"},
+						},
+					},
+				},
+			},
+		})
+		values, err := getTemplateVariableValues(dashboardData, pubdash, PublicDashboardQueryDTO{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]templateVariableValue{
+			"host": {values: []string{".*"}, raw: true},
+			"zone": {values: []string{"a", "b"}, multi: true},
+		}, values)
+	})
+
+	t.Run("returns error when the all value of other template variables is unknown", func(t *testing.T) {
+		dashboardData := simplejson.NewFromAny(map[string]any{
+			"templating": map[string]any{
+				"list": []any{
+					map[string]any{"name": "host", "type": "query", "multi": true, "current": map[string]any{"text": "All", "value": "$__all"}},
+				},
+			},
+		})
+		_, err := getTemplateVariableValues(dashboardData, pubdash, PublicDashboardQueryDTO{})
+		require.ErrorIs(t, err, ErrInvalidTemplateVariableValue)
+	})
+}
+
+func TestInterpolateTemplateVariables(t *testing.T) {
+	values := map[string]templateVariableValue{
+		"customer": {values: []string{"acme"}},
+		"region":   {values: []string{"eu", "us"}, multi: true},
+	}
+
+	t.Run("interpolates template variables with their format", func(t *testing.T) {
+		query := simplejson.NewFromAny(map[string]any{
+			"refId":      "A",
+			"datasource": map[string]any{"uid": "$customer", "type": "testdata"},
+			"expr":       `up{customer="$customer", region=~"${region:regex}"}[$__interval]`,
+			"rawSql":     "SELECT * FROM t WHERE region IN (${region:sqlstring}) AND customer = '[[customer]]'",
+			"filters":    []any{map[string]any{"value": "${region}"}},
+		})
+
+		require.NoError(t, interpolateTemplateVariables(query, values))
+
+		assert.Equal(t, `up{customer="acme", region=~"(eu|us)"}[$__interval]`, query.Get("expr").MustString())
+		assert.Equal(t, "SELECT * FROM t WHERE region IN ('eu','us') AND customer = 'acme'", query.Get("rawSql").MustString())
+		assert.Equal(t, "{eu,us}", query.Get("filters").GetIndex(0).Get("value").MustString())
+		assert.Equal(t, "$customer", query.GetPath("datasource", "uid").MustString())
+	})
+
+	t.Run("interpolates custom all values as is", func(t *testing.T) {
+		query := simplejson.NewFromAny(map[string]any{
+			"datasource": map[string]any{"uid": "ds", "type": "prometheus"},
+			"expr":       `up{host=~"$host"}`,
+		})
+		require.NoError(t, interpolateTemplateVariables(query, map[string]templateVariableValue{"host": {values: []string{".*"}, raw: true}}))
+		assert.Equal(t, `up{host=~".*"}`, query.Get("expr").MustString())
+	})
+
+	t.Run("formats multi-value template variables with the default format of the data source", func(t *testing.T) {
+		for dsType, expected := range map[string]string{
+			"prometheus":    `(eu|us)`,
+			"mysql":         `'eu','us'`,
+			"elasticsearch": `("eu" OR "us")`,
+			"graphite":      `{eu,us}`,
+		} {
+			query := simplejson.NewFromAny(map[string]any{
+				"datasource": map[string]any{"uid": "ds", "type": dsType},
+				"expr":       "$region",
+			})
+			require.NoError(t, interpolateTemplateVariables(query, values))
+			assert.Equal(t, expected, query.Get("expr").MustString(), dsType)
+		}
+
+		query := simplejson.NewFromAny(map[string]any{
+			"datasource": map[string]any{"uid": "ds", "type": "postgres"},
+			"rawSql":     "SELECT * FROM t WHERE region IN ($region)",
+		})
+		require.NoError(t, interpolateTemplateVariables(query, map[string]templateVariableValue{"region": {values: []string{"eu"}, multi: true}}))
+		assert.Equal(t, "SELECT * FROM t WHERE region IN ('eu')", query.Get("rawSql").MustString())
+	})
+
+	t.Run("rejects multi-value template variables without a format when the query has no data source type", func(t *testing.T) {
+		query := simplejson.NewFromAny(map[string]any{
+			"datasource": map[string]any{"uid": "ds"},
+			"expr":       `up{region=~"$region"}`,
+		})
+		err := interpolateTemplateVariables(query, values)
+		require.ErrorIs(t, err, ErrInvalidTemplateVariable)
+	})
+}
+
+func TestSanitizeTemplateVariables(t *testing.T) {
+	data := newTemplateVariablesDashboardData()
+	sanitizeTemplateVariables(data, &PublicDashboard{TemplateVariables: &TemplateVariables{
+		{Name: "customer", AllowedValues: []string{"acme", "globex"}},
+	}})
+
+	customer := data.GetPath("templating", "list").GetIndex(0)
+	assert.Equal(t, "custom", customer.Get("type").MustString())
+	assert.Equal(t, "acme,globex", customer.Get("query").MustString())
+	assert.Len(t, customer.Get("options").MustArray(), 2)
+	assert.Equal(t, 0, customer.Get("hide").MustInt())
+	_, hasDefinition := customer.CheckGet("definition")
+	assert.False(t, hasDefinition)
+	_, hasDatasource := customer.CheckGet("datasource")
+	assert.False(t, hasDatasource)
+
+	region := data.GetPath("templating", "list").GetIndex(1)
+	assert.Equal(t, "eu", region.Get("query").MustString())
+	assert.Equal(t, 2, region.Get("hide").MustInt())
+}
@@ -1,8 +1,13 @@
 package validation
 
 import (
+	"slices"
+	"time"
+	"unicode/utf8"
+
 	"github.com/google/uuid"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+	"github.com/grafana/grafana/pkg/components/simplejson"
 	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/grafana/grafana/pkg/util"
 )
@@ -13,6 +18,62 @@ func ValidatePublicDashboard(dto *SavePublicDashboardDTO) error {
 		return ErrInvalidShareType.Errorf("ValidateSavePublicDashboard: invalid share type")
 	}
 
+	// the zero time removes the expiration time
+	if expiresAt := dto.PublicDashboard.ExpiresAt; expiresAt != nil && !expiresAt.IsZero() && !expiresAt.After(time.Now()) {
+		return ErrInvalidExpiresAt.Errorf("ValidateSavePublicDashboard: expiration time %s is in the past", expiresAt)
+	}
+
+	// an empty passphrase removes the passphrase
+	if passphrase := dto.PublicDashboard.Passphrase; passphrase != nil && *passphrase != "" && !IsValidPassphrase(*passphrase) {
+		return ErrInvalidPassphrase.Errorf("ValidateSavePublicDashboard: invalid passphrase length")
+	}
+
+	if rateLimit := dto.PublicDashboard.QueryRateLimit; rateLimit != nil && *rateLimit < 0 {
+		return ErrInvalidQueryRateLimit.Errorf("ValidateSavePublicDashboard: query rate limit %d is negative", *rateLimit)
+	}
+
+	return nil
+}
+
+// IsValidPassphrase checks the passphrase has between 8 and 128 characters.
+func IsValidPassphrase(passphrase string) bool {
+	length := utf8.RuneCountInString(passphrase)
+	return length >= 8 && length <= 128
+}
+
+// ValidateTemplateVariables checks that the template variables viewers can change exist in the
+// dashboard, can be interpolated server side and have allowed values.
+func ValidateTemplateVariables(dashboardData *simplejson.Json, variables *TemplateVariables) error {
+	if variables == nil {
+		return nil
+	}
+
+	dashboardVariables := make(map[string]*simplejson.Json)
+	for _, v := range dashboardData.GetPath("templating", "list").MustArray() {
+		variable := simplejson.NewFromAny(v)
+		dashboardVariables[variable.Get("name").MustString()] = variable
+	}
+
+	seen := make(map[string]bool, len(*variables))
+	for _, v := range *variables {
+		if seen[v.Name] {
+			return ErrInvalidTemplateVariable.Errorf("ValidateTemplateVariables: template variable %s is configured more than once", v.Name)
+		}
+		seen[v.Name] = true
+
+		variable, ok := dashboardVariables[v.Name]
+		if !ok {
+			return ErrInvalidTemplateVariable.Errorf("ValidateTemplateVariables: template variable %s not found in dashboard", v.Name)
+		}
+		switch variable.Get("type").MustString() {
+		case "adhoc", "datasource":
+			return ErrInvalidTemplateVariable.Errorf("ValidateTemplateVariables: template variable %s of type %s is not supported", v.Name, variable.Get("type").MustString())
+		}
+		if len(v.AllowedValues) == 0 {
+			return ErrInvalidTemplateVariable.Errorf("ValidateTemplateVariables: template variable %s has no allowed values", v.Name)
+		}
+	}
+
 	return nil
 }
 
@@ -38,6 +99,19 @@ func ValidateQueryPublicDashboardRequest(req PublicDashboardQueryDTO, pd *Public
 		}
 	}
 
+	// values of template variables that viewers cannot change are ignored
+	for name, values := range req.Variables {
+		variable, ok := pd.TemplateVariables.Find(name)
+		if !ok {
+			continue
+		}
+		for _, value := range values {
+			if !slices.Contains(variable.AllowedValues, value) {
+				return ErrInvalidTemplateVariableValue.Errorf("ValidateQueryPublicDashboardRequest: value of template variable %s is not allowed", name)
+			}
+		}
+	}
+
 	return nil
 }
 
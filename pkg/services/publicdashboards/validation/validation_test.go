@@ -2,7 +2,9 @@ package validation
 
 import (
 	"testing"
+	"time"
 
+	"github.com/grafana/grafana/pkg/components/simplejson"
 	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,6 +24,48 @@ func TestValidatePublicDashboard(t *testing.T) {
 		err := ValidatePublicDashboard(dto)
 		require.Error(t, err)
 	})
+
+	t.Run("Returns no error when expiration time is in the future or zero", func(t *testing.T) {
+		for _, expiresAt := range []time.Time{time.Now().Add(time.Hour), {}} {
+			dto := &SavePublicDashboardDTO{DashboardUid: "abc123", UserId: 1, PublicDashboard: &PublicDashboardDTO{ExpiresAt: &expiresAt}}
+
+			err := ValidatePublicDashboard(dto)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("Returns error when expiration time is in the past", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+		dto := &SavePublicDashboardDTO{DashboardUid: "abc123", UserId: 1, PublicDashboard: &PublicDashboardDTO{ExpiresAt: &expiresAt}}
+
+		err := ValidatePublicDashboard(dto)
+		require.ErrorIs(t, err, ErrInvalidExpiresAt)
+	})
+
+	t.Run("Returns no error when passphrase is valid or empty", func(t *testing.T) {
+		for _, passphrase := range []string{"incident-42", ""} {
+			dto := &SavePublicDashboardDTO{DashboardUid: "abc123", UserId: 1, PublicDashboard: &PublicDashboardDTO{Passphrase: &passphrase}}
+
+			err := ValidatePublicDashboard(dto)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("Returns error when passphrase is too short", func(t *testing.T) {
+		passphrase := "short"
+		dto := &SavePublicDashboardDTO{DashboardUid: "abc123", UserId: 1, PublicDashboard: &PublicDashboardDTO{Passphrase: &passphrase}}
+
+		err := ValidatePublicDashboard(dto)
+		require.ErrorIs(t, err, ErrInvalidPassphrase)
+	})
+
+	t.Run("Returns error when query rate limit is negative", func(t *testing.T) {
+		rateLimit := -1
+		dto := &SavePublicDashboardDTO{DashboardUid: "abc123", UserId: 1, PublicDashboard: &PublicDashboardDTO{QueryRateLimit: &rateLimit}}
+
+		err := ValidatePublicDashboard(dto)
+		require.ErrorIs(t, err, ErrInvalidQueryRateLimit)
+	})
 }
 
 func TestValidateQueryPublicDashboardRequest(t *testing.T) {
@@ -129,6 +173,30 @@ func TestValidateQueryPublicDashboardRequest(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Returns no error when template variable value is allowed",
+			args: args{
+				req: PublicDashboardQueryDTO{
+					Variables: map[string][]string{"customer": {"a"}, "other": {"ignored"}},
+				},
+				pd: &PublicDashboard{
+					TemplateVariables: &TemplateVariables{{Name: "customer", AllowedValues: []string{"a", "b"}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Returns validation error when template variable value is not allowed",
+			args: args{
+				req: PublicDashboardQueryDTO{
+					Variables: map[string][]string{"customer": {"a", "c"}},
+				},
+				pd: &PublicDashboard{
+					TemplateVariables: &TemplateVariables{{Name: "customer", AllowedValues: []string{"a", "b"}}},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -139,6 +207,49 @@ func TestValidateQueryPublicDashboardRequest(t *testing.T) {
 	}
 }
 
+func TestValidateTemplateVariables(t *testing.T) {
+	dashboardData := simplejson.NewFromAny(map[string]any{
+		"templating": map[string]any{
+			"list": []any{
+				map[string]any{"name": "customer", "type": "query"},
+				map[string]any{"name": "filters", "type": "adhoc"},
+			},
+		},
+	})
+
+	t.Run("Returns no error when template variables are valid", func(t *testing.T) {
+		err := ValidateTemplateVariables(dashboardData, &TemplateVariables{{Name: "customer", AllowedValues: []string{"a"}}})
+		require.NoError(t, err)
+	})
+
+	t.Run("Returns no error when template variables are not set", func(t *testing.T) {
+		require.NoError(t, ValidateTemplateVariables(dashboardData, nil))
+	})
+
+	t.Run("Returns error when template variable is not in the dashboard", func(t *testing.T) {
+		err := ValidateTemplateVariables(dashboardData, &TemplateVariables{{Name: "region", AllowedValues: []string{"a"}}})
+		require.ErrorIs(t, err, ErrInvalidTemplateVariable)
+	})
+
+	t.Run("Returns error when template variable type is not supported", func(t *testing.T) {
+		err := ValidateTemplateVariables(dashboardData, &TemplateVariables{{Name: "filters", AllowedValues: []string{"a"}}})
+		require.ErrorIs(t, err, ErrInvalidTemplateVariable)
+	})
+
+	t.Run("Returns error when template variable has no allowed values", func(t *testing.T) {
+		err := ValidateTemplateVariables(dashboardData, &TemplateVariables{{Name: "customer"}})
+		require.ErrorIs(t, err, ErrInvalidTemplateVariable)
+	})
+
+	t.Run("Returns error when template variable is duplicated", func(t *testing.T) {
+		err := ValidateTemplateVariables(dashboardData, &TemplateVariables{
+			{Name: "customer", AllowedValues: []string{"a"}},
+			{Name: "customer", AllowedValues: []string{"b"}},
+		})
+		require.ErrorIs(t, err, ErrInvalidTemplateVariable)
+	})
+}
+
 func TestValidAccessToken(t *testing.T) {
 	t.Run("true", func(t *testing.T) {
 		uuid := "da82510c2aa64d78a2e87fef36c58e89"
@@ -1,7 +1,10 @@
 package queryhistory
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
 	"github.com/grafana/grafana/pkg/api/response"
@@ -18,10 +21,13 @@ func (s *QueryHistoryService) registerAPIEndpoints() {
 	s.RouteRegister.Group("/api/query-history", func(entities routing.RouteRegister) {
 		entities.Post("/", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.createHandler, "Failed to create query history")))
 		entities.Get("/", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.searchHandler, "Failed to get query history")))
+		entities.Get("/export", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.exportHandler, "Failed to export query history")))
 		entities.Delete("/:uid", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.deleteHandler, "Failed to delete query history")))
 		entities.Post("/star/:uid", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.starHandler, "Failed to star query history")))
 		entities.Delete("/star/:uid", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.unstarHandler, "Failed to unstar query history")))
 		entities.Patch("/:uid", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.patchCommentHandler, "Failed to update comment of query in query history")))
+		entities.Put("/share/:uid", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.shareHandler, "Failed to share query in query history")))
+		entities.Put("/tags/:uid", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.updateTagsHandler, "Failed to update tags of query in query history")))
 	})
 }
 
@@ -56,7 +62,7 @@ func (s *QueryHistoryService) createHandler(c *contextmodel.ReqContext) response
 
 	query, err := s.CreateQueryInQueryHistory(c.Req.Context(), c.SignedInUser, cmd)
 	if err != nil {
-		return response.Error(http.StatusInternalServerError, "Failed to create query history", err)
+		return queryHistoryErrorResponse(err, "Failed to create query history")
 	}
 
 	return response.JSON(http.StatusOK, QueryHistoryResponse{Result: query})
@@ -69,24 +75,19 @@ func (s *QueryHistoryService) createHandler(c *contextmodel.ReqContext) response
 // Returns a list of queries in the query history that matches the search criteria.
 // Query history search supports pagination. Use the `limit` parameter to control the maximum number of queries returned; the default limit is 100.
 // You can also use the `page` query parameter to fetch queries from any page other than the first one.
+// By default only queries of the signed in user are searched, use the `scope` parameter to search queries shared with the user by other users.
 //
 // Responses:
 // 200: getQueryHistorySearchResponse
 // 401: unauthorisedError
 // 500: internalServerError
 func (s *QueryHistoryService) searchHandler(c *contextmodel.ReqContext) response.Response {
-	timeRange := gtime.NewTimeRange(c.Query("from"), c.Query("to"))
-
-	query := SearchInQueryHistoryQuery{
-		DatasourceUIDs: c.QueryStrings("datasourceUid"),
-		SearchString:   c.Query("searchString"),
-		OnlyStarred:    c.QueryBoolWithDefault("onlyStarred", false),
-		Sort:           c.Query("sort"),
-		Page:           c.QueryInt("page"),
-		Limit:          c.QueryInt("limit"),
-		From:           timeRange.GetFromAsSecondsEpoch(),
-		To:             timeRange.GetToAsSecondsEpoch(),
+	query, err := searchQueryFromRequest(c)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, err.Error(), err)
 	}
+	query.Page = c.QueryInt("page")
+	query.Limit = c.QueryInt("limit")
 
 	result, err := s.SearchInQueryHistory(c.Req.Context(), c.SignedInUser, query)
 	if err != nil {
@@ -96,6 +97,62 @@ func (s *QueryHistoryService) searchHandler(c *contextmodel.ReqContext) response
 	return response.JSON(http.StatusOK, QueryHistorySearchResponse{Result: result})
 }
 
+// swagger:route GET /query-history/export query_history exportQueries
+//
+// Export query history.
+//
+// Returns queries in the query history that match the search criteria as a library of saved queries.
+// At most 1000 queries are exported.
+//
+// Responses:
+// 200: getQueryHistoryExportResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 500: internalServerError
+func (s *QueryHistoryService) exportHandler(c *contextmodel.ReqContext) response.Response {
+	query, err := searchQueryFromRequest(c)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, err.Error(), err)
+	}
+
+	result, err := s.ExportQueryHistory(c.Req.Context(), c.SignedInUser, query)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to export query history", err)
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
+
+func searchQueryFromRequest(c *contextmodel.ReqContext) (SearchInQueryHistoryQuery, error) {
+	timeRange := gtime.NewTimeRange(c.Query("from"), c.Query("to"))
+
+	createdBy := make([]int64, 0)
+	for _, id := range c.QueryStrings("createdBy") {
+		userID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return SearchInQueryHistoryQuery{}, fmt.Errorf("invalid createdBy %q", id)
+		}
+		createdBy = append(createdBy, userID)
+	}
+
+	scope := c.Query("scope")
+	if scope != "" && scope != "mine" && scope != "shared" && scope != "all" {
+		return SearchInQueryHistoryQuery{}, fmt.Errorf("invalid scope %q", scope)
+	}
+
+	return SearchInQueryHistoryQuery{
+		DatasourceUIDs: c.QueryStrings("datasourceUid"),
+		SearchString:   c.Query("searchString"),
+		OnlyStarred:    c.QueryBoolWithDefault("onlyStarred", false),
+		Tags:           c.QueryStrings("tag"),
+		CreatedBy:      createdBy,
+		Scope:          scope,
+		Sort:           c.Query("sort"),
+		From:           timeRange.GetFromAsSecondsEpoch(),
+		To:             timeRange.GetToAsSecondsEpoch(),
+	}, nil
+}
+
 // swagger:route DELETE /query-history/{query_history_uid} query_history deleteQuery
 //
 // Delete query in query history.
@@ -201,14 +258,87 @@ func (s *QueryHistoryService) unstarHandler(c *contextmodel.ReqContext) response
 	return response.JSON(http.StatusOK, QueryHistoryResponse{Result: query})
 }
 
-// swagger:parameters starQuery patchQueryComment deleteQuery unstarQuery
+// swagger:route PUT /query-history/share/{query_history_uid} query_history shareQuery
+//
+// Share query in query history.
+//
+// Changes who can see query in query history as specified by the UID. Queries can be private, shared with a team of the user or with the whole organization.
+// Only the user who created the query can share it.
+//
+// Responses:
+// 200: getQueryHistoryResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 404: notFoundError
+// 500: internalServerError
+func (s *QueryHistoryService) shareHandler(c *contextmodel.ReqContext) response.Response {
+	queryUID := web.Params(c.Req)[":uid"]
+	if len(queryUID) > 0 && !util.IsValidShortUID(queryUID) {
+		return response.Error(http.StatusNotFound, "Query in query history not found", nil)
+	}
+
+	cmd := ShareQueryInQueryHistoryCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	query, err := s.ShareQueryInQueryHistory(c.Req.Context(), c.SignedInUser, queryUID, cmd)
+	if err != nil {
+		return queryHistoryErrorResponse(err, "Failed to share query in query history")
+	}
+
+	return response.JSON(http.StatusOK, QueryHistoryResponse{Result: query})
+}
+
+// swagger:route PUT /query-history/tags/{query_history_uid} query_history updateQueryTags
+//
+// Update tags of query in query history.
+//
+// Replaces tags of query in query history as specified by the UID.
+//
+// Responses:
+// 200: getQueryHistoryResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 404: notFoundError
+// 500: internalServerError
+func (s *QueryHistoryService) updateTagsHandler(c *contextmodel.ReqContext) response.Response {
+	queryUID := web.Params(c.Req)[":uid"]
+	if len(queryUID) > 0 && !util.IsValidShortUID(queryUID) {
+		return response.Error(http.StatusNotFound, "Query in query history not found", nil)
+	}
+
+	cmd := UpdateQueryTagsInQueryHistoryCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	query, err := s.UpdateQueryTagsInQueryHistory(c.Req.Context(), c.SignedInUser, queryUID, cmd)
+	if err != nil {
+		return queryHistoryErrorResponse(err, "Failed to update tags of query in query history")
+	}
+
+	return response.JSON(http.StatusOK, QueryHistoryResponse{Result: query})
+}
+
+func queryHistoryErrorResponse(err error, message string) response.Response {
+	switch {
+	case errors.Is(err, ErrQueryNotFound):
+		return response.Error(http.StatusNotFound, "Query in query history not found", err)
+	case errors.Is(err, ErrInvalidVisibility), errors.Is(err, ErrNotTeamMember), errors.Is(err, ErrTagTooLong):
+		return response.Error(http.StatusBadRequest, err.Error(), err)
+	}
+	return response.Error(http.StatusInternalServerError, message, err)
+}
+
+// swagger:parameters starQuery patchQueryComment deleteQuery unstarQuery shareQuery updateQueryTags
 type QueryHistoryByUID struct {
 	// in:path
 	// required:true
 	UID string `json:"query_history_uid"`
 }
 
-// swagger:parameters searchQueries
+// swagger:parameters searchQueries exportQueries
 type SearchQueriesParams struct {
 	// List of data source UIDs to search for
 	// in:query
@@ -224,20 +354,30 @@ type SearchQueriesParams struct {
 	// in:query
 	// required: false
 	OnlyStarred bool `json:"onlyStarred"`
-	// Sort method
+	// List of tags the queries have to be tagged with
 	// in:query
 	// required: false
-	// default: time-desc
-	// Enum: time-desc,time-asc
-	Sort string `json:"sort"`
-	// Use this parameter to access hits beyond limit. Numbering starts at 1. limit param acts as page size.
+	// type: array
+	// collectionFormat: multi
+	Tag []string `json:"tag"`
+	// List of IDs of users who created the queries
 	// in:query
 	// required: false
-	Page int `json:"page"`
-	// Limit the number of returned results
+	// type: array
+	// collectionFormat: multi
+	CreatedBy []int64 `json:"createdBy"`
+	// Which queries are searched: queries of the signed in user, queries shared with the user by other users or both
 	// in:query
 	// required: false
-	Limit int `json:"limit"`
+	// default: mine
+	// Enum: mine,shared,all
+	Scope string `json:"scope"`
+	// Sort method
+	// in:query
+	// required: false
+	// default: time-desc
+	// Enum: time-desc,time-asc
+	Sort string `json:"sort"`
 	// From range for the query history search
 	// in:query
 	// required: false
@@ -248,6 +388,18 @@ type SearchQueriesParams struct {
 	To int64 `json:"to"`
 }
 
+// swagger:parameters searchQueries
+type SearchQueriesPaginationParams struct {
+	// Use this parameter to access hits beyond limit. Numbering starts at 1. limit param acts as page size.
+	// in:query
+	// required: false
+	Page int `json:"page"`
+	// Limit the number of returned results
+	// in:query
+	// required: false
+	Limit int `json:"limit"`
+}
+
 // swagger:parameters createQuery
 type CreateQueryParams struct {
 	// in:body
@@ -262,6 +414,20 @@ type PatchQueryCommentParams struct {
 	Body PatchQueryCommentInQueryHistoryCommand `json:"body"`
 }
 
+// swagger:parameters shareQuery
+type ShareQueryParams struct {
+	// in:body
+	// required:true
+	Body ShareQueryInQueryHistoryCommand `json:"body"`
+}
+
+// swagger:parameters updateQueryTags
+type UpdateQueryTagsParams struct {
+	// in:body
+	// required:true
+	Body UpdateQueryTagsInQueryHistoryCommand `json:"body"`
+}
+
 //swagger:response getQueryHistorySearchResponse
 type GetQueryHistorySearchResponse struct {
 	// in: body
@@ -279,3 +445,9 @@ type GetQueryHistoryDeleteQueryResponse struct {
 	// in: body
 	Body QueryHistoryDeleteQueryResponse `json:"body"`
 }
+
+// swagger:response getQueryHistoryExportResponse
+type GetQueryHistoryExportResponse struct {
+	// in: body
+	Body QueryHistoryExport `json:"body"`
+}
@@ -2,13 +2,23 @@ package queryhistory
 
 import (
 	"context"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/util"
 )
 
+// maxExportedQueries is the maximum number of queries in query history export
+const maxExportedQueries = 1000
+
+// maxTagLength is the maximum length of a tag, the size of the term column of the query_history_tag table
+const maxTagLength = 50
+
 type Datasource struct {
 	UID string `json:"uid"`
 }
@@ -21,6 +31,10 @@ type QueryHistoryDetails struct {
 
 // createQuery adds a query into query history
 func (s QueryHistoryService) createQuery(ctx context.Context, user *user.SignedInUser, cmd CreateQueryInQueryHistoryCommand) (QueryHistoryDTO, error) {
+	if cmd.Visibility == "" {
+		cmd.Visibility = VisibilityPrivate
+	}
+
 	queryHistory := QueryHistory{
 		OrgID:         user.OrgID,
 		UID:           util.GenerateShortUID(),
@@ -29,11 +43,24 @@ func (s QueryHistoryService) createQuery(ctx context.Context, user *user.SignedI
 		CreatedBy:     user.UserID,
 		CreatedAt:     s.now().Unix(),
 		Comment:       "",
+		Visibility:    cmd.Visibility,
+	}
+	tags := normalizeTags(cmd.Tags)
+	if err := validateTags(tags); err != nil {
+		return QueryHistoryDTO{}, err
 	}
 
-	err := s.store.WithDbSession(ctx, func(session *db.Session) error {
-		_, err := session.Insert(&queryHistory)
-		return err
+	err := s.store.WithTransactionalDbSession(ctx, func(session *db.Session) error {
+		teamID, err := validateVisibility(session, user, cmd.Visibility, cmd.TeamID)
+		if err != nil {
+			return err
+		}
+		queryHistory.TeamID = teamID
+
+		if _, err := session.Insert(&queryHistory); err != nil {
+			return err
+		}
+		return replaceTags(session, queryHistory.UID, tags)
 	})
 	if err != nil {
 		return QueryHistoryDTO{}, err
@@ -58,17 +85,7 @@ func (s QueryHistoryService) createQuery(ctx context.Context, user *user.SignedI
 		})
 	}
 
-	dto := QueryHistoryDTO{
-		UID:           queryHistory.UID,
-		DatasourceUID: queryHistory.DatasourceUID,
-		CreatedBy:     queryHistory.CreatedBy,
-		CreatedAt:     queryHistory.CreatedAt,
-		Comment:       queryHistory.Comment,
-		Queries:       queryHistory.Queries,
-		Starred:       false,
-	}
-
-	return dto, nil
+	return newQueryHistoryDTO(queryHistory, false, tags), nil
 }
 
 // searchQueries searches for queries in query history based on provided parameters
@@ -101,8 +118,10 @@ func (s QueryHistoryService) searchQueries(ctx context.Context, user *user.Signe
 			query_history.created_at AS created_at,
 			query_history.comment,
 			query_history.queries,
+			query_history.visibility,
+			query_history.team_id,
 		`)
-		writeStarredSQL(query, s.store, &dtosBuilder, false)
+		writeStarredSQL(query, user, s.store, &dtosBuilder, false)
 		writeFiltersSQL(query, user, s.store, &dtosBuilder)
 		writeSortSQL(query, s.store, &dtosBuilder)
 		writeLimitSQL(query, s.store, &dtosBuilder)
@@ -113,10 +132,25 @@ func (s QueryHistoryService) searchQueries(ctx context.Context, user *user.Signe
 			return err
 		}
 
+		uids := make([]string, 0, len(dtos))
+		for _, dto := range dtos {
+			uids = append(uids, dto.UID)
+		}
+		tags, err := getTags(session, uids)
+		if err != nil {
+			return err
+		}
+		for i := range dtos {
+			dtos[i].Tags = tags[dtos[i].UID]
+			if dtos[i].Tags == nil {
+				dtos[i].Tags = []string{}
+			}
+		}
+
 		countBuilder := db.SQLBuilder{}
 		countBuilder.Write(`SELECT
 		`)
-		writeStarredSQL(query, s.store, &countBuilder, true)
+		writeStarredSQL(query, user, s.store, &countBuilder, true)
 		writeFiltersSQL(query, user, s.store, &countBuilder)
 		_, err = session.SQL(countBuilder.GetSQLString(), countBuilder.GetParams()...).Get(&totalCount)
 		return err
@@ -139,12 +173,18 @@ func (s QueryHistoryService) searchQueries(ctx context.Context, user *user.Signe
 func (s QueryHistoryService) deleteQuery(ctx context.Context, user *user.SignedInUser, UID string) (int64, error) {
 	var queryID int64
 	err := s.store.WithTransactionalDbSession(ctx, func(session *db.Session) error {
-		// Try to unstar the query first
-		_, err := session.Table("query_history_star").Where("user_id = ? AND query_uid = ?", user.UserID, UID).Delete(QueryHistoryStar{})
+		// Try to unstar the query first, shared queries can be starred by other users as well
+		_, err := session.Table("query_history_star").Where("query_uid = ?", UID).Delete(QueryHistoryStar{})
 		if err != nil {
 			s.log.Error("Failed to unstar query while deleting it from query history", "query", UID, "user", user.UserID, "error", err)
 		}
 
+		// remove the tags
+		_, err = session.Table("query_history_tag").Where("query_history_item_uid = ?", UID).Delete(QueryHistoryTag{})
+		if err != nil {
+			s.log.Error("Failed to remove the tags for the query item", "query", UID, "user", user.UserID, "error", err)
+		}
+
 		// remove the details
 		_, err = session.Table("query_history_details").Where("query_history_item_uid = ?", UID).Delete(QueryHistoryDetails{})
 		if err != nil {
@@ -171,6 +211,7 @@ func (s QueryHistoryService) deleteQuery(ctx context.Context, user *user.SignedI
 func (s QueryHistoryService) patchQueryComment(ctx context.Context, user *user.SignedInUser, UID string, cmd PatchQueryCommentInQueryHistoryCommand) (QueryHistoryDTO, error) {
 	var queryHistory QueryHistory
 	var isStarred bool
+	var tags map[string][]string
 
 	err := s.store.WithDbSession(ctx, func(session *db.Session) error {
 		exists, err := session.Where("org_id = ? AND created_by = ? AND uid = ?", user.OrgID, user.UserID, UID).Get(&queryHistory)
@@ -192,34 +233,31 @@ func (s QueryHistoryService) patchQueryComment(ctx context.Context, user *user.S
 			return err
 		}
 		isStarred = starred
-		return nil
+
+		tags, err = getTags(session, []string{UID})
+		return err
 	})
 
 	if err != nil {
 		return QueryHistoryDTO{}, err
 	}
 
-	dto := QueryHistoryDTO{
-		UID:           queryHistory.UID,
-		DatasourceUID: queryHistory.DatasourceUID,
-		CreatedBy:     queryHistory.CreatedBy,
-		CreatedAt:     queryHistory.CreatedAt,
-		Comment:       queryHistory.Comment,
-		Queries:       queryHistory.Queries,
-		Starred:       isStarred,
-	}
-
-	return dto, nil
+	return newQueryHistoryDTO(queryHistory, isStarred, tags[queryHistory.UID]), nil
 }
 
 // starQuery adds query into query_history_star table together with user_id and org_id
 func (s QueryHistoryService) starQuery(ctx context.Context, user *user.SignedInUser, UID string) (QueryHistoryDTO, error) {
 	var queryHistory QueryHistory
 	var isStarred bool
+	var tags map[string][]string
 
 	err := s.store.WithDbSession(ctx, func(session *db.Session) error {
 		// Check if query exists as we want to star only existing queries
-		exists, err := session.Table("query_history").Where("org_id = ? AND created_by = ? AND uid = ?", user.OrgID, user.UserID, UID).Get(&queryHistory)
+		visible, params := visibleToUserSQL(user)
+		exists, err := session.Table("query_history").
+			Where("org_id = ? AND uid = ?", user.OrgID, UID).
+			And("(created_by = ? OR "+visible+")", append([]any{user.UserID}, params...)...).
+			Get(&queryHistory)
 		if err != nil {
 			return err
 		}
@@ -242,33 +280,30 @@ func (s QueryHistoryService) starQuery(ctx context.Context, user *user.SignedInU
 		}
 
 		isStarred = true
-		return nil
+
+		tags, err = getTags(session, []string{UID})
+		return err
 	})
 
 	if err != nil {
 		return QueryHistoryDTO{}, err
 	}
 
-	dto := QueryHistoryDTO{
-		UID:           queryHistory.UID,
-		DatasourceUID: queryHistory.DatasourceUID,
-		CreatedBy:     queryHistory.CreatedBy,
-		CreatedAt:     queryHistory.CreatedAt,
-		Comment:       queryHistory.Comment,
-		Queries:       queryHistory.Queries,
-		Starred:       isStarred,
-	}
-
-	return dto, nil
+	return newQueryHistoryDTO(queryHistory, isStarred, tags[queryHistory.UID]), nil
 }
 
 // unstarQuery deletes query with with user_id and org_id from query_history_star table
 func (s QueryHistoryService) unstarQuery(ctx context.Context, user *user.SignedInUser, UID string) (QueryHistoryDTO, error) {
 	var queryHistory QueryHistory
 	var isStarred bool
+	var tags map[string][]string
 
 	err := s.store.WithDbSession(ctx, func(session *db.Session) error {
-		exists, err := session.Table("query_history").Where("org_id = ? AND created_by = ? AND uid = ?", user.OrgID, user.UserID, UID).Get(&queryHistory)
+		visible, params := visibleToUserSQL(user)
+		exists, err := session.Table("query_history").
+			Where("org_id = ? AND uid = ?", user.OrgID, UID).
+			And("(created_by = ? OR "+visible+")", append([]any{user.UserID}, params...)...).
+			Get(&queryHistory)
 		if err != nil {
 			return err
 		}
@@ -285,6 +320,89 @@ func (s QueryHistoryService) unstarQuery(ctx context.Context, user *user.SignedI
 		}
 
 		isStarred = false
+
+		tags, err = getTags(session, []string{UID})
+		return err
+	})
+
+	if err != nil {
+		return QueryHistoryDTO{}, err
+	}
+
+	return newQueryHistoryDTO(queryHistory, isStarred, tags[queryHistory.UID]), nil
+}
+
+// shareQuery changes who can see query in query history, only the user who created the query can share it
+func (s QueryHistoryService) shareQuery(ctx context.Context, user *user.SignedInUser, UID string, cmd ShareQueryInQueryHistoryCommand) (QueryHistoryDTO, error) {
+	var queryHistory QueryHistory
+	var isStarred bool
+	var tags map[string][]string
+
+	err := s.store.WithTransactionalDbSession(ctx, func(session *db.Session) error {
+		exists, err := session.Where("org_id = ? AND created_by = ? AND uid = ?", user.OrgID, user.UserID, UID).Get(&queryHistory)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrQueryNotFound
+		}
+
+		teamID, err := validateVisibility(session, user, cmd.Visibility, cmd.TeamID)
+		if err != nil {
+			return err
+		}
+
+		queryHistory.Visibility = cmd.Visibility
+		queryHistory.TeamID = teamID
+		_, err = session.ID(queryHistory.ID).Cols("visibility", "team_id").Update(&queryHistory)
+		if err != nil {
+			return err
+		}
+
+		starred, err := session.Table("query_history_star").Where("user_id = ? AND query_uid = ?", user.UserID, UID).Exist()
+		if err != nil {
+			return err
+		}
+		isStarred = starred
+
+		tags, err = getTags(session, []string{UID})
+		return err
+	})
+
+	if err != nil {
+		return QueryHistoryDTO{}, err
+	}
+
+	return newQueryHistoryDTO(queryHistory, isStarred, tags[queryHistory.UID]), nil
+}
+
+// updateQueryTags replaces tags of query in query history
+func (s QueryHistoryService) updateQueryTags(ctx context.Context, user *user.SignedInUser, UID string, cmd UpdateQueryTagsInQueryHistoryCommand) (QueryHistoryDTO, error) {
+	var queryHistory QueryHistory
+	var isStarred bool
+	tags := normalizeTags(cmd.Tags)
+	if err := validateTags(tags); err != nil {
+		return QueryHistoryDTO{}, err
+	}
+
+	err := s.store.WithTransactionalDbSession(ctx, func(session *db.Session) error {
+		exists, err := session.Where("org_id = ? AND created_by = ? AND uid = ?", user.OrgID, user.UserID, UID).Get(&queryHistory)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrQueryNotFound
+		}
+
+		if err := replaceTags(session, UID, tags); err != nil {
+			return err
+		}
+
+		starred, err := session.Table("query_history_star").Where("user_id = ? AND query_uid = ?", user.UserID, UID).Exist()
+		if err != nil {
+			return err
+		}
+		isStarred = starred
 		return nil
 	})
 
@@ -292,17 +410,35 @@ func (s QueryHistoryService) unstarQuery(ctx context.Context, user *user.SignedI
 		return QueryHistoryDTO{}, err
 	}
 
-	dto := QueryHistoryDTO{
-		UID:           queryHistory.UID,
-		DatasourceUID: queryHistory.DatasourceUID,
-		CreatedBy:     queryHistory.CreatedBy,
-		CreatedAt:     queryHistory.CreatedAt,
-		Comment:       queryHistory.Comment,
-		Queries:       queryHistory.Queries,
-		Starred:       isStarred,
+	return newQueryHistoryDTO(queryHistory, isStarred, tags), nil
+}
+
+// exportQueries returns queries that match the search as a library of saved queries
+func (s QueryHistoryService) exportQueries(ctx context.Context, user *user.SignedInUser, query SearchInQueryHistoryQuery) (QueryHistoryExport, error) {
+	query.Page = 1
+	query.Limit = maxExportedQueries
+
+	result, err := s.searchQueries(ctx, user, query)
+	if err != nil {
+		return QueryHistoryExport{}, err
 	}
 
-	return dto, nil
+	export := QueryHistoryExport{
+		ExportedAt: s.now().Unix(),
+		Queries:    make([]SavedQuery, 0, len(result.QueryHistory)),
+	}
+	for _, dto := range result.QueryHistory {
+		export.Queries = append(export.Queries, SavedQuery{
+			DatasourceUID: dto.DatasourceUID,
+			Comment:       dto.Comment,
+			Queries:       dto.Queries,
+			Tags:          dto.Tags,
+			CreatedBy:     dto.CreatedBy,
+			CreatedAt:     dto.CreatedAt,
+		})
+	}
+
+	return export, nil
 }
 
 func (s QueryHistoryService) deleteStaleQueries(ctx context.Context, olderThan int64) (int, error) {
@@ -314,6 +450,7 @@ func (s QueryHistoryService) deleteStaleQueries(ctx context.Context, olderThan i
 			LEFT JOIN query_history_star
 			ON query_history_star.query_uid = query_history.uid
 			WHERE query_history_star.query_uid IS NULL
+			AND query_history.visibility = 'private'
 			AND query_history.created_at <= ?
 			ORDER BY query_history.id ASC
 			LIMIT 10000
@@ -323,6 +460,10 @@ func (s QueryHistoryService) deleteStaleQueries(ctx context.Context, olderThan i
 			FROM query_history_details
 			WHERE query_history_item_uid IN (` + uids_sql + `)`
 
+		tags_sql := `DELETE
+			FROM query_history_tag
+			WHERE query_history_item_uid IN (` + uids_sql + `)`
+
 		sql := `DELETE
 			FROM query_history
 			WHERE uid IN (` + uids_sql + `)`
@@ -332,6 +473,11 @@ func (s QueryHistoryService) deleteStaleQueries(ctx context.Context, olderThan i
 			return err
 		}
 
+		_, err = session.Exec(tags_sql, strconv.FormatInt(olderThan, 10))
+		if err != nil {
+			return err
+		}
+
 		res, err := session.Exec(sql, strconv.FormatInt(olderThan, 10))
 		if err != nil {
 			return err
@@ -419,3 +565,94 @@ func (s QueryHistoryService) enforceQueryHistoryRowLimit(ctx context.Context, li
 
 	return int(deletedRowsCount), nil
 }
+
+func newQueryHistoryDTO(queryHistory QueryHistory, starred bool, tags []string) QueryHistoryDTO {
+	if tags == nil {
+		tags = []string{}
+	}
+	return QueryHistoryDTO{
+		UID:           queryHistory.UID,
+		DatasourceUID: queryHistory.DatasourceUID,
+		CreatedBy:     queryHistory.CreatedBy,
+		CreatedAt:     queryHistory.CreatedAt,
+		Comment:       queryHistory.Comment,
+		Queries:       queryHistory.Queries,
+		Starred:       starred,
+		Visibility:    queryHistory.Visibility,
+		TeamID:        queryHistory.TeamID,
+		Tags:          tags,
+	}
+}
+
+// validateVisibility checks that the user can share a query with the given visibility and returns the team ID to store
+func validateVisibility(session *db.Session, user *user.SignedInUser, visibility QueryHistoryVisibility, teamID int64) (int64, error) {
+	if !visibility.IsValid() {
+		return 0, ErrInvalidVisibility
+	}
+	if visibility != VisibilityTeam {
+		return 0, nil
+	}
+
+	isMember, err := session.Table("team_member").Where("org_id = ? AND team_id = ? AND user_id = ?", user.OrgID, teamID, user.UserID).Exist()
+	if err != nil {
+		return 0, err
+	}
+	if !isMember {
+		return 0, ErrNotTeamMember
+	}
+	return teamID, nil
+}
+
+// normalizeTags trims and deduplicates tags and drops the empty ones
+func normalizeTags(tags []string) []string {
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || slices.Contains(result, tag) {
+			continue
+		}
+		result = append(result, tag)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// validateTags checks that the tags fit in the term column of the query_history_tag table
+func validateTags(tags []string) error {
+	for _, tag := range tags {
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return ErrTagTooLong
+		}
+	}
+	return nil
+}
+
+func replaceTags(session *db.Session, UID string, tags []string) error {
+	if _, err := session.Exec("DELETE FROM query_history_tag WHERE query_history_item_uid = ?", UID); err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if _, err := session.Insert(&QueryHistoryTag{QueryHistoryItemUID: UID, Term: tag}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getTags returns tags of the queries by their UIDs
+func getTags(session *db.Session, UIDs []string) (map[string][]string, error) {
+	result := make(map[string][]string, len(UIDs))
+	if len(UIDs) == 0 {
+		return result, nil
+	}
+
+	var tags []QueryHistoryTag
+	err := session.In("query_history_item_uid", UIDs).OrderBy("term").Find(&tags)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		result[tag.QueryHistoryItemUID] = append(result[tag.QueryHistoryItemUID], tag.Term)
+	}
+	return result, nil
+}
@@ -10,8 +10,28 @@ var (
 	ErrQueryNotFound        = errors.New("query in query history not found")
 	ErrStarredQueryNotFound = errors.New("starred query not found")
 	ErrQueryAlreadyStarred  = errors.New("query was already starred")
+	ErrInvalidVisibility    = errors.New("invalid query history visibility")
+	ErrNotTeamMember        = errors.New("user is not a member of the team")
+	ErrTagTooLong           = errors.New("query history tags must not be longer than 50 characters")
 )
 
+// QueryHistoryVisibility defines who can see a query in query history
+// swagger:enum QueryHistoryVisibility
+type QueryHistoryVisibility string
+
+const (
+	// VisibilityPrivate queries are only visible to the user who created them
+	VisibilityPrivate QueryHistoryVisibility = "private"
+	// VisibilityTeam queries are visible to members of the team they are shared with
+	VisibilityTeam QueryHistoryVisibility = "team"
+	// VisibilityOrg queries are visible to all users of the organization
+	VisibilityOrg QueryHistoryVisibility = "org"
+)
+
+func (v QueryHistoryVisibility) IsValid() bool {
+	return v == VisibilityPrivate || v == VisibilityTeam || v == VisibilityOrg
+}
+
 // QueryHistory is the model for query history definitions
 type QueryHistory struct {
 	ID            int64  `xorm:"pk autoincr 'id'"`
@@ -22,6 +42,8 @@ type QueryHistory struct {
 	CreatedAt     int64
 	Comment       string
 	Queries       *simplejson.Json
+	Visibility    QueryHistoryVisibility
+	TeamID        int64 `xorm:"team_id"`
 }
 
 // QueryHistory is the model for query history star definitions
@@ -31,10 +53,20 @@ type QueryHistoryStar struct {
 	UserID   int64  `xorm:"user_id"`
 }
 
+// QueryHistoryTag is the model for query history tag definitions
+type QueryHistoryTag struct {
+	ID                  int64  `xorm:"pk autoincr 'id'"`
+	QueryHistoryItemUID string `xorm:"query_history_item_uid"`
+	Term                string
+}
+
 type SearchInQueryHistoryQuery struct {
 	DatasourceUIDs []string `json:"datasourceUids"`
 	SearchString   string   `json:"searchString"`
 	OnlyStarred    bool     `json:"onlyStarred"`
+	Tags           []string `json:"tags"`
+	CreatedBy      []int64  `json:"createdBy"`
+	Scope          string   `json:"scope"`
 	Sort           string   `json:"sort"`
 	Page           int      `json:"page"`
 	Limit          int      `json:"limit"`
@@ -43,13 +75,16 @@ type SearchInQueryHistoryQuery struct {
 }
 
 type QueryHistoryDTO struct {
-	UID           string           `json:"uid" xorm:"uid"`
-	DatasourceUID string           `json:"datasourceUid" xorm:"datasource_uid"`
-	CreatedBy     int64            `json:"createdBy"`
-	CreatedAt     int64            `json:"createdAt"`
-	Comment       string           `json:"comment"`
-	Queries       *simplejson.Json `json:"queries"`
-	Starred       bool             `json:"starred"`
+	UID           string                 `json:"uid" xorm:"uid"`
+	DatasourceUID string                 `json:"datasourceUid" xorm:"datasource_uid"`
+	CreatedBy     int64                  `json:"createdBy"`
+	CreatedAt     int64                  `json:"createdAt"`
+	Comment       string                 `json:"comment"`
+	Queries       *simplejson.Json       `json:"queries"`
+	Starred       bool                   `json:"starred"`
+	Visibility    QueryHistoryVisibility `json:"visibility"`
+	TeamID        int64                  `json:"teamId" xorm:"team_id"`
+	Tags          []string               `json:"tags" xorm:"-"`
 }
 
 // QueryHistoryResponse is a response struct for QueryHistoryDTO
@@ -83,6 +118,12 @@ type CreateQueryInQueryHistoryCommand struct {
 	// The JSON model of queries.
 	// required: true
 	Queries *simplejson.Json `json:"queries"`
+	// Tags of the query.
+	Tags []string `json:"tags"`
+	// Who can see the query. Defaults to private.
+	Visibility QueryHistoryVisibility `json:"visibility"`
+	// ID of the team the query is shared with if visibility is team.
+	TeamID int64 `json:"teamId"`
 }
 
 // PatchQueryCommentInQueryHistoryCommand is the command for updating comment for query in query history
@@ -91,3 +132,36 @@ type PatchQueryCommentInQueryHistoryCommand struct {
 	// Updated comment
 	Comment string `json:"comment"`
 }
+
+// ShareQueryInQueryHistoryCommand is the command for changing who can see a query in query history
+// swagger:model
+type ShareQueryInQueryHistoryCommand struct {
+	// Who can see the query.
+	// required: true
+	Visibility QueryHistoryVisibility `json:"visibility"`
+	// ID of the team the query is shared with. Required if visibility is team.
+	TeamID int64 `json:"teamId"`
+}
+
+// UpdateQueryTagsInQueryHistoryCommand is the command for replacing tags of query in query history
+// swagger:model
+type UpdateQueryTagsInQueryHistoryCommand struct {
+	// Updated tags
+	Tags []string `json:"tags"`
+}
+
+// QueryHistoryExport is a library of saved queries exported from query history
+type QueryHistoryExport struct {
+	ExportedAt int64        `json:"exportedAt"`
+	Queries    []SavedQuery `json:"queries"`
+}
+
+// SavedQuery is a query in query history export
+type SavedQuery struct {
+	DatasourceUID string           `json:"datasourceUid"`
+	Comment       string           `json:"comment"`
+	Queries       *simplejson.Json `json:"queries"`
+	Tags          []string         `json:"tags"`
+	CreatedBy     int64            `json:"createdBy"`
+	CreatedAt     int64            `json:"createdAt"`
+}
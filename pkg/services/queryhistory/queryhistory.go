@@ -37,6 +37,9 @@ type Service interface {
 	PatchQueryCommentInQueryHistory(ctx context.Context, user *user.SignedInUser, UID string, cmd PatchQueryCommentInQueryHistoryCommand) (QueryHistoryDTO, error)
 	StarQueryInQueryHistory(ctx context.Context, user *user.SignedInUser, UID string) (QueryHistoryDTO, error)
 	UnstarQueryInQueryHistory(ctx context.Context, user *user.SignedInUser, UID string) (QueryHistoryDTO, error)
+	ShareQueryInQueryHistory(ctx context.Context, user *user.SignedInUser, UID string, cmd ShareQueryInQueryHistoryCommand) (QueryHistoryDTO, error)
+	UpdateQueryTagsInQueryHistory(ctx context.Context, user *user.SignedInUser, UID string, cmd UpdateQueryTagsInQueryHistoryCommand) (QueryHistoryDTO, error)
+	ExportQueryHistory(ctx context.Context, user *user.SignedInUser, query SearchInQueryHistoryQuery) (QueryHistoryExport, error)
 	DeleteStaleQueriesInQueryHistory(ctx context.Context, olderThan int64) (int, error)
 	EnforceRowLimitInQueryHistory(ctx context.Context, limit int, starredQueries bool) (int, error)
 }
@@ -74,6 +77,18 @@ func (s QueryHistoryService) UnstarQueryInQueryHistory(ctx context.Context, user
 	return s.unstarQuery(ctx, user, UID)
 }
 
+func (s QueryHistoryService) ShareQueryInQueryHistory(ctx context.Context, user *user.SignedInUser, UID string, cmd ShareQueryInQueryHistoryCommand) (QueryHistoryDTO, error) {
+	return s.shareQuery(ctx, user, UID, cmd)
+}
+
+func (s QueryHistoryService) UpdateQueryTagsInQueryHistory(ctx context.Context, user *user.SignedInUser, UID string, cmd UpdateQueryTagsInQueryHistoryCommand) (QueryHistoryDTO, error) {
+	return s.updateQueryTags(ctx, user, UID, cmd)
+}
+
+func (s QueryHistoryService) ExportQueryHistory(ctx context.Context, user *user.SignedInUser, query SearchInQueryHistoryQuery) (QueryHistoryExport, error) {
+	return s.exportQueries(ctx, user, query)
+}
+
 func (s QueryHistoryService) DeleteStaleQueriesInQueryHistory(ctx context.Context, olderThan int64) (int, error) {
 	return s.deleteStaleQueries(ctx, olderThan)
 }
@@ -34,6 +34,17 @@ func TestIntegrationDeleteStaleQueryFromQueryHistory(t *testing.T) {
 			require.Equal(t, 0, rowsDeleted)
 		})
 
+	testScenarioWithQueryInQueryHistory(t, "Stale shared query history can not be deleted",
+		func(t *testing.T, sc scenarioContext) {
+			resp := shareQuery(sc, ShareQueryInQueryHistoryCommand{Visibility: VisibilityOrg})
+			require.Equal(t, 200, resp.Status())
+
+			olderThan := sc.service.now().Unix() + 60
+			rowsDeleted, err := sc.service.DeleteStaleQueriesInQueryHistory(context.Background(), olderThan)
+			require.NoError(t, err)
+			require.Equal(t, 0, rowsDeleted)
+		})
+
 	testScenarioWithQueryInQueryHistory(t, "Not stale query history is not deleted",
 		func(t *testing.T, sc scenarioContext) {
 			olderThan := sc.service.now().Unix() - 60
@@ -0,0 +1,33 @@
+package queryhistory
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationExportQueryHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	testScenarioWithMultipleQueriesInQueryHistory(t, "When user exports query history, it should return all matching queries",
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.Req.Form.Add("datasourceUid", testDsUID1)
+			resp := sc.service.exportHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+
+			var export QueryHistoryExport
+			require.NoError(t, json.Unmarshal(resp.Body(), &export))
+			require.Len(t, export.Queries, 2)
+			require.Equal(t, "test comment 2", export.Queries[1].Comment)
+			require.Equal(t, testDsUID1, export.Queries[0].DatasourceUID)
+		})
+
+	testScenarioWithMultipleQueriesInQueryHistory(t, "When user exports query history with invalid scope, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.Req.Form.Add("scope", "everything")
+			resp := sc.service.exportHandler(sc.reqContext)
+			require.Equal(t, 400, resp.Status())
+		})
+}
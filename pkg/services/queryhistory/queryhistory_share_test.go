@@ -0,0 +1,145 @@
+package queryhistory
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const testTeamID = int64(10)
+
+func TestIntegrationShareQueryInQueryHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	testScenarioWithQueryInQueryHistory(t, "When user tries to share query with the organization, it should be visible to other users",
+		func(t *testing.T, sc scenarioContext) {
+			resp := shareQuery(sc, ShareQueryInQueryHistoryCommand{Visibility: VisibilityOrg})
+			require.Equal(t, 200, resp.Status())
+			require.Equal(t, VisibilityOrg, validateAndUnMarshalResponse(t, resp).Result.Visibility)
+
+			asOtherUser(sc)
+			require.Equal(t, 0, searchTotalCount(t, sc, ""))
+			require.Equal(t, 1, searchTotalCount(t, sc, "shared"))
+			require.Equal(t, 1, searchTotalCount(t, sc, "all"))
+
+			resp = sc.service.starHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			require.True(t, validateAndUnMarshalResponse(t, resp).Result.Starred)
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When user stars query shared by another user, it should not be starred for the owner",
+		func(t *testing.T, sc scenarioContext) {
+			shareQuery(sc, ShareQueryInQueryHistoryCommand{Visibility: VisibilityOrg})
+			owner := sc.reqContext.SignedInUser
+			asOtherUser(sc)
+			sc.service.starHandler(sc.reqContext)
+
+			sc.reqContext.SignedInUser = owner
+			sc.reqContext.Req.Form.Set("onlyStarred", "true")
+			require.Equal(t, 0, searchTotalCount(t, sc, ""))
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When user tries to share query with a team, it should be visible to team members only",
+		func(t *testing.T, sc scenarioContext) {
+			addTeamMember(t, sc, testUserID)
+			resp := shareQuery(sc, ShareQueryInQueryHistoryCommand{Visibility: VisibilityTeam, TeamID: testTeamID})
+			require.Equal(t, 200, resp.Status())
+			require.Equal(t, testTeamID, validateAndUnMarshalResponse(t, resp).Result.TeamID)
+
+			asOtherUser(sc)
+			require.Equal(t, 0, searchTotalCount(t, sc, "shared"))
+
+			addTeamMember(t, sc, sc.reqContext.SignedInUser.UserID)
+			require.Equal(t, 1, searchTotalCount(t, sc, "shared"))
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When user tries to share query with a team they are not member of, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			resp := shareQuery(sc, ShareQueryInQueryHistoryCommand{Visibility: VisibilityTeam, TeamID: testTeamID})
+			require.Equal(t, 400, resp.Status())
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When user shares a query of a data source another user cannot query, it should not be visible to them",
+		func(t *testing.T, sc scenarioContext) {
+			shareQuery(sc, ShareQueryInQueryHistoryCommand{Visibility: VisibilityOrg})
+
+			asOtherUser(sc)
+			sc.reqContext.SignedInUser.Permissions[testOrgID][datasources.ActionQuery] = []string{datasources.ScopePrefix + testDsUID2}
+			require.Equal(t, 0, searchTotalCount(t, sc, "shared"))
+			resp := sc.service.starHandler(sc.reqContext)
+			require.Equal(t, 404, resp.Status())
+
+			sc.reqContext.SignedInUser.Permissions[testOrgID][datasources.ActionQuery] = []string{datasources.ScopeAll}
+			require.Equal(t, 1, searchTotalCount(t, sc, "shared"))
+		})
+
+	testScenarioWithMixedQueriesInQueryHistory(t, "When user shares a mixed query, it should be visible to users who can query all its data sources",
+		func(t *testing.T, sc scenarioContext) {
+			shareQuery(sc, ShareQueryInQueryHistoryCommand{Visibility: VisibilityOrg})
+
+			asOtherUser(sc)
+			require.Equal(t, 0, searchTotalCount(t, sc, "shared"))
+
+			sc.reqContext.SignedInUser.Permissions[testOrgID][datasources.ActionQuery] = []string{datasources.ScopePrefix + testDsUID1, datasources.ScopePrefix + testDsUID2}
+			require.Equal(t, 1, searchTotalCount(t, sc, "shared"))
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When user tries to share query with invalid visibility, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			resp := shareQuery(sc, ShareQueryInQueryHistoryCommand{Visibility: "public"})
+			require.Equal(t, 400, resp.Status())
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When user tries to share query of another user, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			shareQuery(sc, ShareQueryInQueryHistoryCommand{Visibility: VisibilityOrg})
+			asOtherUser(sc)
+			resp := shareQuery(sc, ShareQueryInQueryHistoryCommand{Visibility: VisibilityPrivate})
+			require.Equal(t, 404, resp.Status())
+		})
+}
+
+func shareQuery(sc scenarioContext, cmd ShareQueryInQueryHistoryCommand) response.Response {
+	sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+	sc.reqContext.Req.Body = mockRequestBody(cmd)
+	return sc.service.shareHandler(sc.reqContext)
+}
+
+// asOtherUser switches to another user of the organization who can query the data source of the initial query
+func asOtherUser(sc scenarioContext) {
+	usr := *sc.reqContext.SignedInUser
+	usr.UserID = testUserID + 1
+	usr.Permissions = map[int64]map[string][]string{
+		testOrgID: {datasources.ActionQuery: {datasources.ScopePrefix + testDsUID1}},
+	}
+	sc.reqContext.SignedInUser = &usr
+}
+
+func addTeamMember(t *testing.T, sc scenarioContext, userID int64) {
+	t.Helper()
+	err := sc.sqlStore.WithDbSession(context.Background(), func(session *db.Session) error {
+		_, err := session.Insert(&team.TeamMember{OrgID: testOrgID, TeamID: testTeamID, UserID: userID, Created: time.Now(), Updated: time.Now()})
+		return err
+	})
+	require.NoError(t, err)
+}
+
+func searchTotalCount(t *testing.T, sc scenarioContext, scope string) int {
+	t.Helper()
+	sc.reqContext.Req.Form.Set("scope", scope)
+	resp := sc.service.searchHandler(sc.reqContext)
+	require.Equal(t, 200, resp.Status())
+	var response QueryHistorySearchResponse
+	require.NoError(t, json.Unmarshal(resp.Body(), &response))
+	return response.Result.TotalCount
+}
@@ -0,0 +1,74 @@
+package queryhistory
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestIntegrationUpdateQueryTagsInQueryHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	testScenarioWithQueryInQueryHistory(t, "When user tries to update tags of query in query history that does not exists, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": "non-existent"})
+			sc.reqContext.Req.Body = mockRequestBody(UpdateQueryTagsInQueryHistoryCommand{Tags: []string{"loki"}})
+			resp := sc.service.updateTagsHandler(sc.reqContext)
+			require.Equal(t, 404, resp.Status())
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When user tries to update tags of query in query history that exists, it should succeed",
+		func(t *testing.T, sc scenarioContext) {
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			sc.reqContext.Req.Body = mockRequestBody(UpdateQueryTagsInQueryHistoryCommand{Tags: []string{"on-call", " loki ", "on-call", ""}})
+			resp := sc.service.updateTagsHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			require.Equal(t, []string{"loki", "on-call"}, validateAndUnMarshalResponse(t, resp).Result.Tags)
+
+			sc.reqContext.Req.Body = mockRequestBody(UpdateQueryTagsInQueryHistoryCommand{Tags: []string{"loki"}})
+			resp = sc.service.updateTagsHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			require.Equal(t, []string{"loki"}, validateAndUnMarshalResponse(t, resp).Result.Tags)
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When user tries to update tags of query in query history with a too long tag, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			sc.reqContext.Req.Body = mockRequestBody(UpdateQueryTagsInQueryHistoryCommand{Tags: []string{strings.Repeat("a", 51)}})
+			resp := sc.service.updateTagsHandler(sc.reqContext)
+			require.Equal(t, 400, resp.Status())
+
+			sc.reqContext.Req.Body = mockRequestBody(UpdateQueryTagsInQueryHistoryCommand{Tags: []string{strings.Repeat("é", 50)}})
+			resp = sc.service.updateTagsHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+		})
+
+	testScenarioWithMultipleQueriesInQueryHistory(t, "When user searches queries by tags, it should return queries with all tags",
+		func(t *testing.T, sc scenarioContext) {
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			sc.reqContext.Req.Body = mockRequestBody(UpdateQueryTagsInQueryHistoryCommand{Tags: []string{"loki", "on-call"}})
+			sc.service.updateTagsHandler(sc.reqContext)
+
+			sc.reqContext.Req.Form.Add("tag", "loki")
+			sc.reqContext.Req.Form.Add("tag", "on-call")
+			resp := sc.service.searchHandler(sc.reqContext)
+			var response QueryHistorySearchResponse
+			err := json.Unmarshal(resp.Body(), &response)
+			require.NoError(t, err)
+			require.Equal(t, 200, resp.Status())
+			require.Equal(t, 1, response.Result.TotalCount)
+			require.Equal(t, sc.initialResult.Result.UID, response.Result.QueryHistory[0].UID)
+			require.Equal(t, []string{"loki", "on-call"}, response.Result.QueryHistory[0].Tags)
+
+			sc.reqContext.Req.Form.Add("tag", "prometheus")
+			resp = sc.service.searchHandler(sc.reqContext)
+			err = json.Unmarshal(resp.Body(), &response)
+			require.NoError(t, err)
+			require.Equal(t, 0, response.Result.TotalCount)
+		})
+}
@@ -5,10 +5,12 @@ import (
 	"strings"
 
 	"github.com/grafana/grafana/pkg/infra/db"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/user"
 )
 
-func writeStarredSQL(query SearchInQueryHistoryQuery, sqlStore db.DB, builder *db.SQLBuilder, isCount bool) {
+func writeStarredSQL(query SearchInQueryHistoryQuery, user *user.SignedInUser, sqlStore db.DB, builder *db.SQLBuilder, isCount bool) {
 	var sql bytes.Buffer
 	if isCount {
 		sql.WriteString(`COUNT(`)
@@ -29,14 +31,18 @@ func writeStarredSQL(query SearchInQueryHistoryQuery, sqlStore db.DB, builder *d
 		sql.WriteString(`LEFT`)
 	}
 
-	sql.WriteString(` JOIN query_history_star ON query_history_star.query_uid = query_history.uid `)
-	builder.Write(sql.String())
+	// Shared queries can be starred by several users, only the stars of the current user are joined
+	sql.WriteString(` JOIN query_history_star ON query_history_star.query_uid = query_history.uid AND query_history_star.user_id = ? `)
+	builder.Write(sql.String(), user.UserID)
 }
 
 func writeFiltersSQL(query SearchInQueryHistoryQuery, user *user.SignedInUser, sqlStore db.DB, builder *db.SQLBuilder) {
-	params := []any{user.OrgID, user.UserID, query.From, query.To, "%" + query.SearchString + "%", "%" + query.SearchString + "%"}
+	builder.Write(" WHERE query_history.org_id = ? AND ", user.OrgID)
+	writeScopeSQL(query, user, builder)
+
+	params := []any{query.From, query.To, "%" + query.SearchString + "%", "%" + query.SearchString + "%"}
 	var sql bytes.Buffer
-	sql.WriteString(" WHERE query_history.org_id = ? AND query_history.created_by = ? AND query_history.created_at >= ? AND query_history.created_at <= ? AND (query_history.queries " + sqlStore.GetDialect().LikeStr() + " ? OR query_history.comment " + sqlStore.GetDialect().LikeStr() + " ?) ")
+	sql.WriteString(" AND query_history.created_at >= ? AND query_history.created_at <= ? AND (query_history.queries " + sqlStore.GetDialect().LikeStr() + " ? OR query_history.comment " + sqlStore.GetDialect().LikeStr() + " ?) ")
 
 	if len(query.DatasourceUIDs) > 0 {
 		q := "?" + strings.Repeat(",?", len(query.DatasourceUIDs)-1)
@@ -52,9 +58,68 @@ func writeFiltersSQL(query SearchInQueryHistoryQuery, user *user.SignedInUser, s
 		sql.WriteString("(query_history.uid IN (SELECT i.query_history_item_uid from query_history_details i WHERE i.datasource_uid IN (" + q + ")))")
 		sql.WriteString(")")
 	}
+
+	if len(query.CreatedBy) > 0 {
+		sql.WriteString(" AND query_history.created_by IN (?" + strings.Repeat(",?", len(query.CreatedBy)-1) + ")")
+		for _, id := range query.CreatedBy {
+			params = append(params, id)
+		}
+	}
+
+	if tags := normalizeTags(query.Tags); len(tags) > 0 {
+		// Queries have to be tagged with all requested tags
+		sql.WriteString(" AND query_history.uid IN (SELECT t.query_history_item_uid FROM query_history_tag t WHERE t.term IN (?" + strings.Repeat(",?", len(tags)-1) + ") GROUP BY t.query_history_item_uid HAVING COUNT(1) = ?)")
+		for _, tag := range tags {
+			params = append(params, tag)
+		}
+		params = append(params, len(tags))
+	}
 	builder.Write(sql.String(), params...)
 }
 
+// writeScopeSQL restricts the search to the queries of the user, the queries shared with the user or both
+func writeScopeSQL(query SearchInQueryHistoryQuery, user *user.SignedInUser, builder *db.SQLBuilder) {
+	visible, params := visibleToUserSQL(user)
+	switch query.Scope {
+	case "shared":
+		builder.Write("query_history.created_by <> ? AND "+visible, append([]any{user.UserID}, params...)...)
+	case "all":
+		builder.Write("(query_history.created_by = ? OR "+visible+")", append([]any{user.UserID}, params...)...)
+	default:
+		builder.Write("query_history.created_by = ?", user.UserID)
+	}
+}
+
+// builtinDatasourceUIDs are the UIDs of queries that don't need a permission to query a data source
+var builtinDatasourceUIDs = []string{"", "-- Mixed --", "__expr__"}
+
+// visibleToUserSQL returns the condition matching queries shared with the organization or with a team of the user,
+// and whose data sources can all be queried by the user
+func visibleToUserSQL(user *user.SignedInUser) (string, []any) {
+	sql := `(query_history.visibility = ? OR (query_history.visibility = ? AND query_history.team_id IN (
+		SELECT team_member.team_id FROM team_member WHERE team_member.org_id = ? AND team_member.user_id = ?)))`
+	params := []any{string(VisibilityOrg), string(VisibilityTeam), user.OrgID, user.UserID}
+
+	uids, hasWildcard := ac.ParseScopes(datasources.ScopePrefix, user.GetPermissions()[datasources.ActionQuery])
+	if hasWildcard {
+		return sql, params
+	}
+	queryable := make([]any, 0, len(uids)+len(builtinDatasourceUIDs))
+	for uid := range uids {
+		queryable = append(queryable, uid)
+	}
+	for _, uid := range builtinDatasourceUIDs {
+		queryable = append(queryable, uid)
+	}
+	q := "?" + strings.Repeat(",?", len(queryable)-1)
+	// Mixed queries have a data source per query, which are stored in query_history_details
+	sql = "(" + sql + " AND query_history.datasource_uid IN (" + q + ") AND NOT EXISTS (" +
+		"SELECT 1 FROM query_history_details d WHERE d.query_history_item_uid = query_history.uid AND d.datasource_uid NOT IN (" + q + ")))"
+	params = append(params, queryable...)
+	params = append(params, queryable...)
+	return sql, params
+}
+
 func writeSortSQL(query SearchInQueryHistoryQuery, sqlStore db.DB, builder *db.SQLBuilder) {
 	if query.Sort == "time-asc" {
 		builder.Write(" ORDER BY created_at ASC ")
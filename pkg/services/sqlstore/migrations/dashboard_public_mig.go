@@ -96,4 +96,45 @@ func addPublicDashboardMigration(mg *Migrator) {
 	mg.AddMigration("backfill empty share column fields with default of public", NewRawSQLMigration(
 		"UPDATE dashboard_public SET share='public' WHERE share=''",
 	))
+
+	mg.AddMigration("add expires_at column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "expires_at",
+		Type:     DB_DateTime,
+		Nullable: true,
+	}))
+
+	mg.AddMigration("add passphrase_hash column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "passphrase_hash",
+		Type:     DB_NVarchar,
+		Length:   255,
+		Nullable: true,
+	}))
+
+	mg.AddMigration("add passphrase_salt column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "passphrase_salt",
+		Type:     DB_NVarchar,
+		Length:   50,
+		Nullable: true,
+	}))
+
+	mg.AddMigration("add query_rate_limit column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "query_rate_limit",
+		Type:     DB_Int,
+		Nullable: false,
+		Default:  "0",
+	}))
+
+	mg.AddMigration("add view_count column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "view_count",
+		Type:     DB_BigInt,
+		Nullable: false,
+		Default:  "0",
+	}))
+
+	mg.AddMigration("add query_count column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "query_count",
+		Type:     DB_BigInt,
+		Nullable: false,
+		Default:  "0",
+	}))
 }
@@ -138,6 +138,14 @@ func (oss *OSSMigrations) AddMigration(mg *Migrator) {
 	accesscontrol.AddActionSetPermissionsMigrator(mg)
 
 	externalsession.AddMigration(mg)
+
+	ualert.AddRuleUpdatedByColumns(mg)
+
+	ualert.AddRuleKeepFiringForColumns(mg)
+
+	ualert.AddSilenceScheduleTables(mg)
+
+	addQueryHistorySharingMigrations(mg)
 }
 
 func addStarMigrations(mg *Migrator) {
@@ -40,3 +40,36 @@ func addQueryHistoryMigrations(mg *Migrator) {
 	}
 	mg.AddMigration("create query_history_details table v1", NewAddTableMigration(queryHistoryDetailsV1))
 }
+
+func addQueryHistorySharingMigrations(mg *Migrator) {
+	queryHistoryV1 := Table{Name: "query_history"}
+
+	mg.AddMigration("add column visibility to query_history", NewAddColumnMigration(queryHistoryV1, &Column{
+		Name: "visibility", Type: DB_NVarchar, Length: 20, Nullable: false, Default: "'private'",
+	}))
+
+	mg.AddMigration("add column team_id to query_history", NewAddColumnMigration(queryHistoryV1, &Column{
+		Name: "team_id", Type: DB_BigInt, Nullable: false, Default: "0",
+	}))
+
+	mg.AddMigration("add index query_history.org_id-visibility", NewAddIndexMigration(queryHistoryV1, &Index{
+		Cols: []string{"org_id", "visibility"},
+	}))
+
+	queryHistoryTagV1 := Table{
+		Name: "query_history_tag",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, Nullable: false, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "query_history_item_uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "term", Type: DB_NVarchar, Length: 50, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"query_history_item_uid", "term"}, Type: UniqueIndex},
+			{Cols: []string{"term"}},
+		},
+	}
+
+	mg.AddMigration("create query_history_tag table v1", NewAddTableMigration(queryHistoryTagV1))
+	mg.AddMigration("add unique index query_history_tag.query_history_item_uid-term", NewAddIndexMigration(queryHistoryTagV1, queryHistoryTagV1.Indices[0]))
+	mg.AddMigration("add index query_history_tag.term", NewAddIndexMigration(queryHistoryTagV1, queryHistoryTagV1.Indices[1]))
+}
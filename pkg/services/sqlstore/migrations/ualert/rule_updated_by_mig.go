@@ -0,0 +1,20 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddRuleUpdatedByColumns adds columns to store the user that made the latest change to an alert rule and each of its versions.
+func AddRuleUpdatedByColumns(mg *migrator.Migrator) {
+	mg.AddMigration("add updated_by column to alert_rule table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule"}, &migrator.Column{
+		Name:     "updated_by",
+		Type:     migrator.DB_NVarchar,
+		Length:   DefaultFieldMaxLength,
+		Nullable: true,
+	}))
+
+	mg.AddMigration("add created_by column to alert_rule_version table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule_version"}, &migrator.Column{
+		Name:     "created_by",
+		Type:     migrator.DB_NVarchar,
+		Length:   DefaultFieldMaxLength,
+		Nullable: true,
+	}))
+}
@@ -326,6 +326,8 @@ type Cfg struct {
 	AlertingAnnotationCleanupSetting   AnnotationCleanupSettings
 	DashboardAnnotationCleanupSettings AnnotationCleanupSettings
 	APIAnnotationCleanupSettings       AnnotationCleanupSettings
+	AnnotationRetentionRules           []AnnotationRetentionRule
+	AnnotationArchiveURL               string
 
 	// GrafanaJavascriptAgent config
 	GrafanaJavascriptAgent GrafanaJavascriptAgent
@@ -434,6 +436,13 @@ type Cfg struct {
 	// LiveAllowedOrigins is a set of origins accepted by Live. If not provided
 	// then Live uses AppURL as the only allowed origin.
 	LiveAllowedOrigins []string
+	// LiveManagedStreamHistorySize is the number of frames kept per managed stream
+	// channel and replayed to new subscribers. History is disabled if both the size
+	// and LiveManagedStreamHistoryMaxAge are 0.
+	LiveManagedStreamHistorySize int
+	// LiveManagedStreamHistoryMaxAge drops frames older than the duration from
+	// managed stream history. 0 keeps frames regardless of age.
+	LiveManagedStreamHistoryMaxAge time.Duration
 
 	// Grafana.com URL, used for OAuth redirect.
 	GrafanaComURL string
@@ -459,6 +468,8 @@ type Cfg struct {
 
 	Search SearchSettings
 
+	Caching CachingSettings
+
 	SecureSocksDSProxy SecureSocksDSProxySettings
 
 	// SAML Auth
@@ -537,6 +548,8 @@ type Cfg struct {
 	IndexWorkers      int
 	IndexMaxBatchSize int
 	IndexListLimit    int
+	// IndexRebuildInterval is how often the index is rebuilt, 0 disables the rebuilds
+	IndexRebuildInterval time.Duration
 }
 
 type UnifiedStorageConfig struct {
@@ -753,7 +766,7 @@ func (cfg *Cfg) readAnnotationSettings() error {
 	cfg.DashboardAnnotationCleanupSettings = newAnnotationCleanupSettings(dashboardAnnotation, "max_age")
 	cfg.APIAnnotationCleanupSettings = newAnnotationCleanupSettings(apiIAnnotation, "max_age")
 
-	return nil
+	return cfg.readAnnotationRetentionSettings()
 }
 
 func (cfg *Cfg) readExpressionsSettings() {
@@ -1270,6 +1283,11 @@ func (cfg *Cfg) parseINIFile(iniFile *ini.File) error {
 	cfg.Search = readSearchSettings(iniFile)
 
 	var err error
+	cfg.Caching, err = readCachingSettings(iniFile)
+	if err != nil {
+		return err
+	}
+
 	cfg.SecureSocksDSProxy, err = readSecureSocksDSProxySettings(iniFile)
 	if err != nil {
 		// if the proxy is misconfigured, disable it rather than crashing
@@ -2062,6 +2080,15 @@ func (cfg *Cfg) readLiveSettings(iniFile *ini.File) error {
 	}
 
 	cfg.LiveAllowedOrigins = originPatterns
+
+	cfg.LiveManagedStreamHistorySize = section.Key("managed_stream_history_size").MustInt(0)
+	if cfg.LiveManagedStreamHistorySize < 0 {
+		return fmt.Errorf("unexpected value %d for [live] managed_stream_history_size", cfg.LiveManagedStreamHistorySize)
+	}
+	cfg.LiveManagedStreamHistoryMaxAge, err = gtime.ParseDuration(section.Key("managed_stream_history_max_age").MustString("0"))
+	if err != nil {
+		return fmt.Errorf("invalid value for [live] managed_stream_history_max_age: %w", err)
+	}
 	return nil
 }
 
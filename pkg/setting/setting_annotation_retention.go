@@ -0,0 +1,91 @@
+package setting
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const annotationRetentionSectionPrefix = "annotations.retention."
+
+// Annotation types that retention rules can be matched on.
+const (
+	AnnotationTypeAlert     = "alert"
+	AnnotationTypeDashboard = "dashboard"
+	AnnotationTypeAPI       = "api"
+)
+
+// AnnotationRetentionRule configures how long the annotations matched by the rule are kept.
+// Empty matchers match all annotations. An annotation is cleaned up by the first rule it matches,
+// and annotations matched by any rule are excluded from the per type cleanup settings.
+type AnnotationRetentionRule struct {
+	Name string
+	// OrgID matches annotations of the organization.
+	OrgID int64
+	// DashboardUID matches annotations created on the dashboard.
+	DashboardUID string
+	// Tags matches annotations that have all the tags.
+	Tags []string
+	// Type matches annotations of the type: alert, dashboard or api.
+	Type string
+	AnnotationCleanupSettings
+	// Archive writes the expired annotations to the archive before deleting them.
+	Archive bool
+}
+
+// read annotation retention rules from ini file. They look like:
+// [annotations.retention.<name>]
+// <field> = <value>
+// e.g.
+// [annotations.retention.deployments]
+// tags = deploy
+// max_age = 5y
+//
+// Rules are applied in the order they are defined.
+func (cfg *Cfg) readAnnotationRetentionSettings() error {
+	retention := cfg.Raw.Section("annotations.retention")
+	cfg.AnnotationArchiveURL = retention.Key("archive_url").MustString("")
+
+	rules := make([]AnnotationRetentionRule, 0)
+	for _, section := range cfg.Raw.Sections() {
+		if !strings.HasPrefix(section.Name(), annotationRetentionSectionPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(section.Name(), annotationRetentionSectionPrefix)
+
+		maxAge, err := gtime.ParseDuration(section.Key("max_age").MustString("0"))
+		if err != nil {
+			return fmt.Errorf("[annotations.retention.%s] invalid max_age: %w", name, err)
+		}
+
+		rule := AnnotationRetentionRule{
+			Name:         name,
+			OrgID:        section.Key("org_id").MustInt64(0),
+			DashboardUID: section.Key("dashboard_uid").MustString(""),
+			Tags:         util.SplitString(section.Key("tags").MustString("")),
+			Type:         section.Key("type").MustString(""),
+			AnnotationCleanupSettings: AnnotationCleanupSettings{
+				MaxAge:   maxAge,
+				MaxCount: section.Key("max_annotations_to_keep").MustInt64(0),
+			},
+			Archive: section.Key("archive").MustBool(false),
+		}
+
+		switch rule.Type {
+		case "", AnnotationTypeAlert, AnnotationTypeDashboard, AnnotationTypeAPI:
+		default:
+			return fmt.Errorf("[annotations.retention.%s] invalid type %q: must be one of alert, dashboard or api", name, rule.Type)
+		}
+		if rule.Archive && cfg.AnnotationArchiveURL == "" {
+			return fmt.Errorf("[annotations.retention.%s] archive is enabled but [annotations.retention] archive_url is not set", name)
+		}
+
+		rules = append(rules, rule)
+	}
+	cfg.AnnotationRetentionRules = rules
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package setting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotationRetentionSettings(t *testing.T) {
+	t.Run("rules are read in order", func(t *testing.T) {
+		cfg, err := NewCfgFromBytes([]byte(`
+[annotations.retention]
+archive_url = file:///var/lib/grafana/annotations
+
+[annotations.retention.deployments]
+tags = deploy, env:prod
+
+[annotations.retention.dashboards]
+org_id = 2
+dashboard_uid = abc
+type = dashboard
+max_age = 2w
+max_annotations_to_keep = 100
+archive = true
+`))
+		require.NoError(t, err)
+		require.Equal(t, "file:///var/lib/grafana/annotations", cfg.AnnotationArchiveURL)
+		require.Equal(t, []AnnotationRetentionRule{
+			{Name: "deployments", Tags: []string{"deploy", "env:prod"}},
+			{
+				Name:                      "dashboards",
+				OrgID:                     2,
+				DashboardUID:              "abc",
+				Type:                      AnnotationTypeDashboard,
+				AnnotationCleanupSettings: AnnotationCleanupSettings{MaxAge: 14 * 24 * time.Hour, MaxCount: 100},
+				Archive:                   true,
+			},
+		}, cfg.AnnotationRetentionRules)
+	})
+
+	t.Run("invalid rules return an error", func(t *testing.T) {
+		testCases := map[string]string{
+			"invalid type": `
+[annotations.retention.test]
+type = panel
+`,
+			"invalid max age": `
+[annotations.retention.test]
+max_age = forever
+`,
+			"archive without url": `
+[annotations.retention.test]
+archive = true
+`,
+		}
+		for name, ini := range testCases {
+			t.Run(name, func(t *testing.T) {
+				_, err := NewCfgFromBytes([]byte(ini))
+				require.Error(t, err)
+			})
+		}
+	})
+}
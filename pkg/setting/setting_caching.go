@@ -0,0 +1,62 @@
+package setting
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/ini.v1"
+)
+
+type CachingSettings struct {
+	// Enabled turns on the caching of data source queries and resource requests.
+	Enabled bool
+	// TTL is the time to live of cached query results.
+	TTL time.Duration
+	// ResourcesTTL is the time to live of cached resource responses. Zero disables the caching of resource requests.
+	ResourcesTTL time.Duration
+	// DataSourceTTLs overrides the time to live of cached query results by data source UID. Zero disables the caching for the data source.
+	DataSourceTTLs map[string]time.Duration
+	// MaxValueSize is the maximum size in bytes of a cached response. Zero means no limit.
+	MaxValueSize int
+}
+
+func readCachingSettings(iniFile *ini.File) (CachingSettings, error) {
+	s := CachingSettings{
+		DataSourceTTLs: map[string]time.Duration{},
+	}
+
+	cachingSection := iniFile.Section("caching")
+	s.Enabled = cachingSection.Key("enabled").MustBool(false)
+	s.TTL = cachingSection.Key("ttl").MustDuration(time.Minute)
+	s.ResourcesTTL = cachingSection.Key("resources_ttl").MustDuration(0)
+	s.MaxValueSize = cachingSection.Key("max_value_mb").MustInt(1) * 1024 * 1024
+
+	for _, item := range strings.Split(cachingSection.Key("datasource_ttls").MustString(""), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		uid, value, ok := strings.Cut(item, "=")
+		if !ok || strings.TrimSpace(uid) == "" {
+			return s, fmt.Errorf("invalid data source ttl %q, expected <datasource uid>=<duration>", item)
+		}
+		ttl, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return s, fmt.Errorf("invalid data source ttl %q: %w", item, err)
+		}
+		if ttl < 0 {
+			return s, fmt.Errorf("invalid data source ttl %q: duration cannot be negative", item)
+		}
+		s.DataSourceTTLs[strings.TrimSpace(uid)] = ttl
+	}
+
+	if s.TTL < 0 || s.ResourcesTTL < 0 {
+		return s, errors.New("caching ttl cannot be negative")
+	}
+	if s.MaxValueSize < 0 {
+		return s, errors.New("caching max_value_mb cannot be negative")
+	}
+	return s, nil
+}
@@ -0,0 +1,59 @@
+package setting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ini.v1"
+)
+
+func TestReadCachingSettings(t *testing.T) {
+	t.Run("should use defaults when section is not defined", func(t *testing.T) {
+		s, err := readCachingSettings(ini.Empty())
+		require.NoError(t, err)
+
+		assert.False(t, s.Enabled)
+		assert.Equal(t, time.Minute, s.TTL)
+		assert.Zero(t, s.ResourcesTTL)
+		assert.Equal(t, 1024*1024, s.MaxValueSize)
+		assert.Empty(t, s.DataSourceTTLs)
+	})
+
+	t.Run("should read data source ttls", func(t *testing.T) {
+		f, err := ini.Load([]byte(`
+[caching]
+enabled = true
+ttl = 5m
+resources_ttl = 30s
+datasource_ttls = postgres=1h, loki = 0s
+max_value_mb = 2
+`))
+		require.NoError(t, err)
+
+		s, err := readCachingSettings(f)
+		require.NoError(t, err)
+
+		assert.True(t, s.Enabled)
+		assert.Equal(t, 5*time.Minute, s.TTL)
+		assert.Equal(t, 30*time.Second, s.ResourcesTTL)
+		assert.Equal(t, 2*1024*1024, s.MaxValueSize)
+		assert.Equal(t, map[string]time.Duration{"postgres": time.Hour, "loki": 0}, s.DataSourceTTLs)
+	})
+
+	t.Run("should fail on invalid values", func(t *testing.T) {
+		for _, section := range []string{
+			"datasource_ttls = postgres",
+			"datasource_ttls = postgres=forever",
+			"datasource_ttls = postgres=-1m",
+			"ttl = -1m",
+		} {
+			f, err := ini.Load([]byte("[caching]\n" + section))
+			require.NoError(t, err)
+
+			_, err = readCachingSettings(f)
+			require.Error(t, err, section)
+		}
+	})
+}
@@ -171,9 +171,16 @@ type UnifiedAlertingStateHistorySettings struct {
 	LokiBasicAuthUsername string
 	LokiMaxQueryLength    time.Duration
 	LokiMaxQuerySize      int
-	MultiPrimary          string
-	MultiSecondaries      []string
-	ExternalLabels        map[string]string
+	// PrometheusWriteURL is the remote write endpoint that state history series are written to,
+	// PrometheusReadURL is the base URL of the Prometheus HTTP API that they are read from.
+	PrometheusWriteURL          string
+	PrometheusReadURL           string
+	PrometheusTenantID          string
+	PrometheusBasicAuthUsername string
+	PrometheusBasicAuthPassword string
+	MultiPrimary                string
+	MultiSecondaries            []string
+	ExternalLabels              map[string]string
 }
 
 // IsEnabled returns true if UnifiedAlertingSettings.Enabled is either nil or true.
@@ -409,19 +416,24 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 	stateHistory := iniFile.Section("unified_alerting.state_history")
 	stateHistoryLabels := iniFile.Section("unified_alerting.state_history.external_labels")
 	uaCfgStateHistory := UnifiedAlertingStateHistorySettings{
-		Enabled:               stateHistory.Key("enabled").MustBool(stateHistoryDefaultEnabled),
-		Backend:               stateHistory.Key("backend").MustString("annotations"),
-		LokiRemoteURL:         stateHistory.Key("loki_remote_url").MustString(""),
-		LokiReadURL:           stateHistory.Key("loki_remote_read_url").MustString(""),
-		LokiWriteURL:          stateHistory.Key("loki_remote_write_url").MustString(""),
-		LokiTenantID:          stateHistory.Key("loki_tenant_id").MustString(""),
-		LokiBasicAuthUsername: stateHistory.Key("loki_basic_auth_username").MustString(""),
-		LokiBasicAuthPassword: stateHistory.Key("loki_basic_auth_password").MustString(""),
-		LokiMaxQueryLength:    stateHistory.Key("loki_max_query_length").MustDuration(lokiDefaultMaxQueryLength),
-		LokiMaxQuerySize:      stateHistory.Key("loki_max_query_size").MustInt(lokiDefaultMaxQuerySize),
-		MultiPrimary:          stateHistory.Key("primary").MustString(""),
-		MultiSecondaries:      splitTrim(stateHistory.Key("secondaries").MustString(""), ","),
-		ExternalLabels:        stateHistoryLabels.KeysHash(),
+		Enabled:                     stateHistory.Key("enabled").MustBool(stateHistoryDefaultEnabled),
+		Backend:                     stateHistory.Key("backend").MustString("annotations"),
+		LokiRemoteURL:               stateHistory.Key("loki_remote_url").MustString(""),
+		LokiReadURL:                 stateHistory.Key("loki_remote_read_url").MustString(""),
+		LokiWriteURL:                stateHistory.Key("loki_remote_write_url").MustString(""),
+		LokiTenantID:                stateHistory.Key("loki_tenant_id").MustString(""),
+		LokiBasicAuthUsername:       stateHistory.Key("loki_basic_auth_username").MustString(""),
+		LokiBasicAuthPassword:       stateHistory.Key("loki_basic_auth_password").MustString(""),
+		LokiMaxQueryLength:          stateHistory.Key("loki_max_query_length").MustDuration(lokiDefaultMaxQueryLength),
+		LokiMaxQuerySize:            stateHistory.Key("loki_max_query_size").MustInt(lokiDefaultMaxQuerySize),
+		PrometheusWriteURL:          stateHistory.Key("prometheus_remote_write_url").MustString(""),
+		PrometheusReadURL:           stateHistory.Key("prometheus_remote_read_url").MustString(""),
+		PrometheusTenantID:          stateHistory.Key("prometheus_tenant_id").MustString(""),
+		PrometheusBasicAuthUsername: stateHistory.Key("prometheus_basic_auth_username").MustString(""),
+		PrometheusBasicAuthPassword: stateHistory.Key("prometheus_basic_auth_password").MustString(""),
+		MultiPrimary:                stateHistory.Key("primary").MustString(""),
+		MultiSecondaries:            splitTrim(stateHistory.Key("secondaries").MustString(""), ","),
+		ExternalLabels:              stateHistoryLabels.KeysHash(),
 	}
 	uaCfg.StateHistory = uaCfgStateHistory
 
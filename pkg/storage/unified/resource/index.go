@@ -4,6 +4,7 @@ import (
 	"context"
 	golog "log"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/blevesearch/bleve/v2"
@@ -21,6 +22,7 @@ type Shard struct {
 	index bleve.Index
 	path  string
 	batch *bleve.Batch
+	rvMu  *sync.Mutex
 }
 
 type Opts struct {
@@ -31,11 +33,20 @@ type Opts struct {
 }
 
 type Index struct {
+	mu     sync.RWMutex
 	shards map[string]Shard
 	opts   Opts
 	s      *server
 	log    log.Logger
 	tracer tracing.Tracer
+
+	// writeMu is held for reading while changes are indexed, and for writing while the shards are replaced
+	writeMu sync.RWMutex
+	// rebuilding is the index that replaces this one once a rebuild finishes.
+	// Changes indexed while the rebuild runs are queued in rebuildChanges, and applied in order to the
+	// rebuilt index once it listed all the resources.
+	rebuilding     *Index
+	rebuildChanges []indexChange
 }
 
 func NewIndex(s *server, opts Opts, tracer tracing.Tracer) *Index {
@@ -92,6 +103,12 @@ func (i *Index) IndexBatches(ctx context.Context, maxSize int, tenants []string)
 // AddToBatches adds resources to their respective shard's batch
 // returns a list of tenants that have changes
 func (i *Index) AddToBatches(ctx context.Context, list *ListResponse) ([]string, error) {
+	return i.addToBatches(ctx, list, nil)
+}
+
+// addToBatches skips resources that are already indexed in the persisted shards of the catch-up,
+// and records all the listed resources so the ones that were deleted can be removed afterwards
+func (i *Index) addToBatches(ctx context.Context, list *ListResponse, c *catchUp) ([]string, error) {
 	_, span := i.tracer.Start(ctx, tracingPrexfixIndex+"AddToBatches")
 	defer span.End()
 
@@ -103,6 +120,10 @@ func (i *Index) AddToBatches(ctx context.Context, list *ListResponse) ([]string,
 			return nil, err
 		}
 
+		if c != nil && c.skip(res, obj.ResourceVersion) {
+			continue
+		}
+
 		shard, err := i.getShard(res.Namespace)
 		if err != nil {
 			return nil, err
@@ -133,6 +154,54 @@ func (i *Index) Init(ctx context.Context) error {
 	logger := i.log.FromContext(ctx)
 
 	start := time.Now().Unix()
+
+	loaded, complete, err := i.loadShards(ctx)
+	if err != nil {
+		return err
+	}
+
+	var listRV int64
+	var totalObjectsFetched int
+	changeLog, ok := i.s.backend.(ChangeLog)
+	switch {
+	case len(loaded) > 0 && complete && ok:
+		logger.Info("catching up persisted shards from the change log", "shards", len(loaded))
+		listRV, totalObjectsFetched, err = i.catchUpChanges(ctx, changeLog, loaded)
+	case len(loaded) > 0:
+		logger.Info("catching up persisted shards", "shards", len(loaded))
+		c := newCatchUp(loaded)
+		listRV, totalObjectsFetched, err = i.indexAll(ctx, c)
+		if err == nil {
+			err = i.removeDeleted(ctx, c)
+		}
+	default:
+		listRV, totalObjectsFetched, err = i.indexAll(ctx, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = i.setResourceVersion(listRV)
+	if err != nil {
+		return err
+	}
+
+	span.AddEvent("indexing finished", trace.WithAttributes(attribute.Int64("objects_indexed", int64(totalObjectsFetched))))
+	end := time.Now().Unix()
+	logger.Info("Initial indexing finished", "seconds", float64(end-start))
+	if IndexServerMetrics != nil {
+		IndexServerMetrics.IndexCreationTime.WithLabelValues().Observe(float64(end - start))
+	}
+
+	return nil
+}
+
+// indexAll lists all the resources and adds them to the index.
+// It returns the oldest resource version of the lists, all changes up to this version are indexed.
+func (i *Index) indexAll(ctx context.Context, c *catchUp) (int64, int, error) {
+	logger := i.log.FromContext(ctx)
+
+	var listRV int64
 	resourceTypes := fetchResourceTypes()
 	totalObjectsFetched := 0
 	for _, rt := range resourceTypes {
@@ -144,22 +213,25 @@ func (i *Index) Init(ctx context.Context) error {
 			logger.Info("fetching resource list", "kind", rt.Key.Resource)
 			list, err := i.s.List(ctx, r)
 			if err != nil {
-				return err
+				return 0, 0, err
 			}
 
 			totalObjectsFetched += len(list.Items)
+			if listRV == 0 || list.ResourceVersion < listRV {
+				listRV = list.ResourceVersion
+			}
 
 			logger.Info("indexing batch", "kind", rt.Key.Resource, "count", len(list.Items))
 			//add changes to batches for shards with changes in the List
-			tenants, err := i.AddToBatches(ctx, list)
+			tenants, err := i.addToBatches(ctx, list, c)
 			if err != nil {
-				return err
+				return 0, 0, err
 			}
 
 			// Index the batches for tenants with changes if the batch is large enough
 			err = i.IndexBatches(ctx, i.opts.BatchSize, tenants)
 			if err != nil {
-				return err
+				return 0, 0, err
 			}
 
 			if list.NextPageToken == "" {
@@ -171,20 +243,14 @@ func (i *Index) Init(ctx context.Context) error {
 	}
 
 	//index all remaining batches
-	logger.Info("indexing remaining batches", "shards", len(i.shards))
-	err := i.IndexBatches(ctx, 1, i.allTenants())
+	tenants := i.allTenants()
+	logger.Info("indexing remaining batches", "shards", len(tenants))
+	err := i.IndexBatches(ctx, 1, tenants)
 	if err != nil {
-		return err
-	}
-
-	span.AddEvent("indexing finished", trace.WithAttributes(attribute.Int64("objects_indexed", int64(totalObjectsFetched))))
-	end := time.Now().Unix()
-	logger.Info("Initial indexing finished", "seconds", float64(end-start))
-	if IndexServerMetrics != nil {
-		IndexServerMetrics.IndexCreationTime.WithLabelValues().Observe(float64(end - start))
+		return 0, 0, err
 	}
 
-	return nil
+	return listRV, totalObjectsFetched, nil
 }
 
 func (i *Index) Index(ctx context.Context, data *Data) error {
@@ -192,6 +258,9 @@ func (i *Index) Index(ctx context.Context, data *Data) error {
 	defer span.End()
 	logger := i.log.FromContext(ctx)
 
+	i.writeMu.RLock()
+	defer i.writeMu.RUnlock()
+
 	// Transform the raw resource into a more generic indexable resource
 	res, err := NewIndexedResource(data.Value.Value)
 	if err != nil {
@@ -207,6 +276,12 @@ func (i *Index) Index(ctx context.Context, data *Data) error {
 	if err != nil {
 		return err
 	}
+	err = shard.setResourceVersion(data.Value.ResourceVersion)
+	if err != nil {
+		return err
+	}
+
+	i.queueRebuildChange(indexChange{data: data})
 
 	// record latency from when event was created to when it was indexed
 	latencySeconds := float64(time.Now().UnixMicro()-data.Value.ResourceVersion) / 1e6
@@ -224,6 +299,9 @@ func (i *Index) Delete(ctx context.Context, uid string, key *ResourceKey) error
 	_, span := i.tracer.Start(ctx, tracingPrexfixIndex+"Delete")
 	defer span.End()
 
+	i.writeMu.RLock()
+	defer i.writeMu.RUnlock()
+
 	shard, err := i.getShard(key.Namespace)
 	if err != nil {
 		return err
@@ -232,14 +310,27 @@ func (i *Index) Delete(ctx context.Context, uid string, key *ResourceKey) error
 	if err != nil {
 		return err
 	}
+
+	i.queueRebuildChange(indexChange{data: &Data{Key: key, Uid: uid}, deleted: true})
 	return nil
 }
 
-func (i *Index) Search(ctx context.Context, tenant string, query string, limit int, offset int) ([]IndexedResource, error) {
+// IndexResults is a page of search results.
+type IndexResults struct {
+	Values []IndexedResource
+	// Total is the number of resources that match the request
+	Total  uint64
+	Facets map[string]*SearchFacet
+	// NextPageToken is empty if there are no more results
+	NextPageToken string
+}
+
+func (i *Index) Search(ctx context.Context, request *SearchRequest) (*IndexResults, error) {
 	ctx, span := i.tracer.Start(ctx, tracingPrexfixIndex+"Search")
 	defer span.End()
 	logger := i.log.FromContext(ctx)
 
+	tenant := request.Tenant
 	if tenant == "" {
 		tenant = "default"
 	}
@@ -256,18 +347,12 @@ func (i *Index) Search(ctx context.Context, tenant string, query string, limit i
 	fields, _ := shard.index.Fields()
 	logger.Debug("indexed fields", "fields", fields)
 
-	// use 10 as a default limit for now
-	if limit <= 0 {
-		limit = 10
+	req, err := newBleveSearchRequest(request)
+	if err != nil {
+		return nil, err
 	}
 
-	req := bleve.NewSearchRequest(bleve.NewQueryStringQuery(query))
-	req.From = offset
-	req.Size = limit
-
-	req.Fields = []string{"*"} // return all indexed fields in search results
-
-	logger.Info("searching index", "query", query, "tenant", tenant)
+	logger.Info("searching index", "query", request.Query, "tenant", tenant)
 	res, err := shard.index.Search(req)
 	if err != nil {
 		return nil, err
@@ -276,16 +361,28 @@ func (i *Index) Search(ctx context.Context, tenant string, query string, limit i
 
 	logger.Info("got search results", "hits", hits)
 
-	results := make([]IndexedResource, len(hits))
+	results := &IndexResults{
+		Values: make([]IndexedResource, len(hits)),
+		Total:  res.Total,
+		Facets: facetsFromResult(res.Facets),
+	}
 	for resKey, hit := range hits {
 		ir := IndexedResource{}.FromSearchHit(hit)
-		results[resKey] = ir
+		results.Values[resKey] = ir
+	}
+
+	next := uint64(req.From + len(hits))
+	if len(hits) > 0 && next < res.Total {
+		results.NextPageToken = SearchContinueToken{StartOffset: int64(next)}.String()
 	}
 
 	return results, nil
 }
 
 func (i *Index) Count() (uint64, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
 	var total uint64
 	for _, shard := range i.shards {
 		count, err := shard.index.DocCount()
@@ -298,6 +395,9 @@ func (i *Index) Count() (uint64, error) {
 }
 
 func (i *Index) allTenants() []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
 	tenants := make([]string, 0, len(i.shards))
 	for tenant := range i.shards {
 		tenants = append(tenants, tenant)
@@ -306,37 +406,52 @@ func (i *Index) allTenants() []string {
 }
 
 func (i *Index) getShard(tenant string) (Shard, error) {
+	i.mu.RLock()
 	shard, ok := i.shards[tenant]
+	i.mu.RUnlock()
+	if ok {
+		return shard, nil
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	shard, ok = i.shards[tenant]
 	if ok {
 		return shard, nil
 	}
 
-	index, path, err := i.createIndex()
+	index, path, err := i.createIndex(tenant)
 	if err != nil {
 		return Shard{}, err
 	}
 
-	shard = Shard{
-		index: index,
-		path:  path,
-		batch: index.NewBatch(),
-	}
-	// TODO: do we need to lock this?
+	shard = newShard(index, path)
 	i.shards[tenant] = shard
 	return shard, nil
 }
 
-func (i *Index) createIndex() (bleve.Index, string, error) {
+// queueRebuildChange queues a change for the rebuilt index if a rebuild is running
+func (i *Index) queueRebuildChange(change indexChange) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.rebuilding != nil {
+		i.rebuildChanges = append(i.rebuildChanges, change)
+	}
+}
+
+func (i *Index) createIndex(tenant string) (bleve.Index, string, error) {
 	if i.opts.IndexDir == "" {
 		return createInMemoryIndex()
 	}
-	return createFileIndex(i.opts.IndexDir)
+	return createFileIndex(filepath.Join(i.opts.IndexDir, tenant))
 }
 
-var mappings = createIndexMappings()
-
 // less memory intensive alternative for larger indexes with less tenants (on-prem)
 func createFileIndex(path string) (bleve.Index, string, error) {
+	mappings, err := createIndexMappings()
+	if err != nil {
+		return nil, "", err
+	}
 	indexPath := filepath.Join(path, uuid.New().String())
 	index, err := bleve.New(indexPath, mappings)
 	if err != nil {
@@ -347,6 +462,10 @@ func createFileIndex(path string) (bleve.Index, string, error) {
 
 // faster indexing when there are many tenants with smaller batches (cloud)
 func createInMemoryIndex() (bleve.Index, string, error) {
+	mappings, err := createIndexMappings()
+	if err != nil {
+		return nil, "", err
+	}
 	index, err := bleve.NewMemOnly(mappings)
 	return index, "", err
 }
@@ -364,6 +483,11 @@ func fetchResourceTypes() []*ListOptions {
 			Group:    "folder.grafana.app",
 			Resource: "folders",
 		},
+	}, &ListOptions{
+		Key: &ResourceKey{
+			Group:    "dashboard.grafana.app",
+			Resource: "dashboards",
+		},
 	})
 	return items
 }
@@ -4,6 +4,10 @@ import (
 	"strings"
 
 	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/single"
 	"github.com/blevesearch/bleve/v2/mapping"
 	"github.com/blevesearch/bleve/v2/search"
 	"github.com/grafana/grafana/pkg/apimachinery/utils"
@@ -22,9 +26,18 @@ type IndexedResource struct {
 	UpdatedAt string
 	UpdatedBy string
 	FolderId  string
+	Tags      []string
+	Labels    map[string]string
 	Spec      any
 }
 
+const (
+	// sortAnalyzer indexes the whole value of a field as a single lowercase term, so the field can be sorted on
+	sortAnalyzer = "sortable"
+	// titleSortField is the field that sorting by Title uses
+	titleSortField = "TitleSort"
+)
+
 func (ir IndexedResource) FromSearchHit(hit *search.DocumentMatch) IndexedResource {
 	ir.Uid = hit.Fields["Uid"].(string)
 	ir.Kind = hit.Fields["Kind"].(string)
@@ -36,6 +49,10 @@ func (ir IndexedResource) FromSearchHit(hit *search.DocumentMatch) IndexedResour
 	ir.UpdatedAt = hit.Fields["UpdatedAt"].(string)
 	ir.UpdatedBy = hit.Fields["UpdatedBy"].(string)
 	ir.Title = hit.Fields["Title"].(string)
+	if folder, ok := hit.Fields["FolderId"].(string); ok {
+		ir.FolderId = folder
+	}
+	ir.Tags = stringsFromField(hit.Fields["Tags"])
 
 	// add indexed spec fields to search results
 	specResult := map[string]any{}
@@ -44,12 +61,38 @@ func (ir IndexedResource) FromSearchHit(hit *search.DocumentMatch) IndexedResour
 			specKey := strings.TrimPrefix(k, "Spec.")
 			specResult[specKey] = v
 		}
+		if strings.HasPrefix(k, "Labels.") {
+			if ir.Labels == nil {
+				ir.Labels = map[string]string{}
+			}
+			if label, ok := v.(string); ok {
+				ir.Labels[strings.TrimPrefix(k, "Labels.")] = label
+			}
+		}
 		ir.Spec = specResult
 	}
 
 	return ir
 }
 
+// stringsFromField reads a stored field that can have several values.
+// A field with a single value is returned as a string, and as a slice otherwise.
+func stringsFromField(field any) []string {
+	switch v := field.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
 // NewIndexedResource creates a new IndexedResource from a raw resource.
 // rawResource is the raw json for the resource from unified storage.
 func NewIndexedResource(rawResource []byte) (*IndexedResource, error) {
@@ -83,28 +126,53 @@ func NewIndexedResource(rawResource []byte) (*IndexedResource, error) {
 		ir.UpdatedAt = ir.CreatedAt
 	}
 	ir.UpdatedBy = meta.GetUpdatedBy()
+	ir.FolderId = meta.GetFolder()
+	ir.Labels = meta.GetLabels()
 	spec, err := meta.GetSpec()
 	if err != nil {
 		return nil, err
 	}
 	ir.Spec = spec
+	ir.Tags = tagsFromSpec(spec)
 
 	return ir, nil
 }
 
-func createIndexMappings() *mapping.IndexMappingImpl {
+// tagsFromSpec returns the tags of resources that have them in their spec, like dashboards.
+func tagsFromSpec(spec any) []string {
+	s, ok := spec.(map[string]any)
+	if !ok {
+		return nil
+	}
+	tags, ok := s["tags"].([]any)
+	if !ok {
+		return nil
+	}
+	return stringsFromField(tags)
+}
+
+func createIndexMappings() (*mapping.IndexMappingImpl, error) {
 	// Create the index mapping
 	indexMapping := bleve.NewIndexMapping()
 	// Create an individual index mapping for each kind
 	indexMapping.TypeField = "Kind"
 
+	err := indexMapping.AddCustomAnalyzer(sortAnalyzer, map[string]any{
+		"type":          custom.Name,
+		"tokenizer":     single.Name,
+		"token_filters": []string{lowercase.Name},
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// for all kinds, create their index mappings
 	for k := range getSpecObjectMappings() {
 		objMapping := createIndexMappingForKind(k)
 		indexMapping.AddDocumentMapping(k, objMapping)
 	}
 
-	return indexMapping
+	return indexMapping, nil
 }
 
 func createIndexMappingForKind(resourceKind string) *mapping.DocumentMapping {
@@ -120,9 +188,17 @@ func createIndexMappingForKind(resourceKind string) *mapping.DocumentMapping {
 		"CreatedBy": bleve.NewTextFieldMapping(),
 		"UpdatedAt": bleve.NewDateTimeFieldMapping(),
 		"UpdatedBy": bleve.NewTextFieldMapping(),
-		"FolderId":  bleve.NewTextFieldMapping(),
+		"FolderId":  bleve.NewKeywordFieldMapping(),
+		"Tags":      bleve.NewKeywordFieldMapping(),
 	}
 
+	// a second mapping of the title that is only used for sorting
+	titleSortMapping := bleve.NewTextFieldMapping()
+	titleSortMapping.Name = titleSortField
+	titleSortMapping.Analyzer = sortAnalyzer
+	titleSortMapping.Store = false
+	titleSortMapping.IncludeInAll = false
+
 	// Spec is different for all resources, so we need to generate the spec mapping based on the kind
 	specMapping := createSpecObjectMapping(resourceKind)
 
@@ -132,10 +208,16 @@ func createIndexMappingForKind(resourceKind string) *mapping.DocumentMapping {
 	// map spec
 	objectMapping.AddSubDocumentMapping("Spec", specMapping)
 
+	// labels are indexed as "Labels.<key>" with their whole value as a single term
+	labelsMapping := bleve.NewDocumentMapping()
+	labelsMapping.DefaultAnalyzer = keyword.Name
+	objectMapping.AddSubDocumentMapping("Labels", labelsMapping)
+
 	// map top level fields
 	for k, v := range baseFields {
 		objectMapping.AddFieldMappingsAt(k, v)
 	}
+	objectMapping.AddFieldMappingsAt("Title", titleSortMapping)
 
 	return objectMapping
 }
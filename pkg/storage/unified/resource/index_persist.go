@@ -0,0 +1,422 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// resourceVersionKey is the internal key of a shard that stores the resource version the shard is up to date with
+var resourceVersionKey = []byte("_resourceVersion")
+
+func newShard(index bleve.Index, path string) Shard {
+	return Shard{
+		index: index,
+		path:  path,
+		batch: index.NewBatch(),
+		rvMu:  &sync.Mutex{},
+	}
+}
+
+// ResourceVersion returns the resource version the shard is up to date with, or 0 if it is unknown
+func (s Shard) ResourceVersion() (int64, error) {
+	v, err := s.index.GetInternal(resourceVersionKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(v) == 0 {
+		return 0, nil
+	}
+	return strconv.ParseInt(string(v), 10, 64)
+}
+
+// setResourceVersion stores the resource version of the shard if it is newer than the stored one
+func (s Shard) setResourceVersion(rv int64) error {
+	s.rvMu.Lock()
+	defer s.rvMu.Unlock()
+
+	current, err := s.ResourceVersion()
+	if err != nil {
+		return err
+	}
+	if rv <= current {
+		return nil
+	}
+	return s.index.SetInternal(resourceVersionKey, []byte(strconv.FormatInt(rv, 10)))
+}
+
+// setResourceVersion stores the resource version of all shards
+func (i *Index) setResourceVersion(rv int64) error {
+	if rv <= 0 {
+		return nil
+	}
+	for _, tenant := range i.allTenants() {
+		shard, err := i.getShard(tenant)
+		if err != nil {
+			return err
+		}
+		err = shard.setResourceVersion(rv)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadShards opens the shards that were persisted in the index directory.
+// The shards are stored in <IndexDir>/<tenant>/<id>. It returns the resource version of each loaded shard,
+// and false if the shard of a tenant could not be loaded, in which case the tenant must be reindexed from a list.
+func (i *Index) loadShards(ctx context.Context) (map[string]int64, bool, error) {
+	logger := i.log.FromContext(ctx)
+	loaded := make(map[string]int64)
+	complete := true
+	if i.opts.IndexDir == "" {
+		return loaded, complete, nil
+	}
+
+	entries, err := os.ReadDir(i.opts.IndexDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return loaded, complete, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		tenant := entry.Name()
+		shard, rv, err := i.openShard(ctx, filepath.Join(i.opts.IndexDir, tenant))
+		if err != nil {
+			return nil, false, err
+		}
+		if shard == nil {
+			logger.Info("no usable shard found, the tenant will be reindexed", "tenant", tenant)
+			if err := os.RemoveAll(filepath.Join(i.opts.IndexDir, tenant)); err != nil {
+				return nil, false, err
+			}
+			complete = false
+			continue
+		}
+
+		i.mu.Lock()
+		i.shards[tenant] = *shard
+		i.mu.Unlock()
+		loaded[tenant] = rv
+		logger.Info("loaded persisted shard", "tenant", tenant, "path", shard.path, "resourceVersion", rv)
+	}
+
+	return loaded, complete, nil
+}
+
+// openShard opens the most recent shard in the directory of a tenant and removes all others.
+// Shards that cannot be opened, or that do not have a resource version, are removed.
+func (i *Index) openShard(ctx context.Context, dir string) (*Shard, int64, error) {
+	logger := i.log.FromContext(ctx)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var latest *Shard
+	var latestRV int64
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		index, err := bleve.Open(path)
+		if err != nil {
+			logger.Warn("failed to open persisted shard, removing it", "path", path, "error", err)
+			if err := os.RemoveAll(path); err != nil {
+				return nil, 0, err
+			}
+			continue
+		}
+
+		shard := newShard(index, path)
+		rv, err := shard.ResourceVersion()
+		if err != nil || rv <= latestRV {
+			if err != nil {
+				logger.Warn("failed to read resource version of persisted shard, removing it", "path", path, "error", err)
+			}
+			if err := closeAndRemove(shard); err != nil {
+				return nil, 0, err
+			}
+			continue
+		}
+
+		if latest != nil {
+			if err := closeAndRemove(*latest); err != nil {
+				return nil, 0, err
+			}
+		}
+		latest = &shard
+		latestRV = rv
+	}
+
+	return latest, latestRV, nil
+}
+
+func closeAndRemove(shard Shard) error {
+	if err := shard.index.Close(); err != nil {
+		return err
+	}
+	if shard.path == "" {
+		return nil
+	}
+	return os.RemoveAll(shard.path)
+}
+
+// catchUpChanges indexes the changes written since the oldest resource version of the persisted shards.
+// Changes that are already indexed in the shard of their tenant are skipped.
+// It returns the resource version of the last change, and the number of changes indexed.
+func (i *Index) catchUpChanges(ctx context.Context, changeLog ChangeLog, loaded map[string]int64) (int64, int, error) {
+	ctx, span := i.tracer.Start(ctx, tracingPrexfixIndex+"CatchUpChanges")
+	defer span.End()
+	logger := i.log.FromContext(ctx)
+
+	var since int64
+	for _, rv := range loaded {
+		if since == 0 || rv < since {
+			since = rv
+		}
+	}
+
+	lastRV := since
+	total := 0
+	for _, rt := range fetchResourceTypes() {
+		logger.Info("reading changes", "kind", rt.Key.Resource, "since", since)
+		rv, err := changeLog.ReadChangesSince(ctx, rt.Key.Group, rt.Key.Resource, since, func(event *WrittenEvent) error {
+			if shardRV, ok := loaded[event.Key.Namespace]; ok && event.ResourceVersion <= shardRV {
+				return nil
+			}
+			change, err := changeFromEvent(event)
+			if err != nil {
+				return err
+			}
+			total++
+			return i.applyChange(ctx, change)
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		if rv > lastRV {
+			lastRV = rv
+		}
+	}
+
+	span.AddEvent("changes indexed", trace.WithAttributes(attribute.Int("changes_indexed", total)))
+	return lastRV, total, nil
+}
+
+// catchUp tracks the resources listed while updating the persisted shards
+type catchUp struct {
+	// resource version of each persisted shard
+	loaded map[string]int64
+	// uids of the listed resources of each tenant
+	seen map[string]map[string]struct{}
+}
+
+func newCatchUp(loaded map[string]int64) *catchUp {
+	return &catchUp{
+		loaded: loaded,
+		seen:   make(map[string]map[string]struct{}),
+	}
+}
+
+// skip returns true if the resource did not change since the shard was persisted
+func (c *catchUp) skip(res *IndexedResource, rv int64) bool {
+	seen, ok := c.seen[res.Namespace]
+	if !ok {
+		seen = make(map[string]struct{})
+		c.seen[res.Namespace] = seen
+	}
+	seen[res.Uid] = struct{}{}
+
+	shardRV, ok := c.loaded[res.Namespace]
+	return ok && rv <= shardRV
+}
+
+// removeDeletedPageSize is the number of documents read at once when looking for deleted resources
+var removeDeletedPageSize = 1000
+
+// removeDeleted removes the resources that were deleted while the persisted shards were not updated
+func (i *Index) removeDeleted(ctx context.Context, c *catchUp) error {
+	_, span := i.tracer.Start(ctx, tracingPrexfixIndex+"RemoveDeleted")
+	defer span.End()
+	logger := i.log.FromContext(ctx)
+
+	totalDeleted := 0
+	for tenant := range c.loaded {
+		shard, err := i.getShard(tenant)
+		if err != nil {
+			return err
+		}
+		// the documents are paged by id, so deleting the ones of a page does not move the next pages
+		var after []string
+		for {
+			req := bleve.NewSearchRequestOptions(bleve.NewMatchAllQuery(), removeDeletedPageSize, 0, false)
+			req.SortBy([]string{"_id"})
+			req.SearchAfter = after
+			res, err := shard.index.Search(req)
+			if err != nil {
+				return err
+			}
+			if len(res.Hits) == 0 {
+				break
+			}
+			after = []string{res.Hits[len(res.Hits)-1].ID}
+
+			batch := shard.index.NewBatch()
+			for _, hit := range res.Hits {
+				if _, ok := c.seen[tenant][hit.ID]; !ok {
+					batch.Delete(hit.ID)
+				}
+			}
+			if batch.Size() > 0 {
+				logger.Debug("removing deleted resources", "tenant", tenant, "count", batch.Size())
+				totalDeleted += batch.Size()
+				err = shard.index.Batch(batch)
+				if err != nil {
+					return err
+				}
+			}
+			if len(res.Hits) < removeDeletedPageSize {
+				break
+			}
+		}
+	}
+
+	span.AddEvent("deleted resources removed", trace.WithAttributes(attribute.Int("resources_deleted", totalDeleted)))
+	return nil
+}
+
+// indexChange is a change of a resource indexed from the watch
+type indexChange struct {
+	data    *Data
+	deleted bool
+}
+
+// changeFromEvent returns the change of a written event. The value of deleted resources is a DeletedMarker.
+func changeFromEvent(event *WrittenEvent) (indexChange, error) {
+	if event.Type == WatchEvent_DELETED {
+		marker := &DeletedMarker{}
+		if err := json.Unmarshal(event.Value, marker); err != nil {
+			return indexChange{}, err
+		}
+		return indexChange{data: &Data{Key: event.Key, Uid: string(marker.UID)}, deleted: true}, nil
+	}
+	data, err := getData(&WatchEvent_Resource{Value: event.Value, Version: event.ResourceVersion})
+	if err != nil {
+		return indexChange{}, err
+	}
+	return indexChange{data: data}, nil
+}
+
+func (i *Index) applyChange(ctx context.Context, change indexChange) error {
+	if change.deleted {
+		return i.Delete(ctx, change.data.Uid, change.data.Key)
+	}
+	return i.Index(ctx, change.data)
+}
+
+func (i *Index) applyChanges(ctx context.Context, changes []indexChange) error {
+	for _, change := range changes {
+		if err := i.applyChange(ctx, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// takeRebuildChanges returns the changes queued for the rebuilt index and empties the queue
+func (i *Index) takeRebuildChanges() []indexChange {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	changes := i.rebuildChanges
+	i.rebuildChanges = nil
+	return changes
+}
+
+// Rebuild indexes all resources into new shards and replaces the current shards with them.
+// This compacts the shards and drops anything that was missed by the watch.
+// Changes indexed while the resources are listed are queued, and applied in order to the new shards
+// before they replace the current ones, so that the listed versions never overwrite newer changes.
+func (i *Index) Rebuild(ctx context.Context) error {
+	ctx, span := i.tracer.Start(ctx, tracingPrexfixIndex+"Rebuild")
+	defer span.End()
+	logger := i.log.FromContext(ctx)
+
+	start := time.Now()
+	rebuilt := NewIndex(i.s, i.opts, i.tracer)
+
+	i.mu.Lock()
+	i.rebuilding = rebuilt
+	i.rebuildChanges = nil
+	i.mu.Unlock()
+
+	listRV, totalObjectsFetched, err := rebuilt.indexAll(ctx, nil)
+	if err == nil {
+		err = rebuilt.setResourceVersion(listRV)
+	}
+	if err == nil {
+		// apply the changes queued so far without blocking the watch
+		err = rebuilt.applyChanges(ctx, i.takeRebuildChanges())
+	}
+	if err != nil {
+		i.abortRebuild(ctx, rebuilt)
+		return err
+	}
+
+	// block the watch while the last changes are applied and the shards are replaced
+	i.writeMu.Lock()
+	err = rebuilt.applyChanges(ctx, i.takeRebuildChanges())
+	if err != nil {
+		i.writeMu.Unlock()
+		i.abortRebuild(ctx, rebuilt)
+		return err
+	}
+	i.mu.Lock()
+	old := i.shards
+	i.shards = rebuilt.shards
+	i.rebuilding = nil
+	i.mu.Unlock()
+	i.writeMu.Unlock()
+
+	for tenant, shard := range old {
+		if err := closeAndRemove(shard); err != nil {
+			logger.Warn("failed to remove replaced shard", "tenant", tenant, "path", shard.path, "error", err)
+		}
+	}
+
+	span.AddEvent("rebuild finished", trace.WithAttributes(attribute.Int64("objects_indexed", int64(totalObjectsFetched))))
+	logger.Info("index rebuild finished", "seconds", time.Since(start).Seconds(), "shards", len(rebuilt.shards))
+	return nil
+}
+
+// abortRebuild stops queuing changes for the rebuilt index and removes its shards
+func (i *Index) abortRebuild(ctx context.Context, rebuilt *Index) {
+	i.mu.Lock()
+	i.rebuilding = nil
+	i.rebuildChanges = nil
+	i.mu.Unlock()
+
+	for _, shard := range rebuilt.shards {
+		if err := closeAndRemove(shard); err != nil {
+			i.log.FromContext(ctx).Warn("failed to remove shard of failed rebuild", "path", shard.path, "error", err)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestIndexPersistence(t *testing.T) {
+	dir := t.TempDir()
+
+	index := newTestIndex(t)
+	index.opts.IndexDir = dir
+	list := &ListResponse{Items: []*ResourceWrapper{
+		withResourceVersion(testDashboard("1", "one", "", `[]`, `{}`), 1),
+		withResourceVersion(testDashboard("2", "two", "", `[]`, `{}`), 2),
+	}}
+	_, err := index.AddToBatches(testContext, list)
+	require.NoError(t, err)
+	err = index.IndexBatches(testContext, 1, []string{testTenant})
+	require.NoError(t, err)
+	require.NoError(t, index.setResourceVersion(2))
+	closeShards(t, index)
+
+	t.Run("should load the persisted shards with their resource version", func(t *testing.T) {
+		index := newTestIndex(t)
+		index.opts.IndexDir = dir
+		loaded, complete, err := index.loadShards(testContext)
+		require.NoError(t, err)
+		defer closeShards(t, index)
+
+		assert.Equal(t, map[string]int64{testTenant: 2}, loaded)
+		assert.True(t, complete)
+		assertCountEquals(t, index, 2)
+	})
+
+	t.Run("should only index changes and remove deleted resources when catching up", func(t *testing.T) {
+		removeDeletedPageSize = 1
+		defer func() { removeDeletedPageSize = 1000 }()
+
+		index := newTestIndex(t)
+		index.opts.IndexDir = dir
+		loaded, _, err := index.loadShards(testContext)
+		require.NoError(t, err)
+		defer closeShards(t, index)
+
+		c := newCatchUp(loaded)
+		list := &ListResponse{Items: []*ResourceWrapper{
+			withResourceVersion(testDashboard("2", "two", "", `[]`, `{}`), 2),
+			withResourceVersion(testDashboard("3", "three", "", `[]`, `{}`), 3),
+		}}
+		tenants, err := index.addToBatches(testContext, list, c)
+		require.NoError(t, err)
+		assert.Equal(t, []string{testTenant}, tenants)
+
+		shard, err := index.getShard(testTenant)
+		require.NoError(t, err)
+		assert.Equal(t, 1, shard.batch.Size())
+
+		err = index.IndexBatches(testContext, 1, tenants)
+		require.NoError(t, err)
+		err = index.removeDeleted(testContext, c)
+		require.NoError(t, err)
+
+		results, err := index.Search(testContext, &SearchRequest{Tenant: testTenant, SortBy: []string{"Title"}})
+		require.NoError(t, err)
+		uids := []string{}
+		for _, v := range results.Values {
+			uids = append(uids, v.Uid)
+		}
+		assert.Equal(t, []string{"3", "2"}, uids)
+	})
+
+	t.Run("should index the changes since the resource version of the shards when catching up", func(t *testing.T) {
+		index := newTestIndex(t)
+		index.opts.IndexDir = dir
+		loaded, _, err := index.loadShards(testContext)
+		require.NoError(t, err)
+		defer closeShards(t, index)
+
+		changes := &fakeChangeLog{events: []*WrittenEvent{
+			// already indexed in the shard
+			writtenEvent(WatchEvent_ADDED, testDashboard("1", "one", "", `[]`, `{}`), 1),
+			writtenEvent(WatchEvent_ADDED, testDashboard("4", "four", "", `[]`, `{}`), 3),
+			writtenEvent(WatchEvent_DELETED, deletedMarker(t, "2"), 4),
+		}}
+		rv, total, err := index.catchUpChanges(testContext, changes, loaded)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), rv)
+		assert.Equal(t, 2, total)
+		assert.Equal(t, int64(2), changes.since)
+
+		results, err := index.Search(testContext, &SearchRequest{Tenant: testTenant, SortBy: []string{"Title"}})
+		require.NoError(t, err)
+		uids := []string{}
+		for _, v := range results.Values {
+			uids = append(uids, v.Uid)
+		}
+		assert.Equal(t, []string{"4", "3"}, uids)
+	})
+
+	t.Run("should remove shards without a resource version", func(t *testing.T) {
+		stale := filepath.Join(dir, "stale")
+		index := newTestIndex(t)
+		index.opts.IndexDir = dir
+		_, err := index.getShard("stale")
+		require.NoError(t, err)
+		closeShards(t, index)
+
+		index = newTestIndex(t)
+		index.opts.IndexDir = dir
+		loaded, complete, err := index.loadShards(testContext)
+		require.NoError(t, err)
+		defer closeShards(t, index)
+
+		assert.NotContains(t, loaded, "stale")
+		assert.False(t, complete)
+		_, err = os.Stat(stale)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestShardResourceVersion(t *testing.T) {
+	index := newTestIndex(t)
+	shard, err := index.getShard(testTenant)
+	require.NoError(t, err)
+
+	rv, err := shard.ResourceVersion()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rv)
+
+	require.NoError(t, shard.setResourceVersion(10))
+	require.NoError(t, shard.setResourceVersion(5))
+	rv, err = shard.ResourceVersion()
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), rv)
+}
+
+func TestIndexRebuildChanges(t *testing.T) {
+	index := newTestIndex(t)
+	rebuilt := newTestIndex(t)
+	index.rebuilding = rebuilt
+
+	one := &Data{Key: &ResourceKey{Namespace: testTenant}, Uid: "1", Value: withResourceVersion(testDashboard("1", "one", "", `[]`, `{}`), 1)}
+	require.NoError(t, index.Index(testContext, one))
+	require.NoError(t, index.Delete(testContext, "1", one.Key))
+	two := &Data{Key: &ResourceKey{Namespace: testTenant}, Uid: "2", Value: withResourceVersion(testDashboard("2", "two", "", `[]`, `{}`), 2)}
+	require.NoError(t, index.Index(testContext, two))
+
+	// an older version listed by the rebuild is replaced by the queued changes
+	_, err := rebuilt.AddToBatches(testContext, &ListResponse{Items: []*ResourceWrapper{
+		withResourceVersion(testDashboard("1", "old", "", `[]`, `{}`), 0),
+	}})
+	require.NoError(t, err)
+	require.NoError(t, rebuilt.IndexBatches(testContext, 1, []string{testTenant}))
+
+	changes := index.takeRebuildChanges()
+	require.Len(t, changes, 3)
+	require.Empty(t, index.takeRebuildChanges())
+	require.NoError(t, rebuilt.applyChanges(testContext, changes))
+
+	results, err := rebuilt.Search(testContext, &SearchRequest{Tenant: testTenant})
+	require.NoError(t, err)
+	require.Len(t, results.Values, 1)
+	assert.Equal(t, "2", results.Values[0].Uid)
+}
+
+type fakeChangeLog struct {
+	events []*WrittenEvent
+	since  int64
+}
+
+func (f *fakeChangeLog) ReadChangesSince(_ context.Context, group, resource string, since int64, fn func(*WrittenEvent) error) (int64, error) {
+	f.since = since
+	last := since
+	for _, event := range f.events {
+		if event.Key.Group != group || event.Key.Resource != resource || event.ResourceVersion <= since {
+			continue
+		}
+		if err := fn(event); err != nil {
+			return last, err
+		}
+		last = event.ResourceVersion
+	}
+	return last, nil
+}
+
+func writtenEvent(action WatchEvent_Type, r *ResourceWrapper, rv int64) *WrittenEvent {
+	return &WrittenEvent{
+		WriteEvent: WriteEvent{
+			Type:  action,
+			Key:   &ResourceKey{Namespace: testTenant, Group: "dashboard.grafana.app", Resource: "dashboards"},
+			Value: r.Value,
+		},
+		ResourceVersion: rv,
+	}
+}
+
+func deletedMarker(t *testing.T, uid string) *ResourceWrapper {
+	value, err := json.Marshal(&DeletedMarker{ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid), Namespace: testTenant}})
+	require.NoError(t, err)
+	return &ResourceWrapper{Value: value}
+}
+
+func withResourceVersion(r *ResourceWrapper, rv int64) *ResourceWrapper {
+	r.ResourceVersion = rv
+	return r
+}
+
+func closeShards(t *testing.T, index *Index) {
+	for _, shard := range index.shards {
+		require.NoError(t, shard.index.Close())
+	}
+}
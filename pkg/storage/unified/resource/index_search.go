@@ -0,0 +1,174 @@
+package resource
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+const (
+	defaultSearchLimit = 10
+	defaultFacetLimit  = 10
+)
+
+type SearchContinueToken struct {
+	StartOffset int64 `json:"o"`
+}
+
+func (c SearchContinueToken) String() string {
+	b, _ := json.Marshal(c)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func GetSearchContinueToken(token string) (*SearchContinueToken, error) {
+	continueVal, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding continue token")
+	}
+
+	t := &SearchContinueToken{}
+	err = json.Unmarshal(continueVal, t)
+	if err != nil {
+		return nil, err
+	}
+	if t.StartOffset < 0 {
+		return nil, fmt.Errorf("invalid continue token offset")
+	}
+
+	return t, nil
+}
+
+// newBleveSearchRequest converts a SearchRequest into a bleve search request
+func newBleveSearchRequest(request *SearchRequest) (*bleve.SearchRequest, error) {
+	q, err := newBleveQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(request.Limit)
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	offset := int(request.Offset)
+	if request.NextPageToken != "" {
+		token, err := GetSearchContinueToken(request.NextPageToken)
+		if err != nil {
+			return nil, err
+		}
+		offset = int(token.StartOffset)
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	req := bleve.NewSearchRequestOptions(q, limit, offset, false)
+	// return all stored fields so the results can be converted back into resources
+	req.Fields = []string{"*"}
+
+	for _, f := range request.Facets {
+		if f.Field == "" {
+			return nil, fmt.Errorf("facet field is required")
+		}
+		size := int(f.Limit)
+		if size <= 0 {
+			size = defaultFacetLimit
+		}
+		req.AddFacet(f.Field, bleve.NewFacetRequest(f.Field, size))
+	}
+
+	if len(request.SortBy) > 0 {
+		sortBy := make([]string, 0, len(request.SortBy)+1)
+		for _, field := range request.SortBy {
+			sortBy = append(sortBy, sortField(field))
+		}
+		// keep the order stable between pages when the sort fields have equal values
+		sortBy = append(sortBy, "_id")
+		req.SortBy(sortBy)
+	}
+
+	return req, nil
+}
+
+// newBleveQuery combines the query string with the field filters of the request
+func newBleveQuery(request *SearchRequest) (query.Query, error) {
+	queries := []query.Query{}
+	if request.Query == "" {
+		queries = append(queries, bleve.NewMatchAllQuery())
+	} else {
+		queries = append(queries, bleve.NewQueryStringQuery(request.Query))
+	}
+
+	if len(request.Folders) > 0 {
+		folders := make([]query.Query, 0, len(request.Folders))
+		for _, folder := range request.Folders {
+			folders = append(folders, termQuery("FolderId", folder))
+		}
+		queries = append(queries, bleve.NewDisjunctionQuery(folders...))
+	}
+
+	for _, tag := range request.Tags {
+		queries = append(queries, termQuery("Tags", tag))
+	}
+
+	for _, label := range request.Labels {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label filter %q, expected key=value", label)
+		}
+		queries = append(queries, termQuery("Labels."+key, value))
+	}
+
+	if len(queries) == 1 {
+		return queries[0], nil
+	}
+	return bleve.NewConjunctionQuery(queries...), nil
+}
+
+func termQuery(field string, term string) query.Query {
+	q := bleve.NewTermQuery(term)
+	q.SetField(field)
+	return q
+}
+
+// sortField maps a requested sort field to the indexed field used for sorting
+func sortField(field string) string {
+	desc := strings.HasPrefix(field, "-")
+	name := strings.TrimPrefix(field, "-")
+	if name == "Title" {
+		name = titleSortField
+	}
+	if desc {
+		return "-" + name
+	}
+	return name
+}
+
+func facetsFromResult(results search.FacetResults) map[string]*SearchFacet {
+	if len(results) == 0 {
+		return nil
+	}
+	facets := make(map[string]*SearchFacet, len(results))
+	for name, result := range results {
+		facet := &SearchFacet{
+			Field:   result.Field,
+			Total:   int64(result.Total),
+			Missing: int64(result.Missing),
+			Other:   int64(result.Other),
+		}
+		if result.Terms != nil {
+			for _, term := range result.Terms.Terms() {
+				facet.Terms = append(facet.Terms, &SearchFacet_Term{
+					Term:  term.Term,
+					Count: int64(term.Count),
+				})
+			}
+		}
+		facets[name] = facet
+	}
+	return facets
+}
@@ -0,0 +1,124 @@
+package resource
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexSearch(t *testing.T) {
+	index := newTestIndex(t)
+	list := &ListResponse{Items: []*ResourceWrapper{
+		testDashboard("1", "beta", "folder-a", `["prod", "db"]`, `{"team": "core"}`),
+		testDashboard("2", "Alpha", "folder-b", `["prod"]`, `{"team": "edge"}`),
+		testDashboard("3", "gamma", "", `["dev"]`, `{}`),
+	}}
+	_, err := index.AddToBatches(testContext, list)
+	require.NoError(t, err)
+	err = index.IndexBatches(testContext, 1, []string{testTenant})
+	require.NoError(t, err)
+
+	search := func(t *testing.T, req *SearchRequest) *IndexResults {
+		t.Helper()
+		req.Tenant = testTenant
+		results, err := index.Search(testContext, req)
+		require.NoError(t, err)
+		return results
+	}
+	uids := func(results *IndexResults) []string {
+		res := make([]string, 0, len(results.Values))
+		for _, v := range results.Values {
+			res = append(res, v.Uid)
+		}
+		return res
+	}
+
+	t.Run("should match all resources without a query", func(t *testing.T) {
+		results := search(t, &SearchRequest{})
+		assert.Equal(t, uint64(3), results.Total)
+		assert.Len(t, results.Values, 3)
+		assert.Empty(t, results.NextPageToken)
+	})
+
+	t.Run("should filter by folder", func(t *testing.T) {
+		results := search(t, &SearchRequest{Folders: []string{"folder-a", "folder-b"}, SortBy: []string{"Title"}})
+		assert.Equal(t, []string{"2", "1"}, uids(results))
+		assert.Equal(t, "folder-b", results.Values[0].FolderId)
+	})
+
+	t.Run("should only match resources with all tags", func(t *testing.T) {
+		results := search(t, &SearchRequest{Tags: []string{"prod", "db"}})
+		assert.Equal(t, []string{"1"}, uids(results))
+		assert.ElementsMatch(t, []string{"prod", "db"}, results.Values[0].Tags)
+	})
+
+	t.Run("should filter by label", func(t *testing.T) {
+		results := search(t, &SearchRequest{Labels: []string{"team=edge"}})
+		assert.Equal(t, []string{"2"}, uids(results))
+		assert.Equal(t, map[string]string{"team": "edge"}, results.Values[0].Labels)
+	})
+
+	t.Run("should fail on invalid label filter", func(t *testing.T) {
+		_, err := index.Search(testContext, &SearchRequest{Tenant: testTenant, Labels: []string{"team"}})
+		require.Error(t, err)
+	})
+
+	t.Run("should sort by title ignoring case", func(t *testing.T) {
+		results := search(t, &SearchRequest{SortBy: []string{"Title"}})
+		assert.Equal(t, []string{"2", "1", "3"}, uids(results))
+
+		results = search(t, &SearchRequest{SortBy: []string{"-Title"}})
+		assert.Equal(t, []string{"3", "1", "2"}, uids(results))
+	})
+
+	t.Run("should count facets", func(t *testing.T) {
+		results := search(t, &SearchRequest{Facets: []*SearchFacetRequest{{Field: "Tags"}}})
+		require.Contains(t, results.Facets, "Tags")
+		facet := results.Facets["Tags"]
+		assert.Equal(t, int64(4), facet.Total)
+		terms := map[string]int64{}
+		for _, term := range facet.Terms {
+			terms[term.Term] = term.Count
+		}
+		assert.Equal(t, map[string]int64{"prod": 2, "db": 1, "dev": 1}, terms)
+	})
+
+	t.Run("should page through results with the next page token", func(t *testing.T) {
+		req := &SearchRequest{Limit: 2, SortBy: []string{"Title"}}
+		results := search(t, req)
+		assert.Equal(t, []string{"2", "1"}, uids(results))
+		require.NotEmpty(t, results.NextPageToken)
+
+		results = search(t, &SearchRequest{Limit: 2, SortBy: []string{"Title"}, NextPageToken: results.NextPageToken})
+		assert.Equal(t, []string{"3"}, uids(results))
+		assert.Empty(t, results.NextPageToken)
+	})
+
+	t.Run("should fail on invalid page token", func(t *testing.T) {
+		_, err := index.Search(testContext, &SearchRequest{Tenant: testTenant, NextPageToken: "invalid"})
+		require.Error(t, err)
+	})
+}
+
+func testDashboard(uid, title, folder, tags, labels string) *ResourceWrapper {
+	resource := `{
+		"kind": "Dashboard",
+		"apiVersion": "dashboard.grafana.app/v0alpha1",
+		"metadata": {
+			"uid": "<uid>",
+			"name": "<uid>",
+			"namespace": "default",
+			"creationTimestamp": "2024-10-30T18:30:54Z",
+			"annotations": {"grafana.app/folder": "<folder>"},
+			"labels": <labels>
+		},
+		"spec": {
+			"title": "<title>",
+			"tags": <tags>
+		}
+	}`
+	r := strings.NewReplacer("<uid>", uid, "<title>", title, "<folder>", folder, "<tags>", tags, "<labels>", labels)
+	return &ResourceWrapper{Value: []byte(r.Replace(resource))}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	"github.com/grafana/grafana/pkg/setting"
@@ -28,12 +29,16 @@ func (is *IndexServer) Search(ctx context.Context, req *SearchRequest) (*SearchR
 	ctx, span := is.tracer.Start(ctx, tracingPrefixIndexServer+"Search")
 	defer span.End()
 
-	results, err := is.index.Search(ctx, req.Tenant, req.Query, int(req.Limit), int(req.Offset))
+	results, err := is.index.Search(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	res := &SearchResponse{}
-	for _, r := range results {
+	res := &SearchResponse{
+		TotalHits:     int64(results.Total),
+		Facets:        results.Facets,
+		NextPageToken: results.NextPageToken,
+	}
+	for _, r := range results.Values {
 		resJsonBytes, err := json.Marshal(r)
 		if err != nil {
 			return nil, err
@@ -67,9 +72,30 @@ func (is *IndexServer) Load(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+
+	if is.cfg.IndexRebuildInterval > 0 {
+		go is.rebuild(ctx, is.cfg.IndexRebuildInterval)
+	}
 	return nil
 }
 
+// rebuild periodically rebuilds the index until the context is done
+func (is *IndexServer) rebuild(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			is.log.Info("rebuilding index")
+			if err := is.index.Rebuild(ctx); err != nil {
+				is.log.Error("Error rebuilding index", "error", err)
+			}
+		}
+	}
+}
+
 // Watch resources for changes and update the index
 func (is *IndexServer) Watch(ctx context.Context) error {
 	rtList := fetchResourceTypes()
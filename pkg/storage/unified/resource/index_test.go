@@ -129,7 +129,7 @@ func assertCountEquals(t *testing.T, index *Index, expected uint64) {
 }
 
 func assertSearchCountEquals(t *testing.T, index *Index, expected int) {
-	results, err := index.Search(testContext, testTenant, "*", expected+1, 0)
+	results, err := index.Search(testContext, &SearchRequest{Tenant: testTenant, Query: "*", Limit: int64(expected + 1)})
 	require.NoError(t, err)
-	assert.Equal(t, expected, len(results))
+	assert.Equal(t, expected, len(results.Values))
 }
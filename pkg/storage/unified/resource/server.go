@@ -77,6 +77,14 @@ type StorageBackend interface {
 	WatchWriteEvents(ctx context.Context) (<-chan *WrittenEvent, error)
 }
 
+// The ChangeLog is implemented by storage backends that keep the history of the writes.
+// The index uses it to catch up from the resource version of its persisted shards without listing every resource.
+type ChangeLog interface {
+	// Read the events written to a group/resource after the resource version, oldest first.
+	// Returns the resource version of the last event, or since when there are none
+	ReadChangesSince(ctx context.Context, group, resource string, since int64, fn func(*WrittenEvent) error) (int64, error)
+}
+
 // This interface is not exposed to end users directly
 // Access to this interface is already gated by access control
 type BlobSupport interface {
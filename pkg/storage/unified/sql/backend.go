@@ -31,6 +31,7 @@ type Backend interface {
 	resource.StorageBackend
 	resource.DiagnosticsServer
 	resource.LifecycleHooks
+	resource.ChangeLog
 }
 
 type BackendOptions struct {
@@ -617,6 +618,26 @@ func fetchLatestRV(ctx context.Context, x db.ContextExecer, d sqltemplate.Dialec
 func (b *backend) poll(ctx context.Context, grp string, res string, since int64, stream chan<- *resource.WrittenEvent) (int64, error) {
 	ctx, span := b.tracer.Start(ctx, tracePrefix+"poll")
 	defer span.End()
+
+	var nextRV int64
+	_, err := b.readHistorySince(ctx, grp, res, since, func(event *resource.WrittenEvent) error {
+		nextRV = event.ResourceVersion
+		stream <- event
+		return nil
+	})
+	return nextRV, err
+}
+
+// ReadChangesSince implements resource.ChangeLog.
+func (b *backend) ReadChangesSince(ctx context.Context, group, res string, since int64, fn func(*resource.WrittenEvent) error) (int64, error) {
+	ctx, span := b.tracer.Start(ctx, tracePrefix+"ReadChangesSince")
+	defer span.End()
+
+	return b.readHistorySince(ctx, group, res, since, fn)
+}
+
+// readHistorySince calls fn with the events of the history written after since, and returns the resource version of the last one.
+func (b *backend) readHistorySince(ctx context.Context, grp string, res string, since int64, fn func(*resource.WrittenEvent) error) (int64, error) {
 	var records []*historyPollResponse
 	err := b.db.WithTx(ctx, ReadCommittedRO, func(ctx context.Context, tx db.Tx) error {
 		var err error
@@ -630,20 +651,19 @@ func (b *backend) poll(ctx context.Context, grp string, res string, since int64,
 		return err
 	})
 	if err != nil {
-		return 0, fmt.Errorf("poll history: %w", err)
+		return since, fmt.Errorf("poll history: %w", err)
 	}
 
-	var nextRV int64
+	lastRV := since
 	for _, rec := range records {
 		if rec.Key.Group == "" || rec.Key.Resource == "" || rec.Key.Name == "" {
-			return nextRV, fmt.Errorf("missing key in response")
+			return lastRV, fmt.Errorf("missing key in response")
 		}
-		nextRV = rec.ResourceVersion
-		prevRV := rec.PreviousRV
-		if prevRV == nil {
-			*prevRV = int64(0)
+		var prevRV int64
+		if rec.PreviousRV != nil {
+			prevRV = *rec.PreviousRV
 		}
-		stream <- &resource.WrittenEvent{
+		err := fn(&resource.WrittenEvent{
 			WriteEvent: resource.WriteEvent{
 				Value: rec.Value,
 				Key: &resource.ResourceKey{
@@ -653,14 +673,18 @@ func (b *backend) poll(ctx context.Context, grp string, res string, since int64,
 					Name:      rec.Key.Name,
 				},
 				Type:       resource.WatchEvent_Type(rec.Action),
-				PreviousRV: *prevRV,
+				PreviousRV: prevRV,
 			},
 			ResourceVersion: rec.ResourceVersion,
 			// Timestamp:  , // TODO: add timestamp
+		})
+		if err != nil {
+			return lastRV, err
 		}
+		lastRV = rec.ResourceVersion
 	}
 
-	return nextRV, nil
+	return lastRV, nil
 }
 
 // resourceVersionAtomicInc atomically increases the version of a kind within a transaction.
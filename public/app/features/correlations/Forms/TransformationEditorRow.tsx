@@ -146,8 +146,8 @@ const TransformationEditorRow = (props: Props) => {
                 <div>
                   <p>
                     <Trans i18nKey="correlations.transform-row.expression-tooltip">
-                      Required for regular expression. The expression the transformation will use. Logfmt does not use
-                      further specifications.
+                      Required for regular expression, JSONPath and template. The expression the transformation will
+                      use. Logfmt does not use further specifications.
                     </Trans>
                   </p>
                 </div>
@@ -178,14 +178,17 @@ const TransformationEditorRow = (props: Props) => {
           <Stack gap={0.5}>
             <Label htmlFor={`config.transformations.${defaultValue.id}.mapValue`}>
               <Trans i18nKey="correlations.transform-row.map-value-label">Map value</Trans>
+              {getSupportedTransTypeDetails(watch(`config.transformations.${index}.type`)).mapValueDetails.required
+                ? ' *'
+                : ''}
             </Label>
             <Tooltip
               content={
                 <div>
                   <p>
                     <Trans i18nKey="correlations.transform-row.map-value-tooltip">
-                      Optional. Defines the name of the variable. This is currently only valid for regular expressions
-                      with a single, unnamed capture group.
+                      Defines the name of the variable. Optional for regular expressions with a single, unnamed capture
+                      group and for JSONPath. Required for template, URL encode and lowercase.
                     </Trans>
                   </p>
                 </div>
@@ -195,9 +198,16 @@ const TransformationEditorRow = (props: Props) => {
             </Tooltip>
           </Stack>
         }
+        invalid={!!formState.errors?.config?.transformations?.[index]?.mapValue}
+        error={formState.errors?.config?.transformations?.[index]?.mapValue?.message}
       >
         <Input
-          {...register(`config.transformations.${index}.mapValue`)}
+          {...register(`config.transformations.${index}.mapValue`, {
+            required: getSupportedTransTypeDetails(watch(`config.transformations.${index}.type`)).mapValueDetails
+              .required
+              ? t('correlations.transform-row.map-value-required', 'Please define a map value')
+              : undefined,
+          })}
           defaultValue={defaultValue.mapValue}
           readOnly={readOnly}
           disabled={!getSupportedTransTypeDetails(watch(`config.transformations.${index}.type`)).mapValueDetails.show}
@@ -92,6 +92,83 @@ export function getSupportedTransTypeDetails(
           ),
         },
       };
+    case SupportedTransformationType.JSONPath:
+      return {
+        label: t('correlations.trans-details.jsonpath-label', 'JSONPath'),
+        value: SupportedTransformationType.JSONPath,
+        description: t(
+          'correlations.trans-details.jsonpath-description',
+          'Field will be parsed as JSON and the value selected by the JSONPath expression is added as a variable.'
+        ),
+        expressionDetails: {
+          show: true,
+          required: true,
+          helpText: t(
+            'correlations.trans-details.jsonpath-expression',
+            'JSONPath expression selecting a single value, for example $.trace.id or $.spans[0]["span-id"].'
+          ),
+        },
+        mapValueDetails: {
+          show: true,
+          required: false,
+          helpText: t(
+            'correlations.trans-details.jsonpath-map-values',
+            'Defines the name of the variable. Defaults to the name of the field.'
+          ),
+        },
+      };
+    case SupportedTransformationType.Template:
+      return {
+        label: t('correlations.trans-details.template-label', 'Template'),
+        value: SupportedTransformationType.Template,
+        description: t(
+          'correlations.trans-details.template-description',
+          'Combines variables created by the previous transformations into a new variable.'
+        ),
+        expressionDetails: {
+          show: true,
+          required: true,
+          helpText: t(
+            'correlations.trans-details.template-expression',
+            'Text with variables referenced as $name or ${name}, for example ${namespace}/${pod}.'
+          ),
+        },
+        mapValueDetails: {
+          show: true,
+          required: true,
+          helpText: t('correlations.trans-details.template-map-values', 'Defines the name of the new variable.'),
+        },
+      };
+    case SupportedTransformationType.URLEncode:
+      return {
+        label: t('correlations.trans-details.urlencode-label', 'URL encode'),
+        value: SupportedTransformationType.URLEncode,
+        description: t(
+          'correlations.trans-details.urlencode-description',
+          'URL-encodes the value of a variable created by the previous transformations.'
+        ),
+        expressionDetails: { show: false },
+        mapValueDetails: {
+          show: true,
+          required: true,
+          helpText: t('correlations.trans-details.urlencode-map-values', 'Name of the variable to encode.'),
+        },
+      };
+    case SupportedTransformationType.Lowercase:
+      return {
+        label: t('correlations.trans-details.lowercase-label', 'Lowercase'),
+        value: SupportedTransformationType.Lowercase,
+        description: t(
+          'correlations.trans-details.lowercase-description',
+          'Converts the value of a variable created by the previous transformations to lowercase.'
+        ),
+        expressionDetails: { show: false },
+        mapValueDetails: {
+          show: true,
+          required: true,
+          helpText: t('correlations.trans-details.lowercase-map-values', 'Name of the variable to convert.'),
+        },
+      };
     default:
       return {
         label: transType,
@@ -0,0 +1,61 @@
+import { SupportedTransformationType } from '@grafana/data';
+
+import { getJSONPathValue, getTransformationVars, interpolateTemplate } from './transformations';
+
+describe('correlation transformations', () => {
+  const logLine = JSON.stringify({ trace: { id: 'abc123' }, spans: [{ 'span-id': 'def456' }], level: 'ERROR' });
+
+  it('extracts a value with JSONPath', () => {
+    expect(
+      getTransformationVars(
+        { type: SupportedTransformationType.JSONPath, expression: '$.trace.id', mapValue: 'traceId' },
+        logLine,
+        'line'
+      )
+    ).toEqual({ traceId: { value: 'abc123' } });
+  });
+
+  it('uses the field name if map value is not defined for JSONPath', () => {
+    expect(
+      getTransformationVars(
+        { type: SupportedTransformationType.JSONPath, expression: "$.spans[0]['span-id']" },
+        logLine,
+        'line'
+      )
+    ).toEqual({ line: { value: 'def456' } });
+  });
+
+  it('returns no variables if the JSONPath does not match', () => {
+    expect(getJSONPathValue(logLine, '$.trace.parent')).toBeUndefined();
+    expect(getJSONPathValue('not json', '$.trace.id')).toBeUndefined();
+    expect(
+      getTransformationVars({ type: SupportedTransformationType.JSONPath, expression: '$.missing' }, logLine, 'line')
+    ).toEqual({});
+  });
+
+  it('combines variables with a template', () => {
+    const vars = { namespace: { value: 'prod' }, pod: { value: 'api-1' } };
+    expect(
+      getTransformationVars(
+        { type: SupportedTransformationType.Template, expression: '${namespace}/$pod', mapValue: 'target' },
+        logLine,
+        'line',
+        vars
+      )
+    ).toEqual({ target: { value: 'prod/api-1' } });
+  });
+
+  it('does not create the template variable if a variable is missing', () => {
+    expect(interpolateTemplate('${namespace}/${pod}', { namespace: { value: 'prod' } })).toBeUndefined();
+  });
+
+  it('url encodes and lowercases variables', () => {
+    const vars = { query: { value: 'a b&c' }, level: { value: 'ERROR' } };
+    expect(
+      getTransformationVars({ type: SupportedTransformationType.URLEncode, mapValue: 'query' }, logLine, 'line', vars)
+    ).toEqual({ query: { value: 'a%20b%26c' } });
+    expect(
+      getTransformationVars({ type: SupportedTransformationType.Lowercase, mapValue: 'level' }, logLine, 'line', vars)
+    ).toEqual({ level: { value: 'error' } });
+  });
+});
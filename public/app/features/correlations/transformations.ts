@@ -3,10 +3,15 @@ import logfmt from 'logfmt';
 import { ScopedVars, DataLinkTransformationConfig, SupportedTransformationType } from '@grafana/data';
 import { safeStringifyValue } from 'app/core/utils/explore';
 
+/**
+ * Returns variables created by the transformation. Transformations that combine or modify variables
+ * (template, urlencode and lowercase) read the variables created so far from `vars`.
+ */
 export const getTransformationVars = (
   transformation: DataLinkTransformationConfig,
   fieldValue: string,
-  fieldName: string
+  fieldName: string,
+  vars: ScopedVars = {}
 ): ScopedVars => {
   let transformationScopedVars: ScopedVars = {};
   let transformVal: { [key: string]: string | boolean | null | undefined } = {};
@@ -24,6 +29,27 @@ export const getTransformationVars = (
     }
   } else if (transformation.type === SupportedTransformationType.Logfmt) {
     transformVal = logfmt.parse(fieldValue);
+  } else if (transformation.type === SupportedTransformationType.JSONPath && transformation.expression) {
+    const value = getJSONPathValue(fieldValue, transformation.expression);
+    if (value !== undefined) {
+      transformVal[transformation.mapValue || fieldName] =
+        typeof value === 'string' ? value : safeStringifyValue(value);
+    }
+  } else if (transformation.type === SupportedTransformationType.Template && transformation.expression) {
+    const value = interpolateTemplate(transformation.expression, vars);
+    if (value !== undefined && transformation.mapValue) {
+      transformVal[transformation.mapValue] = value;
+    }
+  } else if (transformation.type === SupportedTransformationType.URLEncode && transformation.mapValue) {
+    const value = vars[transformation.mapValue]?.value;
+    if (value !== undefined) {
+      transformVal[transformation.mapValue] = encodeURIComponent(String(value));
+    }
+  } else if (transformation.type === SupportedTransformationType.Lowercase && transformation.mapValue) {
+    const value = vars[transformation.mapValue]?.value;
+    if (value !== undefined) {
+      transformVal[transformation.mapValue] = String(value).toLowerCase();
+    }
   }
 
   Object.keys(transformVal).forEach((key) => {
@@ -34,3 +60,53 @@ export const getTransformationVars = (
 
   return transformationScopedVars;
 };
+
+const jsonPathSegmentRegex = /\.([^.[]+)|\[(?:'([^']*)'|"([^"]*)"|(\d+))\]/y;
+
+/**
+ * Returns the value selected by the JSONPath expression or undefined if the value does not exist.
+ * Supports the same subset of JSONPath as the server side validation: dot notation, bracket notation and array indexes.
+ */
+export function getJSONPathValue(fieldValue: unknown, expression: string): unknown {
+  let current: unknown = fieldValue;
+  if (typeof current === 'string') {
+    try {
+      current = JSON.parse(current);
+    } catch (e) {
+      return undefined;
+    }
+  }
+  if (!expression.startsWith('$')) {
+    return undefined;
+  }
+
+  jsonPathSegmentRegex.lastIndex = 1;
+  while (jsonPathSegmentRegex.lastIndex < expression.length) {
+    const match = jsonPathSegmentRegex.exec(expression);
+    if (!match || current === null || typeof current !== 'object') {
+      return undefined;
+    }
+    const key = match[1] ?? match[2] ?? match[3] ?? Number(match[4]);
+    current = (current as Record<string | number, unknown>)[key];
+  }
+  return current;
+}
+
+const templateVariableRegex = /\$\{(\w+)\}|\$(\w+)/g;
+
+/**
+ * Replaces $name and ${name} references with values of the variables.
+ * Returns undefined if any of the variables is missing.
+ */
+export function interpolateTemplate(template: string, vars: ScopedVars): string | undefined {
+  let missing = false;
+  const result = template.replace(templateVariableRegex, (_, bracedName?: string, name?: string) => {
+    const value = vars[bracedName ?? name ?? '']?.value;
+    if (value === undefined || value === null) {
+      missing = true;
+      return '';
+    }
+    return String(value);
+  });
+  return missing ? undefined : result;
+}
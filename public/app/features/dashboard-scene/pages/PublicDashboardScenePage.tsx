@@ -11,6 +11,7 @@ import PageLoader from 'app/core/components/PageLoader/PageLoader';
 import { GrafanaRouteComponentProps } from 'app/core/navigation/types';
 import { PublicDashboardFooter } from 'app/features/dashboard/components/PublicDashboard/PublicDashboardsFooter';
 import { PublicDashboardNotAvailable } from 'app/features/dashboard/components/PublicDashboardNotAvailable/PublicDashboardNotAvailable';
+import { PublicDashboardPassphrase } from 'app/features/dashboard/components/PublicDashboardPassphrase/PublicDashboardPassphrase';
 import {
   PublicDashboardPageRouteParams,
   PublicDashboardPageRouteSearchParams,
@@ -55,6 +56,14 @@ export function PublicDashboardScenePage({ route }: Props) {
     return <PublicDashboardNotAvailable paused />;
   }
 
+  if (dashboard.state.meta.publicDashboardExpired) {
+    return <PublicDashboardNotAvailable expired />;
+  }
+
+  if (dashboard.state.meta.publicDashboardPassphraseRequired) {
+    return <PublicDashboardPassphrase accessToken={accessToken} />;
+  }
+
   if (dashboard.state.meta.dashboardNotFound) {
     return <PublicDashboardNotAvailable />;
   }
@@ -9,11 +9,18 @@ import { Switch } from '@grafana/ui/src/components/Switch/Switch';
 import { contextSrv } from 'app/core/core';
 import { t, Trans } from 'app/core/internationalization';
 import { publicDashboardApi, useUpdatePublicDashboardMutation } from 'app/features/dashboard/api/publicDashboardApi';
+import { AccessConfiguration } from 'app/features/dashboard/components/ShareModal/SharePublicDashboard/ConfigPublicDashboard/AccessConfiguration';
 import { ConfigPublicDashboardForm } from 'app/features/dashboard/components/ShareModal/SharePublicDashboard/ConfigPublicDashboard/ConfigPublicDashboard';
+import { TemplateVariablesConfiguration } from 'app/features/dashboard/components/ShareModal/SharePublicDashboard/ConfigPublicDashboard/TemplateVariablesConfiguration';
+import {
+  PublicDashboard,
+  PublicDashboardTemplateVariable,
+} from 'app/features/dashboard/components/ShareModal/SharePublicDashboard/SharePublicDashboardUtils';
 import { DashboardInteractions } from 'app/features/dashboard-scene/utils/interactions';
 import { AccessControlAction } from 'app/types';
 
 import { useShareDrawerContext } from '../../ShareDrawer/ShareDrawerContext';
+import { getTemplateVariableOptions } from '../../public-dashboards/utils';
 
 const selectors = e2eSelectors.pages.ShareDashboardDrawer.ShareExternally.Configuration;
 
@@ -32,6 +39,7 @@ export default function ShareConfiguration() {
   const disableForm = isLoading || !hasWritePermissions;
   const timeRangeState = sceneGraph.getTimeRange(dashboard);
   const timeRange = timeRangeState.useState();
+  const templateVariables = getTemplateVariableOptions(dashboard);
 
   const { handleSubmit, setValue, control } = useForm<FormInput>({
     defaultValues: {
@@ -58,6 +66,26 @@ export default function ShareConfiguration() {
     });
   };
 
+  const onTemplateVariablesChange = (value: PublicDashboardTemplateVariable[]) => {
+    update({
+      dashboard: dashboard,
+      payload: {
+        ...publicDashboard!,
+        templateVariables: value,
+      },
+    });
+  };
+
+  const onAccessChange = (value: Partial<PublicDashboard>) => {
+    update({
+      dashboard: dashboard,
+      payload: {
+        ...publicDashboard!,
+        ...value,
+      },
+    });
+  };
+
   return (
     <Stack direction="column" gap={2}>
       <Text element="p">
@@ -144,6 +172,23 @@ export default function ShareConfiguration() {
         </form>
         {isLoading && <Spinner />}
       </Stack>
+      <Text element="p">
+        <Trans i18nKey="public-dashboard.configuration.access-label">Access</Trans>
+      </Text>
+      <AccessConfiguration disabled={disableForm} publicDashboard={publicDashboard} onChange={onAccessChange} />
+      {templateVariables.length > 0 && (
+        <>
+          <Text element="p">
+            <Trans i18nKey="public-dashboard.configuration.template-variables-label">Template variables</Trans>
+          </Text>
+          <TemplateVariablesConfiguration
+            disabled={disableForm}
+            variables={templateVariables}
+            value={publicDashboard?.templateVariables}
+            onChange={onTemplateVariablesChange}
+          />
+        </>
+      )}
     </Stack>
   );
 }
@@ -16,6 +16,7 @@ import { ShareModal } from '../ShareModal';
 import { ConfirmModal } from './ConfirmModal';
 import { SharePublicDashboardTab } from './SharePublicDashboardTab';
 import { useUnsupportedDatasources } from './hooks';
+import { getTemplateVariableOptions } from './utils';
 
 interface Props extends SceneComponentProps<SharePublicDashboardTab> {
   publicDashboard?: PublicDashboard;
@@ -31,6 +32,7 @@ export function ConfigPublicDashboard({ model, publicDashboard, isGetLoading }:
   const { isDirty } = dashboard.useState();
   const [deletePublicDashboard] = useDeletePublicDashboardMutation();
   const hasTemplateVariables = (dashboard.state.$variables?.state.variables.length ?? 0) > 0;
+  const templateVariables = getTemplateVariableOptions(dashboard);
   const unsupportedDataSources = useUnsupportedDatasources(dashboard);
   const timeRangeState = sceneGraph.getTimeRange(model);
   const timeRange = timeRangeState.useState();
@@ -65,6 +67,7 @@ export function ConfigPublicDashboard({ model, publicDashboard, isGetLoading }:
       timeRange={timeRange.value}
       showSaveChangesAlert={hasWritePermissions && isDirty}
       hasTemplateVariables={hasTemplateVariables}
+      templateVariables={templateVariables}
     />
   );
 }
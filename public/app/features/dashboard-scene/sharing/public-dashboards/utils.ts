@@ -1,5 +1,6 @@
 import { DataSourceWithBackend } from '@grafana/runtime';
-import { VizPanel } from '@grafana/scenes';
+import { AdHocFiltersVariable, DataSourceVariable, MultiValueVariable, VizPanel } from '@grafana/scenes';
+import { DashboardTemplateVariableOptions } from 'app/features/dashboard/components/ShareModal/SharePublicDashboard/SharePublicDashboardUtils';
 import { supportedDatasources } from 'app/features/dashboard/components/ShareModal/SharePublicDashboard/SupportedPubdashDatasources';
 import { getDatasourceSrv } from 'app/features/plugins/datasource_srv';
 
@@ -50,3 +51,20 @@ function panelDatasourceTypes(vizPanel: VizPanel) {
 
   return Array.from(types);
 }
+
+/**
+ * Get the template variables that viewers of a public dashboard can be allowed to change.
+ */
+export function getTemplateVariableOptions(scene: DashboardScene): DashboardTemplateVariableOptions[] {
+  const variables = scene.state.$variables?.state.variables ?? [];
+
+  return variables
+    .filter((variable) => !(variable instanceof AdHocFiltersVariable) && !(variable instanceof DataSourceVariable))
+    .map((variable) => ({
+      name: variable.state.name,
+      options:
+        variable instanceof MultiValueVariable
+          ? variable.state.options.map((option) => String(option.value))
+          : [String(variable.getValue() ?? '')],
+    }));
+}
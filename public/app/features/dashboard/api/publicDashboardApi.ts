@@ -6,6 +6,7 @@ import { notifyApp } from 'app/core/actions';
 import { createErrorNotification, createSuccessNotification } from 'app/core/copy/appNotification';
 import { t } from 'app/core/internationalization';
 import {
+  getPublicDashboardUpdatePayload,
   PublicDashboard,
   PublicDashboardSettings,
   PublicDashboardShareType,
@@ -121,7 +122,7 @@ export const publicDashboardApi = createApi({
         return {
           url: `/dashboards/uid/${dashUid}/public-dashboards/${payload.uid}`,
           method: 'PATCH',
-          data: payload,
+          data: getPublicDashboardUpdatePayload(payload),
         };
       },
       async onQueryStarted({ dashboard }, { dispatch, queryFulfilled }) {
@@ -153,7 +154,7 @@ export const publicDashboardApi = createApi({
         return {
           url: `/dashboards/uid/${dashUid}/public-dashboards/${payload.uid}`,
           method: 'PATCH',
-          data: payload,
+          data: getPublicDashboardUpdatePayload(payload),
         };
       },
       async onQueryStarted({ dashboard, payload: { isEnabled } }, { dispatch, queryFulfilled }) {
@@ -193,7 +194,7 @@ export const publicDashboardApi = createApi({
         return {
           url: `/dashboards/uid/${dashUid}/public-dashboards/${payload.uid}`,
           method: 'PATCH',
-          data: payload,
+          data: getPublicDashboardUpdatePayload(payload),
         };
       },
       async onQueryStarted({ dashboard, payload: { share } }, { dispatch, queryFulfilled }) {
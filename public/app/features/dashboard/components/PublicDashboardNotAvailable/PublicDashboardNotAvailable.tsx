@@ -9,7 +9,7 @@ import { getLoginStyles } from '../../../../core/components/Login/LoginLayout';
 
 const selectors = e2eSelectors.pages.PublicDashboard.NotAvailable;
 
-export const PublicDashboardNotAvailable = ({ paused }: { paused?: boolean }) => {
+export const PublicDashboardNotAvailable = ({ paused, expired }: { paused?: boolean; expired?: boolean }) => {
   const styles = useStyles2(getStyles);
   const loginStyles = useStyles2(getLoginStyles);
   const loginBoxBackground = Branding.LoginBoxBackground();
@@ -21,13 +21,20 @@ export const PublicDashboardNotAvailable = ({ paused }: { paused?: boolean }) =>
         <p className={styles.title} data-testid={selectors.title}>
           {paused
             ? 'This dashboard has been paused by the administrator'
-            : 'The dashboard you are trying to access does not exist'}
+            : expired
+              ? 'The link to this dashboard has expired'
+              : 'The dashboard you are trying to access does not exist'}
         </p>
         {paused && (
           <p className={styles.description} data-testid={selectors.pausedDescription}>
             Try again later
           </p>
         )}
+        {expired && (
+          <p className={styles.description} data-testid={selectors.expiredDescription}>
+            Ask the dashboard owner for a new link
+          </p>
+        )}
       </div>
     </Branding.LoginBackground>
   );
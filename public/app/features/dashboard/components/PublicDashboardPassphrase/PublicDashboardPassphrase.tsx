@@ -0,0 +1,101 @@
+import { css, cx } from '@emotion/css';
+import { FormEvent, useState } from 'react';
+
+import { GrafanaTheme2 } from '@grafana/data/src';
+import { selectors as e2eSelectors } from '@grafana/e2e-selectors/src';
+import { getBackendSrv, isFetchError } from '@grafana/runtime';
+import { Alert, Button, Field, Input, useStyles2 } from '@grafana/ui/src';
+
+import { Branding } from '../../../../core/components/Branding/Branding';
+import { getLoginStyles } from '../../../../core/components/Login/LoginLayout';
+
+const selectors = e2eSelectors.pages.PublicDashboard.Passphrase;
+
+export const PublicDashboardPassphrase = ({ accessToken }: { accessToken: string }) => {
+  const styles = useStyles2(getStyles);
+  const loginStyles = useStyles2(getLoginStyles);
+  const loginBoxBackground = Branding.LoginBoxBackground();
+  const [passphrase, setPassphrase] = useState('');
+  const [isLoading, setIsLoading] = useState(false);
+  const [error, setError] = useState<string>();
+
+  const onSubmit = async (event: FormEvent) => {
+    event.preventDefault();
+    setIsLoading(true);
+    setError(undefined);
+
+    try {
+      await getBackendSrv().post(
+        `/api/public/dashboards/${accessToken}/passphrase`,
+        { passphrase },
+        { showErrorAlert: false }
+      );
+      // the passphrase is kept in a cookie, so reloading the dashboard is enough to unlock it
+      window.location.reload();
+    } catch (e) {
+      setError(isFetchError(e) && e.data?.message ? e.data.message : 'Failed to unlock the dashboard');
+      setIsLoading(false);
+    }
+  };
+
+  return (
+    <Branding.LoginBackground className={styles.container} data-testid={selectors.container}>
+      <div className={cx(styles.box, loginBoxBackground)}>
+        <Branding.LoginLogo className={loginStyles.loginLogo} />
+        <p className={styles.title}>This dashboard is protected by a passphrase</p>
+        <form className={styles.form} onSubmit={onSubmit}>
+          {error && <Alert severity="error" title={error} bottomSpacing={2} />}
+          <Field label="Passphrase">
+            <Input
+              type="password"
+              autoFocus
+              value={passphrase}
+              onChange={(e) => setPassphrase(e.currentTarget.value)}
+              data-testid={selectors.input}
+            />
+          </Field>
+          <Button
+            type="submit"
+            fullWidth
+            disabled={isLoading || passphrase === ''}
+            data-testid={selectors.submitButton}
+          >
+            {isLoading ? 'Unlocking...' : 'Unlock'}
+          </Button>
+        </form>
+      </div>
+    </Branding.LoginBackground>
+  );
+};
+
+const getStyles = (theme: GrafanaTheme2) => ({
+  container: css({
+    display: 'flex',
+    justifyContent: 'center',
+    alignItems: 'center',
+    height: '100%',
+
+    ':before': {
+      opacity: 1,
+    },
+  }),
+  box: css({
+    width: '608px',
+    display: 'flex',
+    alignItems: 'center',
+    flexDirection: 'column',
+    gap: theme.spacing(4),
+    zIndex: 1,
+    borderRadius: theme.shape.borderRadius(4),
+    padding: theme.spacing(6, 8),
+    opacity: 1,
+  }),
+  title: css({
+    fontSize: theme.typography.h3.fontSize,
+    textAlign: 'center',
+    margin: 0,
+  }),
+  form: css({
+    width: '100%',
+  }),
+});
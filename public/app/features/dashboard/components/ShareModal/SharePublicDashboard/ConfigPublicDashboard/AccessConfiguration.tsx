@@ -0,0 +1,163 @@
+import { useEffect, useState } from 'react';
+
+import { dateTimeFormat, SelectableValue } from '@grafana/data/src';
+import { selectors as e2eSelectors } from '@grafana/e2e-selectors/src';
+import { Button, FieldSet, Input, Label, Select, Stack, Text, VerticalGroup } from '@grafana/ui/src';
+import { Layout } from '@grafana/ui/src/components/Layout/Layout';
+import { Trans, t } from 'app/core/internationalization';
+
+import { isPublicDashboardExpired, NO_EXPIRATION, PublicDashboard } from '../SharePublicDashboardUtils';
+
+const selectors = e2eSelectors.pages.ShareDashboardModal.PublicDashboard;
+
+const HOUR = 60 * 60 * 1000;
+
+export const AccessConfiguration = ({
+  disabled,
+  publicDashboard,
+  onChange,
+}: {
+  disabled: boolean;
+  publicDashboard?: PublicDashboard;
+  onChange: (value: Partial<PublicDashboard>) => void;
+}) => {
+  const [passphrase, setPassphrase] = useState('');
+  const [queryRateLimit, setQueryRateLimit] = useState(String(publicDashboard?.queryRateLimit ?? 0));
+
+  useEffect(() => {
+    setQueryRateLimit(String(publicDashboard?.queryRateLimit ?? 0));
+  }, [publicDashboard?.queryRateLimit]);
+
+  const expiresAt = publicDashboard?.expiresAt;
+  const views = publicDashboard?.viewCount ?? 0;
+  const queries = publicDashboard?.queryCount ?? 0;
+  const expirationOptions: Array<SelectableValue<number>> = [
+    { label: t('public-dashboard.access-configuration.expiration-never', 'Never'), value: 0 },
+    { label: t('public-dashboard.access-configuration.expiration-hour', 'In 1 hour'), value: HOUR },
+    { label: t('public-dashboard.access-configuration.expiration-day', 'In 1 day'), value: 24 * HOUR },
+    { label: t('public-dashboard.access-configuration.expiration-week', 'In 7 days'), value: 7 * 24 * HOUR },
+    { label: t('public-dashboard.access-configuration.expiration-month', 'In 30 days'), value: 30 * 24 * HOUR },
+  ];
+
+  const onExpirationChange = (option: SelectableValue<number>) => {
+    onChange({
+      expiresAt: option.value ? new Date(Date.now() + option.value).toISOString() : NO_EXPIRATION,
+    });
+  };
+
+  const onPassphraseSave = () => {
+    onChange({ passphrase });
+    setPassphrase('');
+  };
+
+  const onQueryRateLimitSave = () => {
+    const value = Math.max(0, parseInt(queryRateLimit, 10) || 0);
+    setQueryRateLimit(String(value));
+    if (value !== (publicDashboard?.queryRateLimit ?? 0)) {
+      onChange({ queryRateLimit: value });
+    }
+  };
+
+  return (
+    <FieldSet disabled={disabled}>
+      <VerticalGroup spacing="md">
+        <Layout orientation={1} spacing="xs" justify="space-between">
+          <Label
+            description={
+              expiresAt
+                ? isPublicDashboardExpired(publicDashboard)
+                  ? t('public-dashboard.access-configuration.expired-label-desc', 'The link expired on {{date}}', {
+                      date: dateTimeFormat(expiresAt),
+                    })
+                  : t('public-dashboard.access-configuration.expires-label-desc', 'The link expires on {{date}}', {
+                      date: dateTimeFormat(expiresAt),
+                    })
+                : t('public-dashboard.access-configuration.never-expires-label-desc', 'The link never expires')
+            }
+          >
+            <Trans i18nKey="public-dashboard.access-configuration.expiration-label">Link expiration</Trans>
+          </Label>
+          <Select
+            options={expirationOptions}
+            disabled={disabled}
+            placeholder={t('public-dashboard.access-configuration.expiration-placeholder', 'Change expiration')}
+            onChange={onExpirationChange}
+            data-testid={selectors.ExpirationSelect}
+          />
+        </Layout>
+        <Layout orientation={1} spacing="xs" justify="space-between">
+          <Label
+            description={
+              publicDashboard?.passphraseEnabled
+                ? t(
+                    'public-dashboard.access-configuration.passphrase-enabled-label-desc',
+                    'Viewers need to enter the passphrase to access the dashboard'
+                  )
+                : t(
+                    'public-dashboard.access-configuration.passphrase-disabled-label-desc',
+                    'Set a passphrase viewers need to enter to access the dashboard'
+                  )
+            }
+          >
+            <Trans i18nKey="public-dashboard.access-configuration.passphrase-label">Passphrase</Trans>
+          </Label>
+          <Stack gap={1}>
+            <Input
+              type="password"
+              value={passphrase}
+              disabled={disabled}
+              placeholder={t(
+                'public-dashboard.access-configuration.passphrase-placeholder',
+                'Between 8 and 128 characters'
+              )}
+              onChange={(e) => setPassphrase(e.currentTarget.value)}
+              data-testid={selectors.PassphraseInput}
+            />
+            <Button
+              variant="secondary"
+              disabled={disabled || passphrase.length < 8 || passphrase.length > 128}
+              onClick={onPassphraseSave}
+            >
+              <Trans i18nKey="public-dashboard.access-configuration.passphrase-save-button">Set</Trans>
+            </Button>
+            {publicDashboard?.passphraseEnabled && (
+              <Button
+                variant="destructive"
+                fill="outline"
+                disabled={disabled}
+                onClick={() => onChange({ passphrase: '' })}
+              >
+                <Trans i18nKey="public-dashboard.access-configuration.passphrase-remove-button">Remove</Trans>
+              </Button>
+            )}
+          </Stack>
+        </Layout>
+        <Layout orientation={1} spacing="xs" justify="space-between">
+          <Label
+            description={t(
+              'public-dashboard.access-configuration.query-rate-limit-label-desc',
+              'Maximum number of queries per minute. Use 0 for no limit'
+            )}
+          >
+            <Trans i18nKey="public-dashboard.access-configuration.query-rate-limit-label">Query rate limit</Trans>
+          </Label>
+          <Input
+            type="number"
+            min={0}
+            width={20}
+            value={queryRateLimit}
+            disabled={disabled}
+            onChange={(e) => setQueryRateLimit(e.currentTarget.value)}
+            onBlur={onQueryRateLimitSave}
+            data-testid={selectors.QueryRateLimitInput}
+          />
+        </Layout>
+        <Text color="secondary" variant="bodySmall">
+          <Trans i18nKey="public-dashboard.access-configuration.usage" values={{ views, queries }}>
+            Viewed {{ views }} times, {{ queries }} queries
+          </Trans>
+        </Text>
+      </VerticalGroup>
+    </FieldSet>
+  );
+};
@@ -38,15 +38,20 @@ import { UnsupportedDataSourcesAlert } from '../ModalAlerts/UnsupportedDataSourc
 import { UnsupportedTemplateVariablesAlert } from '../ModalAlerts/UnsupportedTemplateVariablesAlert';
 import {
   dashboardHasTemplateVariables,
+  DashboardTemplateVariableOptions,
   generatePublicDashboardUrl,
+  getTemplateVariableOptions,
   isEmailSharingEnabled,
   PublicDashboard,
+  PublicDashboardTemplateVariable,
 } from '../SharePublicDashboardUtils';
 
+import { AccessConfiguration } from './AccessConfiguration';
 import { Configuration } from './Configuration';
 import { EmailSharingConfiguration } from './EmailSharingConfiguration';
 import { SettingsBar } from './SettingsBar';
 import { SettingsSummary } from './SettingsSummary';
+import { TemplateVariablesConfiguration } from './TemplateVariablesConfiguration';
 
 const selectors = e2eSelectors.pages.ShareDashboardModal.PublicDashboard;
 
@@ -61,6 +66,7 @@ interface Props {
   showSaveChangesAlert?: boolean;
   publicDashboard?: PublicDashboard;
   hasTemplateVariables?: boolean;
+  templateVariables?: DashboardTemplateVariableOptions[];
   timeRange: TimeRange;
   onRevoke: () => void;
   dashboard: DashboardModel | DashboardScene;
@@ -70,6 +76,7 @@ export function ConfigPublicDashboardBase({
   onRevoke,
   timeRange,
   hasTemplateVariables = false,
+  templateVariables = [],
   showSaveChangesAlert = false,
   unsupportedDatasources = [],
   publicDashboard,
@@ -123,6 +130,26 @@ export function ConfigPublicDashboardBase({
     await handleSubmit((data) => onPublicDashboardUpdate(data))();
   };
 
+  const onTemplateVariablesChange = (value: PublicDashboardTemplateVariable[]) => {
+    update({
+      dashboard: dashboard,
+      payload: {
+        ...publicDashboard!,
+        templateVariables: value,
+      },
+    });
+  };
+
+  const onAccessChange = (value: Partial<PublicDashboard>) => {
+    update({
+      dashboard: dashboard,
+      payload: {
+        ...publicDashboard!,
+        ...value,
+      },
+    });
+  };
+
   const onTogglePause = async (value: boolean) => {
     setValue('isPaused', value);
     await handleSubmit((data) => onPauseOrResume(data))();
@@ -207,6 +234,28 @@ export function ConfigPublicDashboardBase({
         </SettingsBar>
       </Field>
 
+      <Field className={styles.fieldSpace}>
+        <SettingsBar title={t('public-dashboard.config.access-title', 'Access')} data-testid={selectors.AccessDropdown}>
+          <AccessConfiguration disabled={disableInputs} publicDashboard={publicDashboard} onChange={onAccessChange} />
+        </SettingsBar>
+      </Field>
+
+      {templateVariables.length > 0 && (
+        <Field className={styles.fieldSpace}>
+          <SettingsBar
+            title={t('public-dashboard.config.template-variables-title', 'Template variables')}
+            data-testid={selectors.TemplateVariablesDropdown}
+          >
+            <TemplateVariablesConfiguration
+              disabled={disableInputs}
+              variables={templateVariables}
+              value={publicDashboard?.templateVariables}
+              onChange={onTemplateVariablesChange}
+            />
+          </SettingsBar>
+        </Field>
+      )}
+
       <Layout
         orientation={isDesktop ? 0 : 1}
         justify={isDesktop ? 'flex-end' : 'flex-start'}
@@ -241,6 +290,7 @@ export function ConfigPublicDashboard({ publicDashboard, unsupportedDatasources
   const timeRange = getTimeRange(dashboard.getDefaultTime(), dashboard);
   const hasWritePermissions = contextSrv.hasPermission(AccessControlAction.DashboardsPublicWrite);
   const hasTemplateVariables = dashboardHasTemplateVariables(dashboard.getVariables());
+  const templateVariables = getTemplateVariableOptions(dashboard.getVariables());
   const [deletePublicDashboard] = useDeletePublicDashboardMutation();
   const onDeletePublicDashboardClick = (onDelete: () => void) => {
     deletePublicDashboard({
@@ -261,6 +311,7 @@ export function ConfigPublicDashboard({ publicDashboard, unsupportedDatasources
           timeRange={timeRange}
           showSaveChangesAlert={hasWritePermissions && dashboard.hasUnsavedChanges()}
           hasTemplateVariables={hasTemplateVariables}
+          templateVariables={templateVariables}
           onRevoke={() => {
             DashboardInteractions.revokePublicDashboardClicked();
             showModal(DeletePublicDashboardModal, {
@@ -0,0 +1,62 @@
+import { SelectableValue } from '@grafana/data/src';
+import { FieldSet, Label, MultiSelect, VerticalGroup } from '@grafana/ui/src';
+import { Layout } from '@grafana/ui/src/components/Layout/Layout';
+import { t } from 'app/core/internationalization';
+
+import { DashboardTemplateVariableOptions, PublicDashboardTemplateVariable } from '../SharePublicDashboardUtils';
+
+export const TemplateVariablesConfiguration = ({
+  disabled,
+  variables,
+  value = [],
+  onChange,
+}: {
+  disabled: boolean;
+  variables: DashboardTemplateVariableOptions[];
+  value?: PublicDashboardTemplateVariable[];
+  onChange: (value: PublicDashboardTemplateVariable[]) => void;
+}) => {
+  const onAllowedValuesChange = (name: string, selected: Array<SelectableValue<string>>) => {
+    const allowedValues = selected.map((option) => option.value!);
+    const others = value.filter((variable) => variable.name !== name);
+    onChange(allowedValues.length > 0 ? [...others, { name, allowedValues }] : others);
+  };
+
+  return (
+    <FieldSet disabled={disabled}>
+      <VerticalGroup spacing="md">
+        {variables.map((variable) => {
+          const allowedValues = value.find((v) => v.name === variable.name)?.allowedValues ?? [];
+          const options = [...new Set([...variable.options, ...allowedValues])].map((option) => ({
+            label: option,
+            value: option,
+          }));
+
+          return (
+            <Layout key={variable.name} orientation={1} spacing="xs" justify="space-between">
+              <Label
+                description={t(
+                  'public-dashboard.settings-configuration.template-variable-label-desc',
+                  'Values viewers can select. Leave empty to use the value saved in the dashboard'
+                )}
+              >
+                {variable.name}
+              </Label>
+              <MultiSelect
+                options={options}
+                value={allowedValues}
+                disabled={disabled}
+                allowCustomValue
+                placeholder={t(
+                  'public-dashboard.settings-configuration.template-variable-placeholder',
+                  'Viewers cannot change this variable'
+                )}
+                onChange={(selected) => onAllowedValuesChange(variable.name, selected)}
+              />
+            </Layout>
+          );
+        })}
+      </VerticalGroup>
+    </FieldSet>
+  );
+};
@@ -9,14 +9,14 @@ export const UnsupportedTemplateVariablesAlert = ({ showDescription = true }: {
     severity="warning"
     title={t(
       'public-dashboard.modal-alerts.unsupported-template-variable-alert-title',
-      'Template variables are not supported'
+      'Template variables are restricted'
     )}
     data-testid={selectors.TemplateVariablesWarningAlert}
     bottomSpacing={0}
   >
     {showDescription && (
       <Trans i18nKey="public-dashboard.modal-alerts.unsupported-template-variable-alert-desc">
-        This public dashboard may not work since it uses template variables
+        Viewers can only change the template variables allowed in the public dashboard settings
       </Trans>
     )}
   </Alert>
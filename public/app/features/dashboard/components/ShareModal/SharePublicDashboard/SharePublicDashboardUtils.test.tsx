@@ -11,6 +11,9 @@ import {
   publicDashboardPersisted,
   generatePublicDashboardUrl,
   getUnsupportedDashboardDatasources,
+  getPublicDashboardUpdatePayload,
+  isPublicDashboardExpired,
+  NO_EXPIRATION,
 } from './SharePublicDashboardUtils';
 
 const mockDS = mockDataSource({
@@ -74,6 +77,33 @@ describe('publicDashboardPersisted', () => {
   });
 });
 
+describe('isPublicDashboardExpired', () => {
+  it('true', () => {
+    expect(isPublicDashboardExpired({ expiresAt: '2020-01-01T00:00:00Z' })).toBe(true);
+  });
+
+  it('false', () => {
+    expect(isPublicDashboardExpired({})).toBe(false);
+    expect(isPublicDashboardExpired({ expiresAt: NO_EXPIRATION })).toBe(false);
+    expect(isPublicDashboardExpired({ expiresAt: new Date(Date.now() + 60000).toISOString() })).toBe(false);
+  });
+});
+
+describe('getPublicDashboardUpdatePayload', () => {
+  it('leaves out an expiration time in the past', () => {
+    expect(getPublicDashboardUpdatePayload({ uid: 'abcd1234', expiresAt: '2020-01-01T00:00:00Z' })).toEqual({
+      uid: 'abcd1234',
+    });
+  });
+
+  it('keeps the expiration time to remove', () => {
+    expect(getPublicDashboardUpdatePayload({ uid: 'abcd1234', expiresAt: NO_EXPIRATION })).toEqual({
+      uid: 'abcd1234',
+      expiresAt: NO_EXPIRATION,
+    });
+  });
+});
+
 describe('getUnsupportedDashboardDatasources', () => {
   it('itIsSupported', async () => {
     const pm = {
@@ -25,9 +25,37 @@ export interface PublicDashboard extends PublicDashboardSettings {
   uid: string;
   dashboardUid: string;
   timeSettings?: object;
+  templateVariables?: PublicDashboardTemplateVariable[];
+  expiresAt?: string;
+  passphraseEnabled?: boolean;
+  passphrase?: string;
+  queryRateLimit?: number;
+  viewCount?: number;
+  queryCount?: number;
   recipients?: Array<{ uid: string; recipient: string }>;
 }
 
+/**
+ * The zero time, saving it as expiration time removes the expiration time of the public dashboard.
+ */
+export const NO_EXPIRATION = '0001-01-01T00:00:00Z';
+
+/**
+ * A template variable that viewers of the public dashboard can change to one of the allowed values.
+ */
+export interface PublicDashboardTemplateVariable {
+  name: string;
+  allowedValues: string[];
+}
+
+/**
+ * A dashboard template variable with the values the public dashboard can allow.
+ */
+export interface DashboardTemplateVariableOptions {
+  name: string;
+  options: string[];
+}
+
 export interface SessionDashboard {
   dashboardTitle: string;
   dashboardUid: string;
@@ -47,6 +75,38 @@ export const dashboardHasTemplateVariables = (variables: TypedVariableModel[]):
   return variables.length > 0;
 };
 
+/**
+ * Get the template variables that viewers of a public dashboard can be allowed to change.
+ */
+export const getTemplateVariableOptions = (variables: TypedVariableModel[]): DashboardTemplateVariableOptions[] => {
+  return variables
+    .filter((variable) => variable.type !== 'adhoc' && variable.type !== 'datasource')
+    .map((variable) => ({
+      name: variable.name,
+      options:
+        'options' in variable
+          ? variable.options.map((option) => String(option.value)).filter((value) => value !== '$__all')
+          : [],
+    }));
+};
+
+export const isPublicDashboardExpired = (publicDashboard?: Partial<PublicDashboard>): boolean => {
+  const expiresAt = publicDashboard?.expiresAt;
+  return !!expiresAt && expiresAt !== NO_EXPIRATION && new Date(expiresAt).getTime() <= Date.now();
+};
+
+/**
+ * Get the payload to update a public dashboard. An expiration time in the past cannot be saved, so it is left
+ * out to keep the saved one when other settings are updated.
+ */
+export const getPublicDashboardUpdatePayload = (payload: Partial<PublicDashboard>): Partial<PublicDashboard> => {
+  if (!isPublicDashboardExpired(payload)) {
+    return payload;
+  }
+  const { expiresAt, ...rest } = payload;
+  return rest;
+};
+
 export const publicDashboardPersisted = (publicDashboard?: PublicDashboard): boolean => {
   return publicDashboard?.uid !== '' && publicDashboard?.uid !== undefined;
 };
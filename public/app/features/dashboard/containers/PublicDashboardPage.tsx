@@ -22,6 +22,7 @@ import { DashboardLoading } from '../components/DashboardLoading/DashboardLoadin
 import { PublicDashboardFooter } from '../components/PublicDashboard/PublicDashboardsFooter';
 import { useGetPublicDashboardConfig } from '../components/PublicDashboard/usePublicDashboardConfig';
 import { PublicDashboardNotAvailable } from '../components/PublicDashboardNotAvailable/PublicDashboardNotAvailable';
+import { PublicDashboardPassphrase } from '../components/PublicDashboardPassphrase/PublicDashboardPassphrase';
 import { DashboardGrid } from '../dashgrid/DashboardGrid';
 import { getTimeSrv } from '../services/TimeSrv';
 import { DashboardModel } from '../state';
@@ -104,6 +105,14 @@ const PublicDashboardPage = (props: Props) => {
     return <PublicDashboardNotAvailable paused />;
   }
 
+  if (dashboard.meta.publicDashboardExpired) {
+    return <PublicDashboardNotAvailable expired />;
+  }
+
+  if (dashboard.meta.publicDashboardPassphraseRequired) {
+    return <PublicDashboardPassphrase accessToken={accessToken ?? ''} />;
+  }
+
   if (dashboard.meta.dashboardNotFound) {
     return <PublicDashboardNotAvailable />;
   }
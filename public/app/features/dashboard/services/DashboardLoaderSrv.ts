@@ -67,6 +67,10 @@ export class DashboardLoaderSrv {
             e.data.statusCode === 404 && e.data.messageId === 'publicdashboards.notFound';
           const isDashboardNotFound =
             e.data.statusCode === 404 && e.data.messageId === 'publicdashboards.dashboardNotFound';
+          const isPublicDashboardExpired =
+            e.data.statusCode === 403 && e.data.messageId === 'publicdashboards.expired';
+          const isPassphraseRequired =
+            e.data.statusCode === 401 && e.data.messageId === 'publicdashboards.passphraseRequired';
 
           const dashboardModel = this._dashboardLoadFailed(
             isPublicDashboardPaused ? 'Public Dashboard paused' : 'Public Dashboard Not found',
@@ -78,6 +82,8 @@ export class DashboardLoaderSrv {
               ...dashboardModel.meta,
               publicDashboardEnabled: isPublicDashboardNotFound ? undefined : !isPublicDashboardPaused,
               dashboardNotFound: isPublicDashboardNotFound || isDashboardNotFound,
+              publicDashboardExpired: isPublicDashboardExpired,
+              publicDashboardPassphraseRequired: isPassphraseRequired,
             },
           };
         });
@@ -172,7 +172,10 @@ export const getFieldLinksForExplore = (options: {
 
           internalLinkSpecificVars = {
             ...internalLinkSpecificVars,
-            ...getTransformationVars(transformation, fieldValue, field.name),
+            ...getTransformationVars(transformation, fieldValue, field.name, {
+              ...scopedVars,
+              ...internalLinkSpecificVars,
+            }),
           };
         });
       }
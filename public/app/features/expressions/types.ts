@@ -83,6 +83,17 @@ export const reducerTypes: Array<SelectableValue<string>> = [
   { value: ReducerID.sum, label: 'Sum', description: 'Get the sum of all values' },
   { value: ReducerID.count, label: 'Count', description: 'Get the number of values' },
   { value: ReducerID.last, label: 'Last', description: 'Get the last value' },
+  { value: ReducerID.first, label: 'First', description: 'Get the first value' },
+  { value: ReducerID.range, label: 'Range', description: 'Difference between maximum and minimum values' },
+  { value: ReducerID.delta, label: 'Delta', description: 'Difference between last and first values' },
+  { value: 'rate', label: 'Rate', description: 'Per-second change between first and last values' },
+  { value: 'stddev', label: 'Standard deviation', description: 'Get the population standard deviation' },
+  { value: ReducerID.variance, label: 'Variance', description: 'Get the population variance' },
+  { value: 'count_non_null', label: 'Count non-null', description: 'Get the number of non-null values' },
+  { value: ReducerID.p50, label: '50th percentile', description: 'Get the 50th percentile value' },
+  { value: ReducerID.p90, label: '90th percentile', description: 'Get the 90th percentile value' },
+  { value: ReducerID.p95, label: '95th percentile', description: 'Get the 95th percentile value' },
+  { value: ReducerID.p99, label: '99th percentile', description: 'Get the 99th percentile value' },
 ];
 
 export enum ReducerMode {
@@ -121,6 +132,12 @@ export const upsamplingTypes: Array<SelectableValue<string>> = [
   { value: 'pad', label: 'pad', description: 'fill with the last known value' },
   { value: 'backfilling', label: 'backfilling', description: 'fill with the next known value' },
   { value: 'fillna', label: 'fillna', description: 'Fill with NaNs' },
+  { value: 'linear', label: 'linear', description: 'interpolate between the last and the next known values' },
+];
+
+export const alignmentTypes: Array<SelectableValue<string>> = [
+  { value: 'from', label: 'from', description: 'start at the beginning of the time range' },
+  { value: 'clock', label: 'clock', description: 'start at a multiple of the window, e.g. on the minute' },
 ];
 
 export const thresholdFunctions: Array<SelectableValue<EvalFunction>> = [
@@ -141,6 +158,7 @@ export interface ExpressionQuery extends DataQuery {
   window?: string;
   downsampler?: string;
   upsampler?: string;
+  alignment?: string;
   conditions?: ClassicCondition[];
   settings?: ExpressionQuerySettings;
 }
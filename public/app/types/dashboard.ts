@@ -66,6 +66,8 @@ export interface DashboardMeta {
   annotationsPermissions?: AnnotationsPermissions;
   publicDashboardEnabled?: boolean;
   dashboardNotFound?: boolean;
+  publicDashboardExpired?: boolean;
+  publicDashboardPassphraseRequired?: boolean;
   isEmbedded?: boolean;
   isNew?: boolean;
 